@@ -1,12 +1,13 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.6
+// 	protoc-gen-go v1.36.8
 // 	protoc        v3.12.4
 // source: proto/url_shortener/v1/url_shortener.proto
 
 package url_shortener_v1
 
 import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -22,8 +23,12 @@ const (
 )
 
 type CreateShortURLRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Optional password required to resolve the link. A protected link is always created anew.
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// Optional number of times the link resolves, 1 for single-use links. A limited link is always created anew.
+	MaxClicks     int64 `protobuf:"varint,3,opt,name=max_clicks,json=maxClicks,proto3" json:"max_clicks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,9 +70,26 @@ func (x *CreateShortURLRequest) GetUrl() string {
 	return ""
 }
 
+func (x *CreateShortURLRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShortURLRequest) GetMaxClicks() int64 {
+	if x != nil {
+		return x.MaxClicks
+	}
+	return 0
+}
+
 type GetOriginalURLRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Short code, or full short URL such as https://go.example.com/{code} whose host selects the domain.
+	ShortUrl string `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// Required for password protected links.
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -109,9 +131,17 @@ func (x *GetOriginalURLRequest) GetShortUrl() string {
 	return ""
 }
 
+func (x *GetOriginalURLRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 type CreateShortURLResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Full short URL on the domain of the call, or the bare code if the domain has no base URL.
+	ShortUrl      string `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -201,11 +231,15 @@ var File_proto_url_shortener_v1_url_shortener_proto protoreflect.FileDescriptor
 
 const file_proto_url_shortener_v1_url_shortener_proto_rawDesc = "" +
 	"\n" +
-	"*proto/url_shortener/v1/url_shortener.proto\x12\rurl_shortener\")\n" +
-	"\x15CreateShortURLRequest\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\"4\n" +
-	"\x15GetOriginalURLRequest\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"5\n" +
+	"*proto/url_shortener/v1/url_shortener.proto\x12\rurl_shortener\x1a\x17validate/validate.proto\"\x93\x01\n" +
+	"\x15CreateShortURLRequest\x12-\n" +
+	"\x03url\x18\x01 \x01(\tB\x1b\xfaB\x18r\x16\x18\x80\x102\x0e^(?i)https?://\x88\x01\x01R\x03url\x12#\n" +
+	"\bpassword\x18\x02 \x01(\tB\a\xfaB\x04r\x02(HR\bpassword\x12&\n" +
+	"\n" +
+	"max_clicks\x18\x03 \x01(\x03B\a\xfaB\x04\"\x02(\x00R\tmaxClicks\"\x93\x01\n" +
+	"\x15GetOriginalURLRequest\x12U\n" +
+	"\tshort_url\x18\x01 \x01(\tB8\xfaB5r3\x18\x80\x102.^((?i:https?)://[^/?#]+/)?[0-9A-Za-z_-]{1,64}$R\bshortUrl\x12#\n" +
+	"\bpassword\x18\x02 \x01(\tB\a\xfaB\x04r\x02(HR\bpassword\"5\n" +
 	"\x16CreateShortURLResponse\x12\x1b\n" +
 	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"*\n" +
 	"\x16GetOriginalURLResponse\x12\x10\n" +
@@ -0,0 +1,550 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: proto/url_shortener/v1/url_shortener.proto
+
+package url_shortener_v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on CreateShortURLRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateShortURLRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateShortURLRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateShortURLRequestMultiError, or nil if none found.
+func (m *CreateShortURLRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateShortURLRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUrl()) > 2048 {
+		err := CreateShortURLRequestValidationError{
+			field:  "Url",
+			reason: "value length must be at most 2048 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if uri, err := url.Parse(m.GetUrl()); err != nil {
+		err = CreateShortURLRequestValidationError{
+			field:  "Url",
+			reason: "value must be a valid URI",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	} else if !uri.IsAbs() {
+		err := CreateShortURLRequestValidationError{
+			field:  "Url",
+			reason: "value must be absolute",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_CreateShortURLRequest_Url_Pattern.MatchString(m.GetUrl()) {
+		err := CreateShortURLRequestValidationError{
+			field:  "Url",
+			reason: "value does not match regex pattern \"^(?i)https?://\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetPassword()) > 72 {
+		err := CreateShortURLRequestValidationError{
+			field:  "Password",
+			reason: "value length must be at most 72 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetMaxClicks() < 0 {
+		err := CreateShortURLRequestValidationError{
+			field:  "MaxClicks",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CreateShortURLRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateShortURLRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateShortURLRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateShortURLRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateShortURLRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateShortURLRequestMultiError) AllErrors() []error { return m }
+
+// CreateShortURLRequestValidationError is the validation error returned by
+// CreateShortURLRequest.Validate if the designated constraints aren't met.
+type CreateShortURLRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateShortURLRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateShortURLRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateShortURLRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateShortURLRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateShortURLRequestValidationError) ErrorName() string {
+	return "CreateShortURLRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateShortURLRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateShortURLRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateShortURLRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateShortURLRequestValidationError{}
+
+var _CreateShortURLRequest_Url_Pattern = regexp.MustCompile("^(?i)https?://")
+
+// Validate checks the field values on GetOriginalURLRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOriginalURLRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetOriginalURLRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOriginalURLRequestMultiError, or nil if none found.
+func (m *GetOriginalURLRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetOriginalURLRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetShortUrl()) > 2048 {
+		err := GetOriginalURLRequestValidationError{
+			field:  "ShortUrl",
+			reason: "value length must be at most 2048 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_GetOriginalURLRequest_ShortUrl_Pattern.MatchString(m.GetShortUrl()) {
+		err := GetOriginalURLRequestValidationError{
+			field:  "ShortUrl",
+			reason: "value does not match regex pattern \"^((?i:https?)://[^/?#]+/)?[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetPassword()) > 72 {
+		err := GetOriginalURLRequestValidationError{
+			field:  "Password",
+			reason: "value length must be at most 72 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetOriginalURLRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetOriginalURLRequestMultiError is an error wrapping multiple validation
+// errors returned by GetOriginalURLRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetOriginalURLRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetOriginalURLRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetOriginalURLRequestMultiError) AllErrors() []error { return m }
+
+// GetOriginalURLRequestValidationError is the validation error returned by
+// GetOriginalURLRequest.Validate if the designated constraints aren't met.
+type GetOriginalURLRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetOriginalURLRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetOriginalURLRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetOriginalURLRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetOriginalURLRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetOriginalURLRequestValidationError) ErrorName() string {
+	return "GetOriginalURLRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetOriginalURLRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetOriginalURLRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetOriginalURLRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetOriginalURLRequestValidationError{}
+
+var _GetOriginalURLRequest_ShortUrl_Pattern = regexp.MustCompile("^((?i:https?)://[^/?#]+/)?[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on CreateShortURLResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateShortURLResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateShortURLResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateShortURLResponseMultiError, or nil if none found.
+func (m *CreateShortURLResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateShortURLResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ShortUrl
+
+	if len(errors) > 0 {
+		return CreateShortURLResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateShortURLResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateShortURLResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateShortURLResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateShortURLResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateShortURLResponseMultiError) AllErrors() []error { return m }
+
+// CreateShortURLResponseValidationError is the validation error returned by
+// CreateShortURLResponse.Validate if the designated constraints aren't met.
+type CreateShortURLResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateShortURLResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateShortURLResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateShortURLResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateShortURLResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateShortURLResponseValidationError) ErrorName() string {
+	return "CreateShortURLResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateShortURLResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateShortURLResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateShortURLResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateShortURLResponseValidationError{}
+
+// Validate checks the field values on GetOriginalURLResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOriginalURLResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetOriginalURLResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOriginalURLResponseMultiError, or nil if none found.
+func (m *GetOriginalURLResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetOriginalURLResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Url
+
+	if len(errors) > 0 {
+		return GetOriginalURLResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetOriginalURLResponseMultiError is an error wrapping multiple validation
+// errors returned by GetOriginalURLResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetOriginalURLResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetOriginalURLResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetOriginalURLResponseMultiError) AllErrors() []error { return m }
+
+// GetOriginalURLResponseValidationError is the validation error returned by
+// GetOriginalURLResponse.Validate if the designated constraints aren't met.
+type GetOriginalURLResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetOriginalURLResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetOriginalURLResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetOriginalURLResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetOriginalURLResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetOriginalURLResponseValidationError) ErrorName() string {
+	return "GetOriginalURLResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetOriginalURLResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetOriginalURLResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetOriginalURLResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetOriginalURLResponseValidationError{}
@@ -0,0 +1,3412 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v3.12.4
+// source: proto/url_shortener/v2/url_shortener.proto
+
+package url_shortener_v2
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Link_SplitMode int32
+
+const (
+	// Same as SPLIT_MODE_RANDOM.
+	Link_SPLIT_MODE_UNSPECIFIED Link_SplitMode = 0
+	// Picks a variant at random on every resolve.
+	Link_SPLIT_MODE_RANDOM Link_SplitMode = 1
+	// Hashes the visitor, so it keeps getting the same variant while the variants don't change.
+	Link_SPLIT_MODE_STICKY Link_SplitMode = 2
+)
+
+// Enum value maps for Link_SplitMode.
+var (
+	Link_SplitMode_name = map[int32]string{
+		0: "SPLIT_MODE_UNSPECIFIED",
+		1: "SPLIT_MODE_RANDOM",
+		2: "SPLIT_MODE_STICKY",
+	}
+	Link_SplitMode_value = map[string]int32{
+		"SPLIT_MODE_UNSPECIFIED": 0,
+		"SPLIT_MODE_RANDOM":      1,
+		"SPLIT_MODE_STICKY":      2,
+	}
+)
+
+func (x Link_SplitMode) Enum() *Link_SplitMode {
+	p := new(Link_SplitMode)
+	*p = x
+	return p
+}
+
+func (x Link_SplitMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Link_SplitMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_url_shortener_v2_url_shortener_proto_enumTypes[0].Descriptor()
+}
+
+func (Link_SplitMode) Type() protoreflect.EnumType {
+	return &file_proto_url_shortener_v2_url_shortener_proto_enumTypes[0]
+}
+
+func (x Link_SplitMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Link_SplitMode.Descriptor instead.
+func (Link_SplitMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// What happens to incoming query parameters.
+type Passthrough_QueryPolicy int32
+
+const (
+	// They are not forwarded.
+	Passthrough_QUERY_POLICY_UNSPECIFIED Passthrough_QueryPolicy = 0
+	// They are merged into the target, which keeps its value of a parameter it already has.
+	Passthrough_QUERY_POLICY_KEEP Passthrough_QueryPolicy = 1
+	// They are merged into the target, replacing its value of a parameter it already has.
+	Passthrough_QUERY_POLICY_OVERRIDE Passthrough_QueryPolicy = 2
+	// They are merged into the target, and parameters both have are removed.
+	Passthrough_QUERY_POLICY_DROP Passthrough_QueryPolicy = 3
+)
+
+// Enum value maps for Passthrough_QueryPolicy.
+var (
+	Passthrough_QueryPolicy_name = map[int32]string{
+		0: "QUERY_POLICY_UNSPECIFIED",
+		1: "QUERY_POLICY_KEEP",
+		2: "QUERY_POLICY_OVERRIDE",
+		3: "QUERY_POLICY_DROP",
+	}
+	Passthrough_QueryPolicy_value = map[string]int32{
+		"QUERY_POLICY_UNSPECIFIED": 0,
+		"QUERY_POLICY_KEEP":        1,
+		"QUERY_POLICY_OVERRIDE":    2,
+		"QUERY_POLICY_DROP":        3,
+	}
+)
+
+func (x Passthrough_QueryPolicy) Enum() *Passthrough_QueryPolicy {
+	p := new(Passthrough_QueryPolicy)
+	*p = x
+	return p
+}
+
+func (x Passthrough_QueryPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Passthrough_QueryPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_url_shortener_v2_url_shortener_proto_enumTypes[1].Descriptor()
+}
+
+func (Passthrough_QueryPolicy) Type() protoreflect.EnumType {
+	return &file_proto_url_shortener_v2_url_shortener_proto_enumTypes[1]
+}
+
+func (x Passthrough_QueryPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Passthrough_QueryPolicy.Descriptor instead.
+func (Passthrough_QueryPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type ListLinksRequest_Sort int32
+
+const (
+	// Ascending by creation order of codes.
+	ListLinksRequest_SORT_UNSPECIFIED      ListLinksRequest_Sort = 0
+	ListLinksRequest_SORT_CODE_DESC        ListLinksRequest_Sort = 1
+	ListLinksRequest_SORT_CREATE_TIME_ASC  ListLinksRequest_Sort = 2
+	ListLinksRequest_SORT_CREATE_TIME_DESC ListLinksRequest_Sort = 3
+)
+
+// Enum value maps for ListLinksRequest_Sort.
+var (
+	ListLinksRequest_Sort_name = map[int32]string{
+		0: "SORT_UNSPECIFIED",
+		1: "SORT_CODE_DESC",
+		2: "SORT_CREATE_TIME_ASC",
+		3: "SORT_CREATE_TIME_DESC",
+	}
+	ListLinksRequest_Sort_value = map[string]int32{
+		"SORT_UNSPECIFIED":      0,
+		"SORT_CODE_DESC":        1,
+		"SORT_CREATE_TIME_ASC":  2,
+		"SORT_CREATE_TIME_DESC": 3,
+	}
+)
+
+func (x ListLinksRequest_Sort) Enum() *ListLinksRequest_Sort {
+	p := new(ListLinksRequest_Sort)
+	*p = x
+	return p
+}
+
+func (x ListLinksRequest_Sort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListLinksRequest_Sort) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_url_shortener_v2_url_shortener_proto_enumTypes[2].Descriptor()
+}
+
+func (ListLinksRequest_Sort) Type() protoreflect.EnumType {
+	return &file_proto_url_shortener_v2_url_shortener_proto_enumTypes[2]
+}
+
+func (x ListLinksRequest_Sort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListLinksRequest_Sort.Descriptor instead.
+func (ListLinksRequest_Sort) EnumDescriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type GetLinkQRCodeRequest_Format int32
+
+const (
+	// PNG
+	GetLinkQRCodeRequest_FORMAT_UNSPECIFIED GetLinkQRCodeRequest_Format = 0
+	GetLinkQRCodeRequest_FORMAT_PNG         GetLinkQRCodeRequest_Format = 1
+	GetLinkQRCodeRequest_FORMAT_SVG         GetLinkQRCodeRequest_Format = 2
+)
+
+// Enum value maps for GetLinkQRCodeRequest_Format.
+var (
+	GetLinkQRCodeRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "FORMAT_PNG",
+		2: "FORMAT_SVG",
+	}
+	GetLinkQRCodeRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"FORMAT_PNG":         1,
+		"FORMAT_SVG":         2,
+	}
+)
+
+func (x GetLinkQRCodeRequest_Format) Enum() *GetLinkQRCodeRequest_Format {
+	p := new(GetLinkQRCodeRequest_Format)
+	*p = x
+	return p
+}
+
+func (x GetLinkQRCodeRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetLinkQRCodeRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_url_shortener_v2_url_shortener_proto_enumTypes[3].Descriptor()
+}
+
+func (GetLinkQRCodeRequest_Format) Type() protoreflect.EnumType {
+	return &file_proto_url_shortener_v2_url_shortener_proto_enumTypes[3]
+}
+
+func (x GetLinkQRCodeRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetLinkQRCodeRequest_Format.Descriptor instead.
+func (GetLinkQRCodeRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{13, 0}
+}
+
+type GetLinkQRCodeRequest_ErrorCorrection int32
+
+const (
+	// MEDIUM, or HIGH with a logo
+	GetLinkQRCodeRequest_ERROR_CORRECTION_UNSPECIFIED GetLinkQRCodeRequest_ErrorCorrection = 0
+	GetLinkQRCodeRequest_ERROR_CORRECTION_LOW         GetLinkQRCodeRequest_ErrorCorrection = 1
+	GetLinkQRCodeRequest_ERROR_CORRECTION_MEDIUM      GetLinkQRCodeRequest_ErrorCorrection = 2
+	GetLinkQRCodeRequest_ERROR_CORRECTION_QUARTILE    GetLinkQRCodeRequest_ErrorCorrection = 3
+	GetLinkQRCodeRequest_ERROR_CORRECTION_HIGH        GetLinkQRCodeRequest_ErrorCorrection = 4
+)
+
+// Enum value maps for GetLinkQRCodeRequest_ErrorCorrection.
+var (
+	GetLinkQRCodeRequest_ErrorCorrection_name = map[int32]string{
+		0: "ERROR_CORRECTION_UNSPECIFIED",
+		1: "ERROR_CORRECTION_LOW",
+		2: "ERROR_CORRECTION_MEDIUM",
+		3: "ERROR_CORRECTION_QUARTILE",
+		4: "ERROR_CORRECTION_HIGH",
+	}
+	GetLinkQRCodeRequest_ErrorCorrection_value = map[string]int32{
+		"ERROR_CORRECTION_UNSPECIFIED": 0,
+		"ERROR_CORRECTION_LOW":         1,
+		"ERROR_CORRECTION_MEDIUM":      2,
+		"ERROR_CORRECTION_QUARTILE":    3,
+		"ERROR_CORRECTION_HIGH":        4,
+	}
+)
+
+func (x GetLinkQRCodeRequest_ErrorCorrection) Enum() *GetLinkQRCodeRequest_ErrorCorrection {
+	p := new(GetLinkQRCodeRequest_ErrorCorrection)
+	*p = x
+	return p
+}
+
+func (x GetLinkQRCodeRequest_ErrorCorrection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetLinkQRCodeRequest_ErrorCorrection) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_url_shortener_v2_url_shortener_proto_enumTypes[4].Descriptor()
+}
+
+func (GetLinkQRCodeRequest_ErrorCorrection) Type() protoreflect.EnumType {
+	return &file_proto_url_shortener_v2_url_shortener_proto_enumTypes[4]
+}
+
+func (x GetLinkQRCodeRequest_ErrorCorrection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetLinkQRCodeRequest_ErrorCorrection.Descriptor instead.
+func (GetLinkQRCodeRequest_ErrorCorrection) EnumDescriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{13, 1}
+}
+
+type RuleConditions_Platform int32
+
+const (
+	RuleConditions_PLATFORM_UNSPECIFIED RuleConditions_Platform = 0
+	RuleConditions_PLATFORM_IOS         RuleConditions_Platform = 1
+	RuleConditions_PLATFORM_ANDROID     RuleConditions_Platform = 2
+	RuleConditions_PLATFORM_WINDOWS     RuleConditions_Platform = 3
+	RuleConditions_PLATFORM_MACOS       RuleConditions_Platform = 4
+	RuleConditions_PLATFORM_LINUX       RuleConditions_Platform = 5
+	// Any client not recognized as one of the above.
+	RuleConditions_PLATFORM_OTHER RuleConditions_Platform = 6
+)
+
+// Enum value maps for RuleConditions_Platform.
+var (
+	RuleConditions_Platform_name = map[int32]string{
+		0: "PLATFORM_UNSPECIFIED",
+		1: "PLATFORM_IOS",
+		2: "PLATFORM_ANDROID",
+		3: "PLATFORM_WINDOWS",
+		4: "PLATFORM_MACOS",
+		5: "PLATFORM_LINUX",
+		6: "PLATFORM_OTHER",
+	}
+	RuleConditions_Platform_value = map[string]int32{
+		"PLATFORM_UNSPECIFIED": 0,
+		"PLATFORM_IOS":         1,
+		"PLATFORM_ANDROID":     2,
+		"PLATFORM_WINDOWS":     3,
+		"PLATFORM_MACOS":       4,
+		"PLATFORM_LINUX":       5,
+		"PLATFORM_OTHER":       6,
+	}
+)
+
+func (x RuleConditions_Platform) Enum() *RuleConditions_Platform {
+	p := new(RuleConditions_Platform)
+	*p = x
+	return p
+}
+
+func (x RuleConditions_Platform) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RuleConditions_Platform) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_url_shortener_v2_url_shortener_proto_enumTypes[5].Descriptor()
+}
+
+func (RuleConditions_Platform) Type() protoreflect.EnumType {
+	return &file_proto_url_shortener_v2_url_shortener_proto_enumTypes[5]
+}
+
+func (x RuleConditions_Platform) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RuleConditions_Platform.Descriptor instead.
+func (RuleConditions_Platform) EnumDescriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{16, 0}
+}
+
+type RuleConditions_Day int32
+
+const (
+	RuleConditions_DAY_UNSPECIFIED RuleConditions_Day = 0
+	RuleConditions_DAY_MONDAY      RuleConditions_Day = 1
+	RuleConditions_DAY_TUESDAY     RuleConditions_Day = 2
+	RuleConditions_DAY_WEDNESDAY   RuleConditions_Day = 3
+	RuleConditions_DAY_THURSDAY    RuleConditions_Day = 4
+	RuleConditions_DAY_FRIDAY      RuleConditions_Day = 5
+	RuleConditions_DAY_SATURDAY    RuleConditions_Day = 6
+	RuleConditions_DAY_SUNDAY      RuleConditions_Day = 7
+)
+
+// Enum value maps for RuleConditions_Day.
+var (
+	RuleConditions_Day_name = map[int32]string{
+		0: "DAY_UNSPECIFIED",
+		1: "DAY_MONDAY",
+		2: "DAY_TUESDAY",
+		3: "DAY_WEDNESDAY",
+		4: "DAY_THURSDAY",
+		5: "DAY_FRIDAY",
+		6: "DAY_SATURDAY",
+		7: "DAY_SUNDAY",
+	}
+	RuleConditions_Day_value = map[string]int32{
+		"DAY_UNSPECIFIED": 0,
+		"DAY_MONDAY":      1,
+		"DAY_TUESDAY":     2,
+		"DAY_WEDNESDAY":   3,
+		"DAY_THURSDAY":    4,
+		"DAY_FRIDAY":      5,
+		"DAY_SATURDAY":    6,
+		"DAY_SUNDAY":      7,
+	}
+)
+
+func (x RuleConditions_Day) Enum() *RuleConditions_Day {
+	p := new(RuleConditions_Day)
+	*p = x
+	return p
+}
+
+func (x RuleConditions_Day) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RuleConditions_Day) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_url_shortener_v2_url_shortener_proto_enumTypes[6].Descriptor()
+}
+
+func (RuleConditions_Day) Type() protoreflect.EnumType {
+	return &file_proto_url_shortener_v2_url_shortener_proto_enumTypes[6]
+}
+
+func (x RuleConditions_Day) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RuleConditions_Day.Descriptor instead.
+func (RuleConditions_Day) EnumDescriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{16, 1}
+}
+
+type LinkChange_Type int32
+
+const (
+	LinkChange_TYPE_UNSPECIFIED LinkChange_Type = 0
+	LinkChange_TYPE_CREATED     LinkChange_Type = 1
+	LinkChange_TYPE_UPDATED     LinkChange_Type = 2
+	LinkChange_TYPE_DELETED     LinkChange_Type = 3
+)
+
+// Enum value maps for LinkChange_Type.
+var (
+	LinkChange_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CREATED",
+		2: "TYPE_UPDATED",
+		3: "TYPE_DELETED",
+	}
+	LinkChange_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CREATED":     1,
+		"TYPE_UPDATED":     2,
+		"TYPE_DELETED":     3,
+	}
+)
+
+func (x LinkChange_Type) Enum() *LinkChange_Type {
+	p := new(LinkChange_Type)
+	*p = x
+	return p
+}
+
+func (x LinkChange_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LinkChange_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_url_shortener_v2_url_shortener_proto_enumTypes[7].Descriptor()
+}
+
+func (LinkChange_Type) Type() protoreflect.EnumType {
+	return &file_proto_url_shortener_v2_url_shortener_proto_enumTypes[7]
+}
+
+func (x LinkChange_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LinkChange_Type.Descriptor instead.
+func (LinkChange_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{36, 0}
+}
+
+type Link struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Short code. Output only.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Absolute http(s) URL the link redirects to. With a template it may contain placeholders
+	// after the host: {name}, {name=default} or {+name}. Required on create and when named in the
+	// update mask; empty in updates that leave it out. Left out of password protected links for
+	// calls without the admin token.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Output only.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Output only.
+	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	Owner      string                 `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The link stops resolving at this time. Unset means never.
+	ExpireTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expire_time,json=expireTime,proto3" json:"expire_time,omitempty"`
+	Tags       []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Disabled links are kept but don't resolve.
+	Disabled bool `protobuf:"varint,8,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// Password required to resolve the link. Input only, never returned; an empty password in
+	// an update removes the protection.
+	Password string `protobuf:"bytes,9,opt,name=password,proto3" json:"password,omitempty"`
+	// Whether the link has a password. Output only.
+	PasswordProtected bool `protobuf:"varint,10,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	// Number of times the link resolves, 1 for single-use links. 0 means unlimited.
+	// Updating it restarts the count, so a full update without a mask leaves it unchanged.
+	MaxClicks int64 `protobuf:"varint,11,opt,name=max_clicks,json=maxClicks,proto3" json:"max_clicks,omitempty"`
+	// Resolves left when max_clicks is set. Output only.
+	RemainingClicks int64 `protobuf:"varint,12,opt,name=remaining_clicks,json=remainingClicks,proto3" json:"remaining_clicks,omitempty"`
+	// Split variants. Unless a redirect rule matches, every resolve picks one of them in
+	// proportion to their weights instead of target.
+	Variants  []*Variant     `protobuf:"bytes,13,rep,name=variants,proto3" json:"variants,omitempty"`
+	SplitMode Link_SplitMode `protobuf:"varint,14,opt,name=split_mode,json=splitMode,proto3,enum=url_shortener.v2.Link_SplitMode" json:"split_mode,omitempty"`
+	// Carries the query and trailing path of resolve requests over to the target.
+	Passthrough *Passthrough `protobuf:"bytes,15,opt,name=passthrough,proto3" json:"passthrough,omitempty"`
+	// Makes target a template filled at resolve time. Unset for links with an exact target.
+	Template *LinkTemplate `protobuf:"bytes,16,opt,name=template,proto3" json:"template,omitempty"`
+	// Domain the link is served from, empty for the default domain. Codes are only valid on the
+	// domain of their link. Defaults to the domain selected by the x-link-domain metadata or the
+	// authority of the call. Immutable.
+	Domain string `protobuf:"bytes,17,opt,name=domain,proto3" json:"domain,omitempty"`
+	// Full short URL on the domain of the link, empty if it has no base URL. Output only.
+	ShortUrl string `protobuf:"bytes,18,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// Campaign or folder the link is filed under, empty for none.
+	Campaign string `protobuf:"bytes,19,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	// Resolves of the link. Output only.
+	Clicks        int64 `protobuf:"varint,20,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Link) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Link) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Link) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Link) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Link) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Link) GetExpireTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return nil
+}
+
+func (x *Link) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Link) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Link) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Link) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *Link) GetMaxClicks() int64 {
+	if x != nil {
+		return x.MaxClicks
+	}
+	return 0
+}
+
+func (x *Link) GetRemainingClicks() int64 {
+	if x != nil {
+		return x.RemainingClicks
+	}
+	return 0
+}
+
+func (x *Link) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Link) GetSplitMode() Link_SplitMode {
+	if x != nil {
+		return x.SplitMode
+	}
+	return Link_SPLIT_MODE_UNSPECIFIED
+}
+
+func (x *Link) GetPassthrough() *Passthrough {
+	if x != nil {
+		return x.Passthrough
+	}
+	return nil
+}
+
+func (x *Link) GetTemplate() *LinkTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *Link) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Link) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *Link) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *Link) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+// LinkTemplate fills the placeholders of a link target from the trailing path of the request,
+// its query parameters, or else the defaults of the placeholders.
+type LinkTemplate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pattern of the trailing path, e.g. "{version}/{page}". Segments are literals or placeholders
+	// without defaults; the last one may be {+name}, which takes the rest of the path.
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkTemplate) Reset() {
+	*x = LinkTemplate{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkTemplate) ProtoMessage() {}
+
+func (x *LinkTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkTemplate.ProtoReflect.Descriptor instead.
+func (*LinkTemplate) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LinkTemplate) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type Passthrough struct {
+	state protoimpl.MessageState  `protogen:"open.v1"`
+	Query Passthrough_QueryPolicy `protobuf:"varint,1,opt,name=query,proto3,enum=url_shortener.v2.Passthrough_QueryPolicy" json:"query,omitempty"`
+	// Appends the trailing path of the short URL, /code/extra/path, to the target path.
+	Path bool `protobuf:"varint,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Static campaign parameters added to the target, replacing any it has.
+	Utm           *Utm `protobuf:"bytes,3,opt,name=utm,proto3" json:"utm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Passthrough) Reset() {
+	*x = Passthrough{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passthrough) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passthrough) ProtoMessage() {}
+
+func (x *Passthrough) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passthrough.ProtoReflect.Descriptor instead.
+func (*Passthrough) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Passthrough) GetQuery() Passthrough_QueryPolicy {
+	if x != nil {
+		return x.Query
+	}
+	return Passthrough_QUERY_POLICY_UNSPECIFIED
+}
+
+func (x *Passthrough) GetPath() bool {
+	if x != nil {
+		return x.Path
+	}
+	return false
+}
+
+func (x *Passthrough) GetUtm() *Utm {
+	if x != nil {
+		return x.Utm
+	}
+	return nil
+}
+
+type Utm struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Medium        string                 `protobuf:"bytes,2,opt,name=medium,proto3" json:"medium,omitempty"`
+	Campaign      string                 `protobuf:"bytes,3,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	Term          string                 `protobuf:"bytes,4,opt,name=term,proto3" json:"term,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Utm) Reset() {
+	*x = Utm{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Utm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Utm) ProtoMessage() {}
+
+func (x *Utm) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Utm.ProtoReflect.Descriptor instead.
+func (*Utm) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Utm) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Utm) GetMedium() string {
+	if x != nil {
+		return x.Medium
+	}
+	return ""
+}
+
+func (x *Utm) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *Utm) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *Utm) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// Variant is one destination of a link splitting its traffic for A/B tests.
+type Variant struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Target string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Relative share of the traffic. 0 pauses the variant.
+	Weight        int32 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Variant) Reset() {
+	*x = Variant{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variant) ProtoMessage() {}
+
+func (x *Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variant.ProtoReflect.Descriptor instead.
+func (*Variant) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Variant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Variant) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Variant) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type CreateLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *Link                  `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLinkRequest) Reset() {
+	*x = CreateLinkRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLinkRequest) ProtoMessage() {}
+
+func (x *CreateLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateLinkRequest) GetLink() *Link {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+type GetLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkRequest) Reset() {
+	*x = GetLinkRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkRequest) ProtoMessage() {}
+
+func (x *GetLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ResolveLinkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Required for password protected links.
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// Identifies a returning visitor for sticky split links. Defaults to the caller's address.
+	VisitorId string `protobuf:"bytes,3,opt,name=visitor_id,json=visitorId,proto3" json:"visitor_id,omitempty"`
+	// Escaped trailing path after the code. Only links forwarding paths accept one.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// Raw query string of the short URL, e.g. "gclid=abc&src=ad". It is merged into the target
+	// by links forwarding queries and matched by redirect rules.
+	Query string `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	// Matched by redirect rules.
+	UserAgent      string `protobuf:"bytes,6,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	AcceptLanguage string `protobuf:"bytes,7,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResolveLinkRequest) Reset() {
+	*x = ResolveLinkRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveLinkRequest) ProtoMessage() {}
+
+func (x *ResolveLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveLinkRequest.ProtoReflect.Descriptor instead.
+func (*ResolveLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResolveLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ResolveLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ResolveLinkRequest) GetVisitorId() string {
+	if x != nil {
+		return x.VisitorId
+	}
+	return ""
+}
+
+func (x *ResolveLinkRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ResolveLinkRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ResolveLinkRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *ResolveLinkRequest) GetAcceptLanguage() string {
+	if x != nil {
+		return x.AcceptLanguage
+	}
+	return ""
+}
+
+type ResolveLinkResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Target string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	// The picked split variant, empty if the link has none or a redirect rule matched.
+	VariantId     string `protobuf:"bytes,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveLinkResponse) Reset() {
+	*x = ResolveLinkResponse{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveLinkResponse) ProtoMessage() {}
+
+func (x *ResolveLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveLinkResponse.ProtoReflect.Descriptor instead.
+func (*ResolveLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ResolveLinkResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ResolveLinkResponse) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+type UpdateLinkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The link to update. Its code identifies the link.
+	Link *Link `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	// Fields to update: target, owner, expire_time, tags, campaign, disabled, password, max_clicks.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLinkRequest) Reset() {
+	*x = UpdateLinkRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkRequest) ProtoMessage() {}
+
+func (x *UpdateLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateLinkRequest) GetLink() *Link {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *UpdateLinkRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLinkRequest) Reset() {
+	*x = DeleteLinkRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLinkRequest) ProtoMessage() {}
+
+func (x *DeleteLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLinkRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ListLinksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of links to return. Defaults to 50, capped at 1000.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only return links with this owner.
+	Owner string `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Only return links with this tag.
+	Tag string `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Also return disabled links.
+	ShowDisabled bool `protobuf:"varint,5,opt,name=show_disabled,json=showDisabled,proto3" json:"show_disabled,omitempty"`
+	// Only return links whose target host equals this domain (case-insensitive).
+	TargetDomain string `protobuf:"bytes,6,opt,name=target_domain,json=targetDomain,proto3" json:"target_domain,omitempty"`
+	// Only return links created at or after this time.
+	CreateTimeAfter *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time_after,json=createTimeAfter,proto3" json:"create_time_after,omitempty"`
+	// Only return links created before this time.
+	CreateTimeBefore *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time_before,json=createTimeBefore,proto3" json:"create_time_before,omitempty"`
+	// Only return links whose target contains this substring (case-insensitive).
+	TargetContains string `protobuf:"bytes,9,opt,name=target_contains,json=targetContains,proto3" json:"target_contains,omitempty"`
+	// Result order. page_token is only valid for the order it was issued with.
+	Sort ListLinksRequest_Sort `protobuf:"varint,10,opt,name=sort,proto3,enum=url_shortener.v2.ListLinksRequest_Sort" json:"sort,omitempty"`
+	// Only return links in this campaign.
+	Campaign      string `protobuf:"bytes,11,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksRequest) Reset() {
+	*x = ListLinksRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksRequest) ProtoMessage() {}
+
+func (x *ListLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListLinksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLinksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListLinksRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ListLinksRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListLinksRequest) GetShowDisabled() bool {
+	if x != nil {
+		return x.ShowDisabled
+	}
+	return false
+}
+
+func (x *ListLinksRequest) GetTargetDomain() string {
+	if x != nil {
+		return x.TargetDomain
+	}
+	return ""
+}
+
+func (x *ListLinksRequest) GetCreateTimeAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTimeAfter
+	}
+	return nil
+}
+
+func (x *ListLinksRequest) GetCreateTimeBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTimeBefore
+	}
+	return nil
+}
+
+func (x *ListLinksRequest) GetTargetContains() string {
+	if x != nil {
+		return x.TargetContains
+	}
+	return ""
+}
+
+func (x *ListLinksRequest) GetSort() ListLinksRequest_Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return ListLinksRequest_SORT_UNSPECIFIED
+}
+
+func (x *ListLinksRequest) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+type ListLinksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Links []*Link                `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	// Empty when there are no more links.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinksResponse) Reset() {
+	*x = ListLinksResponse{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinksResponse) ProtoMessage() {}
+
+func (x *ListLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListLinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListLinksResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *ListLinksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetLinkQRCodeRequest struct {
+	state  protoimpl.MessageState      `protogen:"open.v1"`
+	Code   string                      `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Format GetLinkQRCodeRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=url_shortener.v2.GetLinkQRCodeRequest_Format" json:"format,omitempty"`
+	// Width and height of the image in pixels. Defaults to 256.
+	Size            int32                                `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	ErrorCorrection GetLinkQRCodeRequest_ErrorCorrection `protobuf:"varint,4,opt,name=error_correction,json=errorCorrection,proto3,enum=url_shortener.v2.GetLinkQRCodeRequest_ErrorCorrection" json:"error_correction,omitempty"`
+	// Quiet zone around the symbol in modules. Defaults to 4.
+	Margin *int32 `protobuf:"varint,5,opt,name=margin,proto3,oneof" json:"margin,omitempty"`
+	// Hex color, RRGGBB or RRGGBBAA with an optional '#'. Defaults to black.
+	ForegroundColor string `protobuf:"bytes,6,opt,name=foreground_color,json=foregroundColor,proto3" json:"foreground_color,omitempty"`
+	// Hex color, RRGGBB or RRGGBBAA with an optional '#'. Defaults to white.
+	BackgroundColor string `protobuf:"bytes,7,opt,name=background_color,json=backgroundColor,proto3" json:"background_color,omitempty"`
+	// Draw the configured logo in the center.
+	Logo          bool `protobuf:"varint,8,opt,name=logo,proto3" json:"logo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkQRCodeRequest) Reset() {
+	*x = GetLinkQRCodeRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkQRCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkQRCodeRequest) ProtoMessage() {}
+
+func (x *GetLinkQRCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkQRCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkQRCodeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetLinkQRCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetLinkQRCodeRequest) GetFormat() GetLinkQRCodeRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return GetLinkQRCodeRequest_FORMAT_UNSPECIFIED
+}
+
+func (x *GetLinkQRCodeRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetLinkQRCodeRequest) GetErrorCorrection() GetLinkQRCodeRequest_ErrorCorrection {
+	if x != nil {
+		return x.ErrorCorrection
+	}
+	return GetLinkQRCodeRequest_ERROR_CORRECTION_UNSPECIFIED
+}
+
+func (x *GetLinkQRCodeRequest) GetMargin() int32 {
+	if x != nil && x.Margin != nil {
+		return *x.Margin
+	}
+	return 0
+}
+
+func (x *GetLinkQRCodeRequest) GetForegroundColor() string {
+	if x != nil {
+		return x.ForegroundColor
+	}
+	return ""
+}
+
+func (x *GetLinkQRCodeRequest) GetBackgroundColor() string {
+	if x != nil {
+		return x.BackgroundColor
+	}
+	return ""
+}
+
+func (x *GetLinkQRCodeRequest) GetLogo() bool {
+	if x != nil {
+		return x.Logo
+	}
+	return false
+}
+
+type QRCode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// image/png or image/svg+xml
+	ContentType string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data        []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Strong entity tag of data.
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// The encoded short URL.
+	Url           string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QRCode) Reset() {
+	*x = QRCode{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QRCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QRCode) ProtoMessage() {}
+
+func (x *QRCode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QRCode.ProtoReflect.Descriptor instead.
+func (*QRCode) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QRCode) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *QRCode) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *QRCode) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *QRCode) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Rule redirects requests matching its conditions to its target instead of the link target.
+// Rules are evaluated in order and the first match wins.
+type Rule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Output only.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Absolute http(s) URL matching requests redirect to.
+	Target        string          `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Conditions    *RuleConditions `protobuf:"bytes,3,opt,name=conditions,proto3" json:"conditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Rule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rule) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Rule) GetConditions() *RuleConditions {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+// RuleConditions must all hold for a rule to match. Each repeated field matches if any of its
+// values does, and empty fields don't restrict the match.
+type RuleConditions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Platform from the User-Agent header.
+	Platforms []RuleConditions_Platform `protobuf:"varint,1,rep,packed,name=platforms,proto3,enum=url_shortener.v2.RuleConditions_Platform" json:"platforms,omitempty"`
+	// Language tags such as "de" or "pt-BR", matched against the preferred language from the
+	// Accept-Language header. A tag also matches its subtags.
+	Languages []string `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
+	// ISO 3166-1 alpha-2 country codes, matched against the GeoIP country of the client.
+	Countries []string `protobuf:"bytes,3,rep,name=countries,proto3" json:"countries,omitempty"`
+	// Days of the week in time_zone.
+	Days []RuleConditions_Day `protobuf:"varint,4,rep,packed,name=days,proto3,enum=url_shortener.v2.RuleConditions_Day" json:"days,omitempty"`
+	// Time of day in time_zone as HH:MM, inclusive. A window with from after to spans midnight.
+	From string `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	// Time of day in time_zone as HH:MM, exclusive.
+	To string `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	// IANA time zone name for days, from and to. Defaults to UTC.
+	TimeZone string `protobuf:"bytes,7,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Required query parameters of the request. An empty value only requires the parameter to be present.
+	Query         map[string]string `protobuf:"bytes,8,rep,name=query,proto3" json:"query,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuleConditions) Reset() {
+	*x = RuleConditions{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleConditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleConditions) ProtoMessage() {}
+
+func (x *RuleConditions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleConditions.ProtoReflect.Descriptor instead.
+func (*RuleConditions) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RuleConditions) GetPlatforms() []RuleConditions_Platform {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+func (x *RuleConditions) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *RuleConditions) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *RuleConditions) GetDays() []RuleConditions_Day {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *RuleConditions) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RuleConditions) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *RuleConditions) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *RuleConditions) GetQuery() map[string]string {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type ListLinkRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinkRulesRequest) Reset() {
+	*x = ListLinkRulesRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinkRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinkRulesRequest) ProtoMessage() {}
+
+func (x *ListLinkRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinkRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListLinkRulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListLinkRulesRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ListLinkRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*Rule                `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinkRulesResponse) Reset() {
+	*x = ListLinkRulesResponse{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinkRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinkRulesResponse) ProtoMessage() {}
+
+func (x *ListLinkRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinkRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListLinkRulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListLinkRulesResponse) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type CreateLinkRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Rule  *Rule                  `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Position of the new rule. Unset appends it.
+	Index         *int32 `protobuf:"varint,3,opt,name=index,proto3,oneof" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLinkRuleRequest) Reset() {
+	*x = CreateLinkRuleRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLinkRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLinkRuleRequest) ProtoMessage() {}
+
+func (x *CreateLinkRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLinkRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateLinkRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateLinkRuleRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateLinkRuleRequest) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *CreateLinkRuleRequest) GetIndex() int32 {
+	if x != nil && x.Index != nil {
+		return *x.Index
+	}
+	return 0
+}
+
+type UpdateLinkRuleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The rule to update. Its id identifies the rule.
+	Rule *Rule `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	// New position of the rule. Unset keeps it in place.
+	Index         *int32 `protobuf:"varint,3,opt,name=index,proto3,oneof" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLinkRuleRequest) Reset() {
+	*x = UpdateLinkRuleRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkRuleRequest) ProtoMessage() {}
+
+func (x *UpdateLinkRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkRuleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLinkRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateLinkRuleRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UpdateLinkRuleRequest) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *UpdateLinkRuleRequest) GetIndex() int32 {
+	if x != nil && x.Index != nil {
+		return *x.Index
+	}
+	return 0
+}
+
+type DeleteLinkRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	RuleId        string                 `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLinkRuleRequest) Reset() {
+	*x = DeleteLinkRuleRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLinkRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLinkRuleRequest) ProtoMessage() {}
+
+func (x *DeleteLinkRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLinkRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLinkRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteLinkRuleRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *DeleteLinkRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+// EvaluateLinkRulesRequest describes the request to evaluate the rules against.
+type EvaluateLinkRulesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Code           string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	UserAgent      string                 `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	AcceptLanguage string                 `protobuf:"bytes,3,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
+	// Client IP address to look up the country of. Defaults to the caller's address.
+	Ip string `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	// Overrides the GeoIP country of ip.
+	Country string `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	// Defaults to now.
+	Time          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+	Query         map[string]string      `protobuf:"bytes,7,rep,name=query,proto3" json:"query,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateLinkRulesRequest) Reset() {
+	*x = EvaluateLinkRulesRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateLinkRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateLinkRulesRequest) ProtoMessage() {}
+
+func (x *EvaluateLinkRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateLinkRulesRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateLinkRulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *EvaluateLinkRulesRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *EvaluateLinkRulesRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *EvaluateLinkRulesRequest) GetAcceptLanguage() string {
+	if x != nil {
+		return x.AcceptLanguage
+	}
+	return ""
+}
+
+func (x *EvaluateLinkRulesRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *EvaluateLinkRulesRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *EvaluateLinkRulesRequest) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *EvaluateLinkRulesRequest) GetQuery() map[string]string {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type EvaluateLinkRulesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The first matching rule, unset if none matched.
+	Rule *Rule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Where the link would redirect to.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// The request context the rules were evaluated against.
+	Platform      RuleConditions_Platform `protobuf:"varint,3,opt,name=platform,proto3,enum=url_shortener.v2.RuleConditions_Platform" json:"platform,omitempty"`
+	Languages     []string                `protobuf:"bytes,4,rep,name=languages,proto3" json:"languages,omitempty"`
+	Country       string                  `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	Time          *timestamppb.Timestamp  `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateLinkRulesResponse) Reset() {
+	*x = EvaluateLinkRulesResponse{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateLinkRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateLinkRulesResponse) ProtoMessage() {}
+
+func (x *EvaluateLinkRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateLinkRulesResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateLinkRulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EvaluateLinkRulesResponse) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *EvaluateLinkRulesResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *EvaluateLinkRulesResponse) GetPlatform() RuleConditions_Platform {
+	if x != nil {
+		return x.Platform
+	}
+	return RuleConditions_PLATFORM_UNSPECIFIED
+}
+
+func (x *EvaluateLinkRulesResponse) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *EvaluateLinkRulesResponse) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *EvaluateLinkRulesResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type GetLinkVariantStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkVariantStatsRequest) Reset() {
+	*x = GetLinkVariantStatsRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkVariantStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkVariantStatsRequest) ProtoMessage() {}
+
+func (x *GetLinkVariantStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkVariantStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkVariantStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetLinkVariantStatsRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VariantStats struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Variant *Variant               `protobuf:"bytes,1,opt,name=variant,proto3" json:"variant,omitempty"`
+	// Resolves that picked the variant.
+	Clicks int64 `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	// The variant has recorded clicks but is no longer configured. Only its id is set.
+	Removed       bool `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariantStats) Reset() {
+	*x = VariantStats{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantStats) ProtoMessage() {}
+
+func (x *VariantStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantStats.ProtoReflect.Descriptor instead.
+func (*VariantStats) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *VariantStats) GetVariant() *Variant {
+	if x != nil {
+		return x.Variant
+	}
+	return nil
+}
+
+func (x *VariantStats) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *VariantStats) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+type GetLinkVariantStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*VariantStats        `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkVariantStatsResponse) Reset() {
+	*x = GetLinkVariantStatsResponse{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkVariantStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkVariantStatsResponse) ProtoMessage() {}
+
+func (x *GetLinkVariantStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkVariantStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkVariantStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetLinkVariantStatsResponse) GetVariants() []*VariantStats {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type GetLinkClickHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Number of UTC days up to and including today, 30 if unset.
+	Days          int32 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkClickHistoryRequest) Reset() {
+	*x = GetLinkClickHistoryRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkClickHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkClickHistoryRequest) ProtoMessage() {}
+
+func (x *GetLinkClickHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkClickHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkClickHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetLinkClickHistoryRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetLinkClickHistoryRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type DailyClicks struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Midnight UTC of the day.
+	Day           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyClicks) Reset() {
+	*x = DailyClicks{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyClicks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyClicks) ProtoMessage() {}
+
+func (x *DailyClicks) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyClicks.ProtoReflect.Descriptor instead.
+func (*DailyClicks) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DailyClicks) GetDay() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Day
+	}
+	return nil
+}
+
+func (x *DailyClicks) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+type LinkClickHistory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resolves of the link since it was created.
+	Total int64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// One entry per day, oldest first, including days without clicks.
+	Days          []*DailyClicks `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkClickHistory) Reset() {
+	*x = LinkClickHistory{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkClickHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkClickHistory) ProtoMessage() {}
+
+func (x *LinkClickHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkClickHistory.ProtoReflect.Descriptor instead.
+func (*LinkClickHistory) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LinkClickHistory) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *LinkClickHistory) GetDays() []*DailyClicks {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type BatchUpdateLinkTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Codes of the links to update.
+	Codes []string `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	// Tags added to the links that don't have them yet.
+	AddTags []string `protobuf:"bytes,2,rep,name=add_tags,json=addTags,proto3" json:"add_tags,omitempty"`
+	// Tags removed from the links. A tag both added and removed is removed.
+	RemoveTags    []string `protobuf:"bytes,3,rep,name=remove_tags,json=removeTags,proto3" json:"remove_tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateLinkTagsRequest) Reset() {
+	*x = BatchUpdateLinkTagsRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateLinkTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateLinkTagsRequest) ProtoMessage() {}
+
+func (x *BatchUpdateLinkTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateLinkTagsRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateLinkTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BatchUpdateLinkTagsRequest) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+func (x *BatchUpdateLinkTagsRequest) GetAddTags() []string {
+	if x != nil {
+		return x.AddTags
+	}
+	return nil
+}
+
+func (x *BatchUpdateLinkTagsRequest) GetRemoveTags() []string {
+	if x != nil {
+		return x.RemoveTags
+	}
+	return nil
+}
+
+type BatchUpdateLinkTagsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The links whose tags changed.
+	Links []*Link `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	// Codes that name no link of the domain.
+	NotFoundCodes []string `protobuf:"bytes,2,rep,name=not_found_codes,json=notFoundCodes,proto3" json:"not_found_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateLinkTagsResponse) Reset() {
+	*x = BatchUpdateLinkTagsResponse{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateLinkTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateLinkTagsResponse) ProtoMessage() {}
+
+func (x *BatchUpdateLinkTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateLinkTagsResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateLinkTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BatchUpdateLinkTagsResponse) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *BatchUpdateLinkTagsResponse) GetNotFoundCodes() []string {
+	if x != nil {
+		return x.NotFoundCodes
+	}
+	return nil
+}
+
+type ListCampaignStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignStatsRequest) Reset() {
+	*x = ListCampaignStatsRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignStatsRequest) ProtoMessage() {}
+
+func (x *ListCampaignStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignStatsRequest.ProtoReflect.Descriptor instead.
+func (*ListCampaignStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{32}
+}
+
+type CampaignStats struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Campaign string                 `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	// Links in the campaign, disabled ones included.
+	Links int64 `protobuf:"varint,2,opt,name=links,proto3" json:"links,omitempty"`
+	// Resolves of the links in the campaign.
+	Clicks        int64 `protobuf:"varint,3,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignStats) Reset() {
+	*x = CampaignStats{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignStats) ProtoMessage() {}
+
+func (x *CampaignStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignStats.ProtoReflect.Descriptor instead.
+func (*CampaignStats) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CampaignStats) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *CampaignStats) GetLinks() int64 {
+	if x != nil {
+		return x.Links
+	}
+	return 0
+}
+
+func (x *CampaignStats) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+type ListCampaignStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by campaign.
+	Campaigns     []*CampaignStats `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignStatsResponse) Reset() {
+	*x = ListCampaignStatsResponse{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignStatsResponse) ProtoMessage() {}
+
+func (x *ListCampaignStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignStatsResponse.ProtoReflect.Descriptor instead.
+func (*ListCampaignStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListCampaignStatsResponse) GetCampaigns() []*CampaignStats {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+type WatchLinksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence number of the last change seen. 0 streams the whole log.
+	AfterSeq      int64 `protobuf:"varint,1,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLinksRequest) Reset() {
+	*x = WatchLinksRequest{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLinksRequest) ProtoMessage() {}
+
+func (x *WatchLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLinksRequest.ProtoReflect.Descriptor instead.
+func (*WatchLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WatchLinksRequest) GetAfterSeq() int64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+type LinkChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Increases in commit order.
+	Seq  int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Type LinkChange_Type        `protobuf:"varint,2,opt,name=type,proto3,enum=url_shortener.v2.LinkChange_Type" json:"type,omitempty"`
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// The link after the change, or before it for deletions. Code and short_url are empty if the
+	// domain of the link is no longer configured.
+	Link *Link `protobuf:"bytes,4,opt,name=link,proto3" json:"link,omitempty"`
+	// Changed fields of updates: the fields of UpdateLink as well as "rules" and "remaining_clicks".
+	Fields        []string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkChange) Reset() {
+	*x = LinkChange{}
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkChange) ProtoMessage() {}
+
+func (x *LinkChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_url_shortener_v2_url_shortener_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkChange.ProtoReflect.Descriptor instead.
+func (*LinkChange) Descriptor() ([]byte, []int) {
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LinkChange) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *LinkChange) GetType() LinkChange_Type {
+	if x != nil {
+		return x.Type
+	}
+	return LinkChange_TYPE_UNSPECIFIED
+}
+
+func (x *LinkChange) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *LinkChange) GetLink() *Link {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *LinkChange) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+var File_proto_url_shortener_v2_url_shortener_proto protoreflect.FileDescriptor
+
+const file_proto_url_shortener_v2_url_shortener_proto_rawDesc = "" +
+	"\n" +
+	"*proto/url_shortener/v2/url_shortener.proto\x12\x10url_shortener.v2\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\"\x9d\b\n" +
+	"\x04Link\x12\x1b\n" +
+	"\x04code\x18\x01 \x01(\tB\a\xfaB\x04r\x02\x18@R\x04code\x126\n" +
+	"\x06target\x18\x02 \x01(\tB\x1e\xfaB\x1br\x19\x18\x80\x102\x0e^(?i)https?://\xd0\x01\x01\x88\x01\x01R\x06target\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12\x1e\n" +
+	"\x05owner\x18\x05 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x01R\x05owner\x12;\n" +
+	"\vexpire_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expireTime\x12;\n" +
+	"\x04tags\x18\a \x03(\tB'\xfaB$\x92\x01!\x10 \x18\x01\"\x1br\x192\x17^[0-9A-Za-z_.:-]{1,64}$R\x04tags\x12\x1a\n" +
+	"\bdisabled\x18\b \x01(\bR\bdisabled\x12#\n" +
+	"\bpassword\x18\t \x01(\tB\a\xfaB\x04r\x02(HR\bpassword\x12-\n" +
+	"\x12password_protected\x18\n" +
+	" \x01(\bR\x11passwordProtected\x12&\n" +
+	"\n" +
+	"max_clicks\x18\v \x01(\x03B\a\xfaB\x04\"\x02(\x00R\tmaxClicks\x12)\n" +
+	"\x10remaining_clicks\x18\f \x01(\x03R\x0fremainingClicks\x12?\n" +
+	"\bvariants\x18\r \x03(\v2\x19.url_shortener.v2.VariantB\b\xfaB\x05\x92\x01\x02\x10\x10R\bvariants\x12I\n" +
+	"\n" +
+	"split_mode\x18\x0e \x01(\x0e2 .url_shortener.v2.Link.SplitModeB\b\xfaB\x05\x82\x01\x02\x10\x01R\tsplitMode\x12?\n" +
+	"\vpassthrough\x18\x0f \x01(\v2\x1d.url_shortener.v2.PassthroughR\vpassthrough\x12:\n" +
+	"\btemplate\x18\x10 \x01(\v2\x1e.url_shortener.v2.LinkTemplateR\btemplate\x12 \n" +
+	"\x06domain\x18\x11 \x01(\tB\b\xfaB\x05r\x03\x18\xfd\x01R\x06domain\x12\x1b\n" +
+	"\tshort_url\x18\x12 \x01(\tR\bshortUrl\x122\n" +
+	"\bcampaign\x18\x13 \x01(\tB\x16\xfaB\x13r\x11\x18\x80\x012\f^[^\\p{Cc}]*$R\bcampaign\x12\x16\n" +
+	"\x06clicks\x18\x14 \x01(\x03R\x06clicks\"U\n" +
+	"\tSplitMode\x12\x1a\n" +
+	"\x16SPLIT_MODE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11SPLIT_MODE_RANDOM\x10\x01\x12\x15\n" +
+	"\x11SPLIT_MODE_STICKY\x10\x02\",\n" +
+	"\fLinkTemplate\x12\x1c\n" +
+	"\x04path\x18\x01 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\x04path\"\x8b\x02\n" +
+	"\vPassthrough\x12I\n" +
+	"\x05query\x18\x01 \x01(\x0e2).url_shortener.v2.Passthrough.QueryPolicyB\b\xfaB\x05\x82\x01\x02\x10\x01R\x05query\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\bR\x04path\x12'\n" +
+	"\x03utm\x18\x03 \x01(\v2\x15.url_shortener.v2.UtmR\x03utm\"t\n" +
+	"\vQueryPolicy\x12\x1c\n" +
+	"\x18QUERY_POLICY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11QUERY_POLICY_KEEP\x10\x01\x12\x19\n" +
+	"\x15QUERY_POLICY_OVERRIDE\x10\x02\x12\x15\n" +
+	"\x11QUERY_POLICY_DROP\x10\x03\"\xb1\x01\n" +
+	"\x03Utm\x12 \n" +
+	"\x06source\x18\x01 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\x06source\x12 \n" +
+	"\x06medium\x18\x02 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\x06medium\x12$\n" +
+	"\bcampaign\x18\x03 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\bcampaign\x12\x1c\n" +
+	"\x04term\x18\x04 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\x04term\x12\"\n" +
+	"\acontent\x18\x05 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\acontent\"\x90\x01\n" +
+	"\aVariant\x12,\n" +
+	"\x02id\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,32}$R\x02id\x123\n" +
+	"\x06target\x18\x02 \x01(\tB\x1b\xfaB\x18r\x16\x18\x80\x102\x0e^(?i)https?://\x88\x01\x01R\x06target\x12\"\n" +
+	"\x06weight\x18\x03 \x01(\x05B\n" +
+	"\xfaB\a\x1a\x05\x18\x90N(\x00R\x06weight\"I\n" +
+	"\x11CreateLinkRequest\x124\n" +
+	"\x04link\x18\x01 \x01(\v2\x16.url_shortener.v2.LinkB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04link\"B\n" +
+	"\x0eGetLinkRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\"\xae\x02\n" +
+	"\x12ResolveLinkRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\x12#\n" +
+	"\bpassword\x18\x02 \x01(\tB\a\xfaB\x04r\x02(HR\bpassword\x12'\n" +
+	"\n" +
+	"visitor_id\x18\x03 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x01R\tvisitorId\x12\x1c\n" +
+	"\x04path\x18\x04 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x10R\x04path\x12\x1e\n" +
+	"\x05query\x18\x05 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x10R\x05query\x12'\n" +
+	"\n" +
+	"user_agent\x18\x06 \x01(\tB\b\xfaB\x05r\x03\x18\x80\bR\tuserAgent\x121\n" +
+	"\x0faccept_language\x18\a \x01(\tB\b\xfaB\x05r\x03\x18\x80\bR\x0eacceptLanguage\"L\n" +
+	"\x13ResolveLinkResponse\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\tR\tvariantId\"\x86\x01\n" +
+	"\x11UpdateLinkRequest\x124\n" +
+	"\x04link\x18\x01 \x01(\v2\x16.url_shortener.v2.LinkB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04link\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"E\n" +
+	"\x11DeleteLinkRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\"\x8c\x05\n" +
+	"\x10ListLinksRequest\x12'\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\n" +
+	"\xfaB\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\x12'\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\tpageToken\x12\x1e\n" +
+	"\x05owner\x18\x03 \x01(\tB\b\xfaB\x05r\x03\x18\x80\x01R\x05owner\x12\x19\n" +
+	"\x03tag\x18\x04 \x01(\tB\a\xfaB\x04r\x02\x18@R\x03tag\x12#\n" +
+	"\rshow_disabled\x18\x05 \x01(\bR\fshowDisabled\x12-\n" +
+	"\rtarget_domain\x18\x06 \x01(\tB\b\xfaB\x05r\x03\x18\xfd\x01R\ftargetDomain\x12F\n" +
+	"\x11create_time_after\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0fcreateTimeAfter\x12H\n" +
+	"\x12create_time_before\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x10createTimeBefore\x121\n" +
+	"\x0ftarget_contains\x18\t \x01(\tB\b\xfaB\x05r\x03\x18\x80\x02R\x0etargetContains\x12E\n" +
+	"\x04sort\x18\n" +
+	" \x01(\x0e2'.url_shortener.v2.ListLinksRequest.SortB\b\xfaB\x05\x82\x01\x02\x10\x01R\x04sort\x12$\n" +
+	"\bcampaign\x18\v \x01(\tB\b\xfaB\x05r\x03\x18\x80\x01R\bcampaign\"e\n" +
+	"\x04Sort\x12\x14\n" +
+	"\x10SORT_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSORT_CODE_DESC\x10\x01\x12\x18\n" +
+	"\x14SORT_CREATE_TIME_ASC\x10\x02\x12\x19\n" +
+	"\x15SORT_CREATE_TIME_DESC\x10\x03\"i\n" +
+	"\x11ListLinksResponse\x12,\n" +
+	"\x05links\x18\x01 \x03(\v2\x16.url_shortener.v2.LinkR\x05links\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8c\x06\n" +
+	"\x14GetLinkQRCodeRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\x12O\n" +
+	"\x06format\x18\x02 \x01(\x0e2-.url_shortener.v2.GetLinkQRCodeRequest.FormatB\b\xfaB\x05\x82\x01\x02\x10\x01R\x06format\x12 \n" +
+	"\x04size\x18\x03 \x01(\x05B\f\xfaB\t\x1a\a\x18\x80 (@@\x01R\x04size\x12k\n" +
+	"\x10error_correction\x18\x04 \x01(\x0e26.url_shortener.v2.GetLinkQRCodeRequest.ErrorCorrectionB\b\xfaB\x05\x82\x01\x02\x10\x01R\x0ferrorCorrection\x12&\n" +
+	"\x06margin\x18\x05 \x01(\x05B\t\xfaB\x06\x1a\x04\x18\x10(\x00H\x00R\x06margin\x88\x01\x01\x12X\n" +
+	"\x10foreground_color\x18\x06 \x01(\tB-\xfaB*r(2#^#?([0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$\xd0\x01\x01R\x0fforegroundColor\x12X\n" +
+	"\x10background_color\x18\a \x01(\tB-\xfaB*r(2#^#?([0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$\xd0\x01\x01R\x0fbackgroundColor\x12\x12\n" +
+	"\x04logo\x18\b \x01(\bR\x04logo\"@\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"FORMAT_PNG\x10\x01\x12\x0e\n" +
+	"\n" +
+	"FORMAT_SVG\x10\x02\"\xa4\x01\n" +
+	"\x0fErrorCorrection\x12 \n" +
+	"\x1cERROR_CORRECTION_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ERROR_CORRECTION_LOW\x10\x01\x12\x1b\n" +
+	"\x17ERROR_CORRECTION_MEDIUM\x10\x02\x12\x1d\n" +
+	"\x19ERROR_CORRECTION_QUARTILE\x10\x03\x12\x19\n" +
+	"\x15ERROR_CORRECTION_HIGH\x10\x04B\t\n" +
+	"\a_margin\"e\n" +
+	"\x06QRCode\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\"\x8d\x01\n" +
+	"\x04Rule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x123\n" +
+	"\x06target\x18\x02 \x01(\tB\x1b\xfaB\x18r\x16\x18\x80\x102\x0e^(?i)https?://\x88\x01\x01R\x06target\x12@\n" +
+	"\n" +
+	"conditions\x18\x03 \x01(\v2 .url_shortener.v2.RuleConditionsR\n" +
+	"conditions\"\xb2\a\n" +
+	"\x0eRuleConditions\x12Z\n" +
+	"\tplatforms\x18\x01 \x03(\x0e2).url_shortener.v2.RuleConditions.PlatformB\x11\xfaB\x0e\x92\x01\v\x10 \"\a\x82\x01\x04\x10\x01 \x00R\tplatforms\x12O\n" +
+	"\tlanguages\x18\x02 \x03(\tB1\xfaB.\x92\x01+\x10 \"'r%2#^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$R\tlanguages\x129\n" +
+	"\tcountries\x18\x03 \x03(\tB\x1b\xfaB\x18\x92\x01\x15\x10 \"\x11r\x0f2\r^[A-Za-z]{2}$R\tcountries\x12K\n" +
+	"\x04days\x18\x04 \x03(\x0e2$.url_shortener.v2.RuleConditions.DayB\x11\xfaB\x0e\x92\x01\v\x10\a\"\a\x82\x01\x04\x10\x01 \x00R\x04days\x12=\n" +
+	"\x04from\x18\x05 \x01(\tB)\xfaB&r$2\x1f^([01][0-9]|2[0-3]):[0-5][0-9]$\xd0\x01\x01R\x04from\x129\n" +
+	"\x02to\x18\x06 \x01(\tB)\xfaB&r$2\x1f^([01][0-9]|2[0-3]):[0-5][0-9]$\xd0\x01\x01R\x02to\x12$\n" +
+	"\ttime_zone\x18\a \x01(\tB\a\xfaB\x04r\x02\x18@R\btimeZone\x12[\n" +
+	"\x05query\x18\b \x03(\v2+.url_shortener.v2.RuleConditions.QueryEntryB\x18\xfaB\x15\x9a\x01\x12\x10 \"\ar\x05\x10\x01\x18\x80\x01*\x05r\x03\x18\x80\x04R\x05query\x1a8\n" +
+	"\n" +
+	"QueryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9e\x01\n" +
+	"\bPlatform\x12\x18\n" +
+	"\x14PLATFORM_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fPLATFORM_IOS\x10\x01\x12\x14\n" +
+	"\x10PLATFORM_ANDROID\x10\x02\x12\x14\n" +
+	"\x10PLATFORM_WINDOWS\x10\x03\x12\x12\n" +
+	"\x0ePLATFORM_MACOS\x10\x04\x12\x12\n" +
+	"\x0ePLATFORM_LINUX\x10\x05\x12\x12\n" +
+	"\x0ePLATFORM_OTHER\x10\x06\"\x92\x01\n" +
+	"\x03Day\x12\x13\n" +
+	"\x0fDAY_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"DAY_MONDAY\x10\x01\x12\x0f\n" +
+	"\vDAY_TUESDAY\x10\x02\x12\x11\n" +
+	"\rDAY_WEDNESDAY\x10\x03\x12\x10\n" +
+	"\fDAY_THURSDAY\x10\x04\x12\x0e\n" +
+	"\n" +
+	"DAY_FRIDAY\x10\x05\x12\x10\n" +
+	"\fDAY_SATURDAY\x10\x06\x12\x0e\n" +
+	"\n" +
+	"DAY_SUNDAY\x10\a\"H\n" +
+	"\x14ListLinkRulesRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\"E\n" +
+	"\x15ListLinkRulesResponse\x12,\n" +
+	"\x05rules\x18\x01 \x03(\v2\x16.url_shortener.v2.RuleR\x05rules\"\xad\x01\n" +
+	"\x15CreateLinkRuleRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\x124\n" +
+	"\x04rule\x18\x02 \x01(\v2\x16.url_shortener.v2.RuleB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04rule\x12\"\n" +
+	"\x05index\x18\x03 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00H\x00R\x05index\x88\x01\x01B\b\n" +
+	"\x06_index\"\xad\x01\n" +
+	"\x15UpdateLinkRuleRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\x124\n" +
+	"\x04rule\x18\x02 \x01(\v2\x16.url_shortener.v2.RuleB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04rule\x12\"\n" +
+	"\x05index\x18\x03 \x01(\x05B\a\xfaB\x04\x1a\x02(\x00H\x00R\x05index\x88\x01\x01B\b\n" +
+	"\x06_index\"m\n" +
+	"\x15DeleteLinkRuleRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\x12\"\n" +
+	"\arule_id\x18\x02 \x01(\tB\t\xfaB\x06r\x04\x10\x01\x18@R\x06ruleId\"\xb8\x03\n" +
+	"\x18EvaluateLinkRulesRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\x12'\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tB\b\xfaB\x05r\x03\x18\x80\bR\tuserAgent\x121\n" +
+	"\x0faccept_language\x18\x03 \x01(\tB\b\xfaB\x05r\x03\x18\x80\bR\x0eacceptLanguage\x12\x1a\n" +
+	"\x02ip\x18\x04 \x01(\tB\n" +
+	"\xfaB\ar\x05\xd0\x01\x01p\x01R\x02ip\x121\n" +
+	"\acountry\x18\x05 \x01(\tB\x17\xfaB\x14r\x122\r^[A-Za-z]{2}$\xd0\x01\x01R\acountry\x12.\n" +
+	"\x04time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12U\n" +
+	"\x05query\x18\a \x03(\v25.url_shortener.v2.EvaluateLinkRulesRequest.QueryEntryB\b\xfaB\x05\x9a\x01\x02\x10 R\x05query\x1a8\n" +
+	"\n" +
+	"QueryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8e\x02\n" +
+	"\x19EvaluateLinkRulesResponse\x12*\n" +
+	"\x04rule\x18\x01 \x01(\v2\x16.url_shortener.v2.RuleR\x04rule\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12E\n" +
+	"\bplatform\x18\x03 \x01(\x0e2).url_shortener.v2.RuleConditions.PlatformR\bplatform\x12\x1c\n" +
+	"\tlanguages\x18\x04 \x03(\tR\tlanguages\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12.\n" +
+	"\x04time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"N\n" +
+	"\x1aGetLinkVariantStatsRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\"u\n" +
+	"\fVariantStats\x123\n" +
+	"\avariant\x18\x01 \x01(\v2\x19.url_shortener.v2.VariantR\avariant\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12\x18\n" +
+	"\aremoved\x18\x03 \x01(\bR\aremoved\"Y\n" +
+	"\x1bGetLinkVariantStatsResponse\x12:\n" +
+	"\bvariants\x18\x01 \x03(\v2\x1e.url_shortener.v2.VariantStatsR\bvariants\"n\n" +
+	"\x1aGetLinkClickHistoryRequest\x120\n" +
+	"\x04code\x18\x01 \x01(\tB\x1c\xfaB\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x04code\x12\x1e\n" +
+	"\x04days\x18\x02 \x01(\x05B\n" +
+	"\xfaB\a\x1a\x05\x18\xee\x02(\x00R\x04days\"S\n" +
+	"\vDailyClicks\x12,\n" +
+	"\x03day\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x03day\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"[\n" +
+	"\x10LinkClickHistory\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x121\n" +
+	"\x04days\x18\x02 \x03(\v2\x1d.url_shortener.v2.DailyClicksR\x04days\"\xe8\x01\n" +
+	"\x1aBatchUpdateLinkTagsRequest\x12<\n" +
+	"\x05codes\x18\x01 \x03(\tB&\xfaB#\x92\x01 \b\x01\x10\xe8\a\"\x19r\x172\x15^[0-9A-Za-z_-]{1,64}$R\x05codes\x12B\n" +
+	"\badd_tags\x18\x02 \x03(\tB'\xfaB$\x92\x01!\x10 \x18\x01\"\x1br\x192\x17^[0-9A-Za-z_.:-]{1,64}$R\aaddTags\x12H\n" +
+	"\vremove_tags\x18\x03 \x03(\tB'\xfaB$\x92\x01!\x10 \x18\x01\"\x1br\x192\x17^[0-9A-Za-z_.:-]{1,64}$R\n" +
+	"removeTags\"s\n" +
+	"\x1bBatchUpdateLinkTagsResponse\x12,\n" +
+	"\x05links\x18\x01 \x03(\v2\x16.url_shortener.v2.LinkR\x05links\x12&\n" +
+	"\x0fnot_found_codes\x18\x02 \x03(\tR\rnotFoundCodes\"\x1a\n" +
+	"\x18ListCampaignStatsRequest\"Y\n" +
+	"\rCampaignStats\x12\x1a\n" +
+	"\bcampaign\x18\x01 \x01(\tR\bcampaign\x12\x14\n" +
+	"\x05links\x18\x02 \x01(\x03R\x05links\x12\x16\n" +
+	"\x06clicks\x18\x03 \x01(\x03R\x06clicks\"Z\n" +
+	"\x19ListCampaignStatsResponse\x12=\n" +
+	"\tcampaigns\x18\x01 \x03(\v2\x1f.url_shortener.v2.CampaignStatsR\tcampaigns\"9\n" +
+	"\x11WatchLinksRequest\x12$\n" +
+	"\tafter_seq\x18\x01 \x01(\x03B\a\xfaB\x04\"\x02(\x00R\bafterSeq\"\x9d\x02\n" +
+	"\n" +
+	"LinkChange\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x125\n" +
+	"\x04type\x18\x02 \x01(\x0e2!.url_shortener.v2.LinkChange.TypeR\x04type\x12.\n" +
+	"\x04time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12*\n" +
+	"\x04link\x18\x04 \x01(\v2\x16.url_shortener.v2.LinkR\x04link\x12\x16\n" +
+	"\x06fields\x18\x05 \x03(\tR\x06fields\"R\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_CREATED\x10\x01\x12\x10\n" +
+	"\fTYPE_UPDATED\x10\x02\x12\x10\n" +
+	"\fTYPE_DELETED\x10\x032\x9b\f\n" +
+	"\x13UrlShortenerService\x12I\n" +
+	"\n" +
+	"CreateLink\x12#.url_shortener.v2.CreateLinkRequest\x1a\x16.url_shortener.v2.Link\x12C\n" +
+	"\aGetLink\x12 .url_shortener.v2.GetLinkRequest\x1a\x16.url_shortener.v2.Link\x12Z\n" +
+	"\vResolveLink\x12$.url_shortener.v2.ResolveLinkRequest\x1a%.url_shortener.v2.ResolveLinkResponse\x12I\n" +
+	"\n" +
+	"UpdateLink\x12#.url_shortener.v2.UpdateLinkRequest\x1a\x16.url_shortener.v2.Link\x12I\n" +
+	"\n" +
+	"DeleteLink\x12#.url_shortener.v2.DeleteLinkRequest\x1a\x16.google.protobuf.Empty\x12T\n" +
+	"\tListLinks\x12\".url_shortener.v2.ListLinksRequest\x1a#.url_shortener.v2.ListLinksResponse\x12Q\n" +
+	"\rGetLinkQRCode\x12&.url_shortener.v2.GetLinkQRCodeRequest\x1a\x18.url_shortener.v2.QRCode\x12`\n" +
+	"\rListLinkRules\x12&.url_shortener.v2.ListLinkRulesRequest\x1a'.url_shortener.v2.ListLinkRulesResponse\x12Q\n" +
+	"\x0eCreateLinkRule\x12'.url_shortener.v2.CreateLinkRuleRequest\x1a\x16.url_shortener.v2.Rule\x12Q\n" +
+	"\x0eUpdateLinkRule\x12'.url_shortener.v2.UpdateLinkRuleRequest\x1a\x16.url_shortener.v2.Rule\x12Q\n" +
+	"\x0eDeleteLinkRule\x12'.url_shortener.v2.DeleteLinkRuleRequest\x1a\x16.google.protobuf.Empty\x12l\n" +
+	"\x11EvaluateLinkRules\x12*.url_shortener.v2.EvaluateLinkRulesRequest\x1a+.url_shortener.v2.EvaluateLinkRulesResponse\x12r\n" +
+	"\x13GetLinkVariantStats\x12,.url_shortener.v2.GetLinkVariantStatsRequest\x1a-.url_shortener.v2.GetLinkVariantStatsResponse\x12g\n" +
+	"\x13GetLinkClickHistory\x12,.url_shortener.v2.GetLinkClickHistoryRequest\x1a\".url_shortener.v2.LinkClickHistory\x12r\n" +
+	"\x13BatchUpdateLinkTags\x12,.url_shortener.v2.BatchUpdateLinkTagsRequest\x1a-.url_shortener.v2.BatchUpdateLinkTagsResponse\x12l\n" +
+	"\x11ListCampaignStats\x12*.url_shortener.v2.ListCampaignStatsRequest\x1a+.url_shortener.v2.ListCampaignStatsResponse\x12Q\n" +
+	"\n" +
+	"WatchLinks\x12#.url_shortener.v2.WatchLinksRequest\x1a\x1c.url_shortener.v2.LinkChange0\x01BVZTgithub.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2;url_shortener_v2b\x06proto3"
+
+var (
+	file_proto_url_shortener_v2_url_shortener_proto_rawDescOnce sync.Once
+	file_proto_url_shortener_v2_url_shortener_proto_rawDescData []byte
+)
+
+func file_proto_url_shortener_v2_url_shortener_proto_rawDescGZIP() []byte {
+	file_proto_url_shortener_v2_url_shortener_proto_rawDescOnce.Do(func() {
+		file_proto_url_shortener_v2_url_shortener_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_url_shortener_v2_url_shortener_proto_rawDesc), len(file_proto_url_shortener_v2_url_shortener_proto_rawDesc)))
+	})
+	return file_proto_url_shortener_v2_url_shortener_proto_rawDescData
+}
+
+var file_proto_url_shortener_v2_url_shortener_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_proto_url_shortener_v2_url_shortener_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_proto_url_shortener_v2_url_shortener_proto_goTypes = []any{
+	(Link_SplitMode)(0),                       // 0: url_shortener.v2.Link.SplitMode
+	(Passthrough_QueryPolicy)(0),              // 1: url_shortener.v2.Passthrough.QueryPolicy
+	(ListLinksRequest_Sort)(0),                // 2: url_shortener.v2.ListLinksRequest.Sort
+	(GetLinkQRCodeRequest_Format)(0),          // 3: url_shortener.v2.GetLinkQRCodeRequest.Format
+	(GetLinkQRCodeRequest_ErrorCorrection)(0), // 4: url_shortener.v2.GetLinkQRCodeRequest.ErrorCorrection
+	(RuleConditions_Platform)(0),              // 5: url_shortener.v2.RuleConditions.Platform
+	(RuleConditions_Day)(0),                   // 6: url_shortener.v2.RuleConditions.Day
+	(LinkChange_Type)(0),                      // 7: url_shortener.v2.LinkChange.Type
+	(*Link)(nil),                              // 8: url_shortener.v2.Link
+	(*LinkTemplate)(nil),                      // 9: url_shortener.v2.LinkTemplate
+	(*Passthrough)(nil),                       // 10: url_shortener.v2.Passthrough
+	(*Utm)(nil),                               // 11: url_shortener.v2.Utm
+	(*Variant)(nil),                           // 12: url_shortener.v2.Variant
+	(*CreateLinkRequest)(nil),                 // 13: url_shortener.v2.CreateLinkRequest
+	(*GetLinkRequest)(nil),                    // 14: url_shortener.v2.GetLinkRequest
+	(*ResolveLinkRequest)(nil),                // 15: url_shortener.v2.ResolveLinkRequest
+	(*ResolveLinkResponse)(nil),               // 16: url_shortener.v2.ResolveLinkResponse
+	(*UpdateLinkRequest)(nil),                 // 17: url_shortener.v2.UpdateLinkRequest
+	(*DeleteLinkRequest)(nil),                 // 18: url_shortener.v2.DeleteLinkRequest
+	(*ListLinksRequest)(nil),                  // 19: url_shortener.v2.ListLinksRequest
+	(*ListLinksResponse)(nil),                 // 20: url_shortener.v2.ListLinksResponse
+	(*GetLinkQRCodeRequest)(nil),              // 21: url_shortener.v2.GetLinkQRCodeRequest
+	(*QRCode)(nil),                            // 22: url_shortener.v2.QRCode
+	(*Rule)(nil),                              // 23: url_shortener.v2.Rule
+	(*RuleConditions)(nil),                    // 24: url_shortener.v2.RuleConditions
+	(*ListLinkRulesRequest)(nil),              // 25: url_shortener.v2.ListLinkRulesRequest
+	(*ListLinkRulesResponse)(nil),             // 26: url_shortener.v2.ListLinkRulesResponse
+	(*CreateLinkRuleRequest)(nil),             // 27: url_shortener.v2.CreateLinkRuleRequest
+	(*UpdateLinkRuleRequest)(nil),             // 28: url_shortener.v2.UpdateLinkRuleRequest
+	(*DeleteLinkRuleRequest)(nil),             // 29: url_shortener.v2.DeleteLinkRuleRequest
+	(*EvaluateLinkRulesRequest)(nil),          // 30: url_shortener.v2.EvaluateLinkRulesRequest
+	(*EvaluateLinkRulesResponse)(nil),         // 31: url_shortener.v2.EvaluateLinkRulesResponse
+	(*GetLinkVariantStatsRequest)(nil),        // 32: url_shortener.v2.GetLinkVariantStatsRequest
+	(*VariantStats)(nil),                      // 33: url_shortener.v2.VariantStats
+	(*GetLinkVariantStatsResponse)(nil),       // 34: url_shortener.v2.GetLinkVariantStatsResponse
+	(*GetLinkClickHistoryRequest)(nil),        // 35: url_shortener.v2.GetLinkClickHistoryRequest
+	(*DailyClicks)(nil),                       // 36: url_shortener.v2.DailyClicks
+	(*LinkClickHistory)(nil),                  // 37: url_shortener.v2.LinkClickHistory
+	(*BatchUpdateLinkTagsRequest)(nil),        // 38: url_shortener.v2.BatchUpdateLinkTagsRequest
+	(*BatchUpdateLinkTagsResponse)(nil),       // 39: url_shortener.v2.BatchUpdateLinkTagsResponse
+	(*ListCampaignStatsRequest)(nil),          // 40: url_shortener.v2.ListCampaignStatsRequest
+	(*CampaignStats)(nil),                     // 41: url_shortener.v2.CampaignStats
+	(*ListCampaignStatsResponse)(nil),         // 42: url_shortener.v2.ListCampaignStatsResponse
+	(*WatchLinksRequest)(nil),                 // 43: url_shortener.v2.WatchLinksRequest
+	(*LinkChange)(nil),                        // 44: url_shortener.v2.LinkChange
+	nil,                                       // 45: url_shortener.v2.RuleConditions.QueryEntry
+	nil,                                       // 46: url_shortener.v2.EvaluateLinkRulesRequest.QueryEntry
+	(*timestamppb.Timestamp)(nil),             // 47: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),             // 48: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                     // 49: google.protobuf.Empty
+}
+var file_proto_url_shortener_v2_url_shortener_proto_depIdxs = []int32{
+	47, // 0: url_shortener.v2.Link.create_time:type_name -> google.protobuf.Timestamp
+	47, // 1: url_shortener.v2.Link.update_time:type_name -> google.protobuf.Timestamp
+	47, // 2: url_shortener.v2.Link.expire_time:type_name -> google.protobuf.Timestamp
+	12, // 3: url_shortener.v2.Link.variants:type_name -> url_shortener.v2.Variant
+	0,  // 4: url_shortener.v2.Link.split_mode:type_name -> url_shortener.v2.Link.SplitMode
+	10, // 5: url_shortener.v2.Link.passthrough:type_name -> url_shortener.v2.Passthrough
+	9,  // 6: url_shortener.v2.Link.template:type_name -> url_shortener.v2.LinkTemplate
+	1,  // 7: url_shortener.v2.Passthrough.query:type_name -> url_shortener.v2.Passthrough.QueryPolicy
+	11, // 8: url_shortener.v2.Passthrough.utm:type_name -> url_shortener.v2.Utm
+	8,  // 9: url_shortener.v2.CreateLinkRequest.link:type_name -> url_shortener.v2.Link
+	8,  // 10: url_shortener.v2.UpdateLinkRequest.link:type_name -> url_shortener.v2.Link
+	48, // 11: url_shortener.v2.UpdateLinkRequest.update_mask:type_name -> google.protobuf.FieldMask
+	47, // 12: url_shortener.v2.ListLinksRequest.create_time_after:type_name -> google.protobuf.Timestamp
+	47, // 13: url_shortener.v2.ListLinksRequest.create_time_before:type_name -> google.protobuf.Timestamp
+	2,  // 14: url_shortener.v2.ListLinksRequest.sort:type_name -> url_shortener.v2.ListLinksRequest.Sort
+	8,  // 15: url_shortener.v2.ListLinksResponse.links:type_name -> url_shortener.v2.Link
+	3,  // 16: url_shortener.v2.GetLinkQRCodeRequest.format:type_name -> url_shortener.v2.GetLinkQRCodeRequest.Format
+	4,  // 17: url_shortener.v2.GetLinkQRCodeRequest.error_correction:type_name -> url_shortener.v2.GetLinkQRCodeRequest.ErrorCorrection
+	24, // 18: url_shortener.v2.Rule.conditions:type_name -> url_shortener.v2.RuleConditions
+	5,  // 19: url_shortener.v2.RuleConditions.platforms:type_name -> url_shortener.v2.RuleConditions.Platform
+	6,  // 20: url_shortener.v2.RuleConditions.days:type_name -> url_shortener.v2.RuleConditions.Day
+	45, // 21: url_shortener.v2.RuleConditions.query:type_name -> url_shortener.v2.RuleConditions.QueryEntry
+	23, // 22: url_shortener.v2.ListLinkRulesResponse.rules:type_name -> url_shortener.v2.Rule
+	23, // 23: url_shortener.v2.CreateLinkRuleRequest.rule:type_name -> url_shortener.v2.Rule
+	23, // 24: url_shortener.v2.UpdateLinkRuleRequest.rule:type_name -> url_shortener.v2.Rule
+	47, // 25: url_shortener.v2.EvaluateLinkRulesRequest.time:type_name -> google.protobuf.Timestamp
+	46, // 26: url_shortener.v2.EvaluateLinkRulesRequest.query:type_name -> url_shortener.v2.EvaluateLinkRulesRequest.QueryEntry
+	23, // 27: url_shortener.v2.EvaluateLinkRulesResponse.rule:type_name -> url_shortener.v2.Rule
+	5,  // 28: url_shortener.v2.EvaluateLinkRulesResponse.platform:type_name -> url_shortener.v2.RuleConditions.Platform
+	47, // 29: url_shortener.v2.EvaluateLinkRulesResponse.time:type_name -> google.protobuf.Timestamp
+	12, // 30: url_shortener.v2.VariantStats.variant:type_name -> url_shortener.v2.Variant
+	33, // 31: url_shortener.v2.GetLinkVariantStatsResponse.variants:type_name -> url_shortener.v2.VariantStats
+	47, // 32: url_shortener.v2.DailyClicks.day:type_name -> google.protobuf.Timestamp
+	36, // 33: url_shortener.v2.LinkClickHistory.days:type_name -> url_shortener.v2.DailyClicks
+	8,  // 34: url_shortener.v2.BatchUpdateLinkTagsResponse.links:type_name -> url_shortener.v2.Link
+	41, // 35: url_shortener.v2.ListCampaignStatsResponse.campaigns:type_name -> url_shortener.v2.CampaignStats
+	7,  // 36: url_shortener.v2.LinkChange.type:type_name -> url_shortener.v2.LinkChange.Type
+	47, // 37: url_shortener.v2.LinkChange.time:type_name -> google.protobuf.Timestamp
+	8,  // 38: url_shortener.v2.LinkChange.link:type_name -> url_shortener.v2.Link
+	13, // 39: url_shortener.v2.UrlShortenerService.CreateLink:input_type -> url_shortener.v2.CreateLinkRequest
+	14, // 40: url_shortener.v2.UrlShortenerService.GetLink:input_type -> url_shortener.v2.GetLinkRequest
+	15, // 41: url_shortener.v2.UrlShortenerService.ResolveLink:input_type -> url_shortener.v2.ResolveLinkRequest
+	17, // 42: url_shortener.v2.UrlShortenerService.UpdateLink:input_type -> url_shortener.v2.UpdateLinkRequest
+	18, // 43: url_shortener.v2.UrlShortenerService.DeleteLink:input_type -> url_shortener.v2.DeleteLinkRequest
+	19, // 44: url_shortener.v2.UrlShortenerService.ListLinks:input_type -> url_shortener.v2.ListLinksRequest
+	21, // 45: url_shortener.v2.UrlShortenerService.GetLinkQRCode:input_type -> url_shortener.v2.GetLinkQRCodeRequest
+	25, // 46: url_shortener.v2.UrlShortenerService.ListLinkRules:input_type -> url_shortener.v2.ListLinkRulesRequest
+	27, // 47: url_shortener.v2.UrlShortenerService.CreateLinkRule:input_type -> url_shortener.v2.CreateLinkRuleRequest
+	28, // 48: url_shortener.v2.UrlShortenerService.UpdateLinkRule:input_type -> url_shortener.v2.UpdateLinkRuleRequest
+	29, // 49: url_shortener.v2.UrlShortenerService.DeleteLinkRule:input_type -> url_shortener.v2.DeleteLinkRuleRequest
+	30, // 50: url_shortener.v2.UrlShortenerService.EvaluateLinkRules:input_type -> url_shortener.v2.EvaluateLinkRulesRequest
+	32, // 51: url_shortener.v2.UrlShortenerService.GetLinkVariantStats:input_type -> url_shortener.v2.GetLinkVariantStatsRequest
+	35, // 52: url_shortener.v2.UrlShortenerService.GetLinkClickHistory:input_type -> url_shortener.v2.GetLinkClickHistoryRequest
+	38, // 53: url_shortener.v2.UrlShortenerService.BatchUpdateLinkTags:input_type -> url_shortener.v2.BatchUpdateLinkTagsRequest
+	40, // 54: url_shortener.v2.UrlShortenerService.ListCampaignStats:input_type -> url_shortener.v2.ListCampaignStatsRequest
+	43, // 55: url_shortener.v2.UrlShortenerService.WatchLinks:input_type -> url_shortener.v2.WatchLinksRequest
+	8,  // 56: url_shortener.v2.UrlShortenerService.CreateLink:output_type -> url_shortener.v2.Link
+	8,  // 57: url_shortener.v2.UrlShortenerService.GetLink:output_type -> url_shortener.v2.Link
+	16, // 58: url_shortener.v2.UrlShortenerService.ResolveLink:output_type -> url_shortener.v2.ResolveLinkResponse
+	8,  // 59: url_shortener.v2.UrlShortenerService.UpdateLink:output_type -> url_shortener.v2.Link
+	49, // 60: url_shortener.v2.UrlShortenerService.DeleteLink:output_type -> google.protobuf.Empty
+	20, // 61: url_shortener.v2.UrlShortenerService.ListLinks:output_type -> url_shortener.v2.ListLinksResponse
+	22, // 62: url_shortener.v2.UrlShortenerService.GetLinkQRCode:output_type -> url_shortener.v2.QRCode
+	26, // 63: url_shortener.v2.UrlShortenerService.ListLinkRules:output_type -> url_shortener.v2.ListLinkRulesResponse
+	23, // 64: url_shortener.v2.UrlShortenerService.CreateLinkRule:output_type -> url_shortener.v2.Rule
+	23, // 65: url_shortener.v2.UrlShortenerService.UpdateLinkRule:output_type -> url_shortener.v2.Rule
+	49, // 66: url_shortener.v2.UrlShortenerService.DeleteLinkRule:output_type -> google.protobuf.Empty
+	31, // 67: url_shortener.v2.UrlShortenerService.EvaluateLinkRules:output_type -> url_shortener.v2.EvaluateLinkRulesResponse
+	34, // 68: url_shortener.v2.UrlShortenerService.GetLinkVariantStats:output_type -> url_shortener.v2.GetLinkVariantStatsResponse
+	37, // 69: url_shortener.v2.UrlShortenerService.GetLinkClickHistory:output_type -> url_shortener.v2.LinkClickHistory
+	39, // 70: url_shortener.v2.UrlShortenerService.BatchUpdateLinkTags:output_type -> url_shortener.v2.BatchUpdateLinkTagsResponse
+	42, // 71: url_shortener.v2.UrlShortenerService.ListCampaignStats:output_type -> url_shortener.v2.ListCampaignStatsResponse
+	44, // 72: url_shortener.v2.UrlShortenerService.WatchLinks:output_type -> url_shortener.v2.LinkChange
+	56, // [56:73] is the sub-list for method output_type
+	39, // [39:56] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_proto_url_shortener_v2_url_shortener_proto_init() }
+func file_proto_url_shortener_v2_url_shortener_proto_init() {
+	if File_proto_url_shortener_v2_url_shortener_proto != nil {
+		return
+	}
+	file_proto_url_shortener_v2_url_shortener_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_url_shortener_v2_url_shortener_proto_msgTypes[19].OneofWrappers = []any{}
+	file_proto_url_shortener_v2_url_shortener_proto_msgTypes[20].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_url_shortener_v2_url_shortener_proto_rawDesc), len(file_proto_url_shortener_v2_url_shortener_proto_rawDesc)),
+			NumEnums:      8,
+			NumMessages:   39,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_url_shortener_v2_url_shortener_proto_goTypes,
+		DependencyIndexes: file_proto_url_shortener_v2_url_shortener_proto_depIdxs,
+		EnumInfos:         file_proto_url_shortener_v2_url_shortener_proto_enumTypes,
+		MessageInfos:      file_proto_url_shortener_v2_url_shortener_proto_msgTypes,
+	}.Build()
+	File_proto_url_shortener_v2_url_shortener_proto = out.File
+	file_proto_url_shortener_v2_url_shortener_proto_goTypes = nil
+	file_proto_url_shortener_v2_url_shortener_proto_depIdxs = nil
+}
@@ -0,0 +1,5962 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: proto/url_shortener/v2/url_shortener.proto
+
+package url_shortener_v2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Link with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Link) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Link with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in LinkMultiError, or nil if none found.
+func (m *Link) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Link) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCode()) > 64 {
+		err := LinkValidationError{
+			field:  "Code",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetTarget() != "" {
+
+		if utf8.RuneCountInString(m.GetTarget()) > 2048 {
+			err := LinkValidationError{
+				field:  "Target",
+				reason: "value length must be at most 2048 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if uri, err := url.Parse(m.GetTarget()); err != nil {
+			err = LinkValidationError{
+				field:  "Target",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := LinkValidationError{
+				field:  "Target",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_Link_Target_Pattern.MatchString(m.GetTarget()) {
+			err := LinkValidationError{
+				field:  "Target",
+				reason: "value does not match regex pattern \"^(?i)https?://\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreateTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "CreateTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "CreateTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreateTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LinkValidationError{
+				field:  "CreateTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdateTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "UpdateTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "UpdateTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdateTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LinkValidationError{
+				field:  "UpdateTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetOwner()) > 128 {
+		err := LinkValidationError{
+			field:  "Owner",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetExpireTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "ExpireTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "ExpireTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpireTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LinkValidationError{
+				field:  "ExpireTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetTags()) > 32 {
+		err := LinkValidationError{
+			field:  "Tags",
+			reason: "value must contain no more than 32 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_Link_Tags_Unique := make(map[string]struct{}, len(m.GetTags()))
+
+	for idx, item := range m.GetTags() {
+		_, _ = idx, item
+
+		if _, exists := _Link_Tags_Unique[item]; exists {
+			err := LinkValidationError{
+				field:  fmt.Sprintf("Tags[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_Link_Tags_Unique[item] = struct{}{}
+		}
+
+		if !_Link_Tags_Pattern.MatchString(item) {
+			err := LinkValidationError{
+				field:  fmt.Sprintf("Tags[%v]", idx),
+				reason: "value does not match regex pattern \"^[0-9A-Za-z_.:-]{1,64}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Disabled
+
+	if len(m.GetPassword()) > 72 {
+		err := LinkValidationError{
+			field:  "Password",
+			reason: "value length must be at most 72 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for PasswordProtected
+
+	if m.GetMaxClicks() < 0 {
+		err := LinkValidationError{
+			field:  "MaxClicks",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for RemainingClicks
+
+	if len(m.GetVariants()) > 16 {
+		err := LinkValidationError{
+			field:  "Variants",
+			reason: "value must contain no more than 16 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetVariants() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LinkValidationError{
+						field:  fmt.Sprintf("Variants[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LinkValidationError{
+						field:  fmt.Sprintf("Variants[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LinkValidationError{
+					field:  fmt.Sprintf("Variants[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if _, ok := Link_SplitMode_name[int32(m.GetSplitMode())]; !ok {
+		err := LinkValidationError{
+			field:  "SplitMode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetPassthrough()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "Passthrough",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "Passthrough",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPassthrough()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LinkValidationError{
+				field:  "Passthrough",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTemplate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "Template",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LinkValidationError{
+					field:  "Template",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTemplate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LinkValidationError{
+				field:  "Template",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetDomain()) > 253 {
+		err := LinkValidationError{
+			field:  "Domain",
+			reason: "value length must be at most 253 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for ShortUrl
+
+	if utf8.RuneCountInString(m.GetCampaign()) > 128 {
+		err := LinkValidationError{
+			field:  "Campaign",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_Link_Campaign_Pattern.MatchString(m.GetCampaign()) {
+		err := LinkValidationError{
+			field:  "Campaign",
+			reason: "value does not match regex pattern \"^[^\\\\p{Cc}]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Clicks
+
+	if len(errors) > 0 {
+		return LinkMultiError(errors)
+	}
+
+	return nil
+}
+
+// LinkMultiError is an error wrapping multiple validation errors returned by
+// Link.ValidateAll() if the designated constraints aren't met.
+type LinkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LinkMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LinkMultiError) AllErrors() []error { return m }
+
+// LinkValidationError is the validation error returned by Link.Validate if the
+// designated constraints aren't met.
+type LinkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LinkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LinkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LinkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LinkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LinkValidationError) ErrorName() string { return "LinkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LinkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLink.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LinkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LinkValidationError{}
+
+var _Link_Target_Pattern = regexp.MustCompile("^(?i)https?://")
+
+var _Link_Tags_Pattern = regexp.MustCompile("^[0-9A-Za-z_.:-]{1,64}$")
+
+var _Link_Campaign_Pattern = regexp.MustCompile("^[^\\p{Cc}]*$")
+
+// Validate checks the field values on LinkTemplate with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LinkTemplate) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LinkTemplate with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LinkTemplateMultiError, or
+// nil if none found.
+func (m *LinkTemplate) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LinkTemplate) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetPath()) > 256 {
+		err := LinkTemplateValidationError{
+			field:  "Path",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return LinkTemplateMultiError(errors)
+	}
+
+	return nil
+}
+
+// LinkTemplateMultiError is an error wrapping multiple validation errors
+// returned by LinkTemplate.ValidateAll() if the designated constraints aren't met.
+type LinkTemplateMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LinkTemplateMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LinkTemplateMultiError) AllErrors() []error { return m }
+
+// LinkTemplateValidationError is the validation error returned by
+// LinkTemplate.Validate if the designated constraints aren't met.
+type LinkTemplateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LinkTemplateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LinkTemplateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LinkTemplateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LinkTemplateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LinkTemplateValidationError) ErrorName() string { return "LinkTemplateValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LinkTemplateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLinkTemplate.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LinkTemplateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LinkTemplateValidationError{}
+
+// Validate checks the field values on Passthrough with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Passthrough) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Passthrough with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in PassthroughMultiError, or
+// nil if none found.
+func (m *Passthrough) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Passthrough) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := Passthrough_QueryPolicy_name[int32(m.GetQuery())]; !ok {
+		err := PassthroughValidationError{
+			field:  "Query",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Path
+
+	if all {
+		switch v := interface{}(m.GetUtm()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PassthroughValidationError{
+					field:  "Utm",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PassthroughValidationError{
+					field:  "Utm",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUtm()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PassthroughValidationError{
+				field:  "Utm",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PassthroughMultiError(errors)
+	}
+
+	return nil
+}
+
+// PassthroughMultiError is an error wrapping multiple validation errors
+// returned by Passthrough.ValidateAll() if the designated constraints aren't met.
+type PassthroughMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PassthroughMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PassthroughMultiError) AllErrors() []error { return m }
+
+// PassthroughValidationError is the validation error returned by
+// Passthrough.Validate if the designated constraints aren't met.
+type PassthroughValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PassthroughValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PassthroughValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PassthroughValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PassthroughValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PassthroughValidationError) ErrorName() string { return "PassthroughValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PassthroughValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPassthrough.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PassthroughValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PassthroughValidationError{}
+
+// Validate checks the field values on Utm with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Utm) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Utm with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in UtmMultiError, or nil if none found.
+func (m *Utm) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Utm) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetSource()) > 256 {
+		err := UtmValidationError{
+			field:  "Source",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetMedium()) > 256 {
+		err := UtmValidationError{
+			field:  "Medium",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetCampaign()) > 256 {
+		err := UtmValidationError{
+			field:  "Campaign",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetTerm()) > 256 {
+		err := UtmValidationError{
+			field:  "Term",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetContent()) > 256 {
+		err := UtmValidationError{
+			field:  "Content",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UtmMultiError(errors)
+	}
+
+	return nil
+}
+
+// UtmMultiError is an error wrapping multiple validation errors returned by
+// Utm.ValidateAll() if the designated constraints aren't met.
+type UtmMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UtmMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UtmMultiError) AllErrors() []error { return m }
+
+// UtmValidationError is the validation error returned by Utm.Validate if the
+// designated constraints aren't met.
+type UtmValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UtmValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UtmValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UtmValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UtmValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UtmValidationError) ErrorName() string { return "UtmValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UtmValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUtm.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UtmValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UtmValidationError{}
+
+// Validate checks the field values on Variant with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Variant) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Variant with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in VariantMultiError, or nil if none found.
+func (m *Variant) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Variant) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_Variant_Id_Pattern.MatchString(m.GetId()) {
+		err := VariantValidationError{
+			field:  "Id",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,32}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetTarget()) > 2048 {
+		err := VariantValidationError{
+			field:  "Target",
+			reason: "value length must be at most 2048 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if uri, err := url.Parse(m.GetTarget()); err != nil {
+		err = VariantValidationError{
+			field:  "Target",
+			reason: "value must be a valid URI",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	} else if !uri.IsAbs() {
+		err := VariantValidationError{
+			field:  "Target",
+			reason: "value must be absolute",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_Variant_Target_Pattern.MatchString(m.GetTarget()) {
+		err := VariantValidationError{
+			field:  "Target",
+			reason: "value does not match regex pattern \"^(?i)https?://\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetWeight(); val < 0 || val > 10000 {
+		err := VariantValidationError{
+			field:  "Weight",
+			reason: "value must be inside range [0, 10000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return VariantMultiError(errors)
+	}
+
+	return nil
+}
+
+// VariantMultiError is an error wrapping multiple validation errors returned
+// by Variant.ValidateAll() if the designated constraints aren't met.
+type VariantMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VariantMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VariantMultiError) AllErrors() []error { return m }
+
+// VariantValidationError is the validation error returned by Variant.Validate
+// if the designated constraints aren't met.
+type VariantValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VariantValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VariantValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VariantValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VariantValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VariantValidationError) ErrorName() string { return "VariantValidationError" }
+
+// Error satisfies the builtin error interface
+func (e VariantValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVariant.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VariantValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VariantValidationError{}
+
+var _Variant_Id_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,32}$")
+
+var _Variant_Target_Pattern = regexp.MustCompile("^(?i)https?://")
+
+// Validate checks the field values on CreateLinkRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateLinkRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateLinkRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateLinkRequestMultiError, or nil if none found.
+func (m *CreateLinkRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateLinkRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetLink() == nil {
+		err := CreateLinkRequestValidationError{
+			field:  "Link",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetLink()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateLinkRequestValidationError{
+					field:  "Link",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateLinkRequestValidationError{
+					field:  "Link",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLink()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateLinkRequestValidationError{
+				field:  "Link",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CreateLinkRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateLinkRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateLinkRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateLinkRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateLinkRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateLinkRequestMultiError) AllErrors() []error { return m }
+
+// CreateLinkRequestValidationError is the validation error returned by
+// CreateLinkRequest.Validate if the designated constraints aren't met.
+type CreateLinkRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateLinkRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateLinkRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateLinkRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateLinkRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateLinkRequestValidationError) ErrorName() string {
+	return "CreateLinkRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateLinkRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateLinkRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateLinkRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateLinkRequestValidationError{}
+
+// Validate checks the field values on GetLinkRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetLinkRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetLinkRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetLinkRequestMultiError,
+// or nil if none found.
+func (m *GetLinkRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetLinkRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_GetLinkRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := GetLinkRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetLinkRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetLinkRequestMultiError is an error wrapping multiple validation errors
+// returned by GetLinkRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetLinkRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetLinkRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetLinkRequestMultiError) AllErrors() []error { return m }
+
+// GetLinkRequestValidationError is the validation error returned by
+// GetLinkRequest.Validate if the designated constraints aren't met.
+type GetLinkRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetLinkRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetLinkRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetLinkRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetLinkRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetLinkRequestValidationError) ErrorName() string { return "GetLinkRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetLinkRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetLinkRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetLinkRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetLinkRequestValidationError{}
+
+var _GetLinkRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on ResolveLinkRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResolveLinkRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResolveLinkRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResolveLinkRequestMultiError, or nil if none found.
+func (m *ResolveLinkRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResolveLinkRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_ResolveLinkRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := ResolveLinkRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetPassword()) > 72 {
+		err := ResolveLinkRequestValidationError{
+			field:  "Password",
+			reason: "value length must be at most 72 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetVisitorId()) > 128 {
+		err := ResolveLinkRequestValidationError{
+			field:  "VisitorId",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetPath()) > 2048 {
+		err := ResolveLinkRequestValidationError{
+			field:  "Path",
+			reason: "value length must be at most 2048 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetQuery()) > 2048 {
+		err := ResolveLinkRequestValidationError{
+			field:  "Query",
+			reason: "value length must be at most 2048 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetUserAgent()) > 1024 {
+		err := ResolveLinkRequestValidationError{
+			field:  "UserAgent",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetAcceptLanguage()) > 1024 {
+		err := ResolveLinkRequestValidationError{
+			field:  "AcceptLanguage",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ResolveLinkRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResolveLinkRequestMultiError is an error wrapping multiple validation errors
+// returned by ResolveLinkRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ResolveLinkRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResolveLinkRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResolveLinkRequestMultiError) AllErrors() []error { return m }
+
+// ResolveLinkRequestValidationError is the validation error returned by
+// ResolveLinkRequest.Validate if the designated constraints aren't met.
+type ResolveLinkRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResolveLinkRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResolveLinkRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResolveLinkRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResolveLinkRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResolveLinkRequestValidationError) ErrorName() string {
+	return "ResolveLinkRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResolveLinkRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResolveLinkRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResolveLinkRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResolveLinkRequestValidationError{}
+
+var _ResolveLinkRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on ResolveLinkResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResolveLinkResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResolveLinkResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResolveLinkResponseMultiError, or nil if none found.
+func (m *ResolveLinkResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResolveLinkResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Target
+
+	// no validation rules for VariantId
+
+	if len(errors) > 0 {
+		return ResolveLinkResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResolveLinkResponseMultiError is an error wrapping multiple validation
+// errors returned by ResolveLinkResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ResolveLinkResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResolveLinkResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResolveLinkResponseMultiError) AllErrors() []error { return m }
+
+// ResolveLinkResponseValidationError is the validation error returned by
+// ResolveLinkResponse.Validate if the designated constraints aren't met.
+type ResolveLinkResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResolveLinkResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResolveLinkResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResolveLinkResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResolveLinkResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResolveLinkResponseValidationError) ErrorName() string {
+	return "ResolveLinkResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResolveLinkResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResolveLinkResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResolveLinkResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResolveLinkResponseValidationError{}
+
+// Validate checks the field values on UpdateLinkRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateLinkRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateLinkRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateLinkRequestMultiError, or nil if none found.
+func (m *UpdateLinkRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateLinkRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetLink() == nil {
+		err := UpdateLinkRequestValidationError{
+			field:  "Link",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetLink()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateLinkRequestValidationError{
+					field:  "Link",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateLinkRequestValidationError{
+					field:  "Link",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLink()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateLinkRequestValidationError{
+				field:  "Link",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdateMask()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateLinkRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateLinkRequestValidationError{
+					field:  "UpdateMask",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdateMask()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateLinkRequestValidationError{
+				field:  "UpdateMask",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateLinkRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateLinkRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateLinkRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateLinkRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateLinkRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateLinkRequestMultiError) AllErrors() []error { return m }
+
+// UpdateLinkRequestValidationError is the validation error returned by
+// UpdateLinkRequest.Validate if the designated constraints aren't met.
+type UpdateLinkRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateLinkRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateLinkRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateLinkRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateLinkRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateLinkRequestValidationError) ErrorName() string {
+	return "UpdateLinkRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateLinkRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateLinkRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateLinkRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateLinkRequestValidationError{}
+
+// Validate checks the field values on DeleteLinkRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DeleteLinkRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteLinkRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteLinkRequestMultiError, or nil if none found.
+func (m *DeleteLinkRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteLinkRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_DeleteLinkRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := DeleteLinkRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteLinkRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteLinkRequestMultiError is an error wrapping multiple validation errors
+// returned by DeleteLinkRequest.ValidateAll() if the designated constraints
+// aren't met.
+type DeleteLinkRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteLinkRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteLinkRequestMultiError) AllErrors() []error { return m }
+
+// DeleteLinkRequestValidationError is the validation error returned by
+// DeleteLinkRequest.Validate if the designated constraints aren't met.
+type DeleteLinkRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteLinkRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteLinkRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteLinkRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteLinkRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteLinkRequestValidationError) ErrorName() string {
+	return "DeleteLinkRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteLinkRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteLinkRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteLinkRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteLinkRequestValidationError{}
+
+var _DeleteLinkRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on ListLinksRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListLinksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListLinksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListLinksRequestMultiError, or nil if none found.
+func (m *ListLinksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListLinksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetPageSize(); val < 0 || val > 1000 {
+		err := ListLinksRequestValidationError{
+			field:  "PageSize",
+			reason: "value must be inside range [0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetPageToken()) > 256 {
+		err := ListLinksRequestValidationError{
+			field:  "PageToken",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetOwner()) > 128 {
+		err := ListLinksRequestValidationError{
+			field:  "Owner",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetTag()) > 64 {
+		err := ListLinksRequestValidationError{
+			field:  "Tag",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for ShowDisabled
+
+	if utf8.RuneCountInString(m.GetTargetDomain()) > 253 {
+		err := ListLinksRequestValidationError{
+			field:  "TargetDomain",
+			reason: "value length must be at most 253 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreateTimeAfter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListLinksRequestValidationError{
+					field:  "CreateTimeAfter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListLinksRequestValidationError{
+					field:  "CreateTimeAfter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreateTimeAfter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListLinksRequestValidationError{
+				field:  "CreateTimeAfter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreateTimeBefore()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListLinksRequestValidationError{
+					field:  "CreateTimeBefore",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListLinksRequestValidationError{
+					field:  "CreateTimeBefore",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreateTimeBefore()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListLinksRequestValidationError{
+				field:  "CreateTimeBefore",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetTargetContains()) > 256 {
+		err := ListLinksRequestValidationError{
+			field:  "TargetContains",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := ListLinksRequest_Sort_name[int32(m.GetSort())]; !ok {
+		err := ListLinksRequestValidationError{
+			field:  "Sort",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetCampaign()) > 128 {
+		err := ListLinksRequestValidationError{
+			field:  "Campaign",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListLinksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListLinksRequestMultiError is an error wrapping multiple validation errors
+// returned by ListLinksRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListLinksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListLinksRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListLinksRequestMultiError) AllErrors() []error { return m }
+
+// ListLinksRequestValidationError is the validation error returned by
+// ListLinksRequest.Validate if the designated constraints aren't met.
+type ListLinksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListLinksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListLinksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListLinksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListLinksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListLinksRequestValidationError) ErrorName() string { return "ListLinksRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListLinksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListLinksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListLinksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListLinksRequestValidationError{}
+
+// Validate checks the field values on ListLinksResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListLinksResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListLinksResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListLinksResponseMultiError, or nil if none found.
+func (m *ListLinksResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListLinksResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetLinks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListLinksResponseValidationError{
+						field:  fmt.Sprintf("Links[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListLinksResponseValidationError{
+						field:  fmt.Sprintf("Links[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListLinksResponseValidationError{
+					field:  fmt.Sprintf("Links[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextPageToken
+
+	if len(errors) > 0 {
+		return ListLinksResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListLinksResponseMultiError is an error wrapping multiple validation errors
+// returned by ListLinksResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListLinksResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListLinksResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListLinksResponseMultiError) AllErrors() []error { return m }
+
+// ListLinksResponseValidationError is the validation error returned by
+// ListLinksResponse.Validate if the designated constraints aren't met.
+type ListLinksResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListLinksResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListLinksResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListLinksResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListLinksResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListLinksResponseValidationError) ErrorName() string {
+	return "ListLinksResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListLinksResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListLinksResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListLinksResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListLinksResponseValidationError{}
+
+// Validate checks the field values on GetLinkQRCodeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetLinkQRCodeRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetLinkQRCodeRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetLinkQRCodeRequestMultiError, or nil if none found.
+func (m *GetLinkQRCodeRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetLinkQRCodeRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_GetLinkQRCodeRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := GetLinkQRCodeRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := GetLinkQRCodeRequest_Format_name[int32(m.GetFormat())]; !ok {
+		err := GetLinkQRCodeRequestValidationError{
+			field:  "Format",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetSize() != 0 {
+
+		if val := m.GetSize(); val < 64 || val > 4096 {
+			err := GetLinkQRCodeRequestValidationError{
+				field:  "Size",
+				reason: "value must be inside range [64, 4096]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if _, ok := GetLinkQRCodeRequest_ErrorCorrection_name[int32(m.GetErrorCorrection())]; !ok {
+		err := GetLinkQRCodeRequestValidationError{
+			field:  "ErrorCorrection",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetForegroundColor() != "" {
+
+		if !_GetLinkQRCodeRequest_ForegroundColor_Pattern.MatchString(m.GetForegroundColor()) {
+			err := GetLinkQRCodeRequestValidationError{
+				field:  "ForegroundColor",
+				reason: "value does not match regex pattern \"^#?([0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetBackgroundColor() != "" {
+
+		if !_GetLinkQRCodeRequest_BackgroundColor_Pattern.MatchString(m.GetBackgroundColor()) {
+			err := GetLinkQRCodeRequestValidationError{
+				field:  "BackgroundColor",
+				reason: "value does not match regex pattern \"^#?([0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Logo
+
+	if m.Margin != nil {
+
+		if val := m.GetMargin(); val < 0 || val > 16 {
+			err := GetLinkQRCodeRequestValidationError{
+				field:  "Margin",
+				reason: "value must be inside range [0, 16]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetLinkQRCodeRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetLinkQRCodeRequestMultiError is an error wrapping multiple validation
+// errors returned by GetLinkQRCodeRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetLinkQRCodeRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetLinkQRCodeRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetLinkQRCodeRequestMultiError) AllErrors() []error { return m }
+
+// GetLinkQRCodeRequestValidationError is the validation error returned by
+// GetLinkQRCodeRequest.Validate if the designated constraints aren't met.
+type GetLinkQRCodeRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetLinkQRCodeRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetLinkQRCodeRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetLinkQRCodeRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetLinkQRCodeRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetLinkQRCodeRequestValidationError) ErrorName() string {
+	return "GetLinkQRCodeRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetLinkQRCodeRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetLinkQRCodeRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetLinkQRCodeRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetLinkQRCodeRequestValidationError{}
+
+var _GetLinkQRCodeRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+var _GetLinkQRCodeRequest_ForegroundColor_Pattern = regexp.MustCompile("^#?([0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$")
+
+var _GetLinkQRCodeRequest_BackgroundColor_Pattern = regexp.MustCompile("^#?([0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$")
+
+// Validate checks the field values on QRCode with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *QRCode) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QRCode with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in QRCodeMultiError, or nil if none found.
+func (m *QRCode) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QRCode) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ContentType
+
+	// no validation rules for Data
+
+	// no validation rules for Etag
+
+	// no validation rules for Url
+
+	if len(errors) > 0 {
+		return QRCodeMultiError(errors)
+	}
+
+	return nil
+}
+
+// QRCodeMultiError is an error wrapping multiple validation errors returned by
+// QRCode.ValidateAll() if the designated constraints aren't met.
+type QRCodeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QRCodeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QRCodeMultiError) AllErrors() []error { return m }
+
+// QRCodeValidationError is the validation error returned by QRCode.Validate if
+// the designated constraints aren't met.
+type QRCodeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QRCodeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QRCodeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QRCodeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QRCodeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QRCodeValidationError) ErrorName() string { return "QRCodeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e QRCodeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQRCode.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QRCodeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QRCodeValidationError{}
+
+// Validate checks the field values on Rule with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Rule) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Rule with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in RuleMultiError, or nil if none found.
+func (m *Rule) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Rule) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if utf8.RuneCountInString(m.GetTarget()) > 2048 {
+		err := RuleValidationError{
+			field:  "Target",
+			reason: "value length must be at most 2048 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if uri, err := url.Parse(m.GetTarget()); err != nil {
+		err = RuleValidationError{
+			field:  "Target",
+			reason: "value must be a valid URI",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	} else if !uri.IsAbs() {
+		err := RuleValidationError{
+			field:  "Target",
+			reason: "value must be absolute",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_Rule_Target_Pattern.MatchString(m.GetTarget()) {
+		err := RuleValidationError{
+			field:  "Target",
+			reason: "value does not match regex pattern \"^(?i)https?://\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetConditions()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RuleValidationError{
+					field:  "Conditions",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RuleValidationError{
+					field:  "Conditions",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetConditions()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RuleValidationError{
+				field:  "Conditions",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return RuleMultiError(errors)
+	}
+
+	return nil
+}
+
+// RuleMultiError is an error wrapping multiple validation errors returned by
+// Rule.ValidateAll() if the designated constraints aren't met.
+type RuleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RuleMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RuleMultiError) AllErrors() []error { return m }
+
+// RuleValidationError is the validation error returned by Rule.Validate if the
+// designated constraints aren't met.
+type RuleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RuleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RuleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RuleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RuleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RuleValidationError) ErrorName() string { return "RuleValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RuleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRule.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RuleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RuleValidationError{}
+
+var _Rule_Target_Pattern = regexp.MustCompile("^(?i)https?://")
+
+// Validate checks the field values on RuleConditions with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RuleConditions) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RuleConditions with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RuleConditionsMultiError,
+// or nil if none found.
+func (m *RuleConditions) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RuleConditions) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetPlatforms()) > 32 {
+		err := RuleConditionsValidationError{
+			field:  "Platforms",
+			reason: "value must contain no more than 32 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetPlatforms() {
+		_, _ = idx, item
+
+		if _, ok := _RuleConditions_Platforms_NotInLookup[item]; ok {
+			err := RuleConditionsValidationError{
+				field:  fmt.Sprintf("Platforms[%v]", idx),
+				reason: "value must not be in list [0]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if _, ok := RuleConditions_Platform_name[int32(item)]; !ok {
+			err := RuleConditionsValidationError{
+				field:  fmt.Sprintf("Platforms[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetLanguages()) > 32 {
+		err := RuleConditionsValidationError{
+			field:  "Languages",
+			reason: "value must contain no more than 32 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetLanguages() {
+		_, _ = idx, item
+
+		if !_RuleConditions_Languages_Pattern.MatchString(item) {
+			err := RuleConditionsValidationError{
+				field:  fmt.Sprintf("Languages[%v]", idx),
+				reason: "value does not match regex pattern \"^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetCountries()) > 32 {
+		err := RuleConditionsValidationError{
+			field:  "Countries",
+			reason: "value must contain no more than 32 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetCountries() {
+		_, _ = idx, item
+
+		if !_RuleConditions_Countries_Pattern.MatchString(item) {
+			err := RuleConditionsValidationError{
+				field:  fmt.Sprintf("Countries[%v]", idx),
+				reason: "value does not match regex pattern \"^[A-Za-z]{2}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetDays()) > 7 {
+		err := RuleConditionsValidationError{
+			field:  "Days",
+			reason: "value must contain no more than 7 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetDays() {
+		_, _ = idx, item
+
+		if _, ok := _RuleConditions_Days_NotInLookup[item]; ok {
+			err := RuleConditionsValidationError{
+				field:  fmt.Sprintf("Days[%v]", idx),
+				reason: "value must not be in list [0]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if _, ok := RuleConditions_Day_name[int32(item)]; !ok {
+			err := RuleConditionsValidationError{
+				field:  fmt.Sprintf("Days[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetFrom() != "" {
+
+		if !_RuleConditions_From_Pattern.MatchString(m.GetFrom()) {
+			err := RuleConditionsValidationError{
+				field:  "From",
+				reason: "value does not match regex pattern \"^([01][0-9]|2[0-3]):[0-5][0-9]$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetTo() != "" {
+
+		if !_RuleConditions_To_Pattern.MatchString(m.GetTo()) {
+			err := RuleConditionsValidationError{
+				field:  "To",
+				reason: "value does not match regex pattern \"^([01][0-9]|2[0-3]):[0-5][0-9]$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetTimeZone()) > 64 {
+		err := RuleConditionsValidationError{
+			field:  "TimeZone",
+			reason: "value length must be at most 64 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetQuery()) > 32 {
+		err := RuleConditionsValidationError{
+			field:  "Query",
+			reason: "value must contain no more than 32 pair(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	{
+		sorted_keys := make([]string, len(m.GetQuery()))
+		i := 0
+		for key := range m.GetQuery() {
+			sorted_keys[i] = key
+			i++
+		}
+		sort.Slice(sorted_keys, func(i, j int) bool { return sorted_keys[i] < sorted_keys[j] })
+		for _, key := range sorted_keys {
+			val := m.GetQuery()[key]
+			_ = val
+
+			if l := utf8.RuneCountInString(key); l < 1 || l > 128 {
+				err := RuleConditionsValidationError{
+					field:  fmt.Sprintf("Query[%v]", key),
+					reason: "value length must be between 1 and 128 runes, inclusive",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+			if utf8.RuneCountInString(val) > 512 {
+				err := RuleConditionsValidationError{
+					field:  fmt.Sprintf("Query[%v]", key),
+					reason: "value length must be at most 512 runes",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return RuleConditionsMultiError(errors)
+	}
+
+	return nil
+}
+
+// RuleConditionsMultiError is an error wrapping multiple validation errors
+// returned by RuleConditions.ValidateAll() if the designated constraints
+// aren't met.
+type RuleConditionsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RuleConditionsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RuleConditionsMultiError) AllErrors() []error { return m }
+
+// RuleConditionsValidationError is the validation error returned by
+// RuleConditions.Validate if the designated constraints aren't met.
+type RuleConditionsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RuleConditionsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RuleConditionsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RuleConditionsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RuleConditionsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RuleConditionsValidationError) ErrorName() string { return "RuleConditionsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RuleConditionsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRuleConditions.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RuleConditionsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RuleConditionsValidationError{}
+
+var _RuleConditions_Platforms_NotInLookup = map[RuleConditions_Platform]struct{}{
+	0: {},
+}
+
+var _RuleConditions_Languages_Pattern = regexp.MustCompile("^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$")
+
+var _RuleConditions_Countries_Pattern = regexp.MustCompile("^[A-Za-z]{2}$")
+
+var _RuleConditions_Days_NotInLookup = map[RuleConditions_Day]struct{}{
+	0: {},
+}
+
+var _RuleConditions_From_Pattern = regexp.MustCompile("^([01][0-9]|2[0-3]):[0-5][0-9]$")
+
+var _RuleConditions_To_Pattern = regexp.MustCompile("^([01][0-9]|2[0-3]):[0-5][0-9]$")
+
+// Validate checks the field values on ListLinkRulesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListLinkRulesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListLinkRulesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListLinkRulesRequestMultiError, or nil if none found.
+func (m *ListLinkRulesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListLinkRulesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_ListLinkRulesRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := ListLinkRulesRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListLinkRulesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListLinkRulesRequestMultiError is an error wrapping multiple validation
+// errors returned by ListLinkRulesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListLinkRulesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListLinkRulesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListLinkRulesRequestMultiError) AllErrors() []error { return m }
+
+// ListLinkRulesRequestValidationError is the validation error returned by
+// ListLinkRulesRequest.Validate if the designated constraints aren't met.
+type ListLinkRulesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListLinkRulesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListLinkRulesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListLinkRulesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListLinkRulesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListLinkRulesRequestValidationError) ErrorName() string {
+	return "ListLinkRulesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListLinkRulesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListLinkRulesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListLinkRulesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListLinkRulesRequestValidationError{}
+
+var _ListLinkRulesRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on ListLinkRulesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListLinkRulesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListLinkRulesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListLinkRulesResponseMultiError, or nil if none found.
+func (m *ListLinkRulesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListLinkRulesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetRules() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListLinkRulesResponseValidationError{
+						field:  fmt.Sprintf("Rules[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListLinkRulesResponseValidationError{
+						field:  fmt.Sprintf("Rules[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListLinkRulesResponseValidationError{
+					field:  fmt.Sprintf("Rules[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListLinkRulesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListLinkRulesResponseMultiError is an error wrapping multiple validation
+// errors returned by ListLinkRulesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListLinkRulesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListLinkRulesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListLinkRulesResponseMultiError) AllErrors() []error { return m }
+
+// ListLinkRulesResponseValidationError is the validation error returned by
+// ListLinkRulesResponse.Validate if the designated constraints aren't met.
+type ListLinkRulesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListLinkRulesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListLinkRulesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListLinkRulesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListLinkRulesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListLinkRulesResponseValidationError) ErrorName() string {
+	return "ListLinkRulesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListLinkRulesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListLinkRulesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListLinkRulesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListLinkRulesResponseValidationError{}
+
+// Validate checks the field values on CreateLinkRuleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateLinkRuleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateLinkRuleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateLinkRuleRequestMultiError, or nil if none found.
+func (m *CreateLinkRuleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateLinkRuleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_CreateLinkRuleRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := CreateLinkRuleRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRule() == nil {
+		err := CreateLinkRuleRequestValidationError{
+			field:  "Rule",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetRule()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateLinkRuleRequestValidationError{
+					field:  "Rule",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateLinkRuleRequestValidationError{
+					field:  "Rule",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRule()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateLinkRuleRequestValidationError{
+				field:  "Rule",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.Index != nil {
+
+		if m.GetIndex() < 0 {
+			err := CreateLinkRuleRequestValidationError{
+				field:  "Index",
+				reason: "value must be greater than or equal to 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateLinkRuleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateLinkRuleRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateLinkRuleRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateLinkRuleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateLinkRuleRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateLinkRuleRequestMultiError) AllErrors() []error { return m }
+
+// CreateLinkRuleRequestValidationError is the validation error returned by
+// CreateLinkRuleRequest.Validate if the designated constraints aren't met.
+type CreateLinkRuleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateLinkRuleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateLinkRuleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateLinkRuleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateLinkRuleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateLinkRuleRequestValidationError) ErrorName() string {
+	return "CreateLinkRuleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateLinkRuleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateLinkRuleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateLinkRuleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateLinkRuleRequestValidationError{}
+
+var _CreateLinkRuleRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on UpdateLinkRuleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateLinkRuleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateLinkRuleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateLinkRuleRequestMultiError, or nil if none found.
+func (m *UpdateLinkRuleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateLinkRuleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_UpdateLinkRuleRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := UpdateLinkRuleRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRule() == nil {
+		err := UpdateLinkRuleRequestValidationError{
+			field:  "Rule",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetRule()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateLinkRuleRequestValidationError{
+					field:  "Rule",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateLinkRuleRequestValidationError{
+					field:  "Rule",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRule()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateLinkRuleRequestValidationError{
+				field:  "Rule",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.Index != nil {
+
+		if m.GetIndex() < 0 {
+			err := UpdateLinkRuleRequestValidationError{
+				field:  "Index",
+				reason: "value must be greater than or equal to 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UpdateLinkRuleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateLinkRuleRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdateLinkRuleRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateLinkRuleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateLinkRuleRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateLinkRuleRequestMultiError) AllErrors() []error { return m }
+
+// UpdateLinkRuleRequestValidationError is the validation error returned by
+// UpdateLinkRuleRequest.Validate if the designated constraints aren't met.
+type UpdateLinkRuleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateLinkRuleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateLinkRuleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateLinkRuleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateLinkRuleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateLinkRuleRequestValidationError) ErrorName() string {
+	return "UpdateLinkRuleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateLinkRuleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateLinkRuleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateLinkRuleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateLinkRuleRequestValidationError{}
+
+var _UpdateLinkRuleRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on DeleteLinkRuleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteLinkRuleRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteLinkRuleRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteLinkRuleRequestMultiError, or nil if none found.
+func (m *DeleteLinkRuleRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteLinkRuleRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_DeleteLinkRuleRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := DeleteLinkRuleRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetRuleId()); l < 1 || l > 64 {
+		err := DeleteLinkRuleRequestValidationError{
+			field:  "RuleId",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteLinkRuleRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteLinkRuleRequestMultiError is an error wrapping multiple validation
+// errors returned by DeleteLinkRuleRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteLinkRuleRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteLinkRuleRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteLinkRuleRequestMultiError) AllErrors() []error { return m }
+
+// DeleteLinkRuleRequestValidationError is the validation error returned by
+// DeleteLinkRuleRequest.Validate if the designated constraints aren't met.
+type DeleteLinkRuleRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteLinkRuleRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteLinkRuleRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteLinkRuleRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteLinkRuleRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteLinkRuleRequestValidationError) ErrorName() string {
+	return "DeleteLinkRuleRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteLinkRuleRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteLinkRuleRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteLinkRuleRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteLinkRuleRequestValidationError{}
+
+var _DeleteLinkRuleRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on EvaluateLinkRulesRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *EvaluateLinkRulesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EvaluateLinkRulesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EvaluateLinkRulesRequestMultiError, or nil if none found.
+func (m *EvaluateLinkRulesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EvaluateLinkRulesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_EvaluateLinkRulesRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := EvaluateLinkRulesRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetUserAgent()) > 1024 {
+		err := EvaluateLinkRulesRequestValidationError{
+			field:  "UserAgent",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetAcceptLanguage()) > 1024 {
+		err := EvaluateLinkRulesRequestValidationError{
+			field:  "AcceptLanguage",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetIp() != "" {
+
+		if ip := net.ParseIP(m.GetIp()); ip == nil {
+			err := EvaluateLinkRulesRequestValidationError{
+				field:  "Ip",
+				reason: "value must be a valid IP address",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetCountry() != "" {
+
+		if !_EvaluateLinkRulesRequest_Country_Pattern.MatchString(m.GetCountry()) {
+			err := EvaluateLinkRulesRequestValidationError{
+				field:  "Country",
+				reason: "value does not match regex pattern \"^[A-Za-z]{2}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EvaluateLinkRulesRequestValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EvaluateLinkRulesRequestValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EvaluateLinkRulesRequestValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetQuery()) > 32 {
+		err := EvaluateLinkRulesRequestValidationError{
+			field:  "Query",
+			reason: "value must contain no more than 32 pair(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return EvaluateLinkRulesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// EvaluateLinkRulesRequestMultiError is an error wrapping multiple validation
+// errors returned by EvaluateLinkRulesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type EvaluateLinkRulesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EvaluateLinkRulesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EvaluateLinkRulesRequestMultiError) AllErrors() []error { return m }
+
+// EvaluateLinkRulesRequestValidationError is the validation error returned by
+// EvaluateLinkRulesRequest.Validate if the designated constraints aren't met.
+type EvaluateLinkRulesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EvaluateLinkRulesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EvaluateLinkRulesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EvaluateLinkRulesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EvaluateLinkRulesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EvaluateLinkRulesRequestValidationError) ErrorName() string {
+	return "EvaluateLinkRulesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EvaluateLinkRulesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEvaluateLinkRulesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EvaluateLinkRulesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EvaluateLinkRulesRequestValidationError{}
+
+var _EvaluateLinkRulesRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+var _EvaluateLinkRulesRequest_Country_Pattern = regexp.MustCompile("^[A-Za-z]{2}$")
+
+// Validate checks the field values on EvaluateLinkRulesResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *EvaluateLinkRulesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EvaluateLinkRulesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EvaluateLinkRulesResponseMultiError, or nil if none found.
+func (m *EvaluateLinkRulesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EvaluateLinkRulesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetRule()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EvaluateLinkRulesResponseValidationError{
+					field:  "Rule",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EvaluateLinkRulesResponseValidationError{
+					field:  "Rule",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRule()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EvaluateLinkRulesResponseValidationError{
+				field:  "Rule",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Target
+
+	// no validation rules for Platform
+
+	// no validation rules for Country
+
+	if all {
+		switch v := interface{}(m.GetTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EvaluateLinkRulesResponseValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EvaluateLinkRulesResponseValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EvaluateLinkRulesResponseValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return EvaluateLinkRulesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// EvaluateLinkRulesResponseMultiError is an error wrapping multiple validation
+// errors returned by EvaluateLinkRulesResponse.ValidateAll() if the
+// designated constraints aren't met.
+type EvaluateLinkRulesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EvaluateLinkRulesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EvaluateLinkRulesResponseMultiError) AllErrors() []error { return m }
+
+// EvaluateLinkRulesResponseValidationError is the validation error returned by
+// EvaluateLinkRulesResponse.Validate if the designated constraints aren't met.
+type EvaluateLinkRulesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EvaluateLinkRulesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EvaluateLinkRulesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EvaluateLinkRulesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EvaluateLinkRulesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EvaluateLinkRulesResponseValidationError) ErrorName() string {
+	return "EvaluateLinkRulesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EvaluateLinkRulesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEvaluateLinkRulesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EvaluateLinkRulesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EvaluateLinkRulesResponseValidationError{}
+
+// Validate checks the field values on GetLinkVariantStatsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetLinkVariantStatsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetLinkVariantStatsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetLinkVariantStatsRequestMultiError, or nil if none found.
+func (m *GetLinkVariantStatsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetLinkVariantStatsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_GetLinkVariantStatsRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := GetLinkVariantStatsRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetLinkVariantStatsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetLinkVariantStatsRequestMultiError is an error wrapping multiple
+// validation errors returned by GetLinkVariantStatsRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetLinkVariantStatsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetLinkVariantStatsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetLinkVariantStatsRequestMultiError) AllErrors() []error { return m }
+
+// GetLinkVariantStatsRequestValidationError is the validation error returned
+// by GetLinkVariantStatsRequest.Validate if the designated constraints aren't met.
+type GetLinkVariantStatsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetLinkVariantStatsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetLinkVariantStatsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetLinkVariantStatsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetLinkVariantStatsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetLinkVariantStatsRequestValidationError) ErrorName() string {
+	return "GetLinkVariantStatsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetLinkVariantStatsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetLinkVariantStatsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetLinkVariantStatsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetLinkVariantStatsRequestValidationError{}
+
+var _GetLinkVariantStatsRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on VariantStats with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *VariantStats) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on VariantStats with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in VariantStatsMultiError, or
+// nil if none found.
+func (m *VariantStats) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *VariantStats) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetVariant()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, VariantStatsValidationError{
+					field:  "Variant",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, VariantStatsValidationError{
+					field:  "Variant",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetVariant()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return VariantStatsValidationError{
+				field:  "Variant",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Clicks
+
+	// no validation rules for Removed
+
+	if len(errors) > 0 {
+		return VariantStatsMultiError(errors)
+	}
+
+	return nil
+}
+
+// VariantStatsMultiError is an error wrapping multiple validation errors
+// returned by VariantStats.ValidateAll() if the designated constraints aren't met.
+type VariantStatsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m VariantStatsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m VariantStatsMultiError) AllErrors() []error { return m }
+
+// VariantStatsValidationError is the validation error returned by
+// VariantStats.Validate if the designated constraints aren't met.
+type VariantStatsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e VariantStatsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e VariantStatsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e VariantStatsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e VariantStatsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e VariantStatsValidationError) ErrorName() string { return "VariantStatsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e VariantStatsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sVariantStats.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = VariantStatsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = VariantStatsValidationError{}
+
+// Validate checks the field values on GetLinkVariantStatsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetLinkVariantStatsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetLinkVariantStatsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetLinkVariantStatsResponseMultiError, or nil if none found.
+func (m *GetLinkVariantStatsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetLinkVariantStatsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetVariants() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetLinkVariantStatsResponseValidationError{
+						field:  fmt.Sprintf("Variants[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetLinkVariantStatsResponseValidationError{
+						field:  fmt.Sprintf("Variants[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetLinkVariantStatsResponseValidationError{
+					field:  fmt.Sprintf("Variants[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetLinkVariantStatsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetLinkVariantStatsResponseMultiError is an error wrapping multiple
+// validation errors returned by GetLinkVariantStatsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type GetLinkVariantStatsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetLinkVariantStatsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetLinkVariantStatsResponseMultiError) AllErrors() []error { return m }
+
+// GetLinkVariantStatsResponseValidationError is the validation error returned
+// by GetLinkVariantStatsResponse.Validate if the designated constraints
+// aren't met.
+type GetLinkVariantStatsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetLinkVariantStatsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetLinkVariantStatsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetLinkVariantStatsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetLinkVariantStatsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetLinkVariantStatsResponseValidationError) ErrorName() string {
+	return "GetLinkVariantStatsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetLinkVariantStatsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetLinkVariantStatsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetLinkVariantStatsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetLinkVariantStatsResponseValidationError{}
+
+// Validate checks the field values on GetLinkClickHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetLinkClickHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetLinkClickHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetLinkClickHistoryRequestMultiError, or nil if none found.
+func (m *GetLinkClickHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetLinkClickHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_GetLinkClickHistoryRequest_Code_Pattern.MatchString(m.GetCode()) {
+		err := GetLinkClickHistoryRequestValidationError{
+			field:  "Code",
+			reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetDays(); val < 0 || val > 366 {
+		err := GetLinkClickHistoryRequestValidationError{
+			field:  "Days",
+			reason: "value must be inside range [0, 366]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetLinkClickHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetLinkClickHistoryRequestMultiError is an error wrapping multiple
+// validation errors returned by GetLinkClickHistoryRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetLinkClickHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetLinkClickHistoryRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetLinkClickHistoryRequestMultiError) AllErrors() []error { return m }
+
+// GetLinkClickHistoryRequestValidationError is the validation error returned
+// by GetLinkClickHistoryRequest.Validate if the designated constraints aren't met.
+type GetLinkClickHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetLinkClickHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetLinkClickHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetLinkClickHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetLinkClickHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetLinkClickHistoryRequestValidationError) ErrorName() string {
+	return "GetLinkClickHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetLinkClickHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetLinkClickHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetLinkClickHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetLinkClickHistoryRequestValidationError{}
+
+var _GetLinkClickHistoryRequest_Code_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+// Validate checks the field values on DailyClicks with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DailyClicks) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DailyClicks with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DailyClicksMultiError, or
+// nil if none found.
+func (m *DailyClicks) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DailyClicks) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetDay()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DailyClicksValidationError{
+					field:  "Day",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DailyClicksValidationError{
+					field:  "Day",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDay()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DailyClicksValidationError{
+				field:  "Day",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Clicks
+
+	if len(errors) > 0 {
+		return DailyClicksMultiError(errors)
+	}
+
+	return nil
+}
+
+// DailyClicksMultiError is an error wrapping multiple validation errors
+// returned by DailyClicks.ValidateAll() if the designated constraints aren't met.
+type DailyClicksMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DailyClicksMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DailyClicksMultiError) AllErrors() []error { return m }
+
+// DailyClicksValidationError is the validation error returned by
+// DailyClicks.Validate if the designated constraints aren't met.
+type DailyClicksValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DailyClicksValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DailyClicksValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DailyClicksValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DailyClicksValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DailyClicksValidationError) ErrorName() string { return "DailyClicksValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DailyClicksValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDailyClicks.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DailyClicksValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DailyClicksValidationError{}
+
+// Validate checks the field values on LinkClickHistory with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *LinkClickHistory) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LinkClickHistory with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// LinkClickHistoryMultiError, or nil if none found.
+func (m *LinkClickHistory) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LinkClickHistory) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Total
+
+	for idx, item := range m.GetDays() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LinkClickHistoryValidationError{
+						field:  fmt.Sprintf("Days[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LinkClickHistoryValidationError{
+						field:  fmt.Sprintf("Days[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LinkClickHistoryValidationError{
+					field:  fmt.Sprintf("Days[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return LinkClickHistoryMultiError(errors)
+	}
+
+	return nil
+}
+
+// LinkClickHistoryMultiError is an error wrapping multiple validation errors
+// returned by LinkClickHistory.ValidateAll() if the designated constraints
+// aren't met.
+type LinkClickHistoryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LinkClickHistoryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LinkClickHistoryMultiError) AllErrors() []error { return m }
+
+// LinkClickHistoryValidationError is the validation error returned by
+// LinkClickHistory.Validate if the designated constraints aren't met.
+type LinkClickHistoryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LinkClickHistoryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LinkClickHistoryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LinkClickHistoryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LinkClickHistoryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LinkClickHistoryValidationError) ErrorName() string { return "LinkClickHistoryValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LinkClickHistoryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLinkClickHistory.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LinkClickHistoryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LinkClickHistoryValidationError{}
+
+// Validate checks the field values on BatchUpdateLinkTagsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchUpdateLinkTagsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchUpdateLinkTagsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchUpdateLinkTagsRequestMultiError, or nil if none found.
+func (m *BatchUpdateLinkTagsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchUpdateLinkTagsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetCodes()); l < 1 || l > 1000 {
+		err := BatchUpdateLinkTagsRequestValidationError{
+			field:  "Codes",
+			reason: "value must contain between 1 and 1000 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetCodes() {
+		_, _ = idx, item
+
+		if !_BatchUpdateLinkTagsRequest_Codes_Pattern.MatchString(item) {
+			err := BatchUpdateLinkTagsRequestValidationError{
+				field:  fmt.Sprintf("Codes[%v]", idx),
+				reason: "value does not match regex pattern \"^[0-9A-Za-z_-]{1,64}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetAddTags()) > 32 {
+		err := BatchUpdateLinkTagsRequestValidationError{
+			field:  "AddTags",
+			reason: "value must contain no more than 32 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_BatchUpdateLinkTagsRequest_AddTags_Unique := make(map[string]struct{}, len(m.GetAddTags()))
+
+	for idx, item := range m.GetAddTags() {
+		_, _ = idx, item
+
+		if _, exists := _BatchUpdateLinkTagsRequest_AddTags_Unique[item]; exists {
+			err := BatchUpdateLinkTagsRequestValidationError{
+				field:  fmt.Sprintf("AddTags[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_BatchUpdateLinkTagsRequest_AddTags_Unique[item] = struct{}{}
+		}
+
+		if !_BatchUpdateLinkTagsRequest_AddTags_Pattern.MatchString(item) {
+			err := BatchUpdateLinkTagsRequestValidationError{
+				field:  fmt.Sprintf("AddTags[%v]", idx),
+				reason: "value does not match regex pattern \"^[0-9A-Za-z_.:-]{1,64}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(m.GetRemoveTags()) > 32 {
+		err := BatchUpdateLinkTagsRequestValidationError{
+			field:  "RemoveTags",
+			reason: "value must contain no more than 32 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_BatchUpdateLinkTagsRequest_RemoveTags_Unique := make(map[string]struct{}, len(m.GetRemoveTags()))
+
+	for idx, item := range m.GetRemoveTags() {
+		_, _ = idx, item
+
+		if _, exists := _BatchUpdateLinkTagsRequest_RemoveTags_Unique[item]; exists {
+			err := BatchUpdateLinkTagsRequestValidationError{
+				field:  fmt.Sprintf("RemoveTags[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_BatchUpdateLinkTagsRequest_RemoveTags_Unique[item] = struct{}{}
+		}
+
+		if !_BatchUpdateLinkTagsRequest_RemoveTags_Pattern.MatchString(item) {
+			err := BatchUpdateLinkTagsRequestValidationError{
+				field:  fmt.Sprintf("RemoveTags[%v]", idx),
+				reason: "value does not match regex pattern \"^[0-9A-Za-z_.:-]{1,64}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchUpdateLinkTagsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchUpdateLinkTagsRequestMultiError is an error wrapping multiple
+// validation errors returned by BatchUpdateLinkTagsRequest.ValidateAll() if
+// the designated constraints aren't met.
+type BatchUpdateLinkTagsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchUpdateLinkTagsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchUpdateLinkTagsRequestMultiError) AllErrors() []error { return m }
+
+// BatchUpdateLinkTagsRequestValidationError is the validation error returned
+// by BatchUpdateLinkTagsRequest.Validate if the designated constraints aren't met.
+type BatchUpdateLinkTagsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchUpdateLinkTagsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchUpdateLinkTagsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchUpdateLinkTagsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchUpdateLinkTagsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchUpdateLinkTagsRequestValidationError) ErrorName() string {
+	return "BatchUpdateLinkTagsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchUpdateLinkTagsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchUpdateLinkTagsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchUpdateLinkTagsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchUpdateLinkTagsRequestValidationError{}
+
+var _BatchUpdateLinkTagsRequest_Codes_Pattern = regexp.MustCompile("^[0-9A-Za-z_-]{1,64}$")
+
+var _BatchUpdateLinkTagsRequest_AddTags_Pattern = regexp.MustCompile("^[0-9A-Za-z_.:-]{1,64}$")
+
+var _BatchUpdateLinkTagsRequest_RemoveTags_Pattern = regexp.MustCompile("^[0-9A-Za-z_.:-]{1,64}$")
+
+// Validate checks the field values on BatchUpdateLinkTagsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchUpdateLinkTagsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchUpdateLinkTagsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchUpdateLinkTagsResponseMultiError, or nil if none found.
+func (m *BatchUpdateLinkTagsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchUpdateLinkTagsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetLinks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchUpdateLinkTagsResponseValidationError{
+						field:  fmt.Sprintf("Links[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchUpdateLinkTagsResponseValidationError{
+						field:  fmt.Sprintf("Links[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchUpdateLinkTagsResponseValidationError{
+					field:  fmt.Sprintf("Links[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchUpdateLinkTagsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchUpdateLinkTagsResponseMultiError is an error wrapping multiple
+// validation errors returned by BatchUpdateLinkTagsResponse.ValidateAll() if
+// the designated constraints aren't met.
+type BatchUpdateLinkTagsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchUpdateLinkTagsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchUpdateLinkTagsResponseMultiError) AllErrors() []error { return m }
+
+// BatchUpdateLinkTagsResponseValidationError is the validation error returned
+// by BatchUpdateLinkTagsResponse.Validate if the designated constraints
+// aren't met.
+type BatchUpdateLinkTagsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchUpdateLinkTagsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchUpdateLinkTagsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchUpdateLinkTagsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchUpdateLinkTagsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchUpdateLinkTagsResponseValidationError) ErrorName() string {
+	return "BatchUpdateLinkTagsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchUpdateLinkTagsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchUpdateLinkTagsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchUpdateLinkTagsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchUpdateLinkTagsResponseValidationError{}
+
+// Validate checks the field values on ListCampaignStatsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListCampaignStatsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListCampaignStatsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListCampaignStatsRequestMultiError, or nil if none found.
+func (m *ListCampaignStatsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListCampaignStatsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ListCampaignStatsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListCampaignStatsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListCampaignStatsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListCampaignStatsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListCampaignStatsRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListCampaignStatsRequestMultiError) AllErrors() []error { return m }
+
+// ListCampaignStatsRequestValidationError is the validation error returned by
+// ListCampaignStatsRequest.Validate if the designated constraints aren't met.
+type ListCampaignStatsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListCampaignStatsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListCampaignStatsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListCampaignStatsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListCampaignStatsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListCampaignStatsRequestValidationError) ErrorName() string {
+	return "ListCampaignStatsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListCampaignStatsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListCampaignStatsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListCampaignStatsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListCampaignStatsRequestValidationError{}
+
+// Validate checks the field values on CampaignStats with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CampaignStats) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CampaignStats with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CampaignStatsMultiError, or
+// nil if none found.
+func (m *CampaignStats) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CampaignStats) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Campaign
+
+	// no validation rules for Links
+
+	// no validation rules for Clicks
+
+	if len(errors) > 0 {
+		return CampaignStatsMultiError(errors)
+	}
+
+	return nil
+}
+
+// CampaignStatsMultiError is an error wrapping multiple validation errors
+// returned by CampaignStats.ValidateAll() if the designated constraints
+// aren't met.
+type CampaignStatsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CampaignStatsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CampaignStatsMultiError) AllErrors() []error { return m }
+
+// CampaignStatsValidationError is the validation error returned by
+// CampaignStats.Validate if the designated constraints aren't met.
+type CampaignStatsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CampaignStatsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CampaignStatsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CampaignStatsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CampaignStatsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CampaignStatsValidationError) ErrorName() string { return "CampaignStatsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CampaignStatsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCampaignStats.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CampaignStatsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CampaignStatsValidationError{}
+
+// Validate checks the field values on ListCampaignStatsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListCampaignStatsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListCampaignStatsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListCampaignStatsResponseMultiError, or nil if none found.
+func (m *ListCampaignStatsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListCampaignStatsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetCampaigns() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListCampaignStatsResponseValidationError{
+						field:  fmt.Sprintf("Campaigns[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListCampaignStatsResponseValidationError{
+						field:  fmt.Sprintf("Campaigns[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListCampaignStatsResponseValidationError{
+					field:  fmt.Sprintf("Campaigns[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListCampaignStatsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListCampaignStatsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListCampaignStatsResponse.ValidateAll() if the
+// designated constraints aren't met.
+type ListCampaignStatsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListCampaignStatsResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListCampaignStatsResponseMultiError) AllErrors() []error { return m }
+
+// ListCampaignStatsResponseValidationError is the validation error returned by
+// ListCampaignStatsResponse.Validate if the designated constraints aren't met.
+type ListCampaignStatsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListCampaignStatsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListCampaignStatsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListCampaignStatsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListCampaignStatsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListCampaignStatsResponseValidationError) ErrorName() string {
+	return "ListCampaignStatsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListCampaignStatsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListCampaignStatsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListCampaignStatsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListCampaignStatsResponseValidationError{}
+
+// Validate checks the field values on WatchLinksRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *WatchLinksRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchLinksRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WatchLinksRequestMultiError, or nil if none found.
+func (m *WatchLinksRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchLinksRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetAfterSeq() < 0 {
+		err := WatchLinksRequestValidationError{
+			field:  "AfterSeq",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return WatchLinksRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// WatchLinksRequestMultiError is an error wrapping multiple validation errors
+// returned by WatchLinksRequest.ValidateAll() if the designated constraints
+// aren't met.
+type WatchLinksRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchLinksRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchLinksRequestMultiError) AllErrors() []error { return m }
+
+// WatchLinksRequestValidationError is the validation error returned by
+// WatchLinksRequest.Validate if the designated constraints aren't met.
+type WatchLinksRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchLinksRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchLinksRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchLinksRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchLinksRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchLinksRequestValidationError) ErrorName() string {
+	return "WatchLinksRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WatchLinksRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchLinksRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchLinksRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchLinksRequestValidationError{}
+
+// Validate checks the field values on LinkChange with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LinkChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LinkChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LinkChangeMultiError, or
+// nil if none found.
+func (m *LinkChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LinkChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Seq
+
+	// no validation rules for Type
+
+	if all {
+		switch v := interface{}(m.GetTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LinkChangeValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LinkChangeValidationError{
+					field:  "Time",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LinkChangeValidationError{
+				field:  "Time",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLink()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LinkChangeValidationError{
+					field:  "Link",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LinkChangeValidationError{
+					field:  "Link",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLink()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LinkChangeValidationError{
+				field:  "Link",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return LinkChangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// LinkChangeMultiError is an error wrapping multiple validation errors
+// returned by LinkChange.ValidateAll() if the designated constraints aren't met.
+type LinkChangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LinkChangeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LinkChangeMultiError) AllErrors() []error { return m }
+
+// LinkChangeValidationError is the validation error returned by
+// LinkChange.Validate if the designated constraints aren't met.
+type LinkChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LinkChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LinkChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LinkChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LinkChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LinkChangeValidationError) ErrorName() string { return "LinkChangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LinkChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLinkChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LinkChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LinkChangeValidationError{}
@@ -0,0 +1,770 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: proto/url_shortener/v2/url_shortener.proto
+
+package url_shortener_v2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UrlShortenerService_CreateLink_FullMethodName          = "/url_shortener.v2.UrlShortenerService/CreateLink"
+	UrlShortenerService_GetLink_FullMethodName             = "/url_shortener.v2.UrlShortenerService/GetLink"
+	UrlShortenerService_ResolveLink_FullMethodName         = "/url_shortener.v2.UrlShortenerService/ResolveLink"
+	UrlShortenerService_UpdateLink_FullMethodName          = "/url_shortener.v2.UrlShortenerService/UpdateLink"
+	UrlShortenerService_DeleteLink_FullMethodName          = "/url_shortener.v2.UrlShortenerService/DeleteLink"
+	UrlShortenerService_ListLinks_FullMethodName           = "/url_shortener.v2.UrlShortenerService/ListLinks"
+	UrlShortenerService_GetLinkQRCode_FullMethodName       = "/url_shortener.v2.UrlShortenerService/GetLinkQRCode"
+	UrlShortenerService_ListLinkRules_FullMethodName       = "/url_shortener.v2.UrlShortenerService/ListLinkRules"
+	UrlShortenerService_CreateLinkRule_FullMethodName      = "/url_shortener.v2.UrlShortenerService/CreateLinkRule"
+	UrlShortenerService_UpdateLinkRule_FullMethodName      = "/url_shortener.v2.UrlShortenerService/UpdateLinkRule"
+	UrlShortenerService_DeleteLinkRule_FullMethodName      = "/url_shortener.v2.UrlShortenerService/DeleteLinkRule"
+	UrlShortenerService_EvaluateLinkRules_FullMethodName   = "/url_shortener.v2.UrlShortenerService/EvaluateLinkRules"
+	UrlShortenerService_GetLinkVariantStats_FullMethodName = "/url_shortener.v2.UrlShortenerService/GetLinkVariantStats"
+	UrlShortenerService_GetLinkClickHistory_FullMethodName = "/url_shortener.v2.UrlShortenerService/GetLinkClickHistory"
+	UrlShortenerService_BatchUpdateLinkTags_FullMethodName = "/url_shortener.v2.UrlShortenerService/BatchUpdateLinkTags"
+	UrlShortenerService_ListCampaignStats_FullMethodName   = "/url_shortener.v2.UrlShortenerService/ListCampaignStats"
+	UrlShortenerService_WatchLinks_FullMethodName          = "/url_shortener.v2.UrlShortenerService/WatchLinks"
+)
+
+// UrlShortenerServiceClient is the client API for UrlShortenerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UrlShortenerServiceClient interface {
+	// CreateLink creates a new short link. Unlike v1 CreateShortURL it never reuses an existing link.
+	CreateLink(ctx context.Context, in *CreateLinkRequest, opts ...grpc.CallOption) (*Link, error)
+	// GetLink returns a link by its code
+	GetLink(ctx context.Context, in *GetLinkRequest, opts ...grpc.CallOption) (*Link, error)
+	// ResolveLink returns the target of an active (not disabled, not expired) link
+	ResolveLink(ctx context.Context, in *ResolveLinkRequest, opts ...grpc.CallOption) (*ResolveLinkResponse, error)
+	// UpdateLink updates the fields of a link listed in update_mask
+	UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*Link, error)
+	// DeleteLink deletes a link
+	DeleteLink(ctx context.Context, in *DeleteLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListLinks returns links ordered by creation, one page at a time
+	ListLinks(ctx context.Context, in *ListLinksRequest, opts ...grpc.CallOption) (*ListLinksResponse, error)
+	// GetLinkQRCode renders a QR code of the public short URL of a link
+	GetLinkQRCode(ctx context.Context, in *GetLinkQRCodeRequest, opts ...grpc.CallOption) (*QRCode, error)
+	// ListLinkRules returns the redirect rules of a link in evaluation order
+	ListLinkRules(ctx context.Context, in *ListLinkRulesRequest, opts ...grpc.CallOption) (*ListLinkRulesResponse, error)
+	// CreateLinkRule adds a redirect rule to a link
+	CreateLinkRule(ctx context.Context, in *CreateLinkRuleRequest, opts ...grpc.CallOption) (*Rule, error)
+	// UpdateLinkRule replaces a redirect rule, optionally moving it
+	UpdateLinkRule(ctx context.Context, in *UpdateLinkRuleRequest, opts ...grpc.CallOption) (*Rule, error)
+	// DeleteLinkRule deletes a redirect rule
+	DeleteLinkRule(ctx context.Context, in *DeleteLinkRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// EvaluateLinkRules reports where a link would redirect a request to, without resolving it
+	EvaluateLinkRules(ctx context.Context, in *EvaluateLinkRulesRequest, opts ...grpc.CallOption) (*EvaluateLinkRulesResponse, error)
+	// GetLinkVariantStats returns how often each split variant of a link was picked
+	GetLinkVariantStats(ctx context.Context, in *GetLinkVariantStatsRequest, opts ...grpc.CallOption) (*GetLinkVariantStatsResponse, error)
+	// GetLinkClickHistory returns the resolves of a link in total and on each of the last days
+	GetLinkClickHistory(ctx context.Context, in *GetLinkClickHistoryRequest, opts ...grpc.CallOption) (*LinkClickHistory, error)
+	// BatchUpdateLinkTags adds tags to and removes tags from many links at once
+	BatchUpdateLinkTags(ctx context.Context, in *BatchUpdateLinkTagsRequest, opts ...grpc.CallOption) (*BatchUpdateLinkTagsResponse, error)
+	// ListCampaignStats returns the number of links and clicks of every campaign
+	ListCampaignStats(ctx context.Context, in *ListCampaignStatsRequest, opts ...grpc.CallOption) (*ListCampaignStatsResponse, error)
+	// WatchLinks streams every link change in commit order, starting after after_seq, and keeps
+	// following new changes. Clients resume by passing the seq of the last change they got.
+	WatchLinks(ctx context.Context, in *WatchLinksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LinkChange], error)
+}
+
+type urlShortenerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUrlShortenerServiceClient(cc grpc.ClientConnInterface) UrlShortenerServiceClient {
+	return &urlShortenerServiceClient{cc}
+}
+
+func (c *urlShortenerServiceClient) CreateLink(ctx context.Context, in *CreateLinkRequest, opts ...grpc.CallOption) (*Link, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Link)
+	err := c.cc.Invoke(ctx, UrlShortenerService_CreateLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) GetLink(ctx context.Context, in *GetLinkRequest, opts ...grpc.CallOption) (*Link, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Link)
+	err := c.cc.Invoke(ctx, UrlShortenerService_GetLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) ResolveLink(ctx context.Context, in *ResolveLinkRequest, opts ...grpc.CallOption) (*ResolveLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveLinkResponse)
+	err := c.cc.Invoke(ctx, UrlShortenerService_ResolveLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*Link, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Link)
+	err := c.cc.Invoke(ctx, UrlShortenerService_UpdateLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) DeleteLink(ctx context.Context, in *DeleteLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UrlShortenerService_DeleteLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) ListLinks(ctx context.Context, in *ListLinksRequest, opts ...grpc.CallOption) (*ListLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLinksResponse)
+	err := c.cc.Invoke(ctx, UrlShortenerService_ListLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) GetLinkQRCode(ctx context.Context, in *GetLinkQRCodeRequest, opts ...grpc.CallOption) (*QRCode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QRCode)
+	err := c.cc.Invoke(ctx, UrlShortenerService_GetLinkQRCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) ListLinkRules(ctx context.Context, in *ListLinkRulesRequest, opts ...grpc.CallOption) (*ListLinkRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLinkRulesResponse)
+	err := c.cc.Invoke(ctx, UrlShortenerService_ListLinkRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) CreateLinkRule(ctx context.Context, in *CreateLinkRuleRequest, opts ...grpc.CallOption) (*Rule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rule)
+	err := c.cc.Invoke(ctx, UrlShortenerService_CreateLinkRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) UpdateLinkRule(ctx context.Context, in *UpdateLinkRuleRequest, opts ...grpc.CallOption) (*Rule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rule)
+	err := c.cc.Invoke(ctx, UrlShortenerService_UpdateLinkRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) DeleteLinkRule(ctx context.Context, in *DeleteLinkRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UrlShortenerService_DeleteLinkRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) EvaluateLinkRules(ctx context.Context, in *EvaluateLinkRulesRequest, opts ...grpc.CallOption) (*EvaluateLinkRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateLinkRulesResponse)
+	err := c.cc.Invoke(ctx, UrlShortenerService_EvaluateLinkRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) GetLinkVariantStats(ctx context.Context, in *GetLinkVariantStatsRequest, opts ...grpc.CallOption) (*GetLinkVariantStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLinkVariantStatsResponse)
+	err := c.cc.Invoke(ctx, UrlShortenerService_GetLinkVariantStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) GetLinkClickHistory(ctx context.Context, in *GetLinkClickHistoryRequest, opts ...grpc.CallOption) (*LinkClickHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkClickHistory)
+	err := c.cc.Invoke(ctx, UrlShortenerService_GetLinkClickHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) BatchUpdateLinkTags(ctx context.Context, in *BatchUpdateLinkTagsRequest, opts ...grpc.CallOption) (*BatchUpdateLinkTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateLinkTagsResponse)
+	err := c.cc.Invoke(ctx, UrlShortenerService_BatchUpdateLinkTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) ListCampaignStats(ctx context.Context, in *ListCampaignStatsRequest, opts ...grpc.CallOption) (*ListCampaignStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCampaignStatsResponse)
+	err := c.cc.Invoke(ctx, UrlShortenerService_ListCampaignStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *urlShortenerServiceClient) WatchLinks(ctx context.Context, in *WatchLinksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LinkChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &UrlShortenerService_ServiceDesc.Streams[0], UrlShortenerService_WatchLinks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLinksRequest, LinkChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UrlShortenerService_WatchLinksClient = grpc.ServerStreamingClient[LinkChange]
+
+// UrlShortenerServiceServer is the server API for UrlShortenerService service.
+// All implementations must embed UnimplementedUrlShortenerServiceServer
+// for forward compatibility.
+type UrlShortenerServiceServer interface {
+	// CreateLink creates a new short link. Unlike v1 CreateShortURL it never reuses an existing link.
+	CreateLink(context.Context, *CreateLinkRequest) (*Link, error)
+	// GetLink returns a link by its code
+	GetLink(context.Context, *GetLinkRequest) (*Link, error)
+	// ResolveLink returns the target of an active (not disabled, not expired) link
+	ResolveLink(context.Context, *ResolveLinkRequest) (*ResolveLinkResponse, error)
+	// UpdateLink updates the fields of a link listed in update_mask
+	UpdateLink(context.Context, *UpdateLinkRequest) (*Link, error)
+	// DeleteLink deletes a link
+	DeleteLink(context.Context, *DeleteLinkRequest) (*emptypb.Empty, error)
+	// ListLinks returns links ordered by creation, one page at a time
+	ListLinks(context.Context, *ListLinksRequest) (*ListLinksResponse, error)
+	// GetLinkQRCode renders a QR code of the public short URL of a link
+	GetLinkQRCode(context.Context, *GetLinkQRCodeRequest) (*QRCode, error)
+	// ListLinkRules returns the redirect rules of a link in evaluation order
+	ListLinkRules(context.Context, *ListLinkRulesRequest) (*ListLinkRulesResponse, error)
+	// CreateLinkRule adds a redirect rule to a link
+	CreateLinkRule(context.Context, *CreateLinkRuleRequest) (*Rule, error)
+	// UpdateLinkRule replaces a redirect rule, optionally moving it
+	UpdateLinkRule(context.Context, *UpdateLinkRuleRequest) (*Rule, error)
+	// DeleteLinkRule deletes a redirect rule
+	DeleteLinkRule(context.Context, *DeleteLinkRuleRequest) (*emptypb.Empty, error)
+	// EvaluateLinkRules reports where a link would redirect a request to, without resolving it
+	EvaluateLinkRules(context.Context, *EvaluateLinkRulesRequest) (*EvaluateLinkRulesResponse, error)
+	// GetLinkVariantStats returns how often each split variant of a link was picked
+	GetLinkVariantStats(context.Context, *GetLinkVariantStatsRequest) (*GetLinkVariantStatsResponse, error)
+	// GetLinkClickHistory returns the resolves of a link in total and on each of the last days
+	GetLinkClickHistory(context.Context, *GetLinkClickHistoryRequest) (*LinkClickHistory, error)
+	// BatchUpdateLinkTags adds tags to and removes tags from many links at once
+	BatchUpdateLinkTags(context.Context, *BatchUpdateLinkTagsRequest) (*BatchUpdateLinkTagsResponse, error)
+	// ListCampaignStats returns the number of links and clicks of every campaign
+	ListCampaignStats(context.Context, *ListCampaignStatsRequest) (*ListCampaignStatsResponse, error)
+	// WatchLinks streams every link change in commit order, starting after after_seq, and keeps
+	// following new changes. Clients resume by passing the seq of the last change they got.
+	WatchLinks(*WatchLinksRequest, grpc.ServerStreamingServer[LinkChange]) error
+	mustEmbedUnimplementedUrlShortenerServiceServer()
+}
+
+// UnimplementedUrlShortenerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUrlShortenerServiceServer struct{}
+
+func (UnimplementedUrlShortenerServiceServer) CreateLink(context.Context, *CreateLinkRequest) (*Link, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLink not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) GetLink(context.Context, *GetLinkRequest) (*Link, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLink not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) ResolveLink(context.Context, *ResolveLinkRequest) (*ResolveLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveLink not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) UpdateLink(context.Context, *UpdateLinkRequest) (*Link, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLink not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) DeleteLink(context.Context, *DeleteLinkRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLink not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) ListLinks(context.Context, *ListLinksRequest) (*ListLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLinks not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) GetLinkQRCode(context.Context, *GetLinkQRCodeRequest) (*QRCode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkQRCode not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) ListLinkRules(context.Context, *ListLinkRulesRequest) (*ListLinkRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLinkRules not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) CreateLinkRule(context.Context, *CreateLinkRuleRequest) (*Rule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLinkRule not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) UpdateLinkRule(context.Context, *UpdateLinkRuleRequest) (*Rule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLinkRule not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) DeleteLinkRule(context.Context, *DeleteLinkRuleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLinkRule not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) EvaluateLinkRules(context.Context, *EvaluateLinkRulesRequest) (*EvaluateLinkRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateLinkRules not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) GetLinkVariantStats(context.Context, *GetLinkVariantStatsRequest) (*GetLinkVariantStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkVariantStats not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) GetLinkClickHistory(context.Context, *GetLinkClickHistoryRequest) (*LinkClickHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkClickHistory not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) BatchUpdateLinkTags(context.Context, *BatchUpdateLinkTagsRequest) (*BatchUpdateLinkTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateLinkTags not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) ListCampaignStats(context.Context, *ListCampaignStatsRequest) (*ListCampaignStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCampaignStats not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) WatchLinks(*WatchLinksRequest, grpc.ServerStreamingServer[LinkChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLinks not implemented")
+}
+func (UnimplementedUrlShortenerServiceServer) mustEmbedUnimplementedUrlShortenerServiceServer() {}
+func (UnimplementedUrlShortenerServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeUrlShortenerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UrlShortenerServiceServer will
+// result in compilation errors.
+type UnsafeUrlShortenerServiceServer interface {
+	mustEmbedUnimplementedUrlShortenerServiceServer()
+}
+
+func RegisterUrlShortenerServiceServer(s grpc.ServiceRegistrar, srv UrlShortenerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUrlShortenerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UrlShortenerService_ServiceDesc, srv)
+}
+
+func _UrlShortenerService_CreateLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).CreateLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_CreateLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).CreateLink(ctx, req.(*CreateLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_GetLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).GetLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_GetLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).GetLink(ctx, req.(*GetLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_ResolveLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).ResolveLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_ResolveLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).ResolveLink(ctx, req.(*ResolveLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_UpdateLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).UpdateLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_UpdateLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).UpdateLink(ctx, req.(*UpdateLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_DeleteLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).DeleteLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_DeleteLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).DeleteLink(ctx, req.(*DeleteLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_ListLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).ListLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_ListLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).ListLinks(ctx, req.(*ListLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_GetLinkQRCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkQRCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).GetLinkQRCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_GetLinkQRCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).GetLinkQRCode(ctx, req.(*GetLinkQRCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_ListLinkRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLinkRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).ListLinkRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_ListLinkRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).ListLinkRules(ctx, req.(*ListLinkRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_CreateLinkRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLinkRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).CreateLinkRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_CreateLinkRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).CreateLinkRule(ctx, req.(*CreateLinkRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_UpdateLinkRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).UpdateLinkRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_UpdateLinkRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).UpdateLinkRule(ctx, req.(*UpdateLinkRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_DeleteLinkRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLinkRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).DeleteLinkRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_DeleteLinkRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).DeleteLinkRule(ctx, req.(*DeleteLinkRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_EvaluateLinkRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateLinkRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).EvaluateLinkRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_EvaluateLinkRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).EvaluateLinkRules(ctx, req.(*EvaluateLinkRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_GetLinkVariantStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkVariantStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).GetLinkVariantStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_GetLinkVariantStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).GetLinkVariantStats(ctx, req.(*GetLinkVariantStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_GetLinkClickHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkClickHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).GetLinkClickHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_GetLinkClickHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).GetLinkClickHistory(ctx, req.(*GetLinkClickHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_BatchUpdateLinkTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateLinkTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).BatchUpdateLinkTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_BatchUpdateLinkTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).BatchUpdateLinkTags(ctx, req.(*BatchUpdateLinkTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_ListCampaignStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCampaignStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlShortenerServiceServer).ListCampaignStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UrlShortenerService_ListCampaignStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlShortenerServiceServer).ListCampaignStats(ctx, req.(*ListCampaignStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UrlShortenerService_WatchLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLinksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UrlShortenerServiceServer).WatchLinks(m, &grpc.GenericServerStream[WatchLinksRequest, LinkChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type UrlShortenerService_WatchLinksServer = grpc.ServerStreamingServer[LinkChange]
+
+// UrlShortenerService_ServiceDesc is the grpc.ServiceDesc for UrlShortenerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UrlShortenerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "url_shortener.v2.UrlShortenerService",
+	HandlerType: (*UrlShortenerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateLink",
+			Handler:    _UrlShortenerService_CreateLink_Handler,
+		},
+		{
+			MethodName: "GetLink",
+			Handler:    _UrlShortenerService_GetLink_Handler,
+		},
+		{
+			MethodName: "ResolveLink",
+			Handler:    _UrlShortenerService_ResolveLink_Handler,
+		},
+		{
+			MethodName: "UpdateLink",
+			Handler:    _UrlShortenerService_UpdateLink_Handler,
+		},
+		{
+			MethodName: "DeleteLink",
+			Handler:    _UrlShortenerService_DeleteLink_Handler,
+		},
+		{
+			MethodName: "ListLinks",
+			Handler:    _UrlShortenerService_ListLinks_Handler,
+		},
+		{
+			MethodName: "GetLinkQRCode",
+			Handler:    _UrlShortenerService_GetLinkQRCode_Handler,
+		},
+		{
+			MethodName: "ListLinkRules",
+			Handler:    _UrlShortenerService_ListLinkRules_Handler,
+		},
+		{
+			MethodName: "CreateLinkRule",
+			Handler:    _UrlShortenerService_CreateLinkRule_Handler,
+		},
+		{
+			MethodName: "UpdateLinkRule",
+			Handler:    _UrlShortenerService_UpdateLinkRule_Handler,
+		},
+		{
+			MethodName: "DeleteLinkRule",
+			Handler:    _UrlShortenerService_DeleteLinkRule_Handler,
+		},
+		{
+			MethodName: "EvaluateLinkRules",
+			Handler:    _UrlShortenerService_EvaluateLinkRules_Handler,
+		},
+		{
+			MethodName: "GetLinkVariantStats",
+			Handler:    _UrlShortenerService_GetLinkVariantStats_Handler,
+		},
+		{
+			MethodName: "GetLinkClickHistory",
+			Handler:    _UrlShortenerService_GetLinkClickHistory_Handler,
+		},
+		{
+			MethodName: "BatchUpdateLinkTags",
+			Handler:    _UrlShortenerService_BatchUpdateLinkTags_Handler,
+		},
+		{
+			MethodName: "ListCampaignStats",
+			Handler:    _UrlShortenerService_ListCampaignStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLinks",
+			Handler:       _UrlShortenerService_WatchLinks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/url_shortener/v2/url_shortener.proto",
+}
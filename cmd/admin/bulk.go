@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/Parzival-05/url-shortener/internal/transfer"
+)
+
+type exportView struct {
+	Links int    `json:"links"`
+	File  string `json:"file,omitempty"`
+}
+
+func (e exportView) columns() []string {
+	return []string{"EXPORTED", "FILE"}
+}
+
+func (e exportView) rows() [][]string {
+	return [][]string{{strconv.Itoa(e.Links), orDash(e.File)}}
+}
+
+// export writes every link of the domain as CSV or NDJSON. Without -o the links go to stdout and
+// nothing else is printed.
+func (a *app) export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	path := flags.String("o", "", "Output file, stdout if empty")
+	fileFormat := flags.String("file-format", string(transfer.FormatNDJSON), "File format: csv or ndjson")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	w := a.stdout
+	var file *os.File
+	if *path != "" {
+		f, err := os.Create(*path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w, file = f, f
+	}
+	count, err := transfer.Export(a.ctx, a.shortener, w, transfer.Format(*fileFormat))
+	if err != nil {
+		return usageIf(err)
+	}
+	if file == nil {
+		return nil
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	return a.out.print(exportView{Links: count, File: *path})
+}
+
+type importView struct {
+	transfer.Summary
+	Results []transfer.Result `json:"results"`
+}
+
+func (i importView) columns() []string {
+	return []string{"LINE", "STATUS", "CODE", "NEW CODE", "TARGET", "ERROR"}
+}
+
+func (i importView) rows() [][]string {
+	rows := make([][]string, 0, len(i.Results))
+	for _, r := range i.Results {
+		code := r.Code
+		if code == "" {
+			code = r.Alias
+		}
+		rows = append(rows, []string{strconv.Itoa(r.Line), string(r.Status), orDash(code), orDash(r.NewCode), orDash(r.Target), orDash(r.Error)})
+	}
+	return rows
+}
+
+// importLinks creates the links of a file. Links keep their code when it is a free code of the
+// domain; -on-conflict decides whether links with taken codes get a new one or are skipped.
+// Invalid rows are reported and skipped, and make the command fail once the file is done.
+func (a *app) importLinks(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	path := flags.String("i", "", "Input file, stdin if empty")
+	fileFormat := flags.String("file-format", string(transfer.FormatNDJSON), "File format: csv, ndjson, bitly or yourls")
+	dryRun := flags.Bool("dry-run", false, "Validate the file and report what would be imported without creating links")
+	onConflict := flags.String("on-conflict", "remap", "What to do with links whose code is taken: remap or skip")
+	mappingPath := flags.String("mapping", "", "Write the old code or alias and new code of every remapped or aliased link to this CSV file")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *onConflict != "remap" && *onConflict != "skip" {
+		return fmt.Errorf("%w: -on-conflict must be remap or skip, got %q", errUsage, *onConflict)
+	}
+	var r io.Reader = a.stdin
+	if *path != "" {
+		f, err := os.Open(*path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var mapping *transfer.MappingWriter
+	if *mappingPath != "" {
+		f, err := os.Create(*mappingPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		mapping = transfer.NewMappingWriter(f)
+	}
+
+	view := importView{Results: []transfer.Result{}}
+	opts := transfer.Options{Format: transfer.Format(*fileFormat), DryRun: *dryRun, SkipTaken: *onConflict == "skip"}
+	summary, err := transfer.Import(a.ctx, a.shortener, r, opts, func(result transfer.Result) error {
+		view.Results = append(view.Results, result)
+		if mapping != nil {
+			return mapping.Write(result)
+		}
+		return nil
+	})
+	if err != nil {
+		return usageIf(err)
+	}
+	if mapping != nil {
+		if err := mapping.Flush(); err != nil {
+			return err
+		}
+	}
+	view.Summary = summary
+	if err := a.out.print(view); err != nil {
+		return err
+	}
+	if a.out.format == formatTable {
+		fmt.Fprintf(a.stderr, "Imported %d, remapped %d, skipped %d, invalid %d\n", summary.Imported, summary.Remapped, summary.Skipped, summary.Invalid)
+	}
+	if summary.Invalid > 0 {
+		return fmt.Errorf("%d of %d links are invalid", summary.Invalid, len(view.Results))
+	}
+	return nil
+}
+
+// usageIf marks errors of unknown file formats and malformed headers as usage errors.
+func usageIf(err error) error {
+	if errors.Is(err, transfer.ErrUnknownFormat) || errors.Is(err, transfer.ErrBadHeader) {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	return err
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	crand "crypto/rand"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/service"
+)
+
+// sqidsAlphabet is the alphabet of sqids, which domains without one use.
+const sqidsAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+type codeView struct {
+	ID   int64  `json:"id"`
+	Code string `json:"code"`
+}
+
+type codeViews []codeView
+
+func (c codeViews) columns() []string {
+	return []string{"ID", "CODE"}
+}
+
+func (c codeViews) rows() [][]string {
+	rows := make([][]string, 0, len(c))
+	for _, v := range c {
+		rows = append(rows, []string{strconv.FormatInt(v.ID, 10), v.Code})
+	}
+	return rows
+}
+
+func (a *app) encode(args []string) error {
+	flags := flag.NewFlagSet("encode", flag.ContinueOnError)
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireArgs(flags, 1, "expected at least one ID"); err != nil {
+		return err
+	}
+	var views codeViews
+	for _, arg := range flags.Args() {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id < 0 {
+			return fmt.Errorf("%w: invalid ID %q", errUsage, arg)
+		}
+		code, err := a.domain.Encode(id)
+		if err != nil {
+			return err
+		}
+		views = append(views, codeView{ID: id, Code: code})
+	}
+	return a.out.print(views)
+}
+
+func (a *app) decode(args []string) error {
+	flags := flag.NewFlagSet("decode", flag.ContinueOnError)
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireArgs(flags, 1, "expected at least one code"); err != nil {
+		return err
+	}
+	var views codeViews
+	for _, code := range flags.Args() {
+		id, ok := a.domain.Decode(code)
+		// Codes decode to an ID only if they are its canonical encoding.
+		if canonical, err := a.domain.Encode(id); !ok || err != nil || canonical != code {
+			return fmt.Errorf("%s: %w", code, service.ErrInvalidUrl)
+		}
+		views = append(views, codeView{ID: id, Code: code})
+	}
+	return a.out.print(views)
+}
+
+type codeMapping struct {
+	ID      int64  `json:"id"`
+	OldCode string `json:"old_code"`
+	NewCode string `json:"new_code"`
+}
+
+type rotationView struct {
+	Alphabet string        `json:"alphabet"`
+	Codes    []codeMapping `json:"codes"`
+}
+
+func (r rotationView) columns() []string {
+	return []string{"ID", "OLD CODE", "NEW CODE"}
+}
+
+func (r rotationView) rows() [][]string {
+	rows := make([][]string, 0, len(r.Codes))
+	for _, m := range r.Codes {
+		rows = append(rows, []string{strconv.FormatInt(m.ID, 10), m.OldCode, m.NewCode})
+	}
+	return rows
+}
+
+// rotateAlphabet prints a new alphabet and the codes every link of the domain gets with it.
+// The alphabet is configuration, so it takes effect once SECRET_ALPHABET, or the alphabet of
+// the domain, is set to it; old codes stop resolving then.
+func (a *app) rotateAlphabet(args []string) error {
+	flags := flag.NewFlagSet("rotate-alphabet", flag.ContinueOnError)
+	alphabet := flags.String("alphabet", "", "New alphabet, a random permutation of the current one if empty")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *alphabet == "" {
+		current := a.domain.Alphabet
+		if current == "" {
+			current = sqidsAlphabet
+		}
+		*alphabet = shuffle(current)
+	}
+	rotated, err := domains.NewRegistry(domains.Domain{Alphabet: *alphabet}, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	next := rotated.Default()
+
+	view := rotationView{Alphabet: *alphabet, Codes: []codeMapping{}}
+	err = a.eachLink(func(link service.Link) error {
+		code, err := next.Encode(link.ID)
+		if err != nil {
+			return err
+		}
+		view.Codes = append(view.Codes, codeMapping{ID: link.ID, OldCode: link.Code, NewCode: code})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if a.out.format == formatTable {
+		fmt.Fprintf(a.stderr, "New alphabet: %s\n", *alphabet)
+	}
+	return a.out.print(view)
+}
+
+// shuffle returns a random permutation of alphabet.
+func shuffle(alphabet string) string {
+	var seed [32]byte
+	_, _ = crand.Read(seed[:])
+	r := rand.New(rand.NewChaCha8(seed))
+	b := []byte(alphabet)
+	r.Shuffle(len(b), func(i, j int) { b[i], b[j] = b[j], b[i] })
+	return string(b)
+}
+
+// eachLink calls fn with every link of the domain, disabled ones included, in ID order.
+func (a *app) eachLink(fn func(service.Link) error) error {
+	query := service.ListLinksQuery{PageSize: service.MaxPageSize, IncludeDisabled: true}
+	for {
+		page, err := a.shortener.ListLinks(a.ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, link := range page.Links {
+			if err := fn(link); err != nil {
+				return err
+			}
+		}
+		if page.NextPageToken == "" {
+			return nil
+		}
+		query.PageToken = page.NextPageToken
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/service"
+)
+
+// linkFlags are the link fields settable by create and update.
+type linkFlags struct {
+	target    string
+	owner     string
+	tags      string
+	disabled  bool
+	expires   string
+	maxClicks int64
+	password  string
+}
+
+// fieldFlags maps flag names to the link fields they set.
+var fieldFlags = map[string]database.LinkField{
+	"target":     database.LinkFieldTarget,
+	"owner":      database.LinkFieldOwner,
+	"tags":       database.LinkFieldTags,
+	"disabled":   database.LinkFieldDisabled,
+	"expires":    database.LinkFieldExpiresAt,
+	"max-clicks": database.LinkFieldMaxClicks,
+	"password":   database.LinkFieldPassword,
+}
+
+func newLinkFlags(name string) (*flag.FlagSet, *linkFlags) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	f := &linkFlags{}
+	flags.StringVar(&f.target, "target", "", "Target URL")
+	flags.StringVar(&f.owner, "owner", "", "Owner")
+	flags.StringVar(&f.tags, "tags", "", "Comma-separated tags")
+	flags.BoolVar(&f.disabled, "disabled", false, "Disable the link")
+	flags.StringVar(&f.expires, "expires", "", "Expiry as an RFC 3339 time or a duration from now, e.g. 72h; empty for none")
+	flags.Int64Var(&f.maxClicks, "max-clicks", 0, "Number of resolves before the link is exhausted, 0 for unlimited")
+	flags.StringVar(&f.password, "password", "", "Password protecting the link, empty for none")
+	return flags, f
+}
+
+// link returns the link described by the flags.
+func (f *linkFlags) link() (database.Link, error) {
+	link := database.Link{
+		Target:    f.target,
+		Owner:     f.owner,
+		Tags:      splitList(f.tags),
+		Disabled:  f.disabled,
+		MaxClicks: f.maxClicks,
+	}
+	if f.expires != "" {
+		expires, err := parseExpiry(f.expires, time.Now())
+		if err != nil {
+			return database.Link{}, err
+		}
+		link.ExpiresAt = &expires
+	}
+	hash, err := service.HashPassword(f.password)
+	if err != nil {
+		return database.Link{}, err
+	}
+	link.PasswordHash = hash
+	return link, nil
+}
+
+// parseExpiry parses an RFC 3339 time or a duration from now.
+func parseExpiry(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(d).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: expires must be an RFC 3339 time or a duration, got %q", errUsage, s)
+	}
+	return t, nil
+}
+
+func (a *app) create(args []string) error {
+	flags, f := newLinkFlags("create")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if f.target == "" {
+		return fmt.Errorf("%w: -target is required", errUsage)
+	}
+	link, err := f.link()
+	if err != nil {
+		return err
+	}
+	created, err := a.shortener.CreateLink(a.ctx, link)
+	if err != nil {
+		return err
+	}
+	return a.out.print(linkViews{toLinkView(created)})
+}
+
+func (a *app) get(args []string) error {
+	flags := flag.NewFlagSet("get", flag.ContinueOnError)
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireArgs(flags, 1, "expected at least one code"); err != nil {
+		return err
+	}
+	var views linkViews
+	for _, code := range flags.Args() {
+		link, err := a.shortener.GetLink(a.ctx, code)
+		if err != nil {
+			return fmt.Errorf("%s: %w", code, err)
+		}
+		views = append(views, toLinkView(link))
+	}
+	return a.out.print(views)
+}
+
+type resolvedView struct {
+	Code      string `json:"code"`
+	Target    string `json:"target"`
+	RuleID    string `json:"rule_id,omitempty"`
+	VariantID string `json:"variant_id,omitempty"`
+}
+
+func (r resolvedView) columns() []string {
+	return []string{"CODE", "TARGET", "RULE", "VARIANT"}
+}
+
+func (r resolvedView) rows() [][]string {
+	return [][]string{{r.Code, r.Target, orDash(r.RuleID), orDash(r.VariantID)}}
+}
+
+func (a *app) resolve(args []string) error {
+	flags := flag.NewFlagSet("resolve", flag.ContinueOnError)
+	password := flags.String("password", "", "Password of a protected link")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: expected one code", errUsage)
+	}
+	resolved, err := a.shortener.Resolve(a.ctx, service.ResolveRequest{Code: flags.Arg(0), Password: *password})
+	if err != nil {
+		return err
+	}
+	return a.out.print(resolvedView{
+		Code:      resolved.Code,
+		Target:    resolved.Target,
+		RuleID:    resolved.RuleID,
+		VariantID: resolved.VariantID,
+	})
+}
+
+func (a *app) update(args []string) error {
+	flags, f := newLinkFlags("update")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: expected one code", errUsage)
+	}
+	var fields []database.LinkField
+	flags.Visit(func(fl *flag.Flag) {
+		fields = append(fields, fieldFlags[fl.Name])
+	})
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: no fields to update", errUsage)
+	}
+	link, err := f.link()
+	if err != nil {
+		return err
+	}
+	updated, err := a.shortener.UpdateLink(a.ctx, flags.Arg(0), link, fields)
+	if err != nil {
+		return err
+	}
+	return a.out.print(linkViews{toLinkView(updated)})
+}
+
+type deletedViews []string
+
+func (d deletedViews) columns() []string {
+	return []string{"DELETED"}
+}
+
+func (d deletedViews) rows() [][]string {
+	rows := make([][]string, 0, len(d))
+	for _, code := range d {
+		rows = append(rows, []string{code})
+	}
+	return rows
+}
+
+func (a *app) delete(args []string) error {
+	flags := flag.NewFlagSet("delete", flag.ContinueOnError)
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireArgs(flags, 1, "expected at least one code"); err != nil {
+		return err
+	}
+	deleted := deletedViews{}
+	for _, code := range flags.Args() {
+		if err := a.shortener.DeleteLink(a.ctx, code); err != nil {
+			// Report what was deleted before the failure.
+			_ = a.out.print(deleted)
+			return fmt.Errorf("%s: %w", code, err)
+		}
+		deleted = append(deleted, code)
+	}
+	return a.out.print(deleted)
+}
@@ -0,0 +1,242 @@
+// Command admin operates the shortener directly on its storage, without a running server.
+//
+//	admin [-storage inmemory|postgres] [-format table|json] [-domain host] <command> [flags] [args]
+//
+// It exits with 0 on success, 1 when an operation fails, 2 on invalid usage or input,
+// 3 when a link is not found and 4 when the health check fails.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/database/sql"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	_ "github.com/joho/godotenv/autoload"
+	"go.uber.org/zap"
+)
+
+// Exit codes.
+const (
+	exitOK        = 0
+	exitError     = 1
+	exitUsage     = 2
+	exitNotFound  = 3
+	exitUnhealthy = 4
+)
+
+// errUsage marks errors in the command line or input, which exit with exitUsage.
+var errUsage = errors.New("invalid usage")
+
+// errUnhealthy is returned by the health command when a check fails.
+var errUnhealthy = errors.New("unhealthy")
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	// raw commands run without the schema version check, e.g. to migrate.
+	raw bool
+	run func(a *app, args []string) error
+}
+
+var commands = []command{
+	{name: "create", usage: "-target URL [flags]", summary: "create a link", run: (*app).create},
+	{name: "get", usage: "CODE...", summary: "print links", run: (*app).get},
+	{name: "resolve", usage: "[-password P] CODE", summary: "resolve a link like a redirect does, counting the click", run: (*app).resolve},
+	{name: "update", usage: "[flags] CODE", summary: "update the given fields of a link", run: (*app).update},
+	{name: "delete", usage: "CODE...", summary: "delete links", run: (*app).delete},
+	{name: "encode", usage: "ID...", summary: "print the codes of link IDs", run: (*app).encode},
+	{name: "decode", usage: "CODE...", summary: "print the link IDs of codes", run: (*app).decode},
+	{name: "export", usage: "[-file-format F] [-o FILE]", summary: "write every link of the domain as CSV or NDJSON", run: (*app).export},
+	{name: "import", usage: "[-file-format F] [-i FILE] [-dry-run] [-on-conflict remap|skip] [-mapping FILE]", summary: "create the links of a CSV, NDJSON, Bitly or YOURLS file", run: (*app).importLinks},
+	{name: "migrate", usage: "", summary: "migrate the storage schema", raw: true, run: (*app).migrate},
+	{name: "copy", usage: "-to STORAGE [-batch N] [-state FILE] [-overwrite] [-verify]", summary: "copy every link to another storage, keeping the codes", run: (*app).copyLinks},
+	{name: "verify", usage: "-to STORAGE [-batch N]", summary: "compare the links with those of another storage", run: (*app).verify},
+	{name: "rotate-alphabet", usage: "[-alphabet A]", summary: "print a new alphabet and the new code of every link", run: (*app).rotateAlphabet},
+	{name: "health", usage: "", summary: "check the storage, schema and config", raw: true, run: (*app).health},
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr, openStorage))
+}
+
+// openStorage opens the storage of the given type.
+func openStorage(typ database.StorageType) (database.DBService, error) {
+	switch typ {
+	case database.InMemory:
+		return inmemory.NewInMemoryDBService(), nil
+	case database.Postgres:
+		return sql.New(), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown storage type %q", errUsage, typ)
+	}
+}
+
+// app is what the commands run against.
+type app struct {
+	ctx       context.Context
+	db        database.DBService
+	storage   database.StorageType
+	open      func(database.StorageType) (database.DBService, error)
+	shortener *service.UrlShortener
+	registry  *domains.Registry
+	domain    *domains.Domain
+	out       printer
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, open func(database.StorageType) (database.DBService, error)) int {
+	flags := flag.NewFlagSet("admin", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	storage := flags.String("storage", string(database.InMemory), fmt.Sprintf("Storage type: '%s' or '%s'", database.InMemory, database.Postgres))
+	format := flags.String("format", formatTable, fmt.Sprintf("Output format: '%s' or '%s'", formatTable, formatJSON))
+	domain := flags.String("domain", "", "Domain of the links, the default domain if empty")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: admin [flags] <command> [command flags] [args]")
+		fmt.Fprintln(stderr, "\nFlags:")
+		flags.PrintDefaults()
+		fmt.Fprintln(stderr, "\nCommands:")
+		for _, c := range commands {
+			fmt.Fprintf(stderr, "  %-16s %s\n", c.name, c.summary)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *format != formatTable && *format != formatJSON {
+		fmt.Fprintf(stderr, "admin: unknown format %q\n", *format)
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	i := slices.IndexFunc(commands, func(c command) bool { return c.name == flags.Arg(0) })
+	if i < 0 {
+		fmt.Fprintf(stderr, "admin: unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return exitUsage
+	}
+	cmd := commands[i]
+
+	// Debug logs of the service would clutter the output.
+	log := zap.NewNop()
+	db, err := open(database.StorageType(*storage))
+	if err != nil {
+		return fail(stderr, cmd.name, err)
+	}
+	defer db.Close()
+	a := &app{
+		ctx:       domains.WithName(ctx, *domain),
+		db:        db,
+		storage:   database.StorageType(*storage),
+		open:      open,
+		shortener: service.NewUrlShortener(db.NewUrlRepository(), log),
+		out:       printer{w: stdout, format: *format},
+		stdin:     stdin,
+		stdout:    stdout,
+		stderr:    stderr,
+	}
+	if !cmd.raw {
+		if err := a.init(*domain); err != nil {
+			return fail(stderr, cmd.name, err)
+		}
+	}
+	return fail(stderr, cmd.name, cmd.run(a, flags.Args()[1:]))
+}
+
+// init loads the domains and checks that the storage is migrated.
+func (a *app) init(domain string) error {
+	registry, err := domains.FromEnv()
+	if err != nil {
+		return err
+	}
+	if a.domain, err = registry.Get(domain); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	a.registry = registry
+	version, err := a.db.SchemaVersion(a.ctx)
+	if err != nil {
+		return err
+	}
+	if version < database.SchemaVersion {
+		return fmt.Errorf("schema version %d is behind expected %d, run the migrate command first", version, database.SchemaVersion)
+	}
+	return nil
+}
+
+// fail prints err, if any, and returns the exit code for it.
+func fail(stderr io.Writer, name string, err error) int {
+	if err == nil {
+		return exitOK
+	}
+	if !errors.Is(err, errUnhealthy) {
+		fmt.Fprintf(stderr, "admin %s: %s\n", name, err)
+	}
+	return exitCode(err)
+}
+
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUnhealthy):
+		return exitUnhealthy
+	case errors.Is(err, service.ErrUrlNotFound):
+		return exitNotFound
+	case errors.Is(err, errUsage),
+		errors.Is(err, flag.ErrHelp),
+		errors.Is(err, service.ErrInvalidUrl),
+		errors.Is(err, service.ErrInvalidTarget),
+		errors.Is(err, service.ErrInvalidMaxClicks),
+		errors.Is(err, service.ErrPasswordTooLong),
+		errors.Is(err, domains.ErrUnknownDomain):
+		return exitUsage
+	default:
+		return exitError
+	}
+}
+
+// parseFlags parses the flags of a command.
+func (a *app) parseFlags(flags *flag.FlagSet, args []string) error {
+	flags.SetOutput(a.stderr)
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	return nil
+}
+
+// requireArgs fails unless the command got at least n arguments.
+func requireArgs(flags *flag.FlagSet, n int, what string) error {
+	if flags.NArg() < n {
+		return fmt.Errorf("%w: %s", errUsage, what)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/transfer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAdmin returns a function running admin commands against one in-memory storage.
+func newTestAdmin(t *testing.T) func(stdin string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", "")
+	db := inmemory.NewInMemoryDBService()
+	open := func(database.StorageType) (database.DBService, error) { return db, nil }
+	return func(stdin string, args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr, open)
+		return code, stdout.String(), stderr.String()
+	}
+}
+
+func TestAdmin_Links(t *testing.T) {
+	admin := newTestAdmin(t)
+
+	code, out, errOut := admin("", "-format", "json", "create", "-target", "https://example.com/a", "-owner", "alice", "-tags", "docs,ops", "-max-clicks", "2")
+	require.Equal(t, exitOK, code, errOut)
+	var created []linkView
+	require.NoError(t, json.Unmarshal([]byte(out), &created))
+	require.Len(t, created, 1)
+	link := created[0]
+	assert.Equal(t, "alice", link.Owner)
+	assert.Equal(t, []string{"docs", "ops"}, link.Tags)
+	assert.Equal(t, "https://sho.rt/"+link.Code, link.ShortURL)
+
+	code, out, _ = admin("", "get", link.Code)
+	require.Equal(t, exitOK, code)
+	assert.Contains(t, out, "CODE")
+	assert.Contains(t, out, "https://example.com/a")
+
+	code, out, _ = admin("", "resolve", link.Code)
+	require.Equal(t, exitOK, code)
+	assert.Contains(t, out, "https://example.com/a")
+
+	// only the flags given are updated
+	code, out, errOut = admin("", "-format", "json", "update", "-target", "https://example.com/b", "-disabled", link.Code)
+	require.Equal(t, exitOK, code, errOut)
+	var updated []linkView
+	require.NoError(t, json.Unmarshal([]byte(out), &updated))
+	assert.Equal(t, "https://example.com/b", updated[0].Target)
+	assert.True(t, updated[0].Disabled)
+	assert.Equal(t, "alice", updated[0].Owner)
+
+	code, _, errOut = admin("", "resolve", link.Code)
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, errOut, "disabled")
+
+	code, out, errOut = admin("", "-format", "json", "decode", link.Code)
+	require.Equal(t, exitOK, code, errOut)
+	var decoded []codeView
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded))
+	assert.Equal(t, link.ID, decoded[0].ID)
+	code, out, _ = admin("", "encode", "1")
+	require.Equal(t, exitOK, code)
+	assert.Contains(t, out, link.Code)
+
+	code, _, _ = admin("", "delete", link.Code)
+	require.Equal(t, exitOK, code)
+	code, _, _ = admin("", "get", link.Code)
+	assert.Equal(t, exitNotFound, code)
+}
+
+func TestAdmin_Usage(t *testing.T) {
+	admin := newTestAdmin(t)
+	for _, args := range [][]string{
+		{},
+		{"frobnicate"},
+		{"-format", "xml", "get", "abc"},
+		{"create"},
+		{"create", "-target", "ftp://example.com"},
+		{"update", "abc"},
+		{"decode", "not a code"},
+		{"-domain", "unknown.example", "get", "abc"},
+	} {
+		code, _, _ := admin("", args...)
+		assert.Equal(t, exitUsage, code, args)
+	}
+}
+
+func TestAdmin_ImportExport(t *testing.T) {
+	admin := newTestAdmin(t)
+
+	input := strings.Join([]string{
+		`{"target": "https://example.com/1", "owner": "alice"}`,
+		`{"target": "not a url"}`,
+		``,
+		`{"target": "https://example.com/2", "tags": ["x"]}`,
+	}, "\n")
+	code, out, errOut := admin(input, "-format", "json", "import")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, errOut, "1 of 3 links are invalid")
+	var imported importView
+	require.NoError(t, json.Unmarshal([]byte(out), &imported))
+	require.Len(t, imported.Results, 3)
+	assert.Equal(t, 2, imported.Imported)
+	assert.Equal(t, transfer.StatusImported, imported.Results[0].Status)
+	assert.NotEmpty(t, imported.Results[0].NewCode)
+	assert.Equal(t, 2, imported.Results[1].Line)
+	assert.Equal(t, transfer.StatusInvalid, imported.Results[1].Status)
+	assert.Equal(t, 4, imported.Results[2].Line)
+
+	path := filepath.Join(t.TempDir(), "links.csv")
+	code, out, errOut = admin("", "export", "-file-format", "csv", "-o", path)
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, out, "2")
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "code,target,"))
+	assert.True(t, strings.HasPrefix(lines[1], imported.Results[0].NewCode+",https://example.com/1,"))
+
+	// the codes of an export are taken where it came from
+	code, out, errOut = admin("", "-format", "json", "import", "-file-format", "csv", "-i", path, "-on-conflict", "skip")
+	require.Equal(t, exitOK, code, errOut)
+	require.NoError(t, json.Unmarshal([]byte(out), &imported))
+	assert.Equal(t, 2, imported.Skipped)
+
+	mapping := filepath.Join(t.TempDir(), "mapping.csv")
+	code, _, errOut = admin("", "import", "-file-format", "csv", "-i", path, "-mapping", mapping)
+	require.Equal(t, exitOK, code, errOut)
+	raw, err = os.ReadFile(mapping)
+	require.NoError(t, err)
+	lines = strings.Split(strings.TrimSpace(string(raw)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "old_code,alias,new_code,short_url", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], imported.Results[0].Code+",,"))
+
+	code, out, _ = admin("", "export")
+	require.Equal(t, exitOK, code)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 4)
+
+	// dry runs create nothing
+	code, _, errOut = admin("", "import", "-dry-run", "-i", path, "-file-format", "csv")
+	require.Equal(t, exitOK, code, errOut)
+	code, out, _ = admin("", "export")
+	require.Equal(t, exitOK, code)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 4)
+
+	for _, args := range [][]string{
+		{"import", "-file-format", "xml"},
+		{"import", "-on-conflict", "fail"},
+		{"export", "-file-format", "bitly"},
+	} {
+		code, _, _ = admin("", args...)
+		assert.Equal(t, exitUsage, code, args)
+	}
+}
+
+func TestAdmin_RotateAlphabet(t *testing.T) {
+	admin := newTestAdmin(t)
+	code, _, _ := admin("", "create", "-target", "https://example.com")
+	require.Equal(t, exitOK, code)
+
+	code, out, errOut := admin("", "-format", "json", "rotate-alphabet")
+	require.Equal(t, exitOK, code, errOut)
+	var rotation rotationView
+	require.NoError(t, json.Unmarshal([]byte(out), &rotation))
+	assert.Len(t, rotation.Alphabet, len(sqidsAlphabet))
+	assert.NotEqual(t, sqidsAlphabet, rotation.Alphabet)
+	require.Len(t, rotation.Codes, 1)
+	assert.NotEqual(t, rotation.Codes[0].OldCode, rotation.Codes[0].NewCode)
+
+	// the new codes are those of the new alphabet
+	t.Setenv("SECRET_ALPHABET", rotation.Alphabet)
+	code, out, _ = admin("", "get", rotation.Codes[0].NewCode)
+	require.Equal(t, exitOK, code)
+	assert.Contains(t, out, "https://example.com")
+
+	code, _, _ = admin("", "rotate-alphabet", "-alphabet", "aab")
+	assert.Equal(t, exitUsage, code)
+}
+
+func TestAdmin_MigrateAndHealth(t *testing.T) {
+	admin := newTestAdmin(t)
+	code, out, errOut := admin("", "-format", "json", "migrate")
+	require.Equal(t, exitOK, code, errOut)
+	var migrated migrateView
+	require.NoError(t, json.Unmarshal([]byte(out), &migrated))
+	assert.Equal(t, database.SchemaVersion, migrated.To)
+
+	code, out, _ = admin("", "health")
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, out, "database")
+
+	t.Setenv("BASE_URL", "not a url")
+	code, out, _ = admin("", "health")
+	assert.Equal(t, exitUnhealthy, code)
+	assert.Contains(t, out, "config")
+}
+
+func TestAdmin_CopyAndVerify(t *testing.T) {
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", "")
+	storages := map[database.StorageType]database.DBService{
+		database.InMemory: inmemory.NewInMemoryDBService(),
+		database.Postgres: inmemory.NewInMemoryDBService(),
+	}
+	open := func(typ database.StorageType) (database.DBService, error) {
+		if db, ok := storages[typ]; ok {
+			return db, nil
+		}
+		return openStorage(typ)
+	}
+	admin := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), args, strings.NewReader(""), &stdout, &stderr, open)
+		return code, stdout.String(), stderr.String()
+	}
+
+	var codes []string
+	for _, target := range []string{"https://example.com/1", "https://example.com/2", "https://example.com/3"} {
+		code, out, errOut := admin("-format", "json", "create", "-target", target)
+		require.Equal(t, exitOK, code, errOut)
+		var created []linkView
+		require.NoError(t, json.Unmarshal([]byte(out), &created))
+		codes = append(codes, created[0].Code)
+	}
+	code, _, _ := admin("delete", codes[2])
+	require.Equal(t, exitOK, code)
+
+	code, _, _ = admin("verify", "-to", "postgres")
+	assert.Equal(t, exitError, code)
+
+	state := filepath.Join(t.TempDir(), "copy.json")
+	code, out, errOut := admin("-format", "json", "copy", "-to", "postgres", "-state", state, "-verify")
+	require.Equal(t, exitOK, code, errOut)
+	var copied copyView
+	require.NoError(t, json.Unmarshal([]byte(out), &copied))
+	assert.Equal(t, 2, copied.Copied)
+	assert.Equal(t, int64(3), copied.Sequence)
+	require.NotNil(t, copied.Verification)
+	assert.True(t, copied.Verification.OK())
+
+	// the state file resumes after the links copied before
+	code, _, _ = admin("create", "-target", "https://example.com/4")
+	require.Equal(t, exitOK, code)
+	code, out, errOut = admin("-format", "json", "copy", "-to", "postgres", "-state", state)
+	require.Equal(t, exitOK, code, errOut)
+	require.NoError(t, json.Unmarshal([]byte(out), &copied))
+	assert.Equal(t, 1, copied.Copied)
+	assert.Equal(t, 0, copied.Existing)
+
+	// codes resolve on the target, which does not issue the code of the deleted link again
+	code, out, _ = admin("-storage", "postgres", "get", codes[0], codes[1])
+	require.Equal(t, exitOK, code)
+	assert.Contains(t, out, "https://example.com/2")
+	code, out, _ = admin("-storage", "postgres", "create", "-target", "https://example.com/5")
+	require.Equal(t, exitOK, code)
+	assert.NotContains(t, out, codes[2])
+
+	// links created on the target before the cutover conflict with those of the source
+	code, _, _ = admin("create", "-target", "https://example.com/6")
+	require.Equal(t, exitOK, code)
+	code, _, errOut = admin("copy", "-to", "postgres", "-state", state)
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, errOut, "1 links conflict")
+
+	for _, args := range [][]string{
+		{"copy"},
+		{"copy", "-to", "inmemory"},
+		{"copy", "-to", "sqlite"},
+		{"verify", "-to", "postgres", "-batch", "0"},
+	} {
+		code, _, _ = admin(args...)
+		assert.Equal(t, exitUsage, code, args)
+	}
+}
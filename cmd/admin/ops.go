@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/health"
+	"github.com/Parzival-05/url-shortener/internal/service"
+)
+
+type migrateView struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+func (m migrateView) columns() []string {
+	return []string{"FROM", "TO"}
+}
+
+func (m migrateView) rows() [][]string {
+	return [][]string{{strconv.FormatInt(m.From, 10), strconv.FormatInt(m.To, 10)}}
+}
+
+func (a *app) migrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	from, err := a.db.SchemaVersion(a.ctx)
+	if err != nil {
+		// Storages that were never migrated have no version yet.
+		from = 0
+	}
+	a.db.SyncDB()
+	to, err := a.db.SchemaVersion(a.ctx)
+	if err != nil {
+		return err
+	}
+	return a.out.print(migrateView{From: from, To: to})
+}
+
+type healthView struct {
+	health.Report
+	Storage map[string]string `json:"storage"`
+}
+
+func (h healthView) columns() []string {
+	return []string{"CHECK", "STATUS", "ERROR"}
+}
+
+func (h healthView) rows() [][]string {
+	var rows [][]string
+	for _, name := range slices.Sorted(maps.Keys(h.Checks)) {
+		check := h.Checks[name]
+		rows = append(rows, []string{name, check.Status, orDash(check.Error)})
+	}
+	return append(rows, []string{"overall", h.Status, "-"})
+}
+
+// health runs the readiness checks of the server once. It fails with errUnhealthy if any fails.
+func (a *app) health(args []string) error {
+	flags := flag.NewFlagSet("health", flag.ContinueOnError)
+	timeout := flags.Duration("timeout", 2*time.Second, "Timeout of every check")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	registry := health.NewRegistry(*timeout)
+	registry.Register(
+		health.Database(a.db),
+		health.Migrations(a.db, database.SchemaVersion),
+		health.Config(service.ValidateConfig),
+	)
+	report := registry.Check(a.ctx)
+	if err := a.out.print(healthView{Report: report, Storage: a.db.Health()}); err != nil {
+		return err
+	}
+	if !report.Ready() {
+		return errUnhealthy
+	}
+	return nil
+}
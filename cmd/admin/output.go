@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/service"
+)
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// table is a command result with a tabular form. Its JSON form is the value itself.
+type table interface {
+	columns() []string
+	rows() [][]string
+}
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (p printer) print(result table) error {
+	if p.format == formatJSON {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(result.columns(), "\t"))
+	for _, row := range result.rows() {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// linkView is the printed form of a link.
+type linkView struct {
+	Code            string     `json:"code"`
+	ShortURL        string     `json:"short_url,omitempty"`
+	ID              int64      `json:"id"`
+	Domain          string     `json:"domain,omitempty"`
+	Target          string     `json:"target"`
+	Owner           string     `json:"owner,omitempty"`
+	Tags            []string   `json:"tags,omitempty"`
+	Disabled        bool       `json:"disabled,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	MaxClicks       int64      `json:"max_clicks,omitempty"`
+	RemainingClicks int64      `json:"remaining_clicks,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func toLinkView(link service.Link) linkView {
+	return linkView{
+		Code:            link.Code,
+		ShortURL:        link.ShortURL,
+		ID:              link.ID,
+		Domain:          link.Domain,
+		Target:          link.Target,
+		Owner:           link.Owner,
+		Tags:            link.Tags,
+		Disabled:        link.Disabled,
+		ExpiresAt:       link.ExpiresAt,
+		MaxClicks:       link.MaxClicks,
+		RemainingClicks: link.RemainingClicks,
+		CreatedAt:       link.CreatedAt,
+		UpdatedAt:       link.UpdatedAt,
+	}
+}
+
+type linkViews []linkView
+
+func (l linkViews) columns() []string {
+	return []string{"CODE", "ID", "TARGET", "OWNER", "TAGS", "STATE", "CLICKS LEFT", "CREATED"}
+}
+
+func (l linkViews) rows() [][]string {
+	rows := make([][]string, 0, len(l))
+	for _, link := range l {
+		state := "active"
+		switch {
+		case link.Disabled:
+			state = "disabled"
+		case link.ExpiresAt != nil && !link.ExpiresAt.After(time.Now()):
+			state = "expired"
+		case link.MaxClicks > 0 && link.RemainingClicks == 0:
+			state = "exhausted"
+		}
+		left := "-"
+		if link.MaxClicks > 0 {
+			left = strconv.FormatInt(link.RemainingClicks, 10)
+		}
+		rows = append(rows, []string{
+			link.Code,
+			strconv.FormatInt(link.ID, 10),
+			link.Target,
+			orDash(link.Owner),
+			orDash(strings.Join(link.Tags, ",")),
+			state,
+			left,
+			link.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return rows
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
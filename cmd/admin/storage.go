@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/dbcopy"
+)
+
+type copyView struct {
+	dbcopy.Report
+	Verification *dbcopy.Verification `json:"verification,omitempty"`
+}
+
+func (c copyView) columns() []string {
+	return []string{"COPIED", "EXISTING", "CONFLICTS", "LAST ID", "SEQUENCE", "VERIFIED"}
+}
+
+func (c copyView) rows() [][]string {
+	verified := "-"
+	if c.Verification != nil {
+		verified = strconv.FormatBool(c.Verification.OK())
+	}
+	return [][]string{{
+		strconv.Itoa(c.Copied),
+		strconv.Itoa(c.Existing),
+		strconv.Itoa(c.Conflicts),
+		strconv.FormatInt(c.LastID, 10),
+		strconv.FormatInt(c.Sequence, 10),
+		verified,
+	}}
+}
+
+// copyState is the progress of a copy saved in its state file.
+type copyState struct {
+	LastID int64 `json:"last_id"`
+}
+
+// copyLinks copies every link to another storage under its ID. With -state the progress is
+// saved after every batch, and a later copy with the same file continues where it stopped, e.g.
+// after an interruption or to catch up with links created since.
+func (a *app) copyLinks(args []string) error {
+	flags := flag.NewFlagSet("copy", flag.ContinueOnError)
+	to := flags.String("to", "", fmt.Sprintf("Target storage: '%s' or '%s'", database.InMemory, database.Postgres))
+	batch := flags.Int("batch", dbcopy.DefaultBatchSize, "Number of links copied at a time")
+	statePath := flags.String("state", "", "File the progress is saved to and resumed from")
+	overwrite := flags.Bool("overwrite", false, "Replace links that differ in the target instead of reporting conflicts")
+	verify := flags.Bool("verify", false, "Compare both storages after the copy")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *batch <= 0 {
+		return fmt.Errorf("%w: -batch must be positive", errUsage)
+	}
+	target, err := a.openTarget(*to)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+	// The target may be new, and copies need its current schema.
+	target.SyncDB()
+
+	var state copyState
+	if *statePath != "" {
+		if state, err = readCopyState(*statePath); err != nil {
+			return err
+		}
+	}
+	src, dst := a.db.NewUrlRepository(), target.NewUrlRepository()
+	opts := dbcopy.Options{BatchSize: *batch, After: state.LastID, Overwrite: *overwrite}
+	if *statePath != "" {
+		opts.Checkpoint = func(lastID int64) error {
+			return writeCopyState(*statePath, copyState{LastID: lastID})
+		}
+	}
+	report, err := dbcopy.Copy(a.ctx, src, dst, opts)
+	if err != nil {
+		return fmt.Errorf("copied up to link %d: %w", report.LastID, err)
+	}
+	view := copyView{Report: report}
+	if *verify {
+		verification, err := dbcopy.Verify(a.ctx, src, dst, *batch)
+		if err != nil {
+			return err
+		}
+		view.Verification = &verification
+	}
+	if err := a.out.print(view); err != nil {
+		return err
+	}
+	switch {
+	case report.Conflicts > 0:
+		return fmt.Errorf("%d links conflict with different links in the target, copy with -overwrite to replace them", report.Conflicts)
+	case view.Verification != nil && !view.Verification.OK():
+		return errors.New("the storages differ")
+	}
+	return nil
+}
+
+type verifyView struct {
+	dbcopy.Verification
+}
+
+func (v verifyView) columns() []string {
+	return []string{"STORAGE", "LINKS", "CHECKSUM"}
+}
+
+func (v verifyView) rows() [][]string {
+	return [][]string{
+		{"source", strconv.Itoa(v.Source.Links), v.Source.Checksum},
+		{"target", strconv.Itoa(v.Target.Links), v.Target.Checksum},
+	}
+}
+
+// verify compares the links of the storage with those of another one by count and checksum.
+func (a *app) verify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	to := flags.String("to", "", fmt.Sprintf("Storage to compare with: '%s' or '%s'", database.InMemory, database.Postgres))
+	batch := flags.Int("batch", dbcopy.DefaultBatchSize, "Number of links read at a time")
+	if err := a.parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *batch <= 0 {
+		return fmt.Errorf("%w: -batch must be positive", errUsage)
+	}
+	target, err := a.openTarget(*to)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+	verification, err := dbcopy.Verify(a.ctx, a.db.NewUrlRepository(), target.NewUrlRepository(), *batch)
+	if err != nil {
+		return err
+	}
+	if err := a.out.print(verifyView{verification}); err != nil {
+		return err
+	}
+	if !verification.OK() {
+		return errors.New("the storages differ")
+	}
+	return nil
+}
+
+// openTarget opens the storage links are copied to or compared with.
+func (a *app) openTarget(to string) (database.DBService, error) {
+	switch database.StorageType(to) {
+	case "":
+		return nil, fmt.Errorf("%w: -to is required", errUsage)
+	case a.storage:
+		return nil, fmt.Errorf("%w: the target is the source storage", errUsage)
+	}
+	return a.open(database.StorageType(to))
+}
+
+func readCopyState(path string) (copyState, error) {
+	var state copyState
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(b, &state); err != nil {
+		return state, fmt.Errorf("%w: state file %s: %w", errUsage, path, err)
+	}
+	return state, nil
+}
+
+// writeCopyState replaces the state file atomically, so an interruption never leaves it torn.
+func writeCopyState(path string, state copyState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
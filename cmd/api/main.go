@@ -14,11 +14,18 @@ import (
 
 	_ "github.com/Parzival-05/url-shortener/docs"
 	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/breaker"
+	"github.com/Parzival-05/url-shortener/internal/database/dbcopy"
+	"github.com/Parzival-05/url-shortener/internal/database/idalloc"
 	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
 	"github.com/Parzival-05/url-shortener/internal/database/sql"
+	"github.com/Parzival-05/url-shortener/internal/domains"
 	"github.com/Parzival-05/url-shortener/internal/grpc"
+	"github.com/Parzival-05/url-shortener/internal/health"
 	"github.com/Parzival-05/url-shortener/internal/http_server"
+	"github.com/Parzival-05/url-shortener/internal/outbox"
 	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
 
 	"go.uber.org/zap"
 	google_grpc "google.golang.org/grpc"
@@ -59,6 +66,11 @@ func (g *GrpcServer) Shutdown(ctx context.Context) error {
 
 // @license.name	MIT
 // @license.url	https://github.com/Parzival-05/url-shortener/blob/main/LICENSE
+
+// @securityDefinitions.apikey	AdminToken
+// @in							header
+// @name						Authorization
+// @description				"Bearer " followed by ADMIN_TOKEN
 func main() {
 	serverTypeS := flag.String("server", string(httpServer), fmt.Sprintf("Type of server to run: '%s', '%s'", string(httpServer), string(grpcServer)))
 	storageTypeS := flag.String("storage", string(database.InMemory), fmt.Sprintf("Storage type: '%s' or '%s'", string(database.InMemory), string(database.Postgres)))
@@ -101,29 +113,47 @@ func main() {
 		db = inmemory.NewInMemoryDBService()
 	}
 	db.SyncDB()
-	urlRepo := db.NewUrlRepository()
-	urlShortener := service.NewUrlShortener(urlRepo, log)
+	urlRepo := withIDBlocks(startDualWrite(db.NewUrlRepository(), storageType, log), db.NewUrlRepository(), log)
+	urlRepo = breaker.New(urlRepo, log, breaker.ConfigFromEnv())
+	dispatcher := webhooks.NewDispatcher(db.NewWebhookStore(), log, webhooks.ConfigFromEnv())
+	go dispatcher.Run(context.Background())
+	urlShortener := service.NewUrlShortener(urlRepo, log).WithPublisher(dispatcher)
+	relay := startChangeRelay(db, log)
 	serverType := ParseServerType(*serverTypeS)
 
+	healthRegistry := health.NewRegistry(envDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second))
+	healthRegistry.Register(
+		health.Database(db),
+		health.Migrations(db, database.SchemaVersion),
+		health.Config(service.ValidateConfig),
+	)
+	go healthRegistry.Run(context.Background(), envDuration("HEALTH_CHECK_INTERVAL", 5*time.Second))
+
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
 	if serverType == httpServer {
-		server := http_server.NewServer(log, db, urlShortener)
+		server := http_server.NewServer(log, db, healthRegistry, urlShortener, dispatcher, relay)
 
 		// Run graceful shutdown in a separate goroutine
-		go gracefulShutdown(server, done)
+		go gracefulShutdown(server, healthRegistry, done)
 
-		err := server.ListenAndServe()
+		var err error
+		if server.TLSConfig != nil {
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
 		if err != nil && err != http.ErrServerClosed {
 			panic(fmt.Sprintf("http server error: %s", err))
 		}
 	} else {
 		grpcApi := grpc.NewServerAPI(log, urlShortener)
+		grpcApiV2 := grpc.NewServerAPIv2(log, urlShortener, relay)
 		var listener net.Listener
-		grpcServer, listener := grpc.New(log, grpcApi)
+		grpcServer, listener := grpc.New(log, healthRegistry, grpcApi, grpcApiV2)
 
 		// Run graceful shutdown in a separate goroutine
-		go gracefulShutdown(&GrpcServer{Server: grpcServer}, done)
+		go gracefulShutdown(&GrpcServer{Server: grpcServer}, healthRegistry, done)
 
 		if err := grpcServer.Serve(listener); err != nil {
 			log.Fatal("gRPC server failed to start", zap.Error(err))
@@ -153,7 +183,100 @@ func setupLogger(appEnv string) *zap.Logger {
 	return logger
 }
 
-func gracefulShutdown(apiServer Server, done chan bool) {
+// startDualWrite mirrors the writes to urlRepo into the storage named by DUAL_WRITE_STORAGE, if
+// set, while copying the links stored before into it, to cut over to that storage without
+// downtime. It returns the repository the service is to use.
+func startDualWrite(urlRepo database.IUrlRepository, storageType database.StorageType, log *zap.Logger) database.IUrlRepository {
+	target := database.StorageType(os.Getenv("DUAL_WRITE_STORAGE"))
+	if target == "" {
+		return urlRepo
+	}
+	var secondary database.DBService
+	switch target {
+	case storageType:
+		log.Fatal("DUAL_WRITE_STORAGE is the storage of the server", zap.String("storage", string(target)))
+	case database.Postgres:
+		secondary = sql.New()
+	case database.InMemory:
+		secondary = inmemory.NewInMemoryDBService()
+	default:
+		log.Fatal("Unknown DUAL_WRITE_STORAGE", zap.String("storage", string(target)))
+	}
+	secondary.SyncDB()
+	secondaryRepo := secondary.NewUrlRepository()
+	log = log.With(zap.String("dual_write_storage", string(target)))
+	go func() {
+		ctx := context.Background()
+		report, err := dbcopy.Copy(ctx, urlRepo, secondaryRepo, dbcopy.Options{})
+		if err != nil {
+			log.Error("Copying links to the dual write storage failed", zap.Int64("last_id", report.LastID), zap.Error(err))
+			return
+		}
+		verification, err := dbcopy.Verify(ctx, urlRepo, secondaryRepo, dbcopy.DefaultBatchSize)
+		if err != nil {
+			log.Error("Verifying the dual write storage failed", zap.Error(err))
+			return
+		}
+		// Writes during the copy can make both differ until the next verification.
+		log.Info("Copied links to the dual write storage",
+			zap.Int("copied", report.Copied),
+			zap.Int("existing", report.Existing),
+			zap.Int("conflicts", report.Conflicts),
+			zap.Bool("verified", verification.OK()))
+	}()
+	return dbcopy.NewDualWrite(urlRepo, secondaryRepo, log)
+}
+
+// withIDBlocks makes urlRepo create links under IDs from blocks of ID_BLOCK_SIZE leased from
+// base, if its storage leases them.
+func withIDBlocks(urlRepo, base database.IUrlRepository, log *zap.Logger) database.IUrlRepository {
+	size := idalloc.BlockSizeFromEnv()
+	leaser, ok := base.(database.IDBlockLeaser)
+	if size == 0 || !ok {
+		return urlRepo
+	}
+	owner := idalloc.Owner()
+	log.Info("Creating links from leased ID blocks", zap.String("owner", owner), zap.Int64("block_size", size))
+	return idalloc.NewRepository(urlRepo, idalloc.NewAllocator(leaser, owner, size))
+}
+
+// startChangeRelay starts relaying the change log of db, and appending it to OUTBOX_FILE_PATH if set.
+func startChangeRelay(db database.DBService, log *zap.Logger) *outbox.Relay {
+	// Invalid domain config is reported by the health checks; changes then come without codes.
+	registry, err := domains.FromEnv()
+	if err != nil {
+		log.Warn("Link changes are relayed without codes", zap.Error(err))
+	}
+	relay := outbox.NewRelay(db.NewChangeLog(), registry, log, outbox.ConfigFromEnv())
+	go relay.Run(context.Background())
+
+	path := os.Getenv("OUTBOX_FILE_PATH")
+	if path == "" {
+		return relay
+	}
+	sink, last, err := outbox.OpenFileSink(path)
+	if err != nil {
+		log.Fatal("Failed to open the change file", zap.String("path", path), zap.Error(err))
+	}
+	go func() {
+		defer sink.Close()
+		if err := relay.Stream(context.Background(), last, sink); err != nil {
+			log.Error("Appending link changes to the file stopped", zap.String("path", path), zap.Error(err))
+		}
+	}()
+	return relay
+}
+
+// envDuration reads a duration such as "5s" from the environment, falling back to def.
+func envDuration(key string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+func gracefulShutdown(apiServer Server, healthRegistry *health.Registry, done chan bool) {
 	// Create context that listens for the interrupt signal from the OS.
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -164,6 +287,11 @@ func gracefulShutdown(apiServer Server, done chan bool) {
 	log.Println("shutting down gracefully, press Ctrl+C again to force")
 	stop() // Allow Ctrl+C to force shutdown
 
+	// Report not ready first and give load balancers time to drain us
+	// before the listener is closed.
+	healthRegistry.Shutdown()
+	time.Sleep(envDuration("SHUTDOWN_DRAIN_DELAY", 0))
+
 	// The context is used to inform the server it has 5 seconds to finish
 	// the request it is currently handling
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
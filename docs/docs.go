@@ -19,66 +19,133 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/admin/links/export": {
             "get": {
-                "description": "get the status of server",
-                "consumes": [
-                    "*/*"
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
+                "description": "Downloads every link of the domain of the request, disabled ones included, as CSV or NDJSON.\nThe codes of an export are kept when it is imported into an instance where they are free.\nRequires the admin token.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Health"
+                    "Admin"
+                ],
+                "summary": "Export links",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format (default ndjson)",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Show the status of server",
                 "responses": {
                     "200": {
-                        "description": "Successful health check",
+                        "description": "The links",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     },
-                    "503": {
-                        "description": "Service is unavailable",
+                    "400": {
+                        "description": "Bad Request - Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/shorten": {
-            "get": {
-                "description": "Retrieves the original, full URL for a given short link code.",
+        "/admin/links/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Creates the links of an uploaded CSV, NDJSON, Bitly or YOURLS file on the domain of the request.\nThe file is the request body or the \"file\" field of a multipart form. Links keep their code\nwhen it is a free code of the domain; on_conflict decides whether links whose code is taken get\na new one or are skipped. Invalid rows are skipped and reported. Requires the admin token.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "URL Shortener"
+                    "Admin"
                 ],
-                "summary": "Get original URL",
+                "summary": "Import links",
                 "parameters": [
                     {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "bitly",
+                            "yourls"
+                        ],
                         "type": "string",
-                        "format": "string",
-                        "description": "The 10-character short code",
-                        "name": "shorten_url",
-                        "in": "query",
-                        "required": true
+                        "description": "File format (default ndjson)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report what would be imported",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "remap",
+                            "skip"
+                        ],
+                        "type": "string",
+                        "description": "What to do with links whose code is taken (default remap)",
+                        "name": "on_conflict",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved the original URL",
+                        "description": "Import summary, rejected rows and code mapping",
                         "schema": {
-                            "$ref": "#/definitions/io_server.GetUrlResponse"
+                            "$ref": "#/definitions/io_server.ImportLinksResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - The short code is invalid or was not found",
+                        "description": "Bad Request - Invalid parameters or file",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -96,9 +163,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/dashboard/login": {
             "post": {
-                "description": "Creates a new short link for a given URL. If the URL already exists, it returns the existing short link.",
+                "description": "Checks the password of a dashboard user and sets a session cookie. Requests with the cookie that\nchange anything must send the returned CSRF token in the X-CSRF-Token header. Failed attempts are\nlimited per client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -106,29 +175,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "URL Shortener"
+                    "Dashboard"
                 ],
-                "summary": "Create a short URL",
+                "summary": "Sign in to the dashboard",
                 "parameters": [
                     {
-                        "description": "URL to be shortened",
+                        "description": "Credentials",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/io_server.CreateUrlRequest"
+                            "$ref": "#/definitions/io_server.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully created or retrieved the short URL",
+                        "description": "Session",
                         "schema": {
-                            "$ref": "#/definitions/io_server.CreateUrlResponse"
+                            "$ref": "#/definitions/io_server.SessionResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid JSON format",
+                        "description": "Bad Request - Missing credentials",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid username or password",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -136,8 +211,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "429": {
+                        "description": "Too many attempts",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -147,41 +222,2866 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "io_server.CreateUrlRequest": {
-            "type": "object",
-            "required": [
-                "url"
-            ],
-            "properties": {
-                "url": {
-                    "type": "string"
+        },
+        "/dashboard/logout": {
+            "post": {
+                "description": "Drops the session cookie. Requires the CSRF token of the session.",
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Sign out of the dashboard",
+                "responses": {
+                    "204": {
+                        "description": "Signed out"
+                    },
+                    "403": {
+                        "description": "Missing or invalid CSRF token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "io_server.CreateUrlResponse": {
-            "type": "object",
-            "required": [
-                "shorten_url"
-            ],
-            "properties": {
-                "shorten_url": {
-                    "type": "string"
+        "/dashboard/session": {
+            "get": {
+                "description": "Returns the user and CSRF token of the session cookie of the caller.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dashboard"
+                ],
+                "summary": "Get the dashboard session",
+                "responses": {
+                    "200": {
+                        "description": "Session",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.SessionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Not signed in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
         },
-        "io_server.GetUrlResponse": {
-            "type": "object",
-            "required": [
-                "url"
-            ],
-            "properties": {
-                "url": {
-                    "type": "string"
+        "/links": {
+            "get": {
+                "description": "Lists links page by page. Pass next_page_token back as page_token to get the next page; a token is only valid with the same sort.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "List links",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of links to return (default 50, max 1000)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_page_token from the previous page",
+                        "name": "page_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only links with this owner",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only links with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only links in this campaign",
+                        "name": "campaign",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only links whose target host equals this domain",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only links created at or after this RFC 3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only links created before this RFC 3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive substring of the target",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "created_at",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list disabled links",
+                        "name": "show_disabled",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of links",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ListLinksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid filter or page token",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/campaigns": {
+            "get": {
+                "description": "Lists every campaign of the domain with the number of its links and of their redirects.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Get campaign stats",
+                "responses": {
+                    "200": {
+                        "description": "Campaign stats",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ListCampaignStatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/changes": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Streams every link mutation after after_seq as newline-delimited JSON, in commit order, and\nkeeps following new ones until the client disconnects. Every line carries the sequence number\n\"seq\", the \"type\" (created, updated or deleted), the changed \"fields\" of updates and the \"link\"\nafter the change, or before it for deletions. Clients resume by passing the last seq they saw.",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Stream link changes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sequence number of the last change seen",
+                        "name": "after_seq",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of changes",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid sequence number",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
                 }
             }
+        },
+        "/links/tags": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Adds tags to and removes tags from up to 1000 links of the domain at once. Codes that name no link\nare reported in not_found_codes rather than failing the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Tag links in bulk",
+                "parameters": [
+                    {
+                        "description": "Codes and tags",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.BatchTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Links whose tags changed",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.BatchTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid codes or tags",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Get a link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The link",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.LinkResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Sets the fields of a link named in fields to the values in the request. A named field left out of\nthe body is cleared, e.g. fields [\"expires_at\"] alone makes the link never expire. Setting\nmax_clicks restarts the count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Update a link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.UpdateLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated link",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.LinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid fields",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/campaign": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Files a link under a campaign or folder, whose links are listed with the campaign filter and\ncounted in the campaign stats. Redirects of links in a campaign are counted. An empty campaign\ntakes the link out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Set link campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campaign",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.CampaignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated link",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.LinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid campaign",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/clicks": {
+            "get": {
+                "description": "Returns the redirects of a link in total and on each of the last days, UTC, up to and including today.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Get click history of a link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days (1-366, default 30)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Click history",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ClickHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid days",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/passthrough": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Replaces what a redirect carries over to the target of a link: the incoming query, merged with the\nkeep, override or drop policy for parameters the target already has, the trailing path of\n/{code}/extra/path and static UTM parameters. An empty body turns passthrough off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Set passthrough options",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Passthrough options",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.PassthroughRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated link",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.LinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid options",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/qr": {
+            "get": {
+                "description": "Renders a QR code of the public short URL (BASE_URL + code) as PNG or SVG. Responses carry an ETag and honor If-None-Match.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Get QR code of a link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Width and height in pixels (64-4096, default 256)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "L",
+                            "M",
+                            "Q",
+                            "H"
+                        ],
+                        "type": "string",
+                        "description": "Error correction level (default M, or H with a logo)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quiet zone in modules (0-16, default 4)",
+                        "name": "margin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Foreground color, hex RRGGBB or RRGGBBAA (default 000000)",
+                        "name": "fg",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Background color, hex RRGGBB or RRGGBBAA (default ffffff)",
+                        "name": "bg",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Draw the configured logo in the center",
+                        "name": "logo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid options",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/rules": {
+            "get": {
+                "description": "Lists the redirect rules of a link in evaluation order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "List redirect rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Redirect rules",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ListRulesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Adds a redirect rule to a link. Rules are evaluated in order on every redirect and the first\nmatching one overrides the link target. All conditions of a rule must hold; a list holds if any\nof its values matches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Create a redirect rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.RuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created rule",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.RuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid rule",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Too many rules",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/rules/evaluate": {
+            "post": {
+                "description": "Dry run for debugging: reports which rule a request would match and where it would redirect to,\nwithout resolving the link. User agent, languages and IP address default to those of this request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Evaluate redirect rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request context",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.EvaluateRulesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Evaluation",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.EvaluateRulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid request context",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/rules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Replaces a redirect rule. With an index the rule is also moved to that position.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Update a redirect rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.RuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated rule",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.RuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid rule",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link or rule not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Delete a redirect rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link or rule not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/tags": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Replaces the tags of a link. An empty list removes them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Set link tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.TagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated link",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.LinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid tags",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/links/{code}/variants": {
+            "get": {
+                "description": "Lists the split variants of a link with the number of redirects that picked each one.\nVariants that were removed but have recorded clicks are listed last with removed set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Split"
+                ],
+                "summary": "Get split variant stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Variant stats",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ListVariantStatsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Replaces the split variants of a link. Unless a redirect rule matches, every redirect picks a variant\nin proportion to the weights: at random, or in sticky mode by hashing the visitor cookie or address,\nso returning visitors keep their variant. An empty list turns splitting off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Split"
+                ],
+                "summary": "Set split variants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variants",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.SplitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated link",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.LinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid variants",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/livez": {
+            "get": {
+                "description": "Reports that the process is running. It doesn't check any dependencies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Process is alive",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Runs all registered dependency checks. Returns 503 if any of them fails or the server is shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Ready to serve traffic",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Not ready",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/shorten": {
+            "get": {
+                "description": "Retrieves the original, full URL for a given short link code on the domain of the request,\nor for a full short URL such as https://go.example.com/{code}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "URL Shortener"
+                ],
+                "summary": "Get original URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "string",
+                        "description": "The short code or full short URL",
+                        "name": "shorten_url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved the original URL",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.GetUrlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - The short code is invalid or was not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "The link is password protected",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "The link is disabled, expired or has no clicks left",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new short link for a given URL on the domain of the Host or X-Link-Domain header and returns\nits full short URL, or the bare code if the domain has no base URL. If the URL already exists on the\ndomain, it returns the existing short link, unless a password, max_clicks, a template, tags, a\ncampaign, an owner or an expiry is given: such links are always created anew.\nWith a template the URL may contain placeholders after the host, {name}, {name=default} or {+name},\nfilled on redirect from the trailing path (matched against template.path) and the query.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "URL Shortener"
+                ],
+                "summary": "Create a short URL",
+                "parameters": [
+                    {
+                        "description": "URL to be shortened",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.CreateUrlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully created or retrieved the short URL",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.CreateUrlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid JSON format, URL or template",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "Webhooks",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ListWebhooksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Subscribes an endpoint to link events. Every event is posted as JSON with the headers\nX-Webhook-Event, X-Webhook-ID (the event ID, unchanged across retries), X-Webhook-Delivery,\nX-Webhook-Timestamp (Unix seconds) and X-Webhook-Signature: \"sha256=\" and the hex HMAC-SHA256\nof the timestamp, a dot and the body, keyed with the secret. Deliveries without a 2xx response\nare retried with exponential backoff and end up dead after the last attempt.\nThe secret is only returned here. Endpoints must not be private, loopback or link-local addresses.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/io_server.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created webhook",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid webhook",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Sends the event of a delivery again as a new delivery, whatever its status. A replayed dead\ndelivery becomes \"replayed\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Replay a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "New delivery",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.DeliveryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.WebhookResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Unsubscribes the endpoint and deletes its delivery log. Pending deliveries are dropped.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns the delivery log of a webhook, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, succeeded, dead or replayed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries, 100 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ListDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid status or limit",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Replays every dead delivery of a webhook, e.g. after the endpoint was fixed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Replay dead webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "New deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/io_server.ListDeliveriesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/{code}": {
+            "get": {
+                "description": "Redirects to the target of the first matching redirect rule of the link, or else of a split variant\nor the link target. Sticky split links set a visitor cookie. Depending on the link, the query and a\ntrailing path (/{code}/extra/path) are forwarded to the target and UTM parameters are added.\nTemplated links fill the placeholders of their target from the trailing path and the query.\nPassword protected links serve a password form instead, unless the request carries the access\ncookie set after a successful password check.\nThe link is looked up on the domain of the Host header, which also decides the redirect status\n(302 unless configured otherwise) and may serve an HTML page for unknown codes.",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Redirect"
+                ],
+                "summary": "Follow a short link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password form"
+                    },
+                    "302": {
+                        "description": "Redirect to the target, 301, 303, 307 or 308 if the domain says so"
+                    },
+                    "400": {
+                        "description": "A template variable is missing or invalid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Link is disabled, expired or has no clicks left",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Checks the submitted password and redirects to the target, setting a short-lived signed access cookie.\nFailed attempts are throttled per link and client.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Redirect"
+                ],
+                "summary": "Unlock a password protected link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link password",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "303": {
+                        "description": "Redirect to the target"
+                    },
+                    "403": {
+                        "description": "Wrong password, the form is served again"
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Link is disabled, expired or has no clicks left",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many attempts"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.BatchTagsRequest": {
+            "type": "object",
+            "properties": {
+                "add_tags": {
+                    "description": "AddTags are added to the links that don't have them yet.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "remove_tags": {
+                    "description": "RemoveTags are removed from the links. A tag both added and removed is removed.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "io_server.BatchTagsResponse": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "description": "Links are the links whose tags changed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.LinkResponse"
+                    }
+                },
+                "not_found_codes": {
+                    "description": "NotFoundCodes name no link of the domain.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "io_server.CampaignRequest": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.CampaignStatsResponse": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "type": "string"
+                },
+                "clicks": {
+                    "description": "Clicks counts the resolves of the links in the campaign.",
+                    "type": "integer"
+                },
+                "links": {
+                    "description": "Links counts the links in the campaign, disabled ones included.",
+                    "type": "integer"
+                }
+            }
+        },
+        "io_server.ClickHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Days holds one entry per day, oldest first, including days without clicks.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.DailyClicksResponse"
+                    }
+                },
+                "total": {
+                    "description": "Total counts the redirects of the link since it was created.",
+                    "type": "integer"
+                }
+            }
+        },
+        "io_server.CodeMapping": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "new_code": {
+                    "description": "NewCode is empty for dry runs that would assign a new code.",
+                    "type": "string"
+                },
+                "old_code": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.CreateUrlRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "campaign": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt optionally stops the link from resolving at that time. Such a link is always\ncreated anew.",
+                    "type": "string"
+                },
+                "max_clicks": {
+                    "description": "MaxClicks optionally limits how many times the link resolves, 1 for a single-use link.\nA limited link is always created anew.",
+                    "type": "integer"
+                },
+                "owner": {
+                    "description": "Owner optionally records who the link belongs to. An owned link is always created anew.",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password optionally protects the link. A protected link is always created anew.",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Tags and Campaign optionally organize the link. Such a link is always created anew.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "template": {
+                    "description": "Template optionally makes URL a template. A templated link is always created anew.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/io_server.Template"
+                        }
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.CreateUrlResponse": {
+            "type": "object",
+            "required": [
+                "shorten_url"
+            ],
+            "properties": {
+                "shorten_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.CreateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events filters the delivered events: link.created, link.updated, link.deleted, link.expired\nor link.clicked. Empty means all of them.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Secret signs the deliveries. It is generated if empty.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL is the http(s) endpoint events are posted to.",
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.DailyClicksResponse": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
+                },
+                "day": {
+                    "description": "Day is the UTC date, e.g. 2024-05-31.",
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.DeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/io_server.WebhookEvent"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is set for pending deliveries.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.EvaluateRulesRequest": {
+            "type": "object",
+            "properties": {
+                "accept_language": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "time": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.EvaluateRulesResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "Rule is the first matching rule, null if none matched.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/io_server.RuleResponse"
+                        }
+                    ]
+                },
+                "target": {
+                    "description": "Target is where the link would redirect to.",
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.GetUrlResponse": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.ImportLinksResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "mapping": {
+                    "description": "Mapping lists the old code or alias and the new code of every remapped or aliased link.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.CodeMapping"
+                    }
+                },
+                "rejected": {
+                    "description": "Rejected lists the rows that were skipped or invalid.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.RejectedRow"
+                    }
+                },
+                "remapped": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "io_server.LinkResponse": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "type": "string"
+                },
+                "clicks": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "max_clicks": {
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "passthrough": {
+                    "$ref": "#/definitions/io_server.Passthrough"
+                },
+                "remaining_clicks": {
+                    "type": "integer"
+                },
+                "short_url": {
+                    "type": "string"
+                },
+                "split_mode": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "description": "Target is left out for password protected links, unless the request has the admin token\nor a dashboard session.",
+                    "type": "string"
+                },
+                "template": {
+                    "$ref": "#/definitions/io_server.Template"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.Variant"
+                    }
+                }
+            }
+        },
+        "io_server.ListCampaignStatsResponse": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.CampaignStatsResponse"
+                    }
+                }
+            }
+        },
+        "io_server.ListDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.DeliveryResponse"
+                    }
+                }
+            }
+        },
+        "io_server.ListLinksResponse": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.LinkResponse"
+                    }
+                },
+                "next_page_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.ListRulesResponse": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.RuleResponse"
+                    }
+                }
+            }
+        },
+        "io_server.ListVariantStatsResponse": {
+            "type": "object",
+            "properties": {
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.VariantStatsResponse"
+                    }
+                }
+            }
+        },
+        "io_server.ListWebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.WebhookResponse"
+                    }
+                }
+            }
+        },
+        "io_server.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.Passthrough": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "description": "Path appends the trailing path of the short URL, /{code}/extra/path, to the target path.",
+                    "type": "boolean"
+                },
+                "query": {
+                    "description": "Query merges the incoming query into the target: keep, override or drop decide about\nparameters the target already has. Unset doesn't forward the query.",
+                    "type": "string"
+                },
+                "utm": {
+                    "description": "UTM parameters are added to the target, replacing any it has.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/io_server.UTM"
+                        }
+                    ]
+                }
+            }
+        },
+        "io_server.PassthroughRequest": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "description": "Path appends the trailing path of the short URL, /{code}/extra/path, to the target path.",
+                    "type": "boolean"
+                },
+                "query": {
+                    "description": "Query merges the incoming query into the target: keep, override or drop decide about\nparameters the target already has. Unset doesn't forward the query.",
+                    "type": "string"
+                },
+                "utm": {
+                    "description": "UTM parameters are added to the target, replacing any it has.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/io_server.UTM"
+                        }
+                    ]
+                }
+            }
+        },
+        "io_server.RejectedRow": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.RuleConditions": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "description": "Countries are ISO 3166-1 alpha-2 codes.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "days": {
+                    "description": "Days are lowercase English day names, e.g. \"monday\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from": {
+                    "description": "From and To bound the time of day as HH:MM.",
+                    "type": "string"
+                },
+                "languages": {
+                    "description": "Languages are language tags such as \"de\" or \"pt-BR\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "platforms": {
+                    "description": "Platforms: ios, android, windows, macos, linux or other.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "query": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "time_zone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.RuleRequest": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "$ref": "#/definitions/io_server.RuleConditions"
+                },
+                "index": {
+                    "description": "Index is the position of the rule. Unset appends a new rule or keeps an updated one in place.",
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.RuleResponse": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "$ref": "#/definitions/io_server.RuleConditions"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "csrf_token": {
+                    "description": "CSRFToken must be sent in the X-CSRF-Token header on requests that change anything.",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.SplitRequest": {
+            "type": "object",
+            "properties": {
+                "split_mode": {
+                    "description": "SplitMode is random (default) or sticky.",
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/io_server.Variant"
+                    }
+                }
+            }
+        },
+        "io_server.TagsRequest": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "io_server.Template": {
+            "type": "object",
+            "properties": {
+                "path": {
+                    "description": "Path is the pattern of the trailing path, e.g. \"{version}/{page}\".",
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.UTM": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "medium": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "term": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.UpdateLinkRequest": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "Fields are some of target, owner, tags, campaign, disabled, expires_at, password and max_clicks.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_clicks": {
+                    "description": "MaxClicks restarts the count of a click-limited link, 0 lifts the limit.",
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password protects the link, an empty one removes the protection.",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validation.FieldViolation"
+                    }
+                }
+            }
+        },
+        "io_server.Variant": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Weight is the relative share of the traffic, 0 pauses the variant.",
+                    "type": "integer"
+                }
+            }
+        },
+        "io_server.VariantStatsResponse": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "description": "Clicks counts the resolves that picked the variant.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "removed": {
+                    "description": "Removed variants have recorded clicks but are no longer configured. Only their id is set.",
+                    "type": "boolean"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "Weight is the relative share of the traffic, 0 pauses the variant.",
+                    "type": "integer"
+                }
+            }
+        },
+        "io_server.WebhookEvent": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "io_server.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret is only returned when the webhook is created.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "validation.FieldViolation": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "\"Bearer \" followed by ADMIN_TOKEN",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,66 @@
+// Package attempts throttles guesses of secrets, such as passwords, per key.
+package attempts
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows at most max attempts per key within window. Each attempt is recorded as it is
+// allowed, so that concurrent attempts can't all pass before any of them fails. A successful
+// attempt resets its key.
+type Limiter struct {
+	max    int
+	window time.Duration
+
+	mu        sync.Mutex
+	attempts  map[string]attemptWindow
+	lastSweep time.Time
+}
+
+type attemptWindow struct {
+	count int
+	reset time.Time
+}
+
+func New(max int, window time.Duration) *Limiter {
+	return &Limiter{
+		max:      max,
+		window:   window,
+		attempts: make(map[string]attemptWindow),
+	}
+}
+
+// Attempt records an attempt for key and reports whether it is within the limit.
+func (l *Limiter) Attempt(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop expired windows so that the map doesn't grow with every client ever seen.
+	if now.Sub(l.lastSweep) > l.window {
+		for k, w := range l.attempts {
+			if now.After(w.reset) {
+				delete(l.attempts, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, ok := l.attempts[key]
+	if !ok || now.After(w.reset) {
+		w = attemptWindow{reset: now.Add(l.window)}
+	}
+	if w.count >= l.max {
+		return false
+	}
+	w.count++
+	l.attempts[key] = w
+	return true
+}
+
+// Reset forgets the attempts of key.
+func (l *Limiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.attempts, key)
+}
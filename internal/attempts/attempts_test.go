@@ -0,0 +1,39 @@
+package attempts
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Window(t *testing.T) {
+	l := New(1, time.Minute)
+	now := time.Now()
+	assert.True(t, l.Attempt("a", now))
+	assert.False(t, l.Attempt("a", now))
+	assert.True(t, l.Attempt("b", now), "keys are limited separately")
+	assert.True(t, l.Attempt("a", now.Add(2*time.Minute)), "window expired")
+	l.Reset("a")
+	assert.True(t, l.Attempt("a", now.Add(2*time.Minute)))
+}
+
+func TestLimiter_Concurrent(t *testing.T) {
+	l := New(5, time.Minute)
+	now := time.Now()
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.Attempt("a", now) {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 5, allowed.Load())
+}
@@ -0,0 +1,242 @@
+// Package dashboard holds the web UI embedded in the binary and the sessions of its users. The
+// UI calls the same JSON API as other clients; users sign in with a password and get a signed
+// session cookie, and requests changing anything must echo the CSRF token of their session.
+package dashboard
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"embed"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/attempts"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// CookieName is the cookie holding the session token.
+	CookieName = "dashboard_session"
+	// CSRFHeader carries the CSRF token of the session on requests that change anything.
+	CSRFHeader = "X-CSRF-Token"
+)
+
+var (
+	ErrInvalidLogin     = errors.New("invalid username or password")
+	ErrTooManyAttempts  = errors.New("too many login attempts, try again later")
+	ErrInvalidUsersSpec = errors.New("DASHBOARD_USERS must be comma separated name:bcrypt-hash pairs")
+)
+
+//go:embed static
+var static embed.FS
+
+// Assets returns the files of the UI.
+func Assets() fs.FS {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	return assets
+}
+
+// Config configures the dashboard.
+type Config struct {
+	// Users maps the names of the users to the bcrypt hashes of their passwords. The dashboard
+	// is off without users.
+	Users map[string]string
+	// Secret signs the session tokens. Sessions don't survive restarts and aren't shared between
+	// replicas without it.
+	Secret []byte
+	// SessionTTL is how long a session lasts after signing in.
+	SessionTTL time.Duration
+	// MaxAttempts logins per client are allowed within AttemptWindow. A successful one resets
+	// the count of the client.
+	MaxAttempts   int
+	AttemptWindow time.Duration
+}
+
+var DefaultConfig = Config{
+	SessionTTL:    12 * time.Hour,
+	MaxAttempts:   5,
+	AttemptWindow: time.Minute,
+}
+
+// ConfigFromEnv reads the config from DASHBOARD_USERS (comma separated name:bcrypt-hash pairs,
+// e.g. from `htpasswd -nbB`), DASHBOARD_SESSION_SECRET and DASHBOARD_SESSION_TTL. A malformed
+// user list is an error rather than locking everyone out silently.
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig
+	users, err := ParseUsers(os.Getenv("DASHBOARD_USERS"))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Users = users
+	cfg.Secret = []byte(os.Getenv("DASHBOARD_SESSION_SECRET"))
+	if d, err := time.ParseDuration(os.Getenv("DASHBOARD_SESSION_TTL")); err == nil && d > 0 {
+		cfg.SessionTTL = d
+	}
+	return cfg, nil
+}
+
+// ParseUsers parses comma separated name:bcrypt-hash pairs.
+func ParseUsers(s string) (map[string]string, error) {
+	users := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, hash, ok := strings.Cut(pair, ":")
+		if !ok || name == "" {
+			return nil, ErrInvalidUsersSpec
+		}
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("%w: user %q: %v", ErrInvalidUsersSpec, name, err)
+		}
+		users[name] = hash
+	}
+	return users, nil
+}
+
+// FromEnv returns sessions configured by ConfigFromEnv, nil if no users are configured.
+func FromEnv(log *zap.Logger) (*Sessions, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil || len(cfg.Users) == 0 {
+		return nil, err
+	}
+	if len(cfg.Secret) == 0 {
+		log.Warn("DASHBOARD_SESSION_SECRET is not set, dashboard sessions won't survive restarts")
+	}
+	return New(cfg), nil
+}
+
+// Session is a signed-in user.
+type Session struct {
+	User    string
+	Expires time.Time
+	// CSRFToken must be sent in CSRFHeader on requests that change anything.
+	CSRFToken string
+}
+
+// Sessions signs users in and verifies their session tokens. Tokens are self-contained, so
+// signing out only drops the cookie; they stay valid until they expire.
+type Sessions struct {
+	cfg Config
+	// dummyHash is compared against for unknown users, so that they take as long as known ones.
+	dummyHash []byte
+	attempts  *attempts.Limiter
+}
+
+func New(cfg Config) *Sessions {
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = DefaultConfig.SessionTTL
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultConfig.MaxAttempts
+	}
+	if cfg.AttemptWindow <= 0 {
+		cfg.AttemptWindow = DefaultConfig.AttemptWindow
+	}
+	if len(cfg.Secret) == 0 {
+		cfg.Secret = make([]byte, 32)
+		_, _ = rand.Read(cfg.Secret)
+	}
+	dummy, _ := bcrypt.GenerateFromPassword([]byte("dashboard"), bcrypt.DefaultCost)
+	return &Sessions{
+		cfg:       cfg,
+		dummyHash: dummy,
+		attempts:  attempts.New(cfg.MaxAttempts, cfg.AttemptWindow),
+	}
+}
+
+// Login checks the password of user, with the attempts of client throttled, and returns the
+// session token and the session.
+func (s *Sessions) Login(user, password, client string, now time.Time) (string, Session, error) {
+	if !s.attempts.Attempt(client, now) {
+		return "", Session{}, ErrTooManyAttempts
+	}
+	hash, ok := s.cfg.Users[user]
+	if !ok {
+		_ = bcrypt.CompareHashAndPassword(s.dummyHash, []byte(password))
+		return "", Session{}, ErrInvalidLogin
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return "", Session{}, ErrInvalidLogin
+	}
+	s.attempts.Reset(client)
+
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+	expires := now.Add(s.cfg.SessionTTL)
+	payload := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString(nonce),
+		base64.RawURLEncoding.EncodeToString([]byte(user)),
+		strconv.FormatInt(expires.Unix(), 10),
+	}, ".")
+	token := payload + "." + base64.RawURLEncoding.EncodeToString(s.mac("session|"+payload))
+	session, _ := s.Verify(token, now)
+	return token, session, nil
+}
+
+// Verify returns the session of a token, false if it is forged, expired or of a user who was
+// removed since.
+func (s *Sessions) Verify(token string, now time.Time) (Session, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return Session{}, false
+	}
+	payload := strings.Join(parts[:3], ".")
+	sig, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil || !hmac.Equal(sig, s.mac("session|"+payload)) {
+		return Session{}, false
+	}
+	user, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Session{}, false
+	}
+	unix, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || !now.Before(time.Unix(unix, 0)) {
+		return Session{}, false
+	}
+	if _, ok := s.cfg.Users[string(user)]; !ok {
+		return Session{}, false
+	}
+	return Session{
+		User:      string(user),
+		Expires:   time.Unix(unix, 0),
+		CSRFToken: base64.RawURLEncoding.EncodeToString(s.mac("csrf|" + parts[0])),
+	}, true
+}
+
+// CheckCSRF reports whether token is the CSRF token of session.
+func (s *Sessions) CheckCSRF(session Session, token string) bool {
+	return token != "" && hmac.Equal([]byte(token), []byte(session.CSRFToken))
+}
+
+func (s *Sessions) mac(msg string) []byte {
+	h := hmac.New(sha256.New, s.cfg.Secret)
+	h.Write([]byte(msg))
+	return h.Sum(nil)
+}
+
+type sessionKey struct{}
+
+// NewContext returns a copy of ctx carrying the session of the request.
+func NewContext(ctx context.Context, session Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// FromContext returns the session stored in ctx, false if the request has none.
+func FromContext(ctx context.Context) (Session, bool) {
+	session, ok := ctx.Value(sessionKey{}).(Session)
+	return session, ok
+}
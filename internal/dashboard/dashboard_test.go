@@ -0,0 +1,133 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func hash(t *testing.T, password string) string {
+	t.Helper()
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(h)
+}
+
+func TestParseUsers(t *testing.T) {
+	alice := hash(t, "wonderland")
+	users, err := ParseUsers(" alice:" + alice + ", ,bob:" + alice)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"alice": alice, "bob": alice}, users)
+
+	users, err = ParseUsers("")
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	for _, spec := range []string{"alice", ":" + alice, "alice:plain"} {
+		_, err := ParseUsers(spec)
+		assert.ErrorIs(t, err, ErrInvalidUsersSpec, spec)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	cfg := Config{Users: map[string]string{"alice": hash(t, "wonderland")}, Secret: []byte("secret"), SessionTTL: time.Hour}
+	sessions := New(cfg)
+
+	_, _, err := sessions.Login("alice", "wrong", "client", now)
+	assert.ErrorIs(t, err, ErrInvalidLogin)
+	_, _, err = sessions.Login("mallory", "wonderland", "client", now)
+	assert.ErrorIs(t, err, ErrInvalidLogin)
+
+	token, session, err := sessions.Login("alice", "wonderland", "client", now)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", session.User)
+	assert.True(t, now.Add(time.Hour).Equal(session.Expires))
+	assert.NotEmpty(t, session.CSRFToken)
+
+	verified, ok := sessions.Verify(token, now.Add(time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, session, verified)
+	assert.True(t, sessions.CheckCSRF(verified, session.CSRFToken))
+	assert.False(t, sessions.CheckCSRF(verified, ""))
+	assert.False(t, sessions.CheckCSRF(verified, "forged"))
+
+	other, otherSession, err := sessions.Login("alice", "wonderland", "client", now)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+	assert.NotEqual(t, session.CSRFToken, otherSession.CSRFToken)
+
+	_, ok = sessions.Verify(token, now.Add(time.Hour))
+	assert.False(t, ok, "expired")
+	_, ok = sessions.Verify(token[:len(token)-2], now)
+	assert.False(t, ok, "forged")
+	_, ok = sessions.Verify("a.b.c", now)
+	assert.False(t, ok, "malformed")
+	_, ok = New(Config{Users: cfg.Users, Secret: []byte("other")}).Verify(token, now)
+	assert.False(t, ok, "signed with another secret")
+	_, ok = New(Config{Users: map[string]string{"bob": cfg.Users["alice"]}, Secret: cfg.Secret}).Verify(token, now)
+	assert.False(t, ok, "removed user")
+}
+
+func TestSessions_ThrottlesFailedLogins(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	sessions := New(Config{
+		Users:         map[string]string{"alice": hash(t, "wonderland")},
+		MaxAttempts:   2,
+		AttemptWindow: time.Minute,
+	})
+
+	for range 2 {
+		_, _, err := sessions.Login("alice", "wrong", "client", now)
+		assert.ErrorIs(t, err, ErrInvalidLogin)
+	}
+	_, _, err := sessions.Login("alice", "wonderland", "client", now)
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+	_, _, err = sessions.Login("alice", "wonderland", "other", now)
+	assert.NoError(t, err)
+
+	_, _, err = sessions.Login("alice", "wonderland", "client", now.Add(2*time.Minute))
+	assert.NoError(t, err)
+}
+
+func TestSessions_ThrottlesConcurrentLogins(t *testing.T) {
+	now := time.Now()
+	sessions := New(Config{Users: map[string]string{"alice": hash(t, "wonderland")}, MaxAttempts: 3})
+
+	var invalid atomic.Int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := sessions.Login("alice", "wrong", "client", now); errors.Is(err, ErrInvalidLogin) {
+				invalid.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 3, invalid.Load(), "parallel guesses don't get past the limit")
+}
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+	session, ok := FromContext(NewContext(context.Background(), Session{User: "alice"}))
+	assert.True(t, ok)
+	assert.Equal(t, "alice", session.User)
+}
+
+func TestAssets(t *testing.T) {
+	for _, name := range []string{"index.html", "app.js", "app.css"} {
+		_, err := fs.Stat(Assets(), name)
+		assert.NoError(t, err, name)
+	}
+}
@@ -0,0 +1,381 @@
+// The dashboard talks to the same JSON API as every other client. The session cookie is sent
+// along by the browser; requests changing anything carry the CSRF token of the session.
+"use strict";
+
+const state = {session: null, pageToken: "", links: new Map()};
+
+const $ = (selector, root = document) => root.querySelector(selector);
+
+class APIError extends Error {}
+
+async function api(method, path, body) {
+  const headers = {Accept: "application/json"};
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+  }
+  if (method !== "GET" && state.session) {
+    headers["X-CSRF-Token"] = state.session.csrf_token;
+  }
+  const resp = await fetch(path, {
+    method,
+    headers,
+    credentials: "same-origin",
+    body: body === undefined ? undefined : JSON.stringify(body),
+  });
+  const payload = await resp.json().catch(() => ({}));
+  if (!resp.ok) {
+    if (resp.status === 401 && path !== "/dashboard/login") {
+      showLogin();
+    }
+    let msg = payload.error || `${resp.status} ${resp.statusText}`;
+    if (payload.violations) {
+      msg = payload.violations.map((v) => (v.field ? `${v.field}: ${v.description}` : v.description)).join("; ");
+    }
+    throw new APIError(msg);
+  }
+  return payload.data;
+}
+
+function splitTags(value) {
+  return value.split(",").map((t) => t.trim()).filter((t) => t !== "");
+}
+
+// toLocalInput formats an RFC 3339 time for a datetime-local input.
+function toLocalInput(value) {
+  if (!value) {
+    return "";
+  }
+  const d = new Date(value);
+  const pad = (n) => String(n).padStart(2, "0");
+  return `${d.getFullYear()}-${pad(d.getMonth() + 1)}-${pad(d.getDate())}T${pad(d.getHours())}:${pad(d.getMinutes())}`;
+}
+
+function fromLocalInput(value) {
+  return value ? new Date(value).toISOString() : undefined;
+}
+
+function el(tag, props = {}, ...children) {
+  const node = document.createElement(tag);
+  Object.assign(node, props);
+  node.append(...children);
+  return node;
+}
+
+function showError(root, err) {
+  $(".error", root).textContent = err ? err.message : "";
+}
+
+// Session
+
+function showLogin() {
+  state.session = null;
+  $("#app").hidden = true;
+  $("#login").hidden = false;
+}
+
+function showApp(session) {
+  state.session = session;
+  $("#user").textContent = session.user;
+  $("#login").hidden = true;
+  $("#app").hidden = false;
+  loadLinks(true);
+}
+
+async function start() {
+  try {
+    showApp(await api("GET", "/dashboard/session"));
+  } catch {
+    showLogin();
+  }
+}
+
+$("#login-form").addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const form = event.target;
+  showError(form);
+  try {
+    const session = await api("POST", "/dashboard/login", {
+      username: form.username.value,
+      password: form.password.value,
+    });
+    form.reset();
+    showApp(session);
+  } catch (err) {
+    showError(form, err);
+  }
+});
+
+$("#logout").addEventListener("click", async () => {
+  await api("POST", "/dashboard/logout").catch(() => {});
+  showLogin();
+});
+
+// Creating links
+
+$("#create-form").addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const form = event.target;
+  showError(form);
+  $(".result", form).textContent = "";
+  const req = {url: form.url.value, owner: state.session.user};
+  if (form.campaign.value) {
+    req.campaign = form.campaign.value;
+  }
+  const tags = splitTags(form.tags.value);
+  if (tags.length > 0) {
+    req.tags = tags;
+  }
+  if (form.expires_at.value) {
+    req.expires_at = fromLocalInput(form.expires_at.value);
+  }
+  if (form.max_clicks.value) {
+    req.max_clicks = Number(form.max_clicks.value);
+  }
+  if (form.password.value) {
+    req.password = form.password.value;
+  }
+  if (form.template_path.value) {
+    req.template = {path: form.template_path.value};
+  }
+  try {
+    const created = await api("POST", "/shorten", req);
+    form.reset();
+    $(".result", form).textContent = `Created ${created.shorten_url}`;
+    loadLinks(true);
+  } catch (err) {
+    showError(form, err);
+  }
+});
+
+// Listing links
+
+async function loadLinks(reset) {
+  const form = $("#search-form");
+  const tbody = $("#links tbody");
+  if (reset) {
+    state.pageToken = "";
+    state.links.clear();
+    tbody.replaceChildren();
+  }
+  const params = new URLSearchParams({sort: "-created_at", page_size: "50"});
+  for (const name of ["q", "tag", "campaign"]) {
+    if (form[name].value) {
+      params.set(name, form[name].value);
+    }
+  }
+  if (form.mine.checked) {
+    params.set("owner", state.session.user);
+  }
+  if (form.show_disabled.checked) {
+    params.set("show_disabled", "true");
+  }
+  if (state.pageToken) {
+    params.set("page_token", state.pageToken);
+  }
+  $("#list-error").textContent = "";
+  try {
+    const page = await api("GET", `/links?${params}`);
+    for (const link of page.links) {
+      state.links.set(link.code, link);
+      tbody.append(linkRow(link));
+    }
+    state.pageToken = page.next_page_token || "";
+    $("#more").hidden = !state.pageToken;
+  } catch (err) {
+    $("#list-error").textContent = err.message;
+  }
+}
+
+function linkRow(link) {
+  const shortURL = link.short_url || link.code;
+  const short = link.short_url ? el("a", {href: link.short_url, target: "_blank", rel: "noopener", textContent: shortURL}) : shortURL;
+  const tags = el("td", {}, ...(link.tags || []).map((t) => el("span", {className: "tag", textContent: t})));
+  let status = link.disabled ? "disabled" : "active";
+  if (!link.disabled && link.expires_at) {
+    status = new Date(link.expires_at) < new Date() ? "expired" : `until ${new Date(link.expires_at).toLocaleString()}`;
+  }
+  if (link.max_clicks) {
+    status += `, ${link.remaining_clicks || 0}/${link.max_clicks} left`;
+  }
+  const action = (label, handler) => {
+    const button = el("button", {type: "button", className: "link", textContent: label});
+    button.addEventListener("click", () => handler(link.code));
+    return button;
+  };
+  const row = el("tr", {className: link.disabled ? "disabled" : ""},
+    el("td", {}, short),
+    el("td", {className: "target", textContent: link.target}),
+    tags,
+    el("td", {textContent: link.campaign || ""}),
+    el("td", {textContent: String(link.clicks || 0)}),
+    el("td", {textContent: status}),
+    el("td", {className: "actions"},
+      action("Edit", openEdit),
+      action(link.disabled ? "Enable" : "Disable", toggleDisabled),
+      action("Clicks", openStats),
+      action("QR", openQR)));
+  row.dataset.code = link.code;
+  return row;
+}
+
+function replaceRow(link) {
+  state.links.set(link.code, link);
+  const row = $(`#links tr[data-code="${CSS.escape(link.code)}"]`);
+  if (row) {
+    row.replaceWith(linkRow(link));
+  }
+}
+
+$("#search-form").addEventListener("submit", (event) => {
+  event.preventDefault();
+  loadLinks(true);
+});
+
+$("#more").addEventListener("click", () => loadLinks(false));
+
+// Editing links
+
+async function toggleDisabled(code) {
+  const link = state.links.get(code);
+  try {
+    replaceRow(await api("PATCH", `/links/${encodeURIComponent(code)}`, {fields: ["disabled"], disabled: !link.disabled}));
+  } catch (err) {
+    $("#list-error").textContent = err.message;
+  }
+}
+
+function openEdit(code) {
+  const link = state.links.get(code);
+  const dialog = $("#edit-dialog");
+  const form = $("#edit-form");
+  form.reset();
+  showError(form);
+  form.dataset.code = code;
+  $(".code", dialog).textContent = code;
+  form.target.value = link.target;
+  form.campaign.value = link.campaign || "";
+  form.tags.value = (link.tags || []).join(", ");
+  form.expires_at.value = toLocalInput(link.expires_at);
+  form.max_clicks.value = link.max_clicks || "";
+  form.disabled.checked = link.disabled;
+  dialog.showModal();
+}
+
+$("#edit-form").addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const form = event.target;
+  const link = state.links.get(form.dataset.code);
+  showError(form);
+  const req = {
+    fields: ["target", "campaign", "tags", "expires_at", "disabled"],
+    target: form.target.value,
+    campaign: form.campaign.value,
+    tags: splitTags(form.tags.value),
+    expires_at: fromLocalInput(form.expires_at.value),
+    disabled: form.disabled.checked,
+  };
+  const maxClicks = Number(form.max_clicks.value || 0);
+  if (maxClicks !== (link.max_clicks || 0)) {
+    req.fields.push("max_clicks");
+    req.max_clicks = maxClicks;
+  }
+  if (form.password.value || form.remove_password.checked) {
+    req.fields.push("password");
+    req.password = form.remove_password.checked ? "" : form.password.value;
+  }
+  try {
+    replaceRow(await api("PATCH", `/links/${encodeURIComponent(form.dataset.code)}`, req));
+    $("#edit-dialog").close();
+  } catch (err) {
+    showError(form, err);
+  }
+});
+
+// Click charts
+
+async function openStats(code) {
+  const dialog = $("#stats-dialog");
+  dialog.dataset.code = code;
+  $(".code", dialog).textContent = code;
+  dialog.showModal();
+  await loadStats();
+}
+
+async function loadStats() {
+  const dialog = $("#stats-dialog");
+  const days = $("select[name=days]", dialog).value;
+  showError(dialog);
+  try {
+    const history = await api("GET", `/links/${encodeURIComponent(dialog.dataset.code)}/clicks?days=${days}`);
+    $(".total", dialog).textContent = `${history.total} clicks in total`;
+    $(".chart", dialog).replaceChildren(barChart(history.days));
+  } catch (err) {
+    $(".chart", dialog).replaceChildren();
+    showError(dialog, err);
+  }
+}
+
+$("#stats-dialog select[name=days]").addEventListener("change", loadStats);
+
+// barChart draws the clicks of each day as a bar, labeling the first, middle and last days.
+function barChart(days) {
+  const ns = "http://www.w3.org/2000/svg";
+  const width = 600;
+  const height = 220;
+  const bottom = 20;
+  const top = 14;
+  const peak = Math.max(1, ...days.map((d) => d.clicks));
+  const step = width / days.length;
+  const svg = document.createElementNS(ns, "svg");
+  svg.setAttribute("viewBox", `0 0 ${width} ${height}`);
+  svg.setAttribute("preserveAspectRatio", "none");
+  const svgEl = (tag, attrs, text) => {
+    const node = document.createElementNS(ns, tag);
+    for (const [k, v] of Object.entries(attrs)) {
+      node.setAttribute(k, String(v));
+    }
+    if (text !== undefined) {
+      node.textContent = text;
+    }
+    svg.append(node);
+    return node;
+  };
+  const labeled = new Set([0, Math.floor(days.length / 2), days.length - 1]);
+  days.forEach((d, i) => {
+    const h = ((height - bottom - top) * d.clicks) / peak;
+    const bar = svgEl("rect", {x: i * step + step * 0.1, y: height - bottom - h, width: step * 0.8, height: h});
+    bar.append(Object.assign(document.createElementNS(ns, "title"), {textContent: `${d.day}: ${d.clicks}`}));
+    if (labeled.has(i)) {
+      svgEl("text", {x: i * step + step / 2, y: height - 5, "text-anchor": "middle"}, d.day.slice(5));
+    }
+  });
+  svgEl("text", {x: 0, y: 10}, `max ${peak}`);
+  return svg;
+}
+
+// QR codes
+
+function openQR(code) {
+  const dialog = $("#qr-dialog");
+  const base = `/links/${encodeURIComponent(code)}/qr`;
+  $(".code", dialog).textContent = code;
+  showError(dialog);
+  const img = $("img", dialog);
+  img.hidden = false;
+  img.onerror = () => {
+    img.hidden = true;
+    showError(dialog, new Error("The QR code could not be rendered. Is BASE_URL configured?"));
+  };
+  img.src = `${base}?format=png&size=256`;
+  for (const a of dialog.querySelectorAll("a[data-format]")) {
+    a.href = `${base}?format=${a.dataset.format}&size=1024`;
+    a.download = `${code}.${a.dataset.format}`;
+  }
+  dialog.showModal();
+}
+
+for (const button of document.querySelectorAll("[data-close]")) {
+  button.addEventListener("click", () => button.closest("dialog").close());
+}
+
+start();
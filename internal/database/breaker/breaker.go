@@ -0,0 +1,226 @@
+// Package breaker keeps a slow or failing storage from holding up requests: every repository
+// call gets a deadline of its own, and a circuit breaker fails calls fast with
+// service.ErrUnavailable while the storage keeps failing, optionally serving the links resolved
+// before from a cache meanwhile.
+package breaker
+
+import (
+	"expvar"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// State is the state of a circuit.
+type State int
+
+const (
+	// Closed lets every call through.
+	Closed State = iota
+	// Open fails every call fast until Config.OpenDuration has passed.
+	Open
+	// HalfOpen lets up to Config.HalfOpenProbes calls through to find out whether the storage
+	// is back.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// Config tunes a Repository. Zero fields take the value of DefaultConfig.
+type Config struct {
+	// ReadTimeout bounds reads of single links, WriteTimeout writes and ListTimeout listings.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	ListTimeout  time.Duration
+	// FailureThreshold is the number of failures in a row that opens the circuit.
+	FailureThreshold int
+	// OpenDuration is how long an open circuit fails calls before probing the storage.
+	OpenDuration time.Duration
+	// HalfOpenProbes is the number of calls let through a half-open circuit, all of which must
+	// succeed to close it.
+	HalfOpenProbes int
+	// StaleCacheSize is the number of links kept to resolve while the storage is unavailable,
+	// 0 for none. They may be out of date.
+	StaleCacheSize int
+}
+
+var DefaultConfig = Config{
+	ReadTimeout:      time.Second,
+	WriteTimeout:     3 * time.Second,
+	ListTimeout:      5 * time.Second,
+	FailureThreshold: 5,
+	OpenDuration:     10 * time.Second,
+	HalfOpenProbes:   1,
+}
+
+// ConfigFromEnv reads the config from DB_READ_TIMEOUT, DB_WRITE_TIMEOUT, DB_LIST_TIMEOUT,
+// BREAKER_FAILURE_THRESHOLD, BREAKER_OPEN_DURATION, BREAKER_HALF_OPEN_PROBES and
+// STALE_RESOLVE_CACHE_SIZE, using DefaultConfig for unset or malformed values.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig
+	envDuration := func(key string, v *time.Duration) {
+		if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+			*v = d
+		}
+	}
+	envInt := func(key string, v *int) {
+		if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+			*v = n
+		}
+	}
+	envDuration("DB_READ_TIMEOUT", &cfg.ReadTimeout)
+	envDuration("DB_WRITE_TIMEOUT", &cfg.WriteTimeout)
+	envDuration("DB_LIST_TIMEOUT", &cfg.ListTimeout)
+	envInt("BREAKER_FAILURE_THRESHOLD", &cfg.FailureThreshold)
+	envDuration("BREAKER_OPEN_DURATION", &cfg.OpenDuration)
+	envInt("BREAKER_HALF_OPEN_PROBES", &cfg.HalfOpenProbes)
+	envInt("STALE_RESOLVE_CACHE_SIZE", &cfg.StaleCacheSize)
+	return cfg
+}
+
+func (c Config) withDefaults() Config {
+	def := DefaultConfig
+	if c.ReadTimeout <= 0 {
+		c.ReadTimeout = def.ReadTimeout
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = def.WriteTimeout
+	}
+	if c.ListTimeout <= 0 {
+		c.ListTimeout = def.ListTimeout
+	}
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = def.FailureThreshold
+	}
+	if c.OpenDuration <= 0 {
+		c.OpenDuration = def.OpenDuration
+	}
+	if c.HalfOpenProbes <= 0 {
+		c.HalfOpenProbes = def.HalfOpenProbes
+	}
+	return c
+}
+
+// metrics are served as storage_breaker by expvar, on /debug/vars: the state of the circuit,
+// the number of times it went into each state (transitions_to_open, ...), and the number of
+// calls that failed, timed out, were rejected by the open circuit or were answered from the
+// stale cache (failures, timeouts, rejected, stale_resolves).
+var metrics = expvar.NewMap("storage_breaker")
+
+func init() {
+	metrics.Set("state", stateVar(Closed))
+}
+
+type stateVar State
+
+func (s stateVar) String() string {
+	return strconv.Quote(State(s).String())
+}
+
+// circuit is the state machine of the breaker.
+type circuit struct {
+	cfg Config
+	log *zap.Logger
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	// probes are the calls let through the half-open circuit, successes those that succeeded.
+	probes    int
+	successes int
+}
+
+// allow reports whether a call may go to the storage.
+func (c *circuit) allow() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.state == Open && c.now().Sub(c.openedAt) >= c.cfg.OpenDuration {
+		c.setState(HalfOpen)
+	}
+	switch c.state {
+	case Open:
+		return false
+	case HalfOpen:
+		if c.probes >= c.cfg.HalfOpenProbes {
+			return false
+		}
+		c.probes++
+	}
+	return true
+}
+
+// outcome is how a call went, as far as the health of the storage is concerned.
+type outcome int
+
+const (
+	succeeded outcome = iota
+	failed
+	// abandoned calls were canceled by the caller and say nothing about the storage.
+	abandoned
+)
+
+// done records the outcome of a call allow let through.
+func (c *circuit) done(o outcome) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch c.state {
+	case Closed:
+		switch o {
+		case succeeded:
+			c.failures = 0
+		case failed:
+			if c.failures++; c.failures >= c.cfg.FailureThreshold {
+				c.setState(Open)
+			}
+		}
+	case HalfOpen:
+		switch o {
+		case succeeded:
+			if c.successes++; c.successes >= c.cfg.HalfOpenProbes {
+				c.setState(Closed)
+			}
+		case failed:
+			c.setState(Open)
+		case abandoned:
+			// Another call may probe instead.
+			c.probes--
+		}
+	}
+}
+
+// setState moves the circuit into state. Must be called with mu held.
+func (c *circuit) setState(state State) {
+	from := c.state
+	c.state, c.failures, c.probes, c.successes = state, 0, 0, 0
+	if state == Open {
+		c.openedAt = c.now()
+	}
+	metrics.Set("state", stateVar(state))
+	metrics.Add("transitions_to_"+state.String(), 1)
+	switch state {
+	case Open:
+		c.log.Warn("Storage circuit opened, failing calls fast", zap.Stringer("from", from), zap.Duration("for", c.cfg.OpenDuration))
+	default:
+		c.log.Info("Storage circuit changed state", zap.Stringer("from", from), zap.Stringer("to", state))
+	}
+}
+
+func (c *circuit) current() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
@@ -0,0 +1,173 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// flakyRepository fails every call with err while it is set, and blocks calls until their
+// context ends while slow is set.
+type flakyRepository struct {
+	*inmemory.InMemoryUrlRepository
+	err  error
+	slow bool
+}
+
+func (f *flakyRepository) GetLink(ctx context.Context, id int64) (database.Link, error) {
+	if f.slow {
+		<-ctx.Done()
+		return database.Link{}, ctx.Err()
+	}
+	if f.err != nil {
+		return database.Link{}, f.err
+	}
+	return f.InMemoryUrlRepository.GetLink(ctx, id)
+}
+
+// newRepository returns a breaker over a flaky repository holding link 1, with a clock the test
+// moves.
+func newRepository(t *testing.T, cfg Config) (*Repository, *flakyRepository, *time.Time) {
+	t.Helper()
+	flaky := &flakyRepository{InMemoryUrlRepository: inmemory.NewInMemoryUrlRepository()}
+	require.NoError(t, flaky.CreateLink(context.Background(), &database.Link{Target: "https://example.com/1"}))
+	r := New(flaky, zaptest.NewLogger(t), cfg)
+	now := time.Now()
+	r.circuit.now = func() time.Time { return now }
+	return r, flaky, &now
+}
+
+func TestRepository_Circuit(t *testing.T) {
+	ctx := context.Background()
+	r, flaky, now := newRepository(t, Config{FailureThreshold: 3, OpenDuration: time.Minute, HalfOpenProbes: 2})
+	down := errors.New("connection refused")
+
+	// missing links are answers, not failures
+	for range 5 {
+		_, err := r.GetLink(ctx, 42)
+		require.ErrorIs(t, err, service.ErrUrlNotFound)
+	}
+	assert.Equal(t, Closed, r.State())
+
+	flaky.err = down
+	for range 3 {
+		_, err := r.GetLink(ctx, 1)
+		require.ErrorIs(t, err, down)
+	}
+	assert.Equal(t, Open, r.State())
+	_, err := r.GetLink(ctx, 1)
+	require.ErrorIs(t, err, service.ErrUnavailable, "an open circuit fails fast")
+
+	// after OpenDuration a probe finds the storage still down
+	*now = now.Add(time.Minute)
+	_, err = r.GetLink(ctx, 1)
+	require.ErrorIs(t, err, down)
+	assert.Equal(t, Open, r.State())
+
+	// and the next finds it back, closing the circuit after HalfOpenProbes successes
+	*now = now.Add(time.Minute)
+	flaky.err = nil
+	_, err = r.GetLink(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, HalfOpen, r.State())
+	_, err = r.GetLink(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, Closed, r.State())
+}
+
+func TestRepository_HalfOpenProbes(t *testing.T) {
+	r, _, now := newRepository(t, Config{FailureThreshold: 1, OpenDuration: time.Second, HalfOpenProbes: 1})
+	r.circuit.allow()
+	r.circuit.done(failed)
+	require.Equal(t, Open, r.State())
+
+	*now = now.Add(time.Second)
+	require.True(t, r.circuit.allow())
+	assert.False(t, r.circuit.allow(), "only one probe at a time")
+	// a probe the caller gave up on lets another one through
+	r.circuit.done(abandoned)
+	assert.True(t, r.circuit.allow())
+}
+
+func TestRepository_Timeout(t *testing.T) {
+	ctx := context.Background()
+	r, flaky, _ := newRepository(t, Config{ReadTimeout: 10 * time.Millisecond, FailureThreshold: 2})
+	flaky.slow = true
+
+	start := time.Now()
+	_, err := r.GetLink(ctx, 1)
+	require.ErrorIs(t, err, service.ErrUnavailable)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// calls the caller cancels do not count
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = r.GetLink(canceled, 1)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, Closed, r.State())
+	_, err = r.GetLink(ctx, 1)
+	require.ErrorIs(t, err, service.ErrUnavailable)
+	assert.Equal(t, Open, r.State())
+}
+
+func TestRepository_StaleResolves(t *testing.T) {
+	ctx := context.Background()
+	r, flaky, _ := newRepository(t, Config{FailureThreshold: 1, StaleCacheSize: 1})
+	link, err := r.GetLink(ctx, 1)
+	require.NoError(t, err)
+
+	flaky.err = errors.New("connection refused")
+	stale, err := r.GetLink(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, link, stale)
+	assert.Equal(t, Open, r.State())
+	// served from the cache with the circuit open too
+	target, err := r.GetUrlByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/1", target)
+	// writes are not
+	_, err = r.ConsumeClick(ctx, 1)
+	require.ErrorIs(t, err, service.ErrUnavailable)
+	_, err = r.GetLink(ctx, 2)
+	require.ErrorIs(t, err, service.ErrUnavailable)
+}
+
+func TestRepository_UpdateRulesErrors(t *testing.T) {
+	ctx := context.Background()
+	r, _, _ := newRepository(t, Config{FailureThreshold: 1})
+	_, err := r.UpdateRules(ctx, 1, func([]rules.Rule) ([]rules.Rule, error) {
+		return nil, service.ErrTooManyRules
+	})
+	require.Equal(t, service.ErrTooManyRules, err, "the error of update is returned as is")
+	assert.Equal(t, Closed, r.State())
+}
+
+func TestStaleCache(t *testing.T) {
+	c := newStaleCache(2)
+	c.put(database.Link{ID: 1})
+	c.put(database.Link{ID: 2})
+	c.put(database.Link{ID: 1, Target: "updated"})
+	c.put(database.Link{ID: 3})
+
+	_, ok := c.get(2)
+	assert.False(t, ok, "the least recently stored link is evicted")
+	link, ok := c.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "updated", link.Target)
+	c.remove(1)
+	_, ok = c.get(1)
+	assert.False(t, ok)
+	_, ok = c.get(3)
+	assert.True(t, ok)
+}
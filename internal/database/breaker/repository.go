@@ -0,0 +1,336 @@
+package breaker
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"go.uber.org/zap"
+)
+
+// Repository is an IUrlRepository that bounds every call of the repository it wraps by a
+// timeout and fails calls fast with service.ErrUnavailable while the circuit is open.
+type Repository struct {
+	repo    database.IUrlRepository
+	cfg     Config
+	circuit *circuit
+	stale   *staleCache
+}
+
+func New(repo database.IUrlRepository, log *zap.Logger, cfg Config) *Repository {
+	cfg = cfg.withDefaults()
+	r := &Repository{
+		repo:    repo,
+		cfg:     cfg,
+		circuit: &circuit{cfg: cfg, log: log, now: time.Now},
+	}
+	if cfg.StaleCacheSize > 0 {
+		r.stale = newStaleCache(cfg.StaleCacheSize)
+	}
+	return r
+}
+
+// State returns the state of the circuit.
+func (r *Repository) State() State {
+	return r.circuit.current()
+}
+
+// call runs f with a deadline of timeout, unless the circuit is open. Errors that say something
+// about the data rather than the storage, like a missing link, and calls canceled by the caller
+// do not count as failures. A call that times out fails with service.ErrUnavailable.
+func call[T any](ctx context.Context, r *Repository, timeout time.Duration, f func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if !r.circuit.allow() {
+		metrics.Add("rejected", 1)
+		return zero, service.ErrUnavailable
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	v, err := f(callCtx)
+	switch {
+	case err == nil || isDataError(err):
+		r.circuit.done(succeeded)
+	case ctx.Err() != nil:
+		r.circuit.done(abandoned)
+	case errors.Is(callCtx.Err(), context.DeadlineExceeded):
+		r.circuit.done(failed)
+		metrics.Add("timeouts", 1)
+		return zero, fmt.Errorf("%w: %w", service.ErrUnavailable, err)
+	default:
+		r.circuit.done(failed)
+		metrics.Add("failures", 1)
+	}
+	return v, err
+}
+
+// isDataError reports whether err is an answer of the storage rather than a failure of it.
+func isDataError(err error) bool {
+	return errors.Is(err, service.ErrUrlNotFound) ||
+		errors.Is(err, service.ErrCodeTaken) ||
+		errors.Is(err, service.ErrLinkExhausted) ||
+		errors.Is(err, errRejectedUpdate)
+}
+
+func exec(ctx context.Context, r *Repository, timeout time.Duration, f func(ctx context.Context) error) error {
+	_, err := call(ctx, r, timeout, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, f(ctx)
+	})
+	return err
+}
+
+// isUnavailable reports whether err means the storage could not answer.
+func isUnavailable(err error) bool {
+	return err != nil && !isDataError(err) && !errors.Is(err, context.Canceled)
+}
+
+func (r *Repository) GetID(ctx context.Context, domain, fullUrl string) (id int64, err error) {
+	return call(ctx, r, r.cfg.ReadTimeout, func(ctx context.Context) (int64, error) {
+		return r.repo.GetID(ctx, domain, fullUrl)
+	})
+}
+
+func (r *Repository) GetUrlByID(ctx context.Context, id int64) (fullUrl string, err error) {
+	fullUrl, err = call(ctx, r, r.cfg.ReadTimeout, func(ctx context.Context) (string, error) {
+		return r.repo.GetUrlByID(ctx, id)
+	})
+	if isUnavailable(err) && r.stale != nil {
+		if link, ok := r.stale.get(id); ok {
+			metrics.Add("stale_resolves", 1)
+			return link.Target, nil
+		}
+	}
+	return fullUrl, err
+}
+
+func (r *Repository) SaveUrl(ctx context.Context, domain, fullUrl string) (err error) {
+	return exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.SaveUrl(ctx, domain, fullUrl)
+	})
+}
+
+func (r *Repository) CreateLink(ctx context.Context, link *database.Link) (err error) {
+	return exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.CreateLink(ctx, link)
+	})
+}
+
+func (r *Repository) ImportLink(ctx context.Context, link *database.Link) (err error) {
+	return exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.ImportLink(ctx, link)
+	})
+}
+
+func (r *Repository) CreateLinkWithID(ctx context.Context, link *database.Link) (err error) {
+	return exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.CreateLinkWithID(ctx, link)
+	})
+}
+
+// GetLink keeps the links it reads in the stale cache, if any, and answers from it while the
+// storage is unavailable.
+func (r *Repository) GetLink(ctx context.Context, id int64) (link database.Link, err error) {
+	link, err = call(ctx, r, r.cfg.ReadTimeout, func(ctx context.Context) (database.Link, error) {
+		return r.repo.GetLink(ctx, id)
+	})
+	if r.stale == nil {
+		return link, err
+	}
+	switch {
+	case err == nil:
+		r.stale.put(link)
+	case errors.Is(err, service.ErrUrlNotFound):
+		r.stale.remove(id)
+	case isUnavailable(err):
+		if stale, ok := r.stale.get(id); ok {
+			metrics.Add("stale_resolves", 1)
+			return stale, nil
+		}
+	}
+	return link, err
+}
+
+func (r *Repository) UpdateLink(ctx context.Context, link database.Link, fields []database.LinkField) (updated database.Link, err error) {
+	updated, err = call(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) (database.Link, error) {
+		return r.repo.UpdateLink(ctx, link, fields)
+	})
+	if err == nil && r.stale != nil {
+		r.stale.put(updated)
+	}
+	return updated, err
+}
+
+func (r *Repository) DeleteLink(ctx context.Context, id int64) (err error) {
+	err = exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.DeleteLink(ctx, id)
+	})
+	if err == nil && r.stale != nil {
+		r.stale.remove(id)
+	}
+	return err
+}
+
+func (r *Repository) ListLinks(ctx context.Context, filter database.ListLinksFilter) (links []database.Link, err error) {
+	return call(ctx, r, r.cfg.ListTimeout, func(ctx context.Context) ([]database.Link, error) {
+		return r.repo.ListLinks(ctx, filter)
+	})
+}
+
+func (r *Repository) UpdateTags(ctx context.Context, domain string, ids []int64, add, remove []string) (updated []database.Link, found []int64, err error) {
+	err = exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) (err error) {
+		updated, found, err = r.repo.UpdateTags(ctx, domain, ids, add, remove)
+		return err
+	})
+	if err == nil && r.stale != nil {
+		for _, link := range updated {
+			r.stale.put(link)
+		}
+	}
+	return updated, found, err
+}
+
+func (r *Repository) CampaignStats(ctx context.Context, domain string) (stats []database.CampaignStats, err error) {
+	return call(ctx, r, r.cfg.ListTimeout, func(ctx context.Context) ([]database.CampaignStats, error) {
+		return r.repo.CampaignStats(ctx, domain)
+	})
+}
+
+func (r *Repository) RecordClick(ctx context.Context, id int64, at time.Time) (err error) {
+	return exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.RecordClick(ctx, id, at)
+	})
+}
+
+func (r *Repository) ClickHistory(ctx context.Context, id int64, since time.Time) (days []database.DailyClicks, err error) {
+	return call(ctx, r, r.cfg.ReadTimeout, func(ctx context.Context) ([]database.DailyClicks, error) {
+		return r.repo.ClickHistory(ctx, id, since)
+	})
+}
+
+func (r *Repository) SetClickHistory(ctx context.Context, id int64, days []database.DailyClicks) (err error) {
+	return exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.SetClickHistory(ctx, id, days)
+	})
+}
+
+func (r *Repository) ConsumeClick(ctx context.Context, id int64) (remaining int64, err error) {
+	return call(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) (int64, error) {
+		return r.repo.ConsumeClick(ctx, id)
+	})
+}
+
+// errRejectedUpdate marks the errors of the update function of UpdateRules, which are no
+// failures of the storage.
+var errRejectedUpdate = errors.New("update rejected")
+
+type rejectedUpdate struct{ err error }
+
+func (e rejectedUpdate) Error() string        { return e.err.Error() }
+func (e rejectedUpdate) Is(target error) bool { return target == errRejectedUpdate }
+func (e rejectedUpdate) Unwrap() error        { return e.err }
+
+func (r *Repository) UpdateRules(ctx context.Context, id int64, update func([]rules.Rule) ([]rules.Rule, error)) (updated database.Link, err error) {
+	updated, err = call(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) (database.Link, error) {
+		return r.repo.UpdateRules(ctx, id, func(current []rules.Rule) ([]rules.Rule, error) {
+			rs, err := update(current)
+			if err != nil {
+				return nil, rejectedUpdate{err}
+			}
+			return rs, nil
+		})
+	})
+	// The error of update is returned as is.
+	var rejected rejectedUpdate
+	if errors.As(err, &rejected) {
+		return database.Link{}, rejected.err
+	}
+	if err == nil && r.stale != nil {
+		r.stale.put(updated)
+	}
+	return updated, err
+}
+
+func (r *Repository) RecordVariantClick(ctx context.Context, id int64, variantID string) (err error) {
+	return exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.RecordVariantClick(ctx, id, variantID)
+	})
+}
+
+func (r *Repository) VariantClicks(ctx context.Context, id int64) (clicks map[string]int64, err error) {
+	return call(ctx, r, r.cfg.ReadTimeout, func(ctx context.Context) (map[string]int64, error) {
+		return r.repo.VariantClicks(ctx, id)
+	})
+}
+
+func (r *Repository) SetVariantClicks(ctx context.Context, id int64, clicks map[string]int64) (err error) {
+	return exec(ctx, r, r.cfg.WriteTimeout, func(ctx context.Context) error {
+		return r.repo.SetVariantClicks(ctx, id, clicks)
+	})
+}
+
+// staleCache keeps the links read last, evicting the least recently stored first.
+type staleCache struct {
+	mu    sync.Mutex
+	size  int
+	links map[int64]*list.Element
+	// order holds the links, the most recently stored first.
+	order *list.List
+}
+
+func newStaleCache(size int) *staleCache {
+	return &staleCache{size: size, links: make(map[int64]*list.Element, size), order: list.New()}
+}
+
+func (c *staleCache) get(id int64) (database.Link, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.links[id]
+	if !ok {
+		return database.Link{}, false
+	}
+	return cloneLink(e.Value.(database.Link)), true
+}
+
+func (c *staleCache) put(link database.Link) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.links[link.ID]; ok {
+		e.Value = cloneLink(link)
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.links, oldest.Value.(database.Link).ID)
+	}
+	c.links[link.ID] = c.order.PushFront(cloneLink(link))
+}
+
+func (c *staleCache) remove(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.links[id]; ok {
+		c.order.Remove(e)
+		delete(c.links, id)
+	}
+}
+
+// cloneLink copies the slices of a link, which callers of the cache may change.
+func cloneLink(link database.Link) database.Link {
+	link.Tags = slices.Clone(link.Tags)
+	link.Rules = rules.Clone(link.Rules)
+	link.Variants = slices.Clone(link.Variants)
+	if link.ExpiresAt != nil {
+		expires := *link.ExpiresAt
+		link.ExpiresAt = &expires
+	}
+	return link
+}
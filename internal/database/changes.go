@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// ChangeType is the kind of a link mutation.
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "created"
+	ChangeUpdated ChangeType = "updated"
+	ChangeDeleted ChangeType = "deleted"
+)
+
+// RedactedPasswordHash stands in for the password hash of protected links in the change log,
+// which tells whether a link is protected but never holds its hash.
+const RedactedPasswordHash = "redacted"
+
+// Redacted returns link as the change log holds it, with RedactedPasswordHash for its password hash.
+func Redacted(link Link) Link {
+	if link.PasswordHash != "" {
+		link.PasswordHash = RedactedPasswordHash
+	}
+	return link
+}
+
+// Change is a committed link mutation. Storages record it together with the mutation itself,
+// so that the change log holds every mutation exactly once, in commit order.
+type Change struct {
+	// Seq numbers the changes from 1 upwards in the order they were committed.
+	Seq  int64
+	Type ChangeType
+	// Link is the link after the change, or before it for deletions, Redacted.
+	Link Link
+	// Fields are the changed fields of updates.
+	Fields []LinkField
+	Time   time.Time
+}
+
+// IChangeLog reads the ordered log of link mutations.
+type IChangeLog interface {
+	// Changes returns up to limit changes with a sequence number above afterSeq, in order.
+	Changes(ctx context.Context, afterSeq int64, limit int) (changes []Change, err error)
+	// LastSeq returns the sequence number of the latest change, 0 if there is none.
+	LastSeq(ctx context.Context) (seq int64, err error)
+}
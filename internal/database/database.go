@@ -2,6 +2,10 @@ package database
 
 import (
 	"context"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
 )
 
 type StorageType string
@@ -11,12 +15,22 @@ const (
 	Postgres StorageType = "postgres"
 )
 
+// SchemaVersion is the storage schema version this build expects.
+// Bump it together with any change to the SQL models.
+const SchemaVersion int64 = 16
+
 // DBService represents a service that interacts with a database.
 type DBService interface {
 	// Health returns a map of health status information.
 	// The keys and values in the map are service-specific.
 	Health() map[string]string
 
+	// Ping verifies the storage is reachable.
+	Ping(ctx context.Context) error
+
+	// SchemaVersion returns the schema version the storage has been migrated to.
+	SchemaVersion(ctx context.Context) (int64, error)
+
 	// Close terminates the database connection.
 	// It returns an error if the connection cannot be closed.
 	Close() error
@@ -24,13 +38,91 @@ type DBService interface {
 	SyncDB()
 
 	NewUrlRepository() IUrlRepository
+
+	// NewWebhookStore returns the storage of webhook subscriptions and deliveries.
+	NewWebhookStore() webhooks.Store
+
+	// NewChangeLog returns the log of the link mutations of the repository.
+	NewChangeLog() IChangeLog
 }
 
 type IUrlRepository interface {
-	// GetID returns the ID for a given URL
-	GetID(ctx context.Context, fullUrl string) (id int64, err error)
+	// GetID returns the ID of the oldest plain link (see Link.Plain) of a given URL on the given domain
+	GetID(ctx context.Context, domain, fullUrl string) (id int64, err error)
 	// GetUrlByID returns the full URL for a given ID
 	GetUrlByID(ctx context.Context, id int64) (fullUrl string, err error)
-	// SaveUrl saves a new URL
-	SaveUrl(ctx context.Context, fullUrl string) (err error)
+	// SaveUrl saves a new URL on the given domain
+	SaveUrl(ctx context.Context, domain, fullUrl string) (err error)
+
+	// CreateLink stores a new link and fills in its ID and timestamps
+	CreateLink(ctx context.Context, link *Link) (err error)
+	// ImportLink stores a new link under its ID, or a new one if it is 0, keeping its CreatedAt
+	// unless it is zero. It fails with service.ErrCodeTaken if the ID is in use. Links created
+	// later get higher IDs.
+	ImportLink(ctx context.Context, link *Link) (err error)
+	// CreateLinkWithID stores a new link under an ID from a block leased with
+	// IDBlockLeaser.LeaseIDs, like ImportLink, but leaves the ID sequence alone, which the lease
+	// moved past the block already. It fails with service.ErrCodeTaken if the ID is in use.
+	CreateLinkWithID(ctx context.Context, link *Link) (err error)
+	// GetLink returns the link with the given ID
+	GetLink(ctx context.Context, id int64) (link Link, err error)
+	// UpdateLink overwrites the given fields of the stored link with the values from link
+	UpdateLink(ctx context.Context, link Link, fields []LinkField) (updated Link, err error)
+	// DeleteLink removes the link with the given ID
+	DeleteLink(ctx context.Context, id int64) (err error)
+	// ListLinks returns up to filter.Limit links matching the filter in filter.Sort order
+	ListLinks(ctx context.Context, filter ListLinksFilter) (links []Link, err error)
+	// UpdateTags adds the tags add to and removes the tags remove from every link on the given
+	// domain with one of the given IDs, in one transaction. It returns the links whose tags
+	// changed and the IDs of all the links found.
+	UpdateTags(ctx context.Context, domain string, ids []int64, add, remove []string) (updated []Link, found []int64, err error)
+	// CampaignStats returns the links and clicks of every campaign of the links on the given
+	// domain, ordered by campaign
+	CampaignStats(ctx context.Context, domain string) (stats []CampaignStats, err error)
+	// RecordClick counts a resolve of the link at the given time, in total and on its UTC day
+	RecordClick(ctx context.Context, id int64, at time.Time) (err error)
+	// ClickHistory returns the clicks of the link on every UTC day with clicks since the day of
+	// since, oldest first
+	ClickHistory(ctx context.Context, id int64, since time.Time) (days []DailyClicks, err error)
+	// SetClickHistory replaces the clicks of the link by UTC day, e.g. with those of a copy of
+	// the link in another storage. It leaves the total clicks of the link alone.
+	SetClickHistory(ctx context.Context, id int64, days []DailyClicks) (err error)
+	// ConsumeClick atomically takes one of the remaining clicks of a click-limited link and
+	// returns the clicks left after it, so 0 for the last one. It fails with
+	// service.ErrLinkExhausted when none are left and is a no-op returning -1 for unlimited links.
+	ConsumeClick(ctx context.Context, id int64) (remaining int64, err error)
+	// UpdateRules replaces the redirect rules of the link with the result of update,
+	// which gets the current rules. Concurrent updates of the same link are serialized,
+	// so none of them is lost. An error from update is returned as is.
+	UpdateRules(ctx context.Context, id int64, update func([]rules.Rule) ([]rules.Rule, error)) (updated Link, err error)
+	// RecordVariantClick counts a resolve of the link that picked the split variant with the given ID
+	RecordVariantClick(ctx context.Context, id int64, variantID string) (err error)
+	// VariantClicks returns the recorded clicks of the link by split variant ID
+	VariantClicks(ctx context.Context, id int64) (clicks map[string]int64, err error)
+	// SetVariantClicks replaces the recorded clicks of the link by split variant ID, e.g. with
+	// those of a copy of the link in another storage
+	SetVariantClicks(ctx context.Context, id int64, clicks map[string]int64) (err error)
+}
+
+// IDSequence is implemented by repositories that issue link IDs from a sequence. Codes derive
+// from IDs, so storages taking over from others must never issue the IDs those issued.
+type IDSequence interface {
+	// LastID returns the highest ID issued, deleted links included, 0 if none.
+	LastID(ctx context.Context) (id int64, err error)
+	// AdvanceIDs makes links created later get IDs above id. It never moves the sequence back.
+	AdvanceIDs(ctx context.Context, id int64) (err error)
+}
+
+// IDBlock is a range of link IDs from Start up to, but excluding, End.
+type IDBlock struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// IDBlockLeaser is implemented by repositories that lease blocks of link IDs to processes, which
+// then hand them out without asking the storage. Leased IDs are never issued again, neither by
+// other leases nor by the sequence of the storage, so a process that dies leaves only a gap.
+type IDBlockLeaser interface {
+	// LeaseIDs claims the next size IDs for owner and records the lease.
+	LeaseIDs(ctx context.Context, owner string, size int64) (block IDBlock, err error)
 }
@@ -0,0 +1,210 @@
+// Package dbcopy moves link data from one storage to another without changing any issued code.
+//
+// Codes are encodings of link IDs, so links are copied under their IDs and the ID sequence of
+// the target is moved past every ID the source has issued. A cutover copies the links with Copy,
+// which can resume after an interruption and catch up with links created since, mirrors the
+// writes of the running service into the target with DualWrite meanwhile, and checks with Verify
+// that both storages hold the same links before switching over.
+package dbcopy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/service"
+)
+
+// DefaultBatchSize is the number of links read from a storage at a time.
+const DefaultBatchSize = 500
+
+// maxReported bounds the IDs listed in reports.
+const maxReported = 100
+
+// Options control a copy.
+type Options struct {
+	// BatchSize is the number of links copied at a time, DefaultBatchSize if 0.
+	BatchSize int
+	// After resumes a copy: only links with higher IDs are copied.
+	After int64
+	// Overwrite replaces links that differ in the target instead of reporting them as conflicts.
+	// It is only safe while nothing else writes to those links of the target.
+	Overwrite bool
+	// Checkpoint is called after every batch with the ID of the last link copied, e.g. to save it
+	// for resuming. An error stops the copy.
+	Checkpoint func(lastID int64) error
+}
+
+// Report is the outcome of a copy.
+type Report struct {
+	// Copied links were created in the target, or replaced there with Overwrite.
+	Copied int `json:"copied"`
+	// Existing links were in the target already, e.g. from an interrupted copy or dual writes.
+	Existing int `json:"existing"`
+	// Conflicts are links whose ID is taken in the target by a different link. ConflictIDs lists
+	// the first of them.
+	Conflicts   int     `json:"conflicts"`
+	ConflictIDs []int64 `json:"conflict_ids,omitempty"`
+	// LastID is the ID of the last link copied, where a resumed copy continues.
+	LastID int64 `json:"last_id"`
+	// Sequence is the last ID issued by the source, which the target does not issue again.
+	// It is 0 if either storage does not use a sequence.
+	Sequence int64 `json:"sequence"`
+}
+
+// Copy copies every link of src with an ID above opts.After to dst in ID order, together with
+// the clicks of its split variants and its daily clicks. Links whose ID is taken in dst are left alone if they are
+// equal and reported as conflicts otherwise, so copying again is harmless.
+func Copy(ctx context.Context, src, dst database.IUrlRepository, opts Options) (Report, error) {
+	report := Report{LastID: opts.After}
+	links := newPager(src, opts.BatchSize, opts.After)
+	for {
+		batch, err := links.nextPage(ctx)
+		if err != nil {
+			return report, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, link := range batch {
+			if err := copyLink(ctx, src, dst, link, opts.Overwrite, &report); err != nil {
+				return report, fmt.Errorf("link %d: %w", link.ID, err)
+			}
+			report.LastID = link.ID
+		}
+		if opts.Checkpoint != nil {
+			if err := opts.Checkpoint(report.LastID); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	srcSeq, ok := src.(database.IDSequence)
+	dstSeq, ok2 := dst.(database.IDSequence)
+	if !ok || !ok2 {
+		return report, nil
+	}
+	last, err := srcSeq.LastID(ctx)
+	if err != nil {
+		return report, err
+	}
+	if err := dstSeq.AdvanceIDs(ctx, last); err != nil {
+		return report, err
+	}
+	report.Sequence = last
+	return report, nil
+}
+
+func copyLink(ctx context.Context, src, dst database.IUrlRepository, link database.Link, overwrite bool, report *Report) error {
+	clicks, err := readClicks(ctx, src, link)
+	if err != nil {
+		return err
+	}
+	stored := link
+	err = dst.ImportLink(ctx, &stored)
+	switch {
+	case err == nil:
+		report.Copied++
+	case errors.Is(err, service.ErrCodeTaken):
+		existing, err := dst.GetLink(ctx, link.ID)
+		if err != nil {
+			return err
+		}
+		existingClicks, err := readClicks(ctx, dst, existing)
+		if err != nil {
+			return err
+		}
+		switch {
+		case digest(existing, existingClicks) == digest(link, clicks):
+			report.Existing++
+			return nil
+		case !overwrite:
+			report.Conflicts++
+			if len(report.ConflictIDs) < maxReported {
+				report.ConflictIDs = append(report.ConflictIDs, link.ID)
+			}
+			return nil
+		}
+		if err := dst.DeleteLink(ctx, link.ID); err != nil {
+			return err
+		}
+		stored = link
+		if err := dst.ImportLink(ctx, &stored); err != nil {
+			return err
+		}
+		report.Copied++
+	default:
+		return err
+	}
+	if len(clicks.variants) > 0 {
+		if err := dst.SetVariantClicks(ctx, link.ID, clicks.variants); err != nil {
+			return err
+		}
+	}
+	if len(clicks.days) == 0 {
+		return nil
+	}
+	return dst.SetClickHistory(ctx, link.ID, clicks.days)
+}
+
+// linkClicks are the clicks of a link kept apart from it: by split variant and by UTC day.
+type linkClicks struct {
+	variants map[string]int64
+	days     []database.DailyClicks
+}
+
+// readClicks returns the clicks of a link kept apart from it. Only links with variants have
+// variant clicks that matter and only clicked links have days, which saves queries for the others.
+func readClicks(ctx context.Context, repo database.IUrlRepository, link database.Link) (clicks linkClicks, err error) {
+	if len(link.Variants) > 0 {
+		if clicks.variants, err = repo.VariantClicks(ctx, link.ID); err != nil {
+			return linkClicks{}, err
+		}
+	}
+	if link.Clicks > 0 {
+		if clicks.days, err = repo.ClickHistory(ctx, link.ID, time.Time{}); err != nil {
+			return linkClicks{}, err
+		}
+	}
+	return clicks, nil
+}
+
+// pager reads every link of a repository, disabled ones included, in ID order.
+type pager struct {
+	repo  database.IUrlRepository
+	size  int
+	after int64
+	done  bool
+}
+
+func newPager(repo database.IUrlRepository, size int, after int64) *pager {
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	return &pager{repo: repo, size: size, after: after}
+}
+
+// nextPage returns the next links, none at the end.
+func (p *pager) nextPage(ctx context.Context) ([]database.Link, error) {
+	if p.done {
+		return nil, nil
+	}
+	links, err := p.repo.ListLinks(ctx, database.ListLinksFilter{
+		After:           &database.LinkCursor{ID: p.after},
+		Limit:           p.size,
+		Sort:            database.SortIDAsc,
+		IncludeDisabled: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(links) < p.size {
+		p.done = true
+	}
+	if len(links) > 0 {
+		p.after = links[len(links)-1].ID
+	}
+	return links, nil
+}
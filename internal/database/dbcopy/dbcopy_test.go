@@ -0,0 +1,183 @@
+package dbcopy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/split"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// newSource returns a repository with links 1 to 5, where 2 and 5 are deleted, 3 is disabled
+// and 4 has split variants with clicks and clicks on two days.
+func newSource(t *testing.T) *inmemory.InMemoryUrlRepository {
+	t.Helper()
+	ctx := context.Background()
+	repo := inmemory.NewInMemoryUrlRepository()
+	expires := time.Now().Add(time.Hour)
+	for _, link := range []database.Link{
+		{Target: "https://example.com/1", Owner: "alice", Tags: []string{"a"}, ExpiresAt: &expires, MaxClicks: 3, RemainingClicks: 2},
+		{Target: "https://example.com/2"},
+		{Target: "https://example.com/3", Domain: "go.brand-a.com", Disabled: true},
+		{Target: "https://example.com/4", Variants: []split.Variant{{ID: "a", Target: "https://example.com/4a", Weight: 1}, {ID: "b", Target: "https://example.com/4b", Weight: 1}}},
+		{Target: "https://example.com/5"},
+	} {
+		require.NoError(t, repo.CreateLink(ctx, &link))
+	}
+	require.NoError(t, repo.DeleteLink(ctx, 2))
+	require.NoError(t, repo.DeleteLink(ctx, 5))
+	require.NoError(t, repo.SetVariantClicks(ctx, 4, map[string]int64{"a": 7, "b": 3}))
+	for _, at := range []time.Time{time.Now().AddDate(0, 0, -3), time.Now(), time.Now()} {
+		require.NoError(t, repo.RecordClick(ctx, 4, at))
+	}
+	return repo
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+	src := newSource(t)
+	dst := inmemory.NewInMemoryUrlRepository()
+
+	var checkpoints []int64
+	report, err := Copy(ctx, src, dst, Options{BatchSize: 2, Checkpoint: func(lastID int64) error {
+		checkpoints = append(checkpoints, lastID)
+		return nil
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, Report{Copied: 3, LastID: 4, Sequence: 5}, report)
+	assert.Equal(t, []int64{3, 4}, checkpoints)
+
+	for _, id := range []int64{1, 3, 4} {
+		want, err := src.GetLink(ctx, id)
+		require.NoError(t, err)
+		got, err := dst.GetLink(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, digest(want, linkClicks{}), digest(got, linkClicks{}), id)
+	}
+	clicks, err := dst.VariantClicks(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a": 7, "b": 3}, clicks)
+	wantDays, err := src.ClickHistory(ctx, 4, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, wantDays, 2)
+	days, err := dst.ClickHistory(ctx, 4, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, wantDays, days)
+
+	// the IDs of deleted links are not issued again
+	created := database.Link{Target: "https://example.com/6"}
+	require.NoError(t, dst.CreateLink(ctx, &created))
+	assert.Equal(t, int64(6), created.ID)
+
+	verification, err := Verify(ctx, src, dst, 2)
+	require.NoError(t, err)
+	assert.False(t, verification.OK())
+	assert.Equal(t, []int64{6}, verification.Extra)
+	assert.Empty(t, verification.Missing)
+	assert.Empty(t, verification.Different)
+
+	// a different click history is a different link
+	require.NoError(t, dst.SetClickHistory(ctx, 4, days[1:]))
+	verification, err = Verify(ctx, src, dst, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, verification.Different)
+}
+
+func TestCopy_Resume(t *testing.T) {
+	ctx := context.Background()
+	src := newSource(t)
+	dst := inmemory.NewInMemoryUrlRepository()
+
+	stop := errors.New("interrupted")
+	report, err := Copy(ctx, src, dst, Options{BatchSize: 1, Checkpoint: func(lastID int64) error {
+		if lastID == 3 {
+			return stop
+		}
+		return nil
+	}})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, int64(3), report.LastID)
+
+	// resuming copies the rest, running again from the start finds everything there
+	report, err = Copy(ctx, src, dst, Options{After: report.LastID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Copied)
+	report, err = Copy(ctx, src, dst, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, Report{Existing: 3, LastID: 4, Sequence: 5}, report)
+
+	verification, err := Verify(ctx, src, dst, 0)
+	require.NoError(t, err)
+	assert.True(t, verification.OK())
+	assert.Equal(t, 3, verification.Source.Links)
+}
+
+func TestCopy_Conflicts(t *testing.T) {
+	ctx := context.Background()
+	src := newSource(t)
+	dst := inmemory.NewInMemoryUrlRepository()
+	require.NoError(t, dst.ImportLink(ctx, &database.Link{ID: 3, Target: "https://example.com/other"}))
+
+	report, err := Copy(ctx, src, dst, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Conflicts)
+	assert.Equal(t, []int64{3}, report.ConflictIDs)
+	verification, err := Verify(ctx, src, dst, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, verification.Different)
+
+	report, err = Copy(ctx, src, dst, Options{Overwrite: true})
+	require.NoError(t, err)
+	assert.Equal(t, Report{Copied: 1, Existing: 2, LastID: 4, Sequence: 5}, report)
+	link, err := dst.GetLink(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/3", link.Target)
+}
+
+func TestDualWrite(t *testing.T) {
+	ctx := context.Background()
+	primary := newSource(t)
+	secondary := inmemory.NewInMemoryUrlRepository()
+	repo := NewDualWrite(primary, secondary, zaptest.NewLogger(t))
+
+	// writes to links the secondary does not have yet are left to the copy
+	_, err := repo.UpdateLink(ctx, database.Link{ID: 1, Owner: "bob"}, []database.LinkField{database.LinkFieldOwner})
+	require.NoError(t, err)
+	_, err = repo.ConsumeClick(ctx, 1)
+	require.NoError(t, err)
+
+	_, err = Copy(ctx, primary, secondary, Options{})
+	require.NoError(t, err)
+
+	link := database.Link{Target: "https://example.com/new"}
+	require.NoError(t, repo.CreateLink(ctx, &link))
+	_, err = repo.UpdateLink(ctx, database.Link{ID: 3, Target: "https://example.com/3b"}, []database.LinkField{database.LinkFieldTarget})
+	require.NoError(t, err)
+	_, err = repo.UpdateRules(ctx, 3, func(current []rules.Rule) ([]rules.Rule, error) {
+		return append(current, rules.Rule{ID: "r1", Target: "https://example.com/mobile"}), nil
+	})
+	require.NoError(t, err)
+	remaining, err := repo.ConsumeClick(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, remaining, "the primary reports the clicks left")
+	require.NoError(t, repo.RecordVariantClick(ctx, 4, "a"))
+	require.NoError(t, repo.RecordClick(ctx, 4, time.Now()))
+	require.NoError(t, repo.DeleteLink(ctx, 1))
+
+	verification, err := Verify(ctx, primary, secondary, 0)
+	require.NoError(t, err)
+	assert.True(t, verification.OK(), verification)
+	assert.Zero(t, repo.Failures())
+
+	got, err := secondary.GetLink(ctx, link.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/new", got.Target)
+}
@@ -0,0 +1,195 @@
+package dbcopy
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"go.uber.org/zap"
+)
+
+// DualWrite is a repository that serves from a primary storage and mirrors every write into a
+// secondary one during a cutover. The primary issues the IDs, which the secondary stores the
+// links under. Writes to the secondary never fail the request: links it does not have yet are
+// left to the copy, and other failures are logged and counted, to be repaired by copying again
+// once Verify finds them.
+type DualWrite struct {
+	primary   database.IUrlRepository
+	secondary database.IUrlRepository
+	log       *zap.Logger
+	failures  atomic.Int64
+}
+
+func NewDualWrite(primary, secondary database.IUrlRepository, log *zap.Logger) *DualWrite {
+	return &DualWrite{primary: primary, secondary: secondary, log: log}
+}
+
+// Failures returns the number of writes the secondary failed.
+func (d *DualWrite) Failures() int64 {
+	return d.failures.Load()
+}
+
+// mirror reports a failed write to the secondary. Links missing there are not failures, the
+// copy brings them over.
+func (d *DualWrite) mirror(op string, id int64, err error) {
+	if err == nil || errors.Is(err, service.ErrUrlNotFound) {
+		return
+	}
+	d.failures.Add(1)
+	d.log.Warn("failed to mirror a write to the secondary storage", zap.String("op", op), zap.Int64("id", id), zap.Error(err))
+}
+
+func (d *DualWrite) GetID(ctx context.Context, domain, fullUrl string) (id int64, err error) {
+	return d.primary.GetID(ctx, domain, fullUrl)
+}
+
+func (d *DualWrite) GetUrlByID(ctx context.Context, id int64) (fullUrl string, err error) {
+	return d.primary.GetUrlByID(ctx, id)
+}
+
+func (d *DualWrite) SaveUrl(ctx context.Context, domain, fullUrl string) (err error) {
+	return d.CreateLink(ctx, &database.Link{Domain: domain, Target: fullUrl})
+}
+
+func (d *DualWrite) CreateLink(ctx context.Context, link *database.Link) (err error) {
+	if err := d.primary.CreateLink(ctx, link); err != nil {
+		return err
+	}
+	mirrored := *link
+	d.mirror("create", link.ID, d.secondary.ImportLink(ctx, &mirrored))
+	return nil
+}
+
+func (d *DualWrite) ImportLink(ctx context.Context, link *database.Link) (err error) {
+	if err := d.primary.ImportLink(ctx, link); err != nil {
+		return err
+	}
+	mirrored := *link
+	d.mirror("import", link.ID, d.secondary.ImportLink(ctx, &mirrored))
+	return nil
+}
+
+// CreateLinkWithID imports the link into the secondary, whose ID sequence no lease moved.
+func (d *DualWrite) CreateLinkWithID(ctx context.Context, link *database.Link) (err error) {
+	if err := d.primary.CreateLinkWithID(ctx, link); err != nil {
+		return err
+	}
+	mirrored := *link
+	d.mirror("create", link.ID, d.secondary.ImportLink(ctx, &mirrored))
+	return nil
+}
+
+func (d *DualWrite) GetLink(ctx context.Context, id int64) (link database.Link, err error) {
+	return d.primary.GetLink(ctx, id)
+}
+
+func (d *DualWrite) UpdateLink(ctx context.Context, link database.Link, fields []database.LinkField) (updated database.Link, err error) {
+	updated, err = d.primary.UpdateLink(ctx, link, fields)
+	if err != nil {
+		return database.Link{}, err
+	}
+	_, err = d.secondary.UpdateLink(ctx, link, fields)
+	d.mirror("update", link.ID, err)
+	return updated, nil
+}
+
+func (d *DualWrite) DeleteLink(ctx context.Context, id int64) (err error) {
+	if err := d.primary.DeleteLink(ctx, id); err != nil {
+		return err
+	}
+	d.mirror("delete", id, d.secondary.DeleteLink(ctx, id))
+	return nil
+}
+
+func (d *DualWrite) ListLinks(ctx context.Context, filter database.ListLinksFilter) (links []database.Link, err error) {
+	return d.primary.ListLinks(ctx, filter)
+}
+
+func (d *DualWrite) UpdateTags(ctx context.Context, domain string, ids []int64, add, remove []string) (updated []database.Link, found []int64, err error) {
+	updated, found, err = d.primary.UpdateTags(ctx, domain, ids, add, remove)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The secondary gets the tags of the primary, which it may not have had before.
+	for _, link := range updated {
+		_, err := d.secondary.UpdateLink(ctx, link, []database.LinkField{database.LinkFieldTags})
+		d.mirror("update_tags", link.ID, err)
+	}
+	return updated, found, nil
+}
+
+func (d *DualWrite) CampaignStats(ctx context.Context, domain string) (stats []database.CampaignStats, err error) {
+	return d.primary.CampaignStats(ctx, domain)
+}
+
+func (d *DualWrite) RecordClick(ctx context.Context, id int64, at time.Time) (err error) {
+	if err := d.primary.RecordClick(ctx, id, at); err != nil {
+		return err
+	}
+	d.mirror("record_click", id, d.secondary.RecordClick(ctx, id, at))
+	return nil
+}
+
+func (d *DualWrite) ClickHistory(ctx context.Context, id int64, since time.Time) (days []database.DailyClicks, err error) {
+	return d.primary.ClickHistory(ctx, id, since)
+}
+
+func (d *DualWrite) SetClickHistory(ctx context.Context, id int64, days []database.DailyClicks) (err error) {
+	if err := d.primary.SetClickHistory(ctx, id, days); err != nil {
+		return err
+	}
+	d.mirror("set_click_history", id, d.secondary.SetClickHistory(ctx, id, days))
+	return nil
+}
+
+func (d *DualWrite) ConsumeClick(ctx context.Context, id int64) (remaining int64, err error) {
+	remaining, err = d.primary.ConsumeClick(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	_, err = d.secondary.ConsumeClick(ctx, id)
+	// The primary decides whether clicks are left.
+	if errors.Is(err, service.ErrLinkExhausted) {
+		err = nil
+	}
+	d.mirror("consume_click", id, err)
+	return remaining, nil
+}
+
+func (d *DualWrite) UpdateRules(ctx context.Context, id int64, update func([]rules.Rule) ([]rules.Rule, error)) (updated database.Link, err error) {
+	updated, err = d.primary.UpdateRules(ctx, id, update)
+	if err != nil {
+		return database.Link{}, err
+	}
+	// The secondary gets the rules of the primary rather than running update again.
+	_, err = d.secondary.UpdateRules(ctx, id, func([]rules.Rule) ([]rules.Rule, error) {
+		return rules.Clone(updated.Rules), nil
+	})
+	d.mirror("update_rules", id, err)
+	return updated, nil
+}
+
+func (d *DualWrite) RecordVariantClick(ctx context.Context, id int64, variantID string) (err error) {
+	if err := d.primary.RecordVariantClick(ctx, id, variantID); err != nil {
+		return err
+	}
+	d.mirror("record_variant_click", id, d.secondary.RecordVariantClick(ctx, id, variantID))
+	return nil
+}
+
+func (d *DualWrite) VariantClicks(ctx context.Context, id int64) (clicks map[string]int64, err error) {
+	return d.primary.VariantClicks(ctx, id)
+}
+
+func (d *DualWrite) SetVariantClicks(ctx context.Context, id int64, clicks map[string]int64) (err error) {
+	if err := d.primary.SetVariantClicks(ctx, id, clicks); err != nil {
+		return err
+	}
+	d.mirror("set_variant_clicks", id, d.secondary.SetVariantClicks(ctx, id, clicks))
+	return nil
+}
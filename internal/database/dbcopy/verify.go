@@ -0,0 +1,151 @@
+package dbcopy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+)
+
+// Side sums up the links of a storage.
+type Side struct {
+	Links int `json:"links"`
+	// Checksum is a SHA-256 over the links in ID order.
+	Checksum string `json:"checksum"`
+}
+
+// Verification compares the links of two storages. The ID lists hold the first mismatches only.
+type Verification struct {
+	Source Side `json:"source"`
+	Target Side `json:"target"`
+	// Missing links are only in the source, Extra links only in the target, and Different links
+	// are in both but differ.
+	Missing   []int64 `json:"missing,omitempty"`
+	Extra     []int64 `json:"extra,omitempty"`
+	Different []int64 `json:"different,omitempty"`
+}
+
+// OK reports whether both storages hold the same links.
+func (v Verification) OK() bool {
+	return v.Source == v.Target
+}
+
+// Verify compares every link of src and dst, together with the clicks of their split variants
+// and their daily clicks.
+// The time a link was last updated is left out, since storages set it themselves.
+func Verify(ctx context.Context, src, dst database.IUrlRepository, batchSize int) (Verification, error) {
+	var v Verification
+	source := newSummer(src, batchSize)
+	target := newSummer(dst, batchSize)
+	s, t := source.next(ctx), target.next(ctx)
+	for (s != nil || t != nil) && source.err == nil && target.err == nil {
+		switch {
+		case t == nil || (s != nil && s.link.ID < t.link.ID):
+			v.Missing = report(v.Missing, s.link.ID)
+			s = source.next(ctx)
+		case s == nil || t.link.ID < s.link.ID:
+			v.Extra = report(v.Extra, t.link.ID)
+			t = target.next(ctx)
+		default:
+			if s.digest != t.digest {
+				v.Different = report(v.Different, s.link.ID)
+			}
+			s, t = source.next(ctx), target.next(ctx)
+		}
+	}
+	v.Source, v.Target = source.side(), target.side()
+	return v, errors.Join(source.err, target.err)
+}
+
+func report(ids []int64, id int64) []int64 {
+	if len(ids) < maxReported {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// summedLink is a link with its digest.
+type summedLink struct {
+	link   database.Link
+	digest [sha256.Size]byte
+}
+
+// summer reads the links of a repository in ID order, counting and checksumming them.
+type summer struct {
+	repo  database.IUrlRepository
+	pager *pager
+	page  []database.Link
+	count int
+	sum   hash.Hash
+	err   error
+}
+
+func newSummer(repo database.IUrlRepository, batchSize int) *summer {
+	return &summer{repo: repo, pager: newPager(repo, batchSize, 0), sum: sha256.New()}
+}
+
+// next returns the next link, nil at the end or on errors, which are kept in err.
+func (s *summer) next(ctx context.Context) *summedLink {
+	if s.err != nil {
+		return nil
+	}
+	if len(s.page) == 0 {
+		if s.page, s.err = s.pager.nextPage(ctx); s.err != nil || len(s.page) == 0 {
+			return nil
+		}
+	}
+	link := s.page[0]
+	s.page = s.page[1:]
+	clicks, err := readClicks(ctx, s.repo, link)
+	if err != nil {
+		s.err = err
+		return nil
+	}
+	d := digest(link, clicks)
+	s.count++
+	s.sum.Write(d[:])
+	return &summedLink{link: link, digest: d}
+}
+
+func (s *summer) side() Side {
+	return Side{Links: s.count, Checksum: hex.EncodeToString(s.sum.Sum(nil))}
+}
+
+// digest hashes the data of a link that a copy preserves, in a form that does not depend on
+// how a storage represents empty values or the precision of its times.
+func digest(link database.Link, clicks linkClicks) [sha256.Size]byte {
+	link.UpdatedAt = time.Time{}
+	link.CreatedAt = link.CreatedAt.UTC().Truncate(time.Microsecond)
+	if link.ExpiresAt != nil {
+		expires := link.ExpiresAt.UTC().Truncate(time.Microsecond)
+		link.ExpiresAt = &expires
+	}
+	if len(link.Tags) == 0 {
+		link.Tags = nil
+	}
+	if len(link.Rules) == 0 {
+		link.Rules = nil
+	}
+	if len(link.Variants) == 0 {
+		link.Variants = nil
+	}
+	if len(clicks.variants) == 0 {
+		clicks.variants = nil
+	}
+	days := make([]database.DailyClicks, 0, len(clicks.days))
+	for _, day := range clicks.days {
+		days = append(days, database.DailyClicks{Day: database.ClickDay(day.Day), Clicks: day.Clicks})
+	}
+	// Links only hold JSON-encodable values, and maps encode with sorted keys.
+	b, _ := json.Marshal(struct {
+		Link   database.Link
+		Clicks map[string]int64
+		Days   []database.DailyClicks
+	}{link, clicks.variants, days})
+	return sha256.Sum256(b)
+}
@@ -0,0 +1,114 @@
+// Package idalloc hands out link IDs from blocks leased from the storage, so that creating a
+// link does not wait on the ID sequence of the storage, and processes sharing a storage never
+// issue the same ID.
+//
+// A block is leased once the previous one runs out and is never leased again, so IDs stay close
+// to the number of links, and a process that stops or crashes leaves only the rest of its block
+// unused.
+package idalloc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/service"
+)
+
+// DefaultBlockSize is the number of IDs leased at a time.
+const DefaultBlockSize = 1000
+
+// BlockSizeFromEnv reads the block size from ID_BLOCK_SIZE, using DefaultBlockSize for unset or
+// malformed values. 0 turns blocks off.
+func BlockSizeFromEnv() int64 {
+	if n, err := strconv.ParseInt(os.Getenv("ID_BLOCK_SIZE"), 10, 64); err == nil && n >= 0 {
+		return n
+	}
+	return DefaultBlockSize
+}
+
+// Owner names the process in the leases it takes.
+func Owner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s/%d", host, os.Getpid())
+}
+
+// Allocator hands out the IDs of its current block in order and leases the next block when the
+// current one runs out. It is safe for concurrent use.
+type Allocator struct {
+	leaser database.IDBlockLeaser
+	owner  string
+	size   int64
+
+	mu    sync.Mutex
+	block database.IDBlock
+}
+
+func NewAllocator(leaser database.IDBlockLeaser, owner string, size int64) *Allocator {
+	if size <= 0 {
+		size = DefaultBlockSize
+	}
+	return &Allocator{leaser: leaser, owner: owner, size: size}
+}
+
+// Next returns an ID no other allocator or the storage itself issues.
+func (a *Allocator) Next(ctx context.Context) (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.block.Start == a.block.End {
+		block, err := a.leaser.LeaseIDs(ctx, a.owner, a.size)
+		if err != nil {
+			return 0, err
+		}
+		a.block = block
+	}
+	id := a.block.Start
+	a.block.Start++
+	return id, nil
+}
+
+// Repository creates links under IDs from an Allocator and passes everything else through.
+type Repository struct {
+	database.IUrlRepository
+	ids *Allocator
+}
+
+func NewRepository(repo database.IUrlRepository, ids *Allocator) *Repository {
+	return &Repository{IUrlRepository: repo, ids: ids}
+}
+
+func (r *Repository) SaveUrl(ctx context.Context, domain, fullUrl string) (err error) {
+	return r.CreateLink(ctx, &database.Link{Domain: domain, Target: fullUrl})
+}
+
+// CreateLink stores the link under the next ID. An ID can be taken already by a link imported
+// under it, or created from the sequence of the storage while the block was leased; it is then
+// skipped.
+func (r *Repository) CreateLink(ctx context.Context, link *database.Link) (err error) {
+	for {
+		id, err := r.ids.Next(ctx)
+		if err != nil {
+			return err
+		}
+		created := *link
+		created.ID = id
+		created.CreatedAt = time.Time{}
+		err = r.IUrlRepository.CreateLinkWithID(ctx, &created)
+		if errors.Is(err, service.ErrCodeTaken) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		*link = created
+		return nil
+	}
+}
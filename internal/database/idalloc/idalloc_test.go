@@ -0,0 +1,95 @@
+package idalloc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocator(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewInMemoryUrlRepository()
+	a := NewAllocator(repo, "a", 3)
+	b := NewAllocator(repo, "b", 3)
+
+	var ids []int64
+	for _, alloc := range []*Allocator{a, a, b, a, a, b} {
+		id, err := alloc.Next(ctx)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []int64{1, 2, 4, 3, 7, 5}, ids)
+
+	leases := repo.Leases()
+	require.Len(t, leases, 3)
+	assert.Equal(t, database.IDBlock{Start: 1, End: 4}, leases[0].IDBlock)
+	assert.Equal(t, "a", leases[0].Owner)
+	assert.Equal(t, database.IDBlock{Start: 4, End: 7}, leases[1].IDBlock)
+	assert.Equal(t, "b", leases[1].Owner)
+
+	// a restarted process leaves the rest of its block unused
+	restarted := NewAllocator(repo, "a", 3)
+	id, err := restarted.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), id)
+}
+
+func TestAllocator_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewInMemoryUrlRepository()
+	const workers, perWorker = 8, 250
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[int64]bool)
+		wg   sync.WaitGroup
+	)
+	for w := range workers {
+		alloc := NewAllocator(repo, fmt.Sprint(w), 10)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				id, err := alloc.Next(ctx)
+				assert.NoError(t, err)
+				mu.Lock()
+				assert.False(t, seen[id], "ID %d issued twice", id)
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, workers*perWorker)
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	base := inmemory.NewInMemoryUrlRepository()
+	repo := NewRepository(base, NewAllocator(base, "test", 5))
+
+	link := database.Link{Target: "https://example.com/1", Owner: "alice"}
+	require.NoError(t, repo.CreateLink(ctx, &link))
+	assert.Equal(t, int64(1), link.ID)
+	assert.False(t, link.CreatedAt.IsZero())
+	assert.Equal(t, "alice", link.Owner)
+
+	// IDs of the block taken by imports are skipped
+	require.NoError(t, base.ImportLink(ctx, &database.Link{ID: 2, Target: "https://example.com/imported"}))
+	require.NoError(t, repo.SaveUrl(ctx, "", "https://example.com/2"))
+	id, err := repo.GetID(ctx, "", "https://example.com/2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+
+	// links created without the allocator get IDs after the block
+	other := database.Link{Target: "https://example.com/other"}
+	require.NoError(t, base.CreateLink(ctx, &other))
+	assert.Equal(t, int64(6), other.ID)
+}
@@ -0,0 +1,43 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+)
+
+// record appends a change to the log. Must be called with the write lock held, so that
+// the change is logged atomically with the mutation and in the order of mutations.
+func (m *InMemoryUrlRepository) record(typ database.ChangeType, link database.Link, fields []database.LinkField) {
+	m.changes = append(m.changes, database.Change{
+		Seq:    int64(len(m.changes)) + 1,
+		Type:   typ,
+		Link:   cloneLink(database.Redacted(link)),
+		Fields: slices.Clone(fields),
+		Time:   time.Now().UTC(),
+	})
+}
+
+func (m *InMemoryUrlRepository) Changes(ctx context.Context, afterSeq int64, limit int) (changes []database.Change, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	start := min(max(afterSeq, 0), int64(len(m.changes)))
+	end := int64(len(m.changes))
+	if limit > 0 {
+		end = min(end, start+int64(limit))
+	}
+	for _, change := range m.changes[start:end] {
+		change.Link = cloneLink(change.Link)
+		change.Fields = slices.Clone(change.Fields)
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func (m *InMemoryUrlRepository) LastSeq(ctx context.Context) (seq int64, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return int64(len(m.changes)), nil
+}
@@ -1,17 +1,31 @@
 package inmemory
 
 import (
+	"cmp"
 	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/rules"
 	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
 )
 
 type InMemoryDBService struct {
+	repo     *InMemoryUrlRepository
+	webhooks *InMemoryWebhookStore
 }
 
 func NewInMemoryDBService() *InMemoryDBService {
-	return &InMemoryDBService{}
+	return &InMemoryDBService{
+		repo:     NewInMemoryUrlRepository(),
+		webhooks: NewInMemoryWebhookStore(),
+	}
 }
 
 func (m *InMemoryDBService) Health() map[string]string {
@@ -21,6 +35,14 @@ func (m *InMemoryDBService) Health() map[string]string {
 	return stats
 }
 
+func (m *InMemoryDBService) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *InMemoryDBService) SchemaVersion(ctx context.Context) (int64, error) {
+	return database.SchemaVersion, nil
+}
+
 func (m *InMemoryDBService) Close() error {
 	return nil
 }
@@ -28,40 +50,565 @@ func (m *InMemoryDBService) Close() error {
 func (m *InMemoryDBService) SyncDB() {
 }
 
+// NewUrlRepository returns a repository over the storage shared by every caller.
 func (m *InMemoryDBService) NewUrlRepository() database.IUrlRepository {
-	return NewInMemoryUrlRepository()
+	return m.repo
+}
+
+// NewChangeLog returns the change log of the repository returned by NewUrlRepository.
+func (m *InMemoryDBService) NewChangeLog() database.IChangeLog {
+	return m.repo
+}
+
+// NewWebhookStore returns a webhook store over the storage shared by every caller.
+func (m *InMemoryDBService) NewWebhookStore() webhooks.Store {
+	return m.webhooks
 }
 
 type InMemoryUrlRepository struct {
-	urlToId map[string]int64
-	idToUrl map[int64]string
+	mu     sync.RWMutex
+	nextID int64
+	// byTarget indexes link IDs, ascending, by domain and target.
+	byTarget map[targetKey][]int64
+	links    map[int64]database.Link
+	// ids and byCreated are sorted indexes for keyset pagination:
+	// link IDs ordered by ID and by (CreatedAt, ID).
+	ids       []int64
+	byCreated []int64
+	// tagged and campaigns index link IDs by tag and by campaign.
+	tagged    map[string]map[int64]struct{}
+	campaigns map[string]map[int64]struct{}
+	// variantClicks counts resolves by link ID and split variant ID.
+	variantClicks map[int64]map[string]int64
+	// clickDays counts resolves by link ID and UTC day.
+	clickDays map[int64]map[time.Time]int64
+	// changes is the change log, changes[i] has the sequence number i+1.
+	changes []database.Change
+	// leases are the ID blocks leased, in the order they were.
+	leases []IDLease
+}
+
+// IDLease records a block of link IDs leased to an owner.
+type IDLease struct {
+	database.IDBlock
+	Owner    string
+	LeasedAt time.Time
 }
 
 func NewInMemoryUrlRepository() *InMemoryUrlRepository {
 	return &InMemoryUrlRepository{
-		urlToId: make(map[string]int64),
-		idToUrl: make(map[int64]string),
+		nextID:   1,
+		byTarget: make(map[targetKey][]int64),
+		links:    make(map[int64]database.Link),
+
+		tagged:        make(map[string]map[int64]struct{}),
+		campaigns:     make(map[string]map[int64]struct{}),
+		variantClicks: make(map[int64]map[string]int64),
+		clickDays:     make(map[int64]map[time.Time]int64),
 	}
 }
 
-func (m *InMemoryUrlRepository) GetID(ctx context.Context, fullUrl string) (id int64, err error) {
-	v, exists := m.urlToId[fullUrl]
-	if !exists {
-		return 0, service.ErrUrlNotFound
+// targetKey indexes the links of a target on a domain.
+type targetKey struct {
+	domain string
+	target string
+}
+
+func keyOf(link database.Link) targetKey {
+	return targetKey{domain: link.Domain, target: link.Target}
+}
+
+func (m *InMemoryUrlRepository) GetID(ctx context.Context, domain, fullUrl string) (id int64, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, id := range m.byTarget[targetKey{domain: domain, target: fullUrl}] {
+		if m.links[id].Plain() {
+			return id, nil
+		}
 	}
-	return v, nil
+	return 0, service.ErrUrlNotFound
 }
 
 func (m *InMemoryUrlRepository) GetUrlByID(ctx context.Context, id int64) (fullUrl string, err error) {
-	v, exists := m.idToUrl[id]
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, exists := m.links[id]
 	if !exists {
 		return "", service.ErrUrlNotFound
 	}
-	return v, nil
+	return v.Target, nil
+}
+
+func (m *InMemoryUrlRepository) SaveUrl(ctx context.Context, domain, fullUrl string) (err error) {
+	return m.CreateLink(ctx, &database.Link{Domain: domain, Target: fullUrl})
+}
+
+func (m *InMemoryUrlRepository) CreateLink(ctx context.Context, link *database.Link) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now().UTC()
+	link.ID = m.nextID
+	link.CreatedAt = now
+	link.UpdatedAt = now
+	m.insert(*link)
+	return nil
+}
+
+func (m *InMemoryUrlRepository) ImportLink(ctx context.Context, link *database.Link) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if link.ID == 0 {
+		link.ID = m.nextID
+	} else if _, exists := m.links[link.ID]; exists {
+		return service.ErrCodeTaken
+	}
+	now := time.Now().UTC()
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = now
+	}
+	link.UpdatedAt = now
+	m.insert(*link)
+	return nil
+}
+
+// CreateLinkWithID stores the link like ImportLink; the leased block is taken from the ID
+// sequence already.
+func (m *InMemoryUrlRepository) CreateLinkWithID(ctx context.Context, link *database.Link) (err error) {
+	return m.ImportLink(ctx, link)
+}
+
+// insert stores a new link and indexes it. Must be called with the write lock held.
+func (m *InMemoryUrlRepository) insert(link database.Link) {
+	m.nextID = max(m.nextID, link.ID+1)
+	m.links[link.ID] = cloneLink(link)
+	i, _ := slices.BinarySearch(m.ids, link.ID)
+	m.ids = slices.Insert(m.ids, i, link.ID)
+	m.insertByCreated(link.ID)
+	m.indexTarget(keyOf(link), link.ID)
+	m.index(link)
+	m.record(database.ChangeCreated, link, nil)
+}
+
+func (m *InMemoryUrlRepository) GetLink(ctx context.Context, id int64) (link database.Link, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, exists := m.links[id]
+	if !exists {
+		return database.Link{}, service.ErrUrlNotFound
+	}
+	return cloneLink(v), nil
+}
+
+func (m *InMemoryUrlRepository) UpdateLink(ctx context.Context, link database.Link, fields []database.LinkField) (updated database.Link, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, exists := m.links[link.ID]
+	if !exists {
+		return database.Link{}, service.ErrUrlNotFound
+	}
+	oldTarget := stored.Target
+	m.unindex(stored)
+	for _, field := range fields {
+		switch field {
+		case database.LinkFieldTarget:
+			stored.Target = link.Target
+		case database.LinkFieldOwner:
+			stored.Owner = link.Owner
+		case database.LinkFieldTags:
+			stored.Tags = slices.Clone(link.Tags)
+		case database.LinkFieldCampaign:
+			stored.Campaign = link.Campaign
+		case database.LinkFieldDisabled:
+			stored.Disabled = link.Disabled
+		case database.LinkFieldExpiresAt:
+			stored.ExpiresAt = link.ExpiresAt
+		case database.LinkFieldPassword:
+			stored.PasswordHash = link.PasswordHash
+		case database.LinkFieldMaxClicks:
+			stored.MaxClicks = link.MaxClicks
+			stored.RemainingClicks = link.MaxClicks
+		case database.LinkFieldVariants:
+			stored.Variants = slices.Clone(link.Variants)
+		case database.LinkFieldSplitMode:
+			stored.SplitMode = link.SplitMode
+		case database.LinkFieldPassthrough:
+			stored.Passthrough = link.Passthrough
+		case database.LinkFieldTemplate:
+			stored.Template = link.Template
+		}
+	}
+	stored.UpdatedAt = time.Now().UTC()
+	m.links[stored.ID] = stored
+	m.index(stored)
+
+	if oldTarget != stored.Target {
+		m.unindexTarget(targetKey{domain: stored.Domain, target: oldTarget}, stored.ID)
+		m.indexTarget(keyOf(stored), stored.ID)
+	}
+	m.record(database.ChangeUpdated, stored, fields)
+	return cloneLink(stored), nil
+}
+
+func (m *InMemoryUrlRepository) DeleteLink(ctx context.Context, id int64) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, exists := m.links[id]
+	if !exists {
+		return service.ErrUrlNotFound
+	}
+	delete(m.links, id)
+	if i, found := slices.BinarySearch(m.ids, id); found {
+		m.ids = slices.Delete(m.ids, i, i+1)
+	}
+	m.byCreated = slices.DeleteFunc(m.byCreated, func(v int64) bool { return v == id })
+	m.unindexTarget(keyOf(stored), id)
+	m.unindex(stored)
+	delete(m.variantClicks, id)
+	delete(m.clickDays, id)
+	m.record(database.ChangeDeleted, stored, nil)
+	return nil
+}
+
+func (m *InMemoryUrlRepository) UpdateTags(ctx context.Context, domain string, ids []int64, add, remove []string) (updated []database.Link, found []int64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now().UTC()
+	for _, id := range ids {
+		stored, exists := m.links[id]
+		if !exists || stored.Domain != domain || slices.Contains(found, id) {
+			continue
+		}
+		found = append(found, id)
+		tags := database.EditTags(stored.Tags, add, remove)
+		if slices.Equal(tags, stored.Tags) {
+			continue
+		}
+		m.unindex(stored)
+		stored.Tags = tags
+		stored.UpdatedAt = now
+		m.links[id] = stored
+		m.index(stored)
+		m.record(database.ChangeUpdated, stored, []database.LinkField{database.LinkFieldTags})
+		updated = append(updated, cloneLink(stored))
+	}
+	return updated, found, nil
+}
+
+func (m *InMemoryUrlRepository) CampaignStats(ctx context.Context, domain string) (stats []database.CampaignStats, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for campaign, ids := range m.campaigns {
+		s := database.CampaignStats{Campaign: campaign}
+		for id := range ids {
+			if link := m.links[id]; link.Domain == domain {
+				s.Links++
+				s.Clicks += link.Clicks
+			}
+		}
+		if s.Links > 0 {
+			stats = append(stats, s)
+		}
+	}
+	slices.SortFunc(stats, func(a, b database.CampaignStats) int { return strings.Compare(a.Campaign, b.Campaign) })
+	return stats, nil
+}
+
+func (m *InMemoryUrlRepository) RecordClick(ctx context.Context, id int64, at time.Time) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, exists := m.links[id]
+	if !exists {
+		return service.ErrUrlNotFound
+	}
+	stored.Clicks++
+	m.links[id] = stored
+	if m.clickDays[id] == nil {
+		m.clickDays[id] = make(map[time.Time]int64)
+	}
+	m.clickDays[id][database.ClickDay(at)]++
+	return nil
+}
+
+func (m *InMemoryUrlRepository) ClickHistory(ctx context.Context, id int64, since time.Time) (days []database.DailyClicks, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if _, exists := m.links[id]; !exists {
+		return nil, service.ErrUrlNotFound
+	}
+	since = database.ClickDay(since)
+	days = []database.DailyClicks{}
+	for day, n := range m.clickDays[id] {
+		if !day.Before(since) {
+			days = append(days, database.DailyClicks{Day: day, Clicks: n})
+		}
+	}
+	slices.SortFunc(days, func(a, b database.DailyClicks) int { return a.Day.Compare(b.Day) })
+	return days, nil
 }
 
-func (m *InMemoryUrlRepository) SaveUrl(ctx context.Context, fullUrl string) (err error) {
-	m.urlToId[fullUrl] = int64(len(m.urlToId))
-	m.idToUrl[m.urlToId[fullUrl]] = fullUrl
+func (m *InMemoryUrlRepository) SetClickHistory(ctx context.Context, id int64, days []database.DailyClicks) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.links[id]; !exists {
+		return service.ErrUrlNotFound
+	}
+	stored := make(map[time.Time]int64, len(days))
+	for _, day := range days {
+		stored[database.ClickDay(day.Day)] += day.Clicks
+	}
+	m.clickDays[id] = stored
 	return nil
 }
+
+func (m *InMemoryUrlRepository) ConsumeClick(ctx context.Context, id int64) (remaining int64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, exists := m.links[id]
+	if !exists {
+		return 0, service.ErrUrlNotFound
+	}
+	if stored.MaxClicks == 0 {
+		return -1, nil
+	}
+	if stored.RemainingClicks <= 0 {
+		return 0, service.ErrLinkExhausted
+	}
+	stored.RemainingClicks--
+	m.links[id] = stored
+	m.record(database.ChangeUpdated, stored, []database.LinkField{database.LinkFieldRemainingClicks})
+	return stored.RemainingClicks, nil
+}
+
+func (m *InMemoryUrlRepository) UpdateRules(ctx context.Context, id int64, update func([]rules.Rule) ([]rules.Rule, error)) (updated database.Link, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, exists := m.links[id]
+	if !exists {
+		return database.Link{}, service.ErrUrlNotFound
+	}
+	updatedRules, err := update(rules.Clone(stored.Rules))
+	if err != nil {
+		return database.Link{}, err
+	}
+	stored.Rules = rules.Clone(updatedRules)
+	stored.UpdatedAt = time.Now().UTC()
+	m.links[id] = stored
+	m.record(database.ChangeUpdated, stored, []database.LinkField{database.LinkFieldRules})
+	return cloneLink(stored), nil
+}
+
+func (m *InMemoryUrlRepository) RecordVariantClick(ctx context.Context, id int64, variantID string) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.links[id]; !exists {
+		return service.ErrUrlNotFound
+	}
+	if m.variantClicks[id] == nil {
+		m.variantClicks[id] = make(map[string]int64)
+	}
+	m.variantClicks[id][variantID]++
+	return nil
+}
+
+func (m *InMemoryUrlRepository) VariantClicks(ctx context.Context, id int64) (clicks map[string]int64, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if _, exists := m.links[id]; !exists {
+		return nil, service.ErrUrlNotFound
+	}
+	clicks = make(map[string]int64, len(m.variantClicks[id]))
+	for variantID, n := range m.variantClicks[id] {
+		clicks[variantID] = n
+	}
+	return clicks, nil
+}
+
+func (m *InMemoryUrlRepository) SetVariantClicks(ctx context.Context, id int64, clicks map[string]int64) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.links[id]; !exists {
+		return service.ErrUrlNotFound
+	}
+	stored := make(map[string]int64, len(clicks))
+	for variantID, n := range clicks {
+		stored[variantID] = n
+	}
+	m.variantClicks[id] = stored
+	return nil
+}
+
+func (m *InMemoryUrlRepository) LastID(ctx context.Context) (id int64, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.nextID - 1, nil
+}
+
+func (m *InMemoryUrlRepository) AdvanceIDs(ctx context.Context, id int64) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID = max(m.nextID, id+1)
+	return nil
+}
+
+// LeaseIDs takes the block from the ID sequence, so links created without it never get its IDs.
+func (m *InMemoryUrlRepository) LeaseIDs(ctx context.Context, owner string, size int64) (block database.IDBlock, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	block = database.IDBlock{Start: m.nextID, End: m.nextID + size}
+	m.nextID = block.End
+	m.leases = append(m.leases, IDLease{IDBlock: block, Owner: owner, LeasedAt: time.Now().UTC()})
+	return block, nil
+}
+
+// Leases returns the ID blocks leased so far.
+func (m *InMemoryUrlRepository) Leases() []IDLease {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.leases)
+}
+
+func (m *InMemoryUrlRepository) ListLinks(ctx context.Context, filter database.ListLinksFilter) (links []database.Link, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	index := m.ids
+	if filter.Sort.ByCreatedAt() {
+		index = m.byCreated
+	}
+	if ids, ok := m.filtered(filter); ok {
+		index = ids
+	}
+	n := len(index)
+	desc := filter.Sort.Desc()
+
+	// Find where the page starts with a binary search over the sorted index,
+	// then walk it in the requested direction.
+	pos, step := 0, 1
+	if desc {
+		pos, step = n-1, -1
+	}
+	if filter.After != nil {
+		cursor := *filter.After
+		if desc {
+			pos = sort.Search(n, func(i int) bool { return !cursor.After(m.links[index[i]], filter.Sort) }) - 1
+		} else {
+			pos = sort.Search(n, func(i int) bool { return cursor.After(m.links[index[i]], filter.Sort) })
+		}
+	}
+	for ; pos >= 0 && pos < n; pos += step {
+		if filter.Limit > 0 && len(links) >= filter.Limit {
+			break
+		}
+		link := m.links[index[pos]]
+		if filter.Matches(link) {
+			links = append(links, cloneLink(link))
+		}
+	}
+	return links, nil
+}
+
+// filtered returns the IDs of the links with the tag and campaign of the filter, if it selects
+// either, sorted for its order. Must be called with the lock held.
+func (m *InMemoryUrlRepository) filtered(filter database.ListLinksFilter) ([]int64, bool) {
+	var sets []map[int64]struct{}
+	if filter.Tag != "" {
+		sets = append(sets, m.tagged[filter.Tag])
+	}
+	if filter.Campaign != "" {
+		sets = append(sets, m.campaigns[filter.Campaign])
+	}
+	if len(sets) == 0 {
+		return nil, false
+	}
+	// The other one is checked by filter.Matches.
+	smallest := slices.MinFunc(sets, func(a, b map[int64]struct{}) int { return cmp.Compare(len(a), len(b)) })
+	ids := slices.Collect(maps.Keys(smallest))
+	if filter.Sort.ByCreatedAt() {
+		slices.SortFunc(ids, func(a, b int64) int {
+			return cmp.Or(m.links[a].CreatedAt.Compare(m.links[b].CreatedAt), cmp.Compare(a, b))
+		})
+	} else {
+		slices.Sort(ids)
+	}
+	return ids, true
+}
+
+// index adds the link to the tag and campaign indexes. Must be called with the write lock held.
+func (m *InMemoryUrlRepository) index(link database.Link) {
+	add := func(index map[string]map[int64]struct{}, key string) {
+		if index[key] == nil {
+			index[key] = make(map[int64]struct{})
+		}
+		index[key][link.ID] = struct{}{}
+	}
+	for _, tag := range link.Tags {
+		add(m.tagged, tag)
+	}
+	if link.Campaign != "" {
+		add(m.campaigns, link.Campaign)
+	}
+}
+
+// unindex removes the link from the tag and campaign indexes. Must be called with the write lock held.
+func (m *InMemoryUrlRepository) unindex(link database.Link) {
+	remove := func(index map[string]map[int64]struct{}, key string) {
+		delete(index[key], link.ID)
+		if len(index[key]) == 0 {
+			delete(index, key)
+		}
+	}
+	for _, tag := range link.Tags {
+		remove(m.tagged, tag)
+	}
+	if link.Campaign != "" {
+		remove(m.campaigns, link.Campaign)
+	}
+}
+
+// insertByCreated adds id to the (CreatedAt, ID) index. Must be called with the write lock held.
+func (m *InMemoryUrlRepository) insertByCreated(id int64) {
+	link := m.links[id]
+	cursor := database.LinkCursor{ID: link.ID, CreatedAt: link.CreatedAt}
+	i := sort.Search(len(m.byCreated), func(i int) bool {
+		return cursor.After(m.links[m.byCreated[i]], database.SortCreatedAtAsc)
+	})
+	m.byCreated = slices.Insert(m.byCreated, i, id)
+}
+
+// indexTarget and unindexTarget add and remove a link ID from the links of key. Must be called
+// with the write lock held.
+func (m *InMemoryUrlRepository) indexTarget(key targetKey, id int64) {
+	ids := m.byTarget[key]
+	if i, found := slices.BinarySearch(ids, id); !found {
+		m.byTarget[key] = slices.Insert(ids, i, id)
+	}
+}
+
+func (m *InMemoryUrlRepository) unindexTarget(key targetKey, id int64) {
+	ids := m.byTarget[key]
+	if i, found := slices.BinarySearch(ids, id); found {
+		ids = slices.Delete(ids, i, i+1)
+	}
+	if len(ids) == 0 {
+		delete(m.byTarget, key)
+		return
+	}
+	m.byTarget[key] = ids
+}
+
+func cloneLink(link database.Link) database.Link {
+	link.Tags = slices.Clone(link.Tags)
+	link.Rules = rules.Clone(link.Rules)
+	link.Variants = slices.Clone(link.Variants)
+	if link.ExpiresAt != nil {
+		expiresAt := *link.ExpiresAt
+		link.ExpiresAt = &expiresAt
+	}
+	if link.Template != nil {
+		template := *link.Template
+		link.Template = &template
+	}
+	return link
+}
@@ -0,0 +1,144 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+)
+
+type InMemoryWebhookStore struct {
+	mu            sync.Mutex
+	subscriptions map[string]webhooks.Subscription
+	// subscriptionIDs and deliveryIDs keep insertion order.
+	subscriptionIDs []string
+	deliveries      map[string]webhooks.Delivery
+	deliveryIDs     []string
+}
+
+func NewInMemoryWebhookStore() *InMemoryWebhookStore {
+	return &InMemoryWebhookStore{
+		subscriptions: make(map[string]webhooks.Subscription),
+		deliveries:    make(map[string]webhooks.Delivery),
+	}
+}
+
+func (m *InMemoryWebhookStore) CreateSubscription(ctx context.Context, sub webhooks.Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub.Events = slices.Clone(sub.Events)
+	m.subscriptions[sub.ID] = sub
+	m.subscriptionIDs = append(m.subscriptionIDs, sub.ID)
+	return nil
+}
+
+func (m *InMemoryWebhookStore) GetSubscription(ctx context.Context, id string) (webhooks.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sub, ok := m.subscriptions[id]
+	if !ok {
+		return webhooks.Subscription{}, webhooks.ErrSubscriptionNotFound
+	}
+	sub.Events = slices.Clone(sub.Events)
+	return sub, nil
+}
+
+func (m *InMemoryWebhookStore) ListSubscriptions(ctx context.Context) ([]webhooks.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subs := make([]webhooks.Subscription, 0, len(m.subscriptionIDs))
+	for _, id := range m.subscriptionIDs {
+		sub := m.subscriptions[id]
+		sub.Events = slices.Clone(sub.Events)
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func (m *InMemoryWebhookStore) DeleteSubscription(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subscriptions[id]; !ok {
+		return webhooks.ErrSubscriptionNotFound
+	}
+	delete(m.subscriptions, id)
+	m.subscriptionIDs = slices.DeleteFunc(m.subscriptionIDs, func(s string) bool { return s == id })
+	m.deliveryIDs = slices.DeleteFunc(m.deliveryIDs, func(d string) bool {
+		if m.deliveries[d].SubscriptionID != id {
+			return false
+		}
+		delete(m.deliveries, d)
+		return true
+	})
+	return nil
+}
+
+func (m *InMemoryWebhookStore) CreateDeliveries(ctx context.Context, deliveries []webhooks.Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range deliveries {
+		m.deliveries[d.ID] = d
+		m.deliveryIDs = append(m.deliveryIDs, d.ID)
+	}
+	return nil
+}
+
+func (m *InMemoryWebhookStore) GetDelivery(ctx context.Context, id string) (webhooks.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.deliveries[id]
+	if !ok {
+		return webhooks.Delivery{}, webhooks.ErrDeliveryNotFound
+	}
+	return d, nil
+}
+
+func (m *InMemoryWebhookStore) ListDeliveries(ctx context.Context, subscriptionID string, status webhooks.DeliveryStatus, limit int) ([]webhooks.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []webhooks.Delivery
+	for _, id := range slices.Backward(m.deliveryIDs) {
+		d := m.deliveries[id]
+		if d.SubscriptionID != subscriptionID || (status != "" && d.Status != status) {
+			continue
+		}
+		deliveries = append(deliveries, d)
+		if len(deliveries) == limit {
+			break
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *InMemoryWebhookStore) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhooks.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []webhooks.Delivery
+	for _, id := range m.deliveryIDs {
+		d := m.deliveries[id]
+		if d.Status == webhooks.StatusPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b webhooks.Delivery) int { return a.NextAttemptAt.Compare(b.NextAttemptAt) })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	for _, d := range due {
+		d.NextAttemptAt = now.Add(lease)
+		m.deliveries[d.ID] = d
+	}
+	return due, nil
+}
+
+func (m *InMemoryWebhookStore) UpdateDelivery(ctx context.Context, delivery webhooks.Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.deliveries[delivery.ID]; !ok {
+		return webhooks.ErrDeliveryNotFound
+	}
+	m.deliveries[delivery.ID] = delivery
+	return nil
+}
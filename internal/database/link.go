@@ -0,0 +1,281 @@
+package database
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+)
+
+// Link is a stored short link. Its public code is derived from ID.
+type Link struct {
+	ID int64
+	// Domain is the name of the domain the link is served from, empty for the default domain.
+	// Codes are encoded per domain, so the domain of a link never changes.
+	Domain string
+	Target string
+	Owner  string
+	Tags   []string
+	// Campaign is the campaign or folder the link is filed under, empty for none.
+	Campaign  string
+	Disabled  bool
+	ExpiresAt *time.Time
+	// PasswordHash is the bcrypt hash of the link password. Empty means the link is not protected.
+	PasswordHash string
+	// MaxClicks limits how many times the link resolves. Zero means unlimited.
+	MaxClicks int64
+	// RemainingClicks is how many resolves are left when MaxClicks is set.
+	RemainingClicks int64
+	// Rules are evaluated in order on resolve. The first matching one overrides Target.
+	Rules []rules.Rule
+	// Variants split the traffic of the link across several targets. Without a matching
+	// rule, every resolve picks one of them instead of Target.
+	Variants  []split.Variant
+	SplitMode split.Mode
+	// Passthrough carries the query and trailing path of requests over to the resolved target.
+	Passthrough passthrough.Options
+	// Template makes Target a template filled from the trailing path and query of requests.
+	// Nil for links with an exact target.
+	Template *urltemplate.Template
+	// Clicks counts the resolves of the link.
+	Clicks    int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Exhausted reports whether the link has used up its clicks.
+func (l Link) Exhausted() bool {
+	return l.MaxClicks > 0 && l.RemainingClicks <= 0
+}
+
+// Concealed returns a password protected link without its targets: Target and the targets of its
+// rules and variants are cleared. Links without a password are returned as they are.
+func (l Link) Concealed() Link {
+	if l.PasswordHash == "" {
+		return l
+	}
+	l.Target = ""
+	l.Rules = slices.Clone(l.Rules)
+	for i := range l.Rules {
+		l.Rules[i].Target = ""
+	}
+	l.Variants = slices.Clone(l.Variants)
+	for i := range l.Variants {
+		l.Variants[i].Target = ""
+	}
+	return l
+}
+
+// Plain reports whether the link is nothing but a target, like the links of v1 creates: it is
+// active, unowned and untagged, has no password, click limit or expiry, and always resolves to
+// Target for every request. Creates of the same target share plain links only.
+func (l Link) Plain() bool {
+	return !l.Disabled && l.ExpiresAt == nil && l.PasswordHash == "" && l.MaxClicks == 0 &&
+		l.Owner == "" && len(l.Tags) == 0 && l.Campaign == "" &&
+		len(l.Rules) == 0 && len(l.Variants) == 0 && l.Passthrough.IsZero() && l.Template == nil
+}
+
+// LinkField names a mutable Link field for partial updates.
+type LinkField string
+
+const (
+	LinkFieldTarget    LinkField = "target"
+	LinkFieldOwner     LinkField = "owner"
+	LinkFieldTags      LinkField = "tags"
+	LinkFieldCampaign  LinkField = "campaign"
+	LinkFieldDisabled  LinkField = "disabled"
+	LinkFieldExpiresAt LinkField = "expires_at"
+	LinkFieldPassword  LinkField = "password"
+	// LinkFieldMaxClicks also resets RemainingClicks to the new MaxClicks.
+	LinkFieldMaxClicks   LinkField = "max_clicks"
+	LinkFieldVariants    LinkField = "variants"
+	LinkFieldSplitMode   LinkField = "split_mode"
+	LinkFieldPassthrough LinkField = "passthrough"
+	LinkFieldTemplate    LinkField = "template"
+)
+
+// LinkFields lists every field that can be updated.
+var LinkFields = []LinkField{
+	LinkFieldTarget,
+	LinkFieldOwner,
+	LinkFieldTags,
+	LinkFieldCampaign,
+	LinkFieldDisabled,
+	LinkFieldExpiresAt,
+	LinkFieldPassword,
+	LinkFieldMaxClicks,
+	LinkFieldVariants,
+	LinkFieldSplitMode,
+	LinkFieldPassthrough,
+	LinkFieldTemplate,
+}
+
+// Fields changed by the storage itself, which only appear in Change.Fields.
+const (
+	// LinkFieldRules is changed by UpdateRules.
+	LinkFieldRules LinkField = "rules"
+	// LinkFieldRemainingClicks is changed by ConsumeClick.
+	LinkFieldRemainingClicks LinkField = "remaining_clicks"
+)
+
+// LinkSort orders ListLinks results.
+type LinkSort string
+
+const (
+	SortIDAsc         LinkSort = "id"
+	SortIDDesc        LinkSort = "-id"
+	SortCreatedAtAsc  LinkSort = "created_at"
+	SortCreatedAtDesc LinkSort = "-created_at"
+)
+
+// Valid reports whether s is a known sort order. The empty sort means SortIDAsc.
+func (s LinkSort) Valid() bool {
+	switch s {
+	case "", SortIDAsc, SortIDDesc, SortCreatedAtAsc, SortCreatedAtDesc:
+		return true
+	}
+	return false
+}
+
+// Desc reports whether s is a descending order.
+func (s LinkSort) Desc() bool {
+	return s == SortIDDesc || s == SortCreatedAtDesc
+}
+
+// ByCreatedAt reports whether s orders by creation time (with ID as a tie-breaker).
+func (s LinkSort) ByCreatedAt() bool {
+	return s == SortCreatedAtAsc || s == SortCreatedAtDesc
+}
+
+// LinkCursor is the sort key of the last link of the previous page.
+type LinkCursor struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+// After reports whether link comes strictly after the cursor in the given order.
+func (c LinkCursor) After(link Link, sort LinkSort) bool {
+	var cmp int
+	if sort.ByCreatedAt() && !link.CreatedAt.Equal(c.CreatedAt) {
+		cmp = link.CreatedAt.Compare(c.CreatedAt)
+	} else {
+		cmp = compareInt64(link.ID, c.ID)
+	}
+	if sort.Desc() {
+		return cmp < 0
+	}
+	return cmp > 0
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// ListLinksFilter selects a page of links.
+type ListLinksFilter struct {
+	// After is the keyset cursor: only links after it in Sort order are returned.
+	After *LinkCursor
+	// Limit is the maximum number of links to return.
+	Limit int
+	Sort  LinkSort
+
+	Owner    string
+	Tag      string
+	Campaign string
+	// IncludeDisabled also returns disabled links.
+	IncludeDisabled bool
+	// TargetDomain matches the host of the target exactly.
+	TargetDomain string
+	// CreatedAfter and CreatedBefore bound the creation time: [CreatedAfter, CreatedBefore).
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// TargetContains matches a case-insensitive substring of the target.
+	TargetContains string
+	// Domain matches the domain of the link exactly if set.
+	Domain *string
+}
+
+// Matches reports whether link passes the filter, ignoring the cursor and limit.
+func (f ListLinksFilter) Matches(link Link) bool {
+	if f.Domain != nil && link.Domain != *f.Domain {
+		return false
+	}
+	if f.Owner != "" && link.Owner != f.Owner {
+		return false
+	}
+	if f.Campaign != "" && link.Campaign != f.Campaign {
+		return false
+	}
+	if link.Disabled && !f.IncludeDisabled {
+		return false
+	}
+	if f.TargetDomain != "" && TargetDomain(link.Target) != strings.ToLower(f.TargetDomain) {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && link.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !link.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	if f.TargetContains != "" && !strings.Contains(strings.ToLower(link.Target), strings.ToLower(f.TargetContains)) {
+		return false
+	}
+	if f.Tag != "" {
+		return slices.Contains(link.Tags, f.Tag)
+	}
+	return true
+}
+
+// CampaignStats aggregates the links of a campaign.
+type CampaignStats struct {
+	Campaign string
+	// Links is the number of links in the campaign, disabled ones included.
+	Links int64
+	// Clicks sums the clicks of the links in the campaign.
+	Clicks int64
+}
+
+// DailyClicks counts the resolves of a link on one UTC day.
+type DailyClicks struct {
+	// Day is midnight UTC of the day.
+	Day    time.Time
+	Clicks int64
+}
+
+// ClickDay returns midnight UTC of the day of t.
+func ClickDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// EditTags returns tags with add appended and remove taken out, keeping the order of the tags
+// and ignoring the ones it has already.
+func EditTags(tags, add, remove []string) []string {
+	result := make([]string, 0, len(tags)+len(add))
+	for _, tag := range slices.Concat(tags, add) {
+		if !slices.Contains(remove, tag) && !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// TargetDomain returns the lower-cased host of a target URL without the port.
+func TargetDomain(target string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
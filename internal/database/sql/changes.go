@@ -0,0 +1,51 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// outboxLockKey is the transaction-level advisory lock serializing outbox writes. Held from the
+// insert to the commit, it makes sequence numbers commit in order, so a reader that has seen
+// a sequence number never misses a smaller one committing later.
+const outboxLockKey int64 = 0x6f7574626f78
+
+// recordChange writes a link mutation to the outbox. It must be the last statement of the
+// transaction of the mutation, to keep the outbox lock short.
+func recordChange(tx *gorm.DB, typ database.ChangeType, link database.Link, fields []database.LinkField) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", outboxLockKey).Error; err != nil {
+		return err
+	}
+	return tx.Create(&OutboxEntry{Type: typ, LinkId: link.ID, Link: database.Redacted(link), Fields: fields}).Error
+}
+
+type ChangeLogPG struct {
+	db dbService
+}
+
+func NewChangeLogPG(db dbService) *ChangeLogPG {
+	return &ChangeLogPG{db: db}
+}
+
+func (c *ChangeLogPG) Changes(ctx context.Context, afterSeq int64, limit int) (changes []database.Change, err error) {
+	query := gorm.G[OutboxEntry](c.db.db).Where("seq > ?", afterSeq).Order("seq")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	entries, err := query.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		changes = append(changes, entry.toChange())
+	}
+	return changes, nil
+}
+
+func (c *ChangeLogPG) LastSeq(ctx context.Context) (seq int64, err error) {
+	err = c.db.db.WithContext(ctx).Model(&OutboxEntry{}).Select("COALESCE(MAX(seq), 0)").Scan(&seq).Error
+	return seq, err
+}
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -9,15 +10,22 @@ import (
 	"time"
 
 	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/joho/godotenv/autoload"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 )
 
 type dbService struct {
+	// db is the primary, which takes every write.
 	db *gorm.DB
+	// replicas take the reads of links, nil without replicas.
+	replicas *replicaSet
+	// searchIndexErr is why SyncDB could not index target search, reported by Health.
+	searchIndexErr error
 }
 
 var (
@@ -44,12 +52,53 @@ func New() *dbService {
 	dbInstance = &dbService{
 		db: db,
 	}
+	if cfg := replicaConfigFromEnv(); len(cfg.DSNs) > 0 {
+		dbInstance.replicas = openReplicas(cfg)
+	}
 	return dbInstance
 }
+
+// reader returns the connection reads of links go to: a healthy replica, or the primary if there
+// is none. replica reports which.
+func (s dbService) reader() (db *gorm.DB, replica bool) {
+	if s.replicas != nil {
+		if db := s.replicas.pick(); db != nil {
+			return db, true
+		}
+	}
+	return s.db, false
+}
+
+// read runs a read of links on a replica. A replica missing the link right after a link was
+// created may not have replayed it yet, so a read failing with gorm.ErrRecordNotFound is then run
+// again on the primary. Reads that find no rows must report it so too.
+func (s dbService) read(query func(db *gorm.DB) error) error {
+	db, replica := s.reader()
+	err := query(db)
+	if replica && errors.Is(err, gorm.ErrRecordNotFound) && s.replicas.createdRecently() {
+		err = query(s.db)
+	}
+	return err
+}
+
+// created records that a link was created, for the read-your-writes guard of read.
+func (s dbService) created() {
+	if s.replicas != nil {
+		s.replicas.created()
+	}
+}
 func (s *dbService) NewUrlRepository() database.IUrlRepository {
 	return NewUrlRepositoryPG(*s)
 }
 
+func (s *dbService) NewChangeLog() database.IChangeLog {
+	return NewChangeLogPG(*s)
+}
+
+func (s *dbService) NewWebhookStore() webhooks.Store {
+	return NewWebhookStorePG(*s)
+}
+
 func (s *dbService) SyncDB() {
 	sqlDB, err := s.db.DB()
 	if err != nil {
@@ -60,12 +109,99 @@ func (s *dbService) SyncDB() {
 	sqlDB.SetMaxOpenConns(50)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
+	var previous int64
+	if s.db.Migrator().HasTable(&SchemaMigration{}) {
+		previous, err = s.SchemaVersion(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to read schema version: %v", err)
+		}
+	}
+
 	var result *gorm.DB
-	err = s.db.AutoMigrate(&Url{})
+	err = s.db.AutoMigrate(&Url{}, &Tag{}, &LinkTag{}, &VariantClick{}, &ClickDay{}, &OutboxEntry{}, &WebhookSubscription{}, &WebhookDelivery{}, &IdRange{}, &IdLease{}, &SchemaMigration{})
 	if err != nil {
 		log.Fatalf("Failed to migrate: %v", err)
 	}
 	s.db.Raw(`CREATE INDEX IF NOT EXISTS fullurl_url_hash_index ON url USING hash(full_url);`).Scan(&result)
+
+	// Substring search over targets uses a trigram index when pg_trgm is available. Without it
+	// search still works, scanning the links, so the database is up and Health tells why.
+	s.searchIndexErr = s.createSearchIndex()
+	if s.searchIndexErr != nil {
+		log.Printf("Target search won't be indexed: %v", s.searchIndexErr)
+	}
+	// Backfill target_domain for rows created before the column existed.
+	err = s.db.Exec(`UPDATE url SET target_domain = lower(substring(full_url from '^[A-Za-z][A-Za-z0-9+.-]*://(?:[^@/?#]*@)?([^:/?#]+)')) WHERE target_domain IS NULL OR target_domain = '';`).Error
+	if err != nil {
+		log.Fatalf("Failed to backfill target_domain: %v", err)
+	}
+
+	// Tags were only kept in the tags column before version 14.
+	if previous > 0 && previous < 14 {
+		if err := s.backfillLinkTags(); err != nil {
+			log.Fatalf("Failed to backfill link tags: %v", err)
+		}
+	}
+
+	// Outbox rows held the password hashes of links before version 16.
+	if previous > 0 && previous < 16 {
+		err = s.db.Exec(`UPDATE outbox_entry SET link = jsonb_set(link, '{PasswordHash}', to_jsonb(?::text))
+			WHERE coalesce(link->>'PasswordHash', '') NOT IN ('', ?)`, database.RedactedPasswordHash, database.RedactedPasswordHash).Error
+		if err != nil {
+			log.Fatalf("Failed to redact password hashes in the outbox: %v", err)
+		}
+	}
+
+	migration := SchemaMigration{Version: database.SchemaVersion, AppliedAt: time.Now()}
+	err = s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&migration).Error
+	if err != nil {
+		log.Fatalf("Failed to record schema version: %v", err)
+	}
+}
+
+// createSearchIndex creates the trigram index of targets.
+func (s *dbService) createSearchIndex() error {
+	if err := s.db.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm;`).Error; err != nil {
+		return fmt.Errorf("pg_trgm is not available: %w", err)
+	}
+	if err := s.db.Exec(`CREATE INDEX IF NOT EXISTS fullurl_url_trgm_index ON url USING gin (full_url gin_trgm_ops);`).Error; err != nil {
+		return fmt.Errorf("failed to create the trigram index: %w", err)
+	}
+	return nil
+}
+
+// backfillLinkTags creates the tag and link_tag rows of the tags column.
+func (s *dbService) backfillLinkTags() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`INSERT INTO tag (name)
+			SELECT DISTINCT jsonb_array_elements_text(tags) FROM url WHERE jsonb_typeof(tags) = 'array'
+			ON CONFLICT (name) DO NOTHING`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`INSERT INTO link_tag (url_id, tag_id)
+			SELECT DISTINCT url.id, tag.id FROM url
+			CROSS JOIN LATERAL jsonb_array_elements_text(url.tags) AS t(name)
+			JOIN tag ON tag.name = t.name
+			WHERE jsonb_typeof(url.tags) = 'array'
+			ON CONFLICT DO NOTHING`).Error
+	})
+}
+
+// Ping checks that the database accepts connections.
+func (s *dbService) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// SchemaVersion returns the latest schema version recorded by SyncDB.
+func (s *dbService) SchemaVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := s.db.WithContext(ctx).Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
 }
 
 // Health checks the health of the database connection by pinging the database.
@@ -79,7 +215,9 @@ func (s *dbService) Health() map[string]string {
 
 	sqlDB, err := s.db.DB()
 	if err != nil {
-		log.Fatalf("Failed to get underlying sql.DB: %v", err)
+		stats["status"] = "down"
+		stats["error"] = fmt.Sprintf("failed to get underlying sql.DB: %v", err)
+		return stats
 	}
 
 	// Ping the database
@@ -87,7 +225,6 @@ func (s *dbService) Health() map[string]string {
 	if err != nil {
 		stats["status"] = "down"
 		stats["error"] = fmt.Sprintf("db down: %v", err)
-		log.Fatalf("db down: %v", err) // Log the error and terminate the program
 		return stats
 	}
 
@@ -122,6 +259,15 @@ func (s *dbService) Health() map[string]string {
 		stats["message"] = "Many connections are being closed due to max lifetime, consider increasing max lifetime or revising the connection usage pattern."
 	}
 
+	if s.searchIndexErr != nil {
+		stats["target_search_index"] = fmt.Sprintf("missing: %v", s.searchIndexErr)
+	}
+
+	// Ejected replicas leave the reads to the others or the primary, the database stays up.
+	if s.replicas != nil {
+		s.replicas.health(stats)
+	}
+
 	return stats
 }
 
@@ -135,5 +281,8 @@ func (s *dbService) Close() error {
 		log.Fatalf("Failed to get underlying sql.DB: %v", err)
 	}
 	log.Printf("Disconnected from database: %s", db)
+	if s.replicas != nil {
+		return errors.Join(s.replicas.close(), sqlDB.Close())
+	}
 	return sqlDB.Close()
 }
@@ -2,13 +2,30 @@ package sql
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/idalloc"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 func mustStartPostgresContainer() (func(context.Context, ...testcontainers.TerminateOption) error, error) {
@@ -75,6 +92,7 @@ func TestNew(t *testing.T) {
 
 func TestHealth(t *testing.T) {
 	srv := New()
+	srv.SyncDB()
 
 	stats := srv.Health()
 
@@ -86,11 +104,36 @@ func TestHealth(t *testing.T) {
 		t.Fatalf("expected error not to be present")
 	}
 
+	if msg, ok := stats["target_search_index"]; ok {
+		t.Fatalf("expected target search to be indexed, got %s", msg)
+	}
+
 	if stats["message"] != "It's healthy" {
 		t.Fatalf("expected message to be 'It's healthy', got %s", stats["message"])
 	}
 }
 
+func TestPing(t *testing.T) {
+	srv := New()
+
+	if err := srv.Ping(context.Background()); err != nil {
+		t.Fatalf("expected Ping() to succeed, got %v", err)
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+
+	version, err := srv.SchemaVersion(context.Background())
+	if err != nil {
+		t.Fatalf("expected SchemaVersion() to succeed, got %v", err)
+	}
+	if version != database.SchemaVersion {
+		t.Fatalf("expected schema version %d, got %d", database.SchemaVersion, version)
+	}
+}
+
 func TestClose(t *testing.T) {
 	srv := New()
 
@@ -98,3 +141,811 @@ func TestClose(t *testing.T) {
 		t.Fatalf("expected Close() to return nil")
 	}
 }
+
+func TestUrlRepositoryPG_Links(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	link := database.Link{Target: "https://example.com", Owner: "alice", Tags: []string{"docs"}}
+	if err := repo.CreateLink(ctx, &link); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+	if link.ID == 0 || link.CreatedAt.IsZero() {
+		t.Fatalf("CreateLink() didn't fill in ID and timestamps: %+v", link)
+	}
+
+	link.Target = "https://example.org"
+	link.Owner = "bob"
+	updated, err := repo.UpdateLink(ctx, link, []database.LinkField{database.LinkFieldTarget})
+	if err != nil {
+		t.Fatalf("UpdateLink() failed: %v", err)
+	}
+	if updated.Target != "https://example.org" || updated.Owner != "alice" {
+		t.Fatalf("UpdateLink() updated wrong fields: %+v", updated)
+	}
+
+	link.PasswordHash = "hash"
+	updated, err = repo.UpdateLink(ctx, link, []database.LinkField{database.LinkFieldPassword})
+	if err != nil || updated.PasswordHash != "hash" {
+		t.Fatalf("UpdateLink(password) = %+v, %v", updated, err)
+	}
+
+	link.Passthrough = passthrough.Options{Query: passthrough.QueryKeep, Path: true, UTM: passthrough.UTM{Source: "pg"}}
+	if _, err := repo.UpdateLink(ctx, link, []database.LinkField{database.LinkFieldPassthrough}); err != nil {
+		t.Fatalf("UpdateLink(passthrough) failed: %v", err)
+	}
+	if got, err := repo.GetLink(ctx, link.ID); err != nil || got.Passthrough != link.Passthrough {
+		t.Fatalf("GetLink() passthrough = %+v, %v, want %+v", got.Passthrough, err, link.Passthrough)
+	}
+
+	link.Template = &urltemplate.Template{Path: "{id}"}
+	updated, err = repo.UpdateLink(ctx, link, []database.LinkField{database.LinkFieldTemplate})
+	if err != nil || updated.Template == nil || *updated.Template != *link.Template {
+		t.Fatalf("UpdateLink(template) = %+v, %v", updated.Template, err)
+	}
+	link.Template = nil
+	if updated, err = repo.UpdateLink(ctx, link, []database.LinkField{database.LinkFieldTemplate}); err != nil || updated.Template != nil {
+		t.Fatalf("UpdateLink(no template) = %+v, %v", updated.Template, err)
+	}
+
+	links, err := repo.ListLinks(ctx, database.ListLinksFilter{Tag: "docs", Limit: 10})
+	if err != nil {
+		t.Fatalf("ListLinks() failed: %v", err)
+	}
+	if len(links) != 1 || links[0].ID != link.ID {
+		t.Fatalf("ListLinks() = %+v, want only link %d", links, link.ID)
+	}
+
+	if err := repo.DeleteLink(ctx, link.ID); err != nil {
+		t.Fatalf("DeleteLink() failed: %v", err)
+	}
+	if _, err := repo.GetLink(ctx, link.ID); !errors.Is(err, service.ErrUrlNotFound) {
+		t.Fatalf("GetLink() after delete = %v, want ErrUrlNotFound", err)
+	}
+}
+
+func TestUrlRepositoryPG_ListLinksKeyset(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	var ids []int64
+	for _, target := range []string{"https://search.example.com/Docs/a", "https://other.example.com/100%_b", "https://search.example.com/c"} {
+		link := database.Link{Target: target, Owner: "keyset"}
+		if err := repo.CreateLink(ctx, &link); err != nil {
+			t.Fatalf("CreateLink() failed: %v", err)
+		}
+		ids = append(ids, link.ID)
+	}
+
+	for _, sort := range []database.LinkSort{database.SortIDDesc, database.SortCreatedAtDesc} {
+		var got []int64
+		filter := database.ListLinksFilter{Owner: "keyset", Sort: sort, Limit: 1}
+		for {
+			links, err := repo.ListLinks(ctx, filter)
+			if err != nil {
+				t.Fatalf("ListLinks(%s) failed: %v", sort, err)
+			}
+			if len(links) == 0 {
+				break
+			}
+			got = append(got, links[0].ID)
+			filter.After = &database.LinkCursor{ID: links[0].ID, CreatedAt: links[0].CreatedAt}
+		}
+		if want := []int64{ids[2], ids[1], ids[0]}; !slices.Equal(got, want) {
+			t.Fatalf("ListLinks(%s) = %v, want %v", sort, got, want)
+		}
+	}
+
+	links, err := repo.ListLinks(ctx, database.ListLinksFilter{Owner: "keyset", TargetDomain: "SEARCH.example.com", TargetContains: "docs"})
+	if err != nil {
+		t.Fatalf("ListLinks() failed: %v", err)
+	}
+	if len(links) != 1 || links[0].ID != ids[0] {
+		t.Fatalf("ListLinks() by domain and substring = %+v, want only link %d", links, ids[0])
+	}
+	// LIKE wildcards in the search string match literally.
+	links, err = repo.ListLinks(ctx, database.ListLinksFilter{Owner: "keyset", TargetContains: "%_"})
+	if err != nil {
+		t.Fatalf("ListLinks() failed: %v", err)
+	}
+	if len(links) != 1 || links[0].ID != ids[1] {
+		t.Fatalf("ListLinks() with wildcards = %+v, want only link %d", links, ids[1])
+	}
+}
+
+func TestUrlRepositoryPG_GetIDSkipsRestrictedLinks(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	const target = "https://example.com/restricted"
+	for _, link := range []database.Link{
+		{Target: target, Disabled: true},
+		{Target: target, PasswordHash: "hash"},
+		{Target: target, MaxClicks: 1, RemainingClicks: 1},
+		{Target: target, Owner: "alice"},
+		{Target: target, Passthrough: passthrough.Options{Path: true}},
+	} {
+		if err := repo.CreateLink(ctx, &link); err != nil {
+			t.Fatalf("CreateLink() failed: %v", err)
+		}
+	}
+	if _, err := repo.GetID(ctx, "", target); !errors.Is(err, service.ErrUrlNotFound) {
+		t.Fatalf("GetID() = %v, want ErrUrlNotFound", err)
+	}
+	plain := database.Link{Target: target}
+	if err := repo.CreateLink(ctx, &plain); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+	if id, err := repo.GetID(ctx, "", target); err != nil || id != plain.ID {
+		t.Fatalf("GetID() = %d, %v, want %d", id, err, plain.ID)
+	}
+}
+
+func TestUrlRepositoryPG_Domains(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	const target = "https://example.com/domains"
+	if err := repo.SaveUrl(ctx, "", target); err != nil {
+		t.Fatalf("SaveUrl() failed: %v", err)
+	}
+	if err := repo.SaveUrl(ctx, "go.brand-a.com", target); err != nil {
+		t.Fatalf("SaveUrl() failed: %v", err)
+	}
+	def, err := repo.GetID(ctx, "", target)
+	if err != nil {
+		t.Fatalf("GetID() failed: %v", err)
+	}
+	branded, err := repo.GetID(ctx, "go.brand-a.com", target)
+	if err != nil || branded == def {
+		t.Fatalf("GetID(go.brand-a.com) = %d, %v, want a link other than %d", branded, err, def)
+	}
+	if _, err := repo.GetID(ctx, "br.nd", target); !errors.Is(err, service.ErrUrlNotFound) {
+		t.Fatalf("GetID(br.nd) = %v, want ErrUrlNotFound", err)
+	}
+	if link, err := repo.GetLink(ctx, branded); err != nil || link.Domain != "go.brand-a.com" {
+		t.Fatalf("GetLink() = %+v, %v, want domain go.brand-a.com", link, err)
+	}
+
+	domain := "go.brand-a.com"
+	links, err := repo.ListLinks(ctx, database.ListLinksFilter{Domain: &domain, TargetContains: "/domains"})
+	if err != nil {
+		t.Fatalf("ListLinks() failed: %v", err)
+	}
+	if len(links) != 1 || links[0].ID != branded {
+		t.Fatalf("ListLinks() by domain = %+v, want only link %d", links, branded)
+	}
+}
+
+func TestUrlRepositoryPG_ConsumeClick(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	const maxClicks, callers = 3, 20
+	link := database.Link{Target: "https://example.com/limited", MaxClicks: maxClicks, RemainingClicks: maxClicks}
+	if err := repo.CreateLink(ctx, &link); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int64
+		mu        sync.Mutex
+		remaining = make(map[int64]bool)
+	)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			left, err := repo.ConsumeClick(ctx, link.ID)
+			switch {
+			case err == nil:
+				succeeded.Add(1)
+				mu.Lock()
+				remaining[left] = true
+				mu.Unlock()
+			case !errors.Is(err, service.ErrLinkExhausted):
+				t.Errorf("ConsumeClick() failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := succeeded.Load(); got != maxClicks {
+		t.Errorf("ConsumeClick() succeeded %d times, want %d", got, maxClicks)
+	}
+	// every caller sees the clicks its own click left
+	if want := map[int64]bool{0: true, 1: true, 2: true}; !maps.Equal(remaining, want) {
+		t.Errorf("ConsumeClick() left %v, want %v", remaining, want)
+	}
+	got, err := repo.GetLink(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("GetLink() failed: %v", err)
+	}
+	if got.RemainingClicks != 0 || !got.Exhausted() {
+		t.Errorf("RemainingClicks = %d, want 0", got.RemainingClicks)
+	}
+
+	unlimited := database.Link{Target: "https://example.com/unlimited"}
+	if err := repo.CreateLink(ctx, &unlimited); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+	if left, err := repo.ConsumeClick(ctx, unlimited.ID); err != nil || left != -1 {
+		t.Errorf("ConsumeClick() on unlimited link = %d, %v, want -1", left, err)
+	}
+	if _, err := repo.ConsumeClick(ctx, 1<<40); !errors.Is(err, service.ErrUrlNotFound) {
+		t.Errorf("ConsumeClick() on missing link = %v, want ErrUrlNotFound", err)
+	}
+}
+
+func TestUrlRepositoryPG_UpdateRules(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	link := database.Link{Target: "https://example.com/rules"}
+	if err := repo.CreateLink(ctx, &link); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.UpdateRules(ctx, link.ID, func(rs []rules.Rule) ([]rules.Rule, error) {
+				return append(rs, rules.Rule{
+					ID:         fmt.Sprint(i),
+					Target:     "https://example.com/rule",
+					Conditions: rules.Conditions{Days: []time.Weekday{time.Monday}},
+				}), nil
+			})
+			if err != nil {
+				t.Errorf("UpdateRules() failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	got, err := repo.GetLink(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("GetLink() failed: %v", err)
+	}
+	if len(got.Rules) != callers {
+		t.Errorf("got %d rules, want %d: concurrent updates were lost", len(got.Rules), callers)
+	}
+	if len(got.Rules) > 0 && !slices.Equal(got.Rules[0].Conditions.Days, []time.Weekday{time.Monday}) {
+		t.Errorf("Rules[0].Conditions = %+v, want Monday", got.Rules[0].Conditions)
+	}
+
+	errStop := errors.New("stop")
+	if _, err := repo.UpdateRules(ctx, link.ID, func(rs []rules.Rule) ([]rules.Rule, error) {
+		return nil, errStop
+	}); !errors.Is(err, errStop) {
+		t.Errorf("UpdateRules() = %v, want the update error", err)
+	}
+	if _, err := repo.UpdateRules(ctx, 1<<40, func(rs []rules.Rule) ([]rules.Rule, error) {
+		return rs, nil
+	}); !errors.Is(err, service.ErrUrlNotFound) {
+		t.Errorf("UpdateRules() on missing link = %v, want ErrUrlNotFound", err)
+	}
+}
+
+func TestUrlRepositoryPG_VariantClicks(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	link := database.Link{
+		Target:    "https://example.com/split",
+		Variants:  []split.Variant{{ID: "a", Target: "https://example.com/a", Weight: 3}, {ID: "b", Target: "https://example.com/b", Weight: 1}},
+		SplitMode: split.ModeSticky,
+	}
+	if err := repo.CreateLink(ctx, &link); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+	got, err := repo.GetLink(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("GetLink() failed: %v", err)
+	}
+	if !slices.Equal(got.Variants, link.Variants) || got.SplitMode != split.ModeSticky {
+		t.Errorf("GetLink() variants = %+v %q, want %+v sticky", got.Variants, got.SplitMode, link.Variants)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := repo.RecordVariantClick(ctx, link.ID, []string{"a", "b"}[i%2]); err != nil {
+				t.Errorf("RecordVariantClick() failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	clicks, err := repo.VariantClicks(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("VariantClicks() failed: %v", err)
+	}
+	if clicks["a"] != 5 || clicks["b"] != 5 {
+		t.Errorf("VariantClicks() = %v, want 5 each", clicks)
+	}
+
+	if err := repo.DeleteLink(ctx, link.ID); err != nil {
+		t.Fatalf("DeleteLink() failed: %v", err)
+	}
+	if clicks, _ = repo.VariantClicks(ctx, link.ID); len(clicks) != 0 {
+		t.Errorf("VariantClicks() after delete = %v, want none", clicks)
+	}
+}
+
+func TestUrlRepositoryPG_TagsAndCampaigns(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	// Links on their own domain keep other tests out of the campaign stats.
+	domain := "campaigns.example"
+	var ids []int64
+	for _, campaign := range []string{"spring", "spring", ""} {
+		link := database.Link{Domain: domain, Target: "https://example.com/" + campaign, Tags: []string{"pg-promo"}, Campaign: campaign}
+		if err := repo.CreateLink(ctx, &link); err != nil {
+			t.Fatalf("CreateLink() failed: %v", err)
+		}
+		ids = append(ids, link.ID)
+	}
+	today := database.ClickDay(time.Now())
+	for _, at := range []time.Time{today.Add(-time.Hour), today.Add(time.Hour), today.Add(2 * time.Hour)} {
+		if err := repo.RecordClick(ctx, ids[0], at); err != nil {
+			t.Fatalf("RecordClick() failed: %v", err)
+		}
+	}
+	if err := repo.RecordClick(ctx, -1, today); !errors.Is(err, service.ErrUrlNotFound) {
+		t.Errorf("RecordClick(missing) = %v, want ErrUrlNotFound", err)
+	}
+	history, err := repo.ClickHistory(ctx, ids[0], today.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("ClickHistory() failed: %v", err)
+	}
+	if want := []database.DailyClicks{{Day: today.AddDate(0, 0, -1), Clicks: 1}, {Day: today, Clicks: 2}}; !slices.Equal(history, want) {
+		t.Errorf("ClickHistory() = %+v, want %+v", history, want)
+	}
+	stats, err := repo.CampaignStats(ctx, domain)
+	if err != nil {
+		t.Fatalf("CampaignStats() failed: %v", err)
+	}
+	if want := []database.CampaignStats{{Campaign: "spring", Links: 2, Clicks: 3}}; !slices.Equal(stats, want) {
+		t.Errorf("CampaignStats() = %+v, want %+v", stats, want)
+	}
+
+	updated, found, err := repo.UpdateTags(ctx, domain, []int64{ids[0], ids[1], -1}, []string{"pg-q2"}, []string{"pg-promo"})
+	if err != nil {
+		t.Fatalf("UpdateTags() failed: %v", err)
+	}
+	if len(updated) != 2 || !slices.Equal(updated[0].Tags, []string{"pg-q2"}) || !slices.Equal(found, ids[:2]) {
+		t.Errorf("UpdateTags() = %+v, %v", updated, found)
+	}
+	if _, found, _ := repo.UpdateTags(ctx, "other.example", ids, []string{"pg-q2"}, nil); len(found) != 0 {
+		t.Errorf("UpdateTags() found %v on another domain", found)
+	}
+
+	links, err := repo.ListLinks(ctx, database.ListLinksFilter{Tag: "pg-promo", Domain: &domain, Limit: 10})
+	if err != nil || len(links) != 1 || links[0].ID != ids[2] {
+		t.Errorf("ListLinks(tag) = %+v, %v, want only link %d", links, err, ids[2])
+	}
+	links, err = repo.ListLinks(ctx, database.ListLinksFilter{Tag: "pg-q2", Campaign: "spring", Limit: 10})
+	if err != nil || len(links) != 2 {
+		t.Errorf("ListLinks(tag, campaign) = %+v, %v, want 2 links", links, err)
+	}
+
+	for _, id := range ids {
+		if err := repo.DeleteLink(ctx, id); err != nil {
+			t.Fatalf("DeleteLink() failed: %v", err)
+		}
+	}
+	if links, _ = repo.ListLinks(ctx, database.ListLinksFilter{Tag: "pg-q2", Limit: 10}); len(links) != 0 {
+		t.Errorf("ListLinks(tag) after delete = %+v, want none", links)
+	}
+}
+
+func TestWebhookStorePG(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	store := srv.NewWebhookStore()
+	ctx := context.Background()
+
+	sub := webhooks.Subscription{ID: "whs_test", URL: "https://hooks.example.com", Secret: "0123456789abcdef", Events: []webhooks.EventType{webhooks.EventLinkCreated}, CreatedAt: time.Now()}
+	if err := store.CreateSubscription(ctx, sub); err != nil {
+		t.Fatalf("CreateSubscription() failed: %v", err)
+	}
+	if got, err := store.GetSubscription(ctx, sub.ID); err != nil || got.URL != sub.URL || !slices.Equal(got.Events, sub.Events) {
+		t.Fatalf("GetSubscription() = %+v, %v, want %+v", got, err, sub)
+	}
+
+	event, err := webhooks.NewEvent(webhooks.EventLinkCreated, webhooks.LinkData{Code: "abc"})
+	if err != nil {
+		t.Fatalf("NewEvent() failed: %v", err)
+	}
+	now := time.Now().UTC()
+	deliveries := []webhooks.Delivery{
+		{ID: "whd_due", SubscriptionID: sub.ID, Event: event, Status: webhooks.StatusPending, NextAttemptAt: now.Add(-time.Second), CreatedAt: now},
+		{ID: "whd_later", SubscriptionID: sub.ID, Event: event, Status: webhooks.StatusPending, NextAttemptAt: now.Add(time.Hour), CreatedAt: now.Add(time.Millisecond)},
+	}
+	if err := store.CreateDeliveries(ctx, deliveries); err != nil {
+		t.Fatalf("CreateDeliveries() failed: %v", err)
+	}
+
+	// Concurrent claims never hand out the same delivery twice.
+	var (
+		wg      sync.WaitGroup
+		claimed atomic.Int64
+	)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := store.ClaimDeliveries(ctx, now, time.Minute, 10)
+			if err != nil {
+				t.Errorf("ClaimDeliveries() failed: %v", err)
+			}
+			claimed.Add(int64(len(got)))
+		}()
+	}
+	wg.Wait()
+	if got := claimed.Load(); got != 1 {
+		t.Fatalf("ClaimDeliveries() claimed %d deliveries, want 1", got)
+	}
+
+	due, err := store.GetDelivery(ctx, "whd_due")
+	if err != nil || due.Event.ID != event.ID {
+		t.Fatalf("GetDelivery() = %+v, %v", due, err)
+	}
+	due.Status = webhooks.StatusDead
+	due.Attempts = 8
+	due.LastStatusCode = 500
+	if err := store.UpdateDelivery(ctx, due); err != nil {
+		t.Fatalf("UpdateDelivery() failed: %v", err)
+	}
+	dead, err := store.ListDeliveries(ctx, sub.ID, webhooks.StatusDead, 0)
+	if err != nil || len(dead) != 1 || dead[0].Attempts != 8 {
+		t.Fatalf("ListDeliveries(dead) = %+v, %v, want whd_due", dead, err)
+	}
+	if all, err := store.ListDeliveries(ctx, sub.ID, "", 0); err != nil || len(all) != 2 || all[0].ID != "whd_later" {
+		t.Fatalf("ListDeliveries() = %+v, %v, want newest first", all, err)
+	}
+
+	if err := store.DeleteSubscription(ctx, sub.ID); err != nil {
+		t.Fatalf("DeleteSubscription() failed: %v", err)
+	}
+	if _, err := store.GetDelivery(ctx, "whd_later"); !errors.Is(err, webhooks.ErrDeliveryNotFound) {
+		t.Errorf("GetDelivery() after DeleteSubscription = %v, want ErrDeliveryNotFound", err)
+	}
+	if err := store.DeleteSubscription(ctx, sub.ID); !errors.Is(err, webhooks.ErrSubscriptionNotFound) {
+		t.Errorf("DeleteSubscription() twice = %v, want ErrSubscriptionNotFound", err)
+	}
+}
+
+func TestChangeLogPG(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	changes := srv.NewChangeLog()
+	ctx := context.Background()
+
+	start, err := changes.LastSeq(ctx)
+	if err != nil {
+		t.Fatalf("LastSeq() failed: %v", err)
+	}
+	link := database.Link{Target: "https://example.com/changes", PasswordHash: "$2a$10$hash", MaxClicks: 2, RemainingClicks: 2}
+	if err := repo.CreateLink(ctx, &link); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+	link.Target = "https://example.com/changed"
+	if _, err := repo.UpdateLink(ctx, link, []database.LinkField{database.LinkFieldTarget}); err != nil {
+		t.Fatalf("UpdateLink() failed: %v", err)
+	}
+	if _, err := repo.ConsumeClick(ctx, link.ID); err != nil {
+		t.Fatalf("ConsumeClick() failed: %v", err)
+	}
+	if err := repo.DeleteLink(ctx, link.ID); err != nil {
+		t.Fatalf("DeleteLink() failed: %v", err)
+	}
+
+	got, err := changes.Changes(ctx, start, 0)
+	if err != nil {
+		t.Fatalf("Changes() failed: %v", err)
+	}
+	want := []struct {
+		typ    database.ChangeType
+		fields []database.LinkField
+	}{
+		{database.ChangeCreated, nil},
+		{database.ChangeUpdated, []database.LinkField{database.LinkFieldTarget}},
+		{database.ChangeUpdated, []database.LinkField{database.LinkFieldRemainingClicks}},
+		{database.ChangeDeleted, nil},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Changes() returned %d changes, want %d", len(got), len(want))
+	}
+	for i, change := range got {
+		if change.Seq != start+int64(i)+1 || change.Type != want[i].typ || !slices.Equal(change.Fields, want[i].fields) || change.Link.ID != link.ID {
+			t.Errorf("change %d = %+v, want type %s and fields %v", i, change, want[i].typ, want[i].fields)
+		}
+		if change.Link.PasswordHash != database.RedactedPasswordHash {
+			t.Errorf("change %d holds password hash %q, want it redacted", i, change.Link.PasswordHash)
+		}
+	}
+	if got[2].Link.RemainingClicks != 1 {
+		t.Errorf("RemainingClicks after ConsumeClick = %d, want 1", got[2].Link.RemainingClicks)
+	}
+
+	limited, err := changes.Changes(ctx, start, 2)
+	if err != nil || len(limited) != 2 {
+		t.Errorf("Changes() with limit 2 = %d changes, %v", len(limited), err)
+	}
+	if last, err := changes.LastSeq(ctx); err != nil || last != start+4 {
+		t.Errorf("LastSeq() = %d, %v, want %d", last, err, start+4)
+	}
+}
+
+func TestUrlRepositoryPG_ImportLink(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	ctx := context.Background()
+
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	imported := &database.Link{ID: 900000, Target: "https://example.com/imported", CreatedAt: created}
+	if err := repo.ImportLink(ctx, imported); err != nil {
+		t.Fatalf("ImportLink() failed: %v", err)
+	}
+	link, err := repo.GetLink(ctx, 900000)
+	if err != nil || link.Target != imported.Target || !link.CreatedAt.Equal(created) {
+		t.Fatalf("GetLink() = %+v, %v, want the imported link", link, err)
+	}
+	if err := repo.ImportLink(ctx, &database.Link{ID: 900000, Target: "https://example.com/other"}); !errors.Is(err, service.ErrCodeTaken) {
+		t.Fatalf("ImportLink() of a taken ID = %v, want ErrCodeTaken", err)
+	}
+
+	// links created later do not collide with imported ones
+	next := &database.Link{Target: "https://example.com/after-import"}
+	if err := repo.CreateLink(ctx, next); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+	if next.ID <= 900000 {
+		t.Fatalf("CreateLink() ID = %d, want above the imported ID", next.ID)
+	}
+	// an import below the sequence does not move it back
+	if err := repo.ImportLink(ctx, &database.Link{ID: 899999, Target: "https://example.com/below"}); err != nil {
+		t.Fatalf("ImportLink() failed: %v", err)
+	}
+	last := &database.Link{Target: "https://example.com/after-import-2"}
+	if err := repo.CreateLink(ctx, last); err != nil || last.ID <= next.ID {
+		t.Fatalf("CreateLink() = %d, %v, want an ID above %d", last.ID, err, next.ID)
+	}
+}
+
+func TestUrlRepositoryPG_SequenceAndVariantClicks(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	seq, ok := repo.(database.IDSequence)
+	if !ok {
+		t.Fatal("UrlRepositoryPG does not implement database.IDSequence")
+	}
+	ctx := context.Background()
+
+	link := &database.Link{Target: "https://example.com/sequence"}
+	if err := repo.CreateLink(ctx, link); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+	if last, err := seq.LastID(ctx); err != nil || last < link.ID {
+		t.Fatalf("LastID() = %d, %v, want at least %d", last, err, link.ID)
+	}
+	if err := seq.AdvanceIDs(ctx, link.ID+100); err != nil {
+		t.Fatalf("AdvanceIDs() failed: %v", err)
+	}
+	// the sequence never moves back
+	if err := seq.AdvanceIDs(ctx, 1); err != nil {
+		t.Fatalf("AdvanceIDs() failed: %v", err)
+	}
+	next := &database.Link{Target: "https://example.com/sequence-next"}
+	if err := repo.CreateLink(ctx, next); err != nil || next.ID <= link.ID+100 {
+		t.Fatalf("CreateLink() = %d, %v, want an ID above %d", next.ID, err, link.ID+100)
+	}
+
+	if err := repo.RecordVariantClick(ctx, link.ID, "old"); err != nil {
+		t.Fatalf("RecordVariantClick() failed: %v", err)
+	}
+	want := map[string]int64{"a": 7, "b": 3}
+	if err := repo.SetVariantClicks(ctx, link.ID, want); err != nil {
+		t.Fatalf("SetVariantClicks() failed: %v", err)
+	}
+	clicks, err := repo.VariantClicks(ctx, link.ID)
+	if err != nil || len(clicks) != 2 || clicks["a"] != 7 || clicks["b"] != 3 {
+		t.Fatalf("VariantClicks() = %v, %v, want %v", clicks, err, want)
+	}
+}
+
+func TestUrlRepositoryPG_LeaseIDs(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	repo := srv.NewUrlRepository()
+	leaser, ok := repo.(database.IDBlockLeaser)
+	if !ok {
+		t.Fatal("UrlRepositoryPG does not implement database.IDBlockLeaser")
+	}
+	ctx := context.Background()
+
+	// concurrent leases get disjoint blocks
+	const workers = 8
+	blocks := make([]database.IDBlock, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			block, err := leaser.LeaseIDs(ctx, fmt.Sprintf("worker-%d", i), 100)
+			if err != nil {
+				t.Errorf("LeaseIDs() failed: %v", err)
+			}
+			blocks[i] = block
+		}()
+	}
+	wg.Wait()
+	slices.SortFunc(blocks, func(a, b database.IDBlock) int { return int(a.Start - b.Start) })
+	for i, block := range blocks {
+		if block.End-block.Start != 100 {
+			t.Fatalf("LeaseIDs() = %+v, want 100 IDs", block)
+		}
+		if i > 0 && block.Start < blocks[i-1].End {
+			t.Fatalf("LeaseIDs() blocks %+v and %+v overlap", blocks[i-1], block)
+		}
+	}
+
+	// links created from the sequence get IDs after the blocks
+	link := &database.Link{Target: "https://example.com/after-lease"}
+	if err := repo.CreateLink(ctx, link); err != nil || link.ID < blocks[workers-1].End {
+		t.Fatalf("CreateLink() = %d, %v, want an ID from %d", link.ID, err, blocks[workers-1].End)
+	}
+	// blocks start after imported links
+	if err := repo.ImportLink(ctx, &database.Link{ID: link.ID + 1000, Target: "https://example.com/imported-far"}); err != nil {
+		t.Fatalf("ImportLink() failed: %v", err)
+	}
+	block, err := leaser.LeaseIDs(ctx, "after-import", 10)
+	if err != nil || block.Start <= link.ID+1000 {
+		t.Fatalf("LeaseIDs() = %+v, %v, want a block after %d", block, err, link.ID+1000)
+	}
+
+	// links created under leased IDs leave the sequence alone
+	seq := repo.(database.IDSequence)
+	before, err := seq.LastID(ctx)
+	if err != nil {
+		t.Fatalf("LastID() failed: %v", err)
+	}
+	leased := &database.Link{ID: block.Start, Target: "https://example.com/leased"}
+	if err := repo.CreateLinkWithID(ctx, leased); err != nil {
+		t.Fatalf("CreateLinkWithID() failed: %v", err)
+	}
+	if after, err := seq.LastID(ctx); err != nil || after != before {
+		t.Fatalf("LastID() = %d, %v after CreateLinkWithID(), want %d", after, err, before)
+	}
+	if err := repo.CreateLinkWithID(ctx, &database.Link{ID: block.Start, Target: "https://example.com/again"}); !errors.Is(err, service.ErrCodeTaken) {
+		t.Fatalf("CreateLinkWithID() = %v for a taken ID, want %v", err, service.ErrCodeTaken)
+	}
+
+	var leases int64
+	if err := srv.db.Model(&IdLease{}).Count(&leases).Error; err != nil || leases < workers+1 {
+		t.Fatalf("leases = %d, %v, want at least %d", leases, err, workers+1)
+	}
+}
+
+// BenchmarkUrlRepositoryPG_CreateLink compares creating links from the ID sequence with creating
+// them from leased blocks, by concurrent callers.
+func BenchmarkUrlRepositoryPG_CreateLink(b *testing.B) {
+	srv := New()
+	srv.SyncDB()
+	base := srv.NewUrlRepository()
+	for _, bc := range []struct {
+		name string
+		repo database.IUrlRepository
+	}{
+		{"sequence", base},
+		{"blocks", idalloc.NewRepository(base, idalloc.NewAllocator(base.(database.IDBlockLeaser), "bench", idalloc.DefaultBlockSize))},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := context.Background()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := bc.repo.CreateLink(ctx, &database.Link{Target: "https://example.com/bench"}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestReplicas(t *testing.T) {
+	srv := New()
+	srv.SyncDB()
+	ctx := context.Background()
+
+	// An empty database stands in for a replica that has not replayed anything yet.
+	if err := srv.db.Exec(`CREATE DATABASE lagging_replica`).Error; err != nil {
+		t.Fatalf("failed to create the replica database: %v", err)
+	}
+	replicaDSN := fmt.Sprintf("host=%s user=%s password=%s dbname=lagging_replica port=%v sslmode=disable", host, username, password, port)
+	replicaDB, err := gorm.Open(gormpostgres.Open(replicaDSN), &gorm.Config{NamingStrategy: schema.NamingStrategy{SingularTable: true}})
+	if err != nil {
+		t.Fatalf("failed to open the replica database: %v", err)
+	}
+	if err := replicaDB.AutoMigrate(&Url{}, &VariantClick{}); err != nil {
+		t.Fatalf("failed to migrate the replica database: %v", err)
+	}
+
+	replicas := openReplicas(replicaConfig{
+		DSNs:                 []string{replicaDSN},
+		CheckInterval:        time.Hour,
+		MaxLag:               time.Minute,
+		ReadYourWritesWindow: 200 * time.Millisecond,
+	})
+	defer replicas.close()
+	svc := dbService{db: srv.db, replicas: replicas}
+	repo := NewUrlRepositoryPG(svc)
+
+	stats := svc.Health()
+	if stats["replicas_healthy"] != "1" || stats["replica_0_status"] != "up" || stats["replica_0_lag"] != "0s" {
+		t.Fatalf("Health() = %v, want one healthy replica", stats)
+	}
+
+	// right after creating a link, a miss on the replica is read again from the primary
+	link := &database.Link{Target: "https://example.com/replicated"}
+	if err := repo.CreateLink(ctx, link); err != nil {
+		t.Fatalf("CreateLink() failed: %v", err)
+	}
+	if got, err := repo.GetLink(ctx, link.ID); err != nil || got.Target != link.Target {
+		t.Fatalf("GetLink() = %+v, %v, want the created link", got, err)
+	}
+	// lookups by target find nothing on the replica either, which is no reason to create the link again
+	if id, err := repo.GetID(ctx, link.Domain, link.Target); err != nil || id != link.ID {
+		t.Fatalf("GetID() = %d, %v, want %d from the primary", id, err, link.ID)
+	}
+	time.Sleep(250 * time.Millisecond)
+	if _, err := repo.GetLink(ctx, link.ID); !errors.Is(err, service.ErrUrlNotFound) {
+		t.Fatalf("GetLink() after the window = %v, want ErrUrlNotFound from the replica", err)
+	}
+	if _, err := repo.GetID(ctx, link.Domain, link.Target); !errors.Is(err, service.ErrUrlNotFound) {
+		t.Fatalf("GetID() after the window = %v, want ErrUrlNotFound from the replica", err)
+	}
+	if links, err := repo.ListLinks(ctx, database.ListLinksFilter{}); err != nil || len(links) != 0 {
+		t.Fatalf("ListLinks() = %v, %v, want the links of the replica", links, err)
+	}
+
+	// an unreachable replica is ejected and reads go to the primary
+	sqlDB, err := replicas.replicas[0].db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+	replicas.checkAll(ctx)
+	stats = svc.Health()
+	if stats["status"] != "up" || stats["replicas_healthy"] != "0" || stats["replica_0_status"] != "ejected" || stats["replica_0_error"] == "" {
+		t.Fatalf("Health() = %v, want the replica ejected", stats)
+	}
+	if got, err := repo.GetLink(ctx, link.ID); err != nil || got.Target != link.Target {
+		t.Fatalf("GetLink() = %+v, %v, want the link from the primary", got, err)
+	}
+}
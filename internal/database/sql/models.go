@@ -1,6 +1,225 @@
 package sql
 
+import (
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+)
+
 type Url struct {
-	Id      int64 `gorm:"primaryKey;AUTO_INCREMENT"`
+	Id int64 `gorm:"primaryKey;AUTO_INCREMENT;index:idx_url_created_at_id,priority:2"`
+	// Domain is the name of the domain the link is served from, '' for the default domain.
+	Domain  string `gorm:"not null;default:'';index"`
 	FullUrl string
+	// TargetDomain is the host of FullUrl, kept for indexed domain filtering.
+	TargetDomain    string   `gorm:"index"`
+	Owner           string   `gorm:"index"`
+	Tags            []string `gorm:"serializer:json;type:jsonb"`
+	Campaign        string   `gorm:"not null;default:'';index"`
+	Disabled        bool     `gorm:"not null;default:false"`
+	ExpiresAt       *time.Time
+	PasswordHash    string                `gorm:"not null;default:''"`
+	MaxClicks       int64                 `gorm:"not null;default:0"`
+	RemainingClicks int64                 `gorm:"not null;default:0"`
+	Rules           []rules.Rule          `gorm:"serializer:json;type:jsonb"`
+	Variants        []split.Variant       `gorm:"serializer:json;type:jsonb"`
+	SplitMode       split.Mode            `gorm:"not null;default:''"`
+	Passthrough     passthrough.Options   `gorm:"serializer:json;type:jsonb"`
+	Template        *urltemplate.Template `gorm:"serializer:json;type:jsonb"`
+	Clicks          int64                 `gorm:"not null;default:0"`
+	CreatedAt       time.Time             `gorm:"index:idx_url_created_at_id,priority:1"`
+	UpdatedAt       time.Time
+}
+
+// Tag is a tag links can be tagged with.
+type Tag struct {
+	Id   int64  `gorm:"primaryKey;autoIncrement"`
+	Name string `gorm:"not null;uniqueIndex"`
+}
+
+// LinkTag tags a link. The tags of a link are also kept in its tags column, to read links
+// without a join.
+type LinkTag struct {
+	UrlId int64 `gorm:"primaryKey;autoIncrement:false"`
+	TagId int64 `gorm:"primaryKey;autoIncrement:false;index"`
+}
+
+// VariantClick counts the resolves of a link that picked a split variant.
+type VariantClick struct {
+	UrlId     int64  `gorm:"primaryKey;autoIncrement:false"`
+	VariantId string `gorm:"primaryKey"`
+	Clicks    int64  `gorm:"not null;default:0"`
+}
+
+// ClickDay counts the resolves of a link on a UTC day.
+type ClickDay struct {
+	UrlId  int64     `gorm:"primaryKey;autoIncrement:false"`
+	Day    time.Time `gorm:"primaryKey;type:date"`
+	Clicks int64     `gorm:"not null;default:0"`
+}
+
+// IdRange is the row ID blocks are claimed from: NextId is the first ID no block has been
+// leased from yet.
+type IdRange struct {
+	Name   string `gorm:"primaryKey"`
+	NextId int64  `gorm:"not null"`
+}
+
+// IdLease records a block of link IDs leased to a process, from Start up to, but excluding, End.
+type IdLease struct {
+	Start    int64  `gorm:"primaryKey;autoIncrement:false"`
+	End      int64  `gorm:"not null"`
+	Owner    string `gorm:"not null;index"`
+	LeasedAt time.Time
+}
+
+// SchemaMigration records every schema version SyncDB has applied.
+type SchemaMigration struct {
+	Version   int64 `gorm:"primaryKey"`
+	AppliedAt time.Time
+}
+
+// OutboxEntry records a link mutation in the transaction of the mutation itself.
+type OutboxEntry struct {
+	Seq       int64                `gorm:"primaryKey;autoIncrement"`
+	Type      database.ChangeType  `gorm:"not null"`
+	LinkId    int64                `gorm:"not null;index"`
+	Link      database.Link        `gorm:"serializer:json;type:jsonb"`
+	Fields    []database.LinkField `gorm:"serializer:json;type:jsonb"`
+	CreatedAt time.Time
+}
+
+func (e OutboxEntry) toChange() database.Change {
+	return database.Change{Seq: e.Seq, Type: e.Type, Link: e.Link, Fields: e.Fields, Time: e.CreatedAt}
+}
+
+// WebhookSubscription is an endpoint webhook events are posted to.
+type WebhookSubscription struct {
+	Id        string               `gorm:"primaryKey"`
+	Url       string               `gorm:"not null"`
+	Secret    string               `gorm:"not null"`
+	Events    []webhooks.EventType `gorm:"serializer:json;type:jsonb"`
+	CreatedAt time.Time
+}
+
+// WebhookDelivery is a webhook event on its way to one subscription.
+type WebhookDelivery struct {
+	Id             string                  `gorm:"primaryKey"`
+	SubscriptionId string                  `gorm:"not null;index:idx_webhook_delivery_subscription,priority:1"`
+	Event          webhooks.Event          `gorm:"serializer:json;type:jsonb"`
+	Status         webhooks.DeliveryStatus `gorm:"not null;index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int                     `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time               `gorm:"index:idx_webhook_delivery_due,priority:2"`
+	LastAttemptAt  *time.Time
+	LastStatusCode int       `gorm:"not null;default:0"`
+	LastError      string    `gorm:"not null;default:''"`
+	CreatedAt      time.Time `gorm:"index:idx_webhook_delivery_subscription,priority:2"`
+}
+
+func (u Url) toLink() database.Link {
+	return database.Link{
+		ID:              u.Id,
+		Domain:          u.Domain,
+		Target:          u.FullUrl,
+		Owner:           u.Owner,
+		Tags:            u.Tags,
+		Campaign:        u.Campaign,
+		Disabled:        u.Disabled,
+		ExpiresAt:       u.ExpiresAt,
+		PasswordHash:    u.PasswordHash,
+		MaxClicks:       u.MaxClicks,
+		RemainingClicks: u.RemainingClicks,
+		Rules:           u.Rules,
+		Variants:        u.Variants,
+		SplitMode:       u.SplitMode,
+		Passthrough:     u.Passthrough,
+		Template:        u.Template,
+		Clicks:          u.Clicks,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
+	}
+}
+
+func fromLink(link database.Link) Url {
+	return Url{
+		Id:              link.ID,
+		Domain:          link.Domain,
+		FullUrl:         link.Target,
+		TargetDomain:    database.TargetDomain(link.Target),
+		Owner:           link.Owner,
+		Tags:            link.Tags,
+		Campaign:        link.Campaign,
+		Disabled:        link.Disabled,
+		ExpiresAt:       link.ExpiresAt,
+		PasswordHash:    link.PasswordHash,
+		MaxClicks:       link.MaxClicks,
+		RemainingClicks: link.RemainingClicks,
+		Rules:           link.Rules,
+		Variants:        link.Variants,
+		SplitMode:       link.SplitMode,
+		Passthrough:     link.Passthrough,
+		Template:        link.Template,
+		Clicks:          link.Clicks,
+		CreatedAt:       link.CreatedAt,
+		UpdatedAt:       link.UpdatedAt,
+	}
+}
+
+// linkColumns maps updatable link fields to their columns.
+var linkColumns = map[database.LinkField]string{
+	database.LinkFieldTarget:      "full_url",
+	database.LinkFieldOwner:       "owner",
+	database.LinkFieldTags:        "tags",
+	database.LinkFieldCampaign:    "campaign",
+	database.LinkFieldDisabled:    "disabled",
+	database.LinkFieldExpiresAt:   "expires_at",
+	database.LinkFieldPassword:    "password_hash",
+	database.LinkFieldMaxClicks:   "max_clicks",
+	database.LinkFieldVariants:    "variants",
+	database.LinkFieldSplitMode:   "split_mode",
+	database.LinkFieldPassthrough: "passthrough",
+	database.LinkFieldTemplate:    "template",
+}
+
+func (s WebhookSubscription) toSubscription() webhooks.Subscription {
+	return webhooks.Subscription{ID: s.Id, URL: s.Url, Secret: s.Secret, Events: s.Events, CreatedAt: s.CreatedAt}
+}
+
+func fromSubscription(sub webhooks.Subscription) WebhookSubscription {
+	return WebhookSubscription{Id: sub.ID, Url: sub.URL, Secret: sub.Secret, Events: sub.Events, CreatedAt: sub.CreatedAt}
+}
+
+func (d WebhookDelivery) toDelivery() webhooks.Delivery {
+	return webhooks.Delivery{
+		ID:             d.Id,
+		SubscriptionID: d.SubscriptionId,
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+func fromDelivery(d webhooks.Delivery) WebhookDelivery {
+	return WebhookDelivery{
+		Id:             d.ID,
+		SubscriptionId: d.SubscriptionID,
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
 }
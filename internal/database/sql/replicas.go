@@ -0,0 +1,244 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// replicaConfig configures the read replicas.
+type replicaConfig struct {
+	// DSNs are the connection strings of the replicas, none to read from the primary.
+	DSNs []string
+	// CheckInterval is how often the health and lag of every replica are checked.
+	CheckInterval time.Duration
+	// MaxLag is the replication lag beyond which a replica is ejected until it catches up.
+	MaxLag time.Duration
+	// ReadYourWritesWindow is how long after a link is created that a replica missing a link is
+	// asked again on the primary, since it may not have replayed the link yet.
+	ReadYourWritesWindow time.Duration
+}
+
+var defaultReplicaConfig = replicaConfig{
+	CheckInterval:        5 * time.Second,
+	MaxLag:               10 * time.Second,
+	ReadYourWritesWindow: 15 * time.Second,
+}
+
+// replicaConfigFromEnv reads the comma separated replica DSNs from URLSHORTENER_DB_REPLICA_DSNS,
+// and the rest from URLSHORTENER_DB_REPLICA_CHECK_INTERVAL, URLSHORTENER_DB_REPLICA_MAX_LAG and
+// URLSHORTENER_DB_READ_YOUR_WRITES_WINDOW, using defaultReplicaConfig for unset or malformed values.
+func replicaConfigFromEnv() replicaConfig {
+	cfg := defaultReplicaConfig
+	for _, dsn := range strings.Split(os.Getenv("URLSHORTENER_DB_REPLICA_DSNS"), ",") {
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			cfg.DSNs = append(cfg.DSNs, dsn)
+		}
+	}
+	envDuration := func(key string, v *time.Duration) {
+		if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+			*v = d
+		}
+	}
+	envDuration("URLSHORTENER_DB_REPLICA_CHECK_INTERVAL", &cfg.CheckInterval)
+	envDuration("URLSHORTENER_DB_REPLICA_MAX_LAG", &cfg.MaxLag)
+	envDuration("URLSHORTENER_DB_READ_YOUR_WRITES_WINDOW", &cfg.ReadYourWritesWindow)
+	return cfg
+}
+
+// replicaLag is the time since the last transaction a replica replayed, or 0 when it has
+// replayed everything it received, so that a replica of an idle primary does not seem behind.
+// It is 0 on a server that is not a replica.
+const replicaLag = `SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`
+
+// replica is a read replica with the outcome of its last check.
+type replica struct {
+	name string
+	db   *gorm.DB
+
+	mu      sync.Mutex
+	healthy bool
+	lag     time.Duration
+	err     error
+	checked time.Time
+}
+
+// check pings the replica and measures its lag. It is ejected if either fails or it lags more
+// than maxLag, and taken back once it passes again.
+func (r *replica) check(ctx context.Context, maxLag time.Duration) {
+	var seconds float64
+	err := r.db.WithContext(ctx).Raw(replicaLag).Scan(&seconds).Error
+	lag := time.Duration(seconds * float64(time.Second))
+	if err == nil && lag > maxLag {
+		err = fmt.Errorf("replication lag %s exceeds %s", lag.Round(time.Millisecond), maxLag)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if healthy := err == nil; healthy != r.healthy {
+		if healthy {
+			log.Printf("Read replica %s is back", r.name)
+		} else {
+			log.Printf("Ejecting read replica %s: %v", r.name, err)
+		}
+	}
+	r.healthy, r.lag, r.err, r.checked = err == nil, lag, err, time.Now()
+}
+
+func (r *replica) isHealthy() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.healthy
+}
+
+// replicaSet routes reads to its healthy replicas in turn.
+type replicaSet struct {
+	cfg      replicaConfig
+	replicas []*replica
+	next     atomic.Uint64
+	// lastCreate is when a link was last created through this process, in Unix nanoseconds.
+	lastCreate atomic.Int64
+	stop       context.CancelFunc
+	done       chan struct{}
+}
+
+// openReplicas connects to the replicas of cfg and checks them once before returning, so that
+// reads go to the healthy ones right away. Replicas that cannot be reached start out ejected.
+func openReplicas(cfg replicaConfig) *replicaSet {
+	set := &replicaSet{cfg: cfg, done: make(chan struct{})}
+	for _, dsn := range cfg.DSNs {
+		db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+			NamingStrategy:       schema.NamingStrategy{SingularTable: true},
+			DisableAutomaticPing: true,
+		})
+		if err != nil {
+			log.Fatalf("Failed to open read replica %s: %v", replicaName(dsn), err)
+		}
+		set.replicas = append(set.replicas, &replica{name: replicaName(dsn), db: db})
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	set.stop = stop
+	set.checkAll(ctx)
+	go set.monitor(ctx)
+	return set
+}
+
+// replicaName identifies a replica in logs and health reports without its password. DSNs are
+// either URLs or key=value pairs.
+func replicaName(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Host != "" {
+		return u.Host
+	}
+	name := ""
+	for _, field := range strings.Fields(dsn) {
+		if v, ok := strings.CutPrefix(field, "host="); ok {
+			name = v + name
+		}
+		if v, ok := strings.CutPrefix(field, "port="); ok {
+			name += ":" + v
+		}
+	}
+	return name
+}
+
+func (s *replicaSet) monitor(ctx context.Context) {
+	defer close(s.done)
+	ticker := time.NewTicker(s.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkAll(ctx)
+		}
+	}
+}
+
+func (s *replicaSet) checkAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, r := range s.replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, s.cfg.CheckInterval)
+			defer cancel()
+			r.check(ctx, s.cfg.MaxLag)
+		}()
+	}
+	wg.Wait()
+}
+
+// pick returns the next healthy replica, nil if there is none.
+func (s *replicaSet) pick() *gorm.DB {
+	n := uint64(len(s.replicas))
+	start := s.next.Add(1)
+	for i := range n {
+		if r := s.replicas[(start+i)%n]; r.isHealthy() {
+			return r.db
+		}
+	}
+	return nil
+}
+
+// created records that a link was created, for the read-your-writes guard.
+func (s *replicaSet) created() {
+	s.lastCreate.Store(time.Now().UnixNano())
+}
+
+// createdRecently reports whether a link was created within the read-your-writes window.
+func (s *replicaSet) createdRecently() bool {
+	return time.Since(time.Unix(0, s.lastCreate.Load())) < s.cfg.ReadYourWritesWindow
+}
+
+// health adds the status and lag of every replica to stats.
+func (s *replicaSet) health(stats map[string]string) {
+	healthy := 0
+	for i, r := range s.replicas {
+		r.mu.Lock()
+		prefix := fmt.Sprintf("replica_%d_", i)
+		stats[prefix+"host"] = r.name
+		stats[prefix+"status"] = "up"
+		if !r.healthy {
+			stats[prefix+"status"] = "ejected"
+		} else {
+			healthy++
+		}
+		stats[prefix+"lag"] = r.lag.Round(time.Millisecond).String()
+		if r.err != nil {
+			stats[prefix+"error"] = r.err.Error()
+		}
+		if !r.checked.IsZero() {
+			stats[prefix+"checked_at"] = r.checked.UTC().Format(time.RFC3339)
+		}
+		r.mu.Unlock()
+	}
+	stats["replicas"] = strconv.Itoa(len(s.replicas))
+	stats["replicas_healthy"] = strconv.Itoa(healthy)
+}
+
+// close stops checking the replicas and disconnects from them.
+func (s *replicaSet) close() error {
+	s.stop()
+	<-s.done
+	var errs []error
+	for _, r := range s.replicas {
+		if sqlDB, err := r.db.DB(); err == nil {
+			errs = append(errs, sqlDB.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -3,10 +3,18 @@ package sql
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/rules"
 	"github.com/Parzival-05/url-shortener/internal/service"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UrlRepositoryPG struct {
@@ -17,27 +25,582 @@ func NewUrlRepositoryPG(db dbService) *UrlRepositoryPG {
 	return &UrlRepositoryPG{db: db}
 }
 
-func (u *UrlRepositoryPG) GetID(ctx context.Context, fullUrl string) (id int64, err error) {
-	url, err := gorm.G[Url](u.db.db).Where("full_url = ?", fullUrl).First(ctx)
+func (u *UrlRepositoryPG) GetID(ctx context.Context, domain, fullUrl string) (id int64, err error) {
+	err = u.db.read(func(db *gorm.DB) error {
+		// The columns narrow the candidates down, Plain checks the JSON ones.
+		urls, err := gorm.G[Url](db).
+			Where("domain = ? AND full_url = ?", domain, fullUrl).
+			Where("NOT disabled AND expires_at IS NULL AND password_hash = '' AND max_clicks = 0 AND COALESCE(owner, '') = '' AND campaign = ''").
+			Order("id").
+			Find(ctx)
+		if err != nil {
+			return err
+		}
+		for _, url := range urls {
+			if url.toLink().Plain() {
+				id = url.Id
+				return nil
+			}
+		}
+		// Not found, like First, so that a replica behind a recent create is not trusted.
+		return gorm.ErrRecordNotFound
+	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return 0, service.ErrUrlNotFound
 		}
+		zap_utils.FromContext(ctx, nil).Error("failed to get url id", zap_utils.Err(err))
 		return 0, err
 	}
-	return url.Id, nil
+	return id, nil
 }
 
 func (u *UrlRepositoryPG) GetUrlByID(ctx context.Context, id int64) (fullUrl string, err error) {
-	url, err := gorm.G[Url](u.db.db).Where("id = ?", id).First(ctx)
+	var url Url
+	err = u.db.read(func(db *gorm.DB) (err error) {
+		url, err = gorm.G[Url](db).Where("id = ?", id).First(ctx)
+		return err
+	})
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", service.ErrUrlNotFound
+		}
+		zap_utils.FromContext(ctx, nil).Debug("failed to get url by id", zap.Int64("id", id), zap_utils.Err(err))
 		return "", err
 	}
 	return url.FullUrl, nil
 }
 
-func (u *UrlRepositoryPG) SaveUrl(ctx context.Context, fullUrl string) (err error) {
-	url := Url{FullUrl: fullUrl}
-	err = gorm.G[Url](u.db.db).Create(ctx, &url)
+func (u *UrlRepositoryPG) SaveUrl(ctx context.Context, domain, fullUrl string) (err error) {
+	url := Url{Domain: domain, FullUrl: fullUrl}
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&url).Error; err != nil {
+			return err
+		}
+		return recordChange(tx, database.ChangeCreated, url.toLink(), nil)
+	})
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to save url", zap_utils.Err(err))
+		return err
+	}
+	u.db.created()
+	return nil
+}
+
+func (u *UrlRepositoryPG) CreateLink(ctx context.Context, link *database.Link) (err error) {
+	url := fromLink(*link)
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&url).Error; err != nil {
+			return err
+		}
+		if err := setLinkTags(tx, url.Id, url.Tags); err != nil {
+			return err
+		}
+		return recordChange(tx, database.ChangeCreated, url.toLink(), nil)
+	})
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to create link", zap_utils.Err(err))
+		return err
+	}
+	u.db.created()
+	*link = url.toLink()
+	return nil
+}
+
+// advanceIDSequence moves the ID sequence of links up to id, so that links created later get
+// higher IDs. It never moves it back, which would reissue the IDs of deleted links.
+const advanceIDSequence = `SELECT setval(s::regclass, GREATEST(?, COALESCE(pg_sequence_last_value(s::regclass), 0))) FROM pg_get_serial_sequence('url', 'id') AS s`
+
+func (u *UrlRepositoryPG) ImportLink(ctx context.Context, link *database.Link) (err error) {
+	return u.insertWithID(ctx, link, true)
+}
+
+// CreateLinkWithID skips moving the ID sequence, a write to it that every insert under a leased
+// ID would otherwise make.
+func (u *UrlRepositoryPG) CreateLinkWithID(ctx context.Context, link *database.Link) (err error) {
+	return u.insertWithID(ctx, link, false)
+}
+
+// insertWithID stores a new link under its ID, or a new one from the sequence if it is 0. With
+// advance, the sequence is moved past the ID.
+func (u *UrlRepositoryPG) insertWithID(ctx context.Context, link *database.Link, advance bool) (err error) {
+	url := fromLink(*link)
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if url.Id == 0 {
+			if err := tx.Create(&url).Error; err != nil {
+				return err
+			}
+		} else {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&url)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return service.ErrCodeTaken
+			}
+			if advance {
+				if err := tx.Exec(advanceIDSequence, url.Id).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if err := setLinkTags(tx, url.Id, url.Tags); err != nil {
+			return err
+		}
+		return recordChange(tx, database.ChangeCreated, url.toLink(), nil)
+	})
+	if err != nil {
+		if !errors.Is(err, service.ErrCodeTaken) {
+			zap_utils.FromContext(ctx, nil).Error("failed to import link", zap_utils.Err(err))
+		}
+		return err
+	}
+	u.db.created()
+	*link = url.toLink()
+	return nil
+}
+
+func (u *UrlRepositoryPG) GetLink(ctx context.Context, id int64) (link database.Link, err error) {
+	var url Url
+	err = u.db.read(func(db *gorm.DB) (err error) {
+		url, err = gorm.G[Url](db).Where("id = ?", id).First(ctx)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return database.Link{}, service.ErrUrlNotFound
+		}
+		return database.Link{}, err
+	}
+	return url.toLink(), nil
+}
+
+func (u *UrlRepositoryPG) UpdateLink(ctx context.Context, link database.Link, fields []database.LinkField) (updated database.Link, err error) {
+	columns := []string{"updated_at"}
+	for _, field := range fields {
+		if column, ok := linkColumns[field]; ok {
+			columns = append(columns, column)
+		}
+		switch field {
+		case database.LinkFieldTarget:
+			columns = append(columns, "target_domain")
+		case database.LinkFieldMaxClicks:
+			columns = append(columns, "remaining_clicks")
+		}
+	}
+	url := fromLink(link)
+	url.RemainingClicks = url.MaxClicks
+	url.UpdatedAt = time.Now()
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Url{}).Where("id = ?", link.ID).Select(columns).Updates(&url)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return service.ErrUrlNotFound
+		}
+		var stored Url
+		if err := tx.Where("id = ?", link.ID).First(&stored).Error; err != nil {
+			return err
+		}
+		if slices.Contains(fields, database.LinkFieldTags) {
+			if err := setLinkTags(tx, stored.Id, stored.Tags); err != nil {
+				return err
+			}
+		}
+		updated = stored.toLink()
+		return recordChange(tx, database.ChangeUpdated, updated, fields)
+	})
+	if err != nil {
+		if !errors.Is(err, service.ErrUrlNotFound) {
+			zap_utils.FromContext(ctx, nil).Error("failed to update link", zap.Int64("id", link.ID), zap_utils.Err(err))
+		}
+		return database.Link{}, err
+	}
+	return updated, nil
+}
+
+func (u *UrlRepositoryPG) DeleteLink(ctx context.Context, id int64) (err error) {
+	return u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var url Url
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&url).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return service.ErrUrlNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&url).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("url_id = ?", id).Delete(&VariantClick{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("url_id = ?", id).Delete(&LinkTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("url_id = ?", id).Delete(&ClickDay{}).Error; err != nil {
+			return err
+		}
+		return recordChange(tx, database.ChangeDeleted, url.toLink(), nil)
+	})
+}
+
+// setLinkTags makes the link_tag rows of the link match tags, creating the tags that do not
+// exist yet.
+func setLinkTags(tx *gorm.DB, id int64, tags []string) error {
+	if err := tx.Where("url_id = ?", id).Delete(&LinkTag{}).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	rows := make([]Tag, 0, len(tags))
+	for _, name := range tags {
+		rows = append(rows, Tag{Name: name})
+	}
+	err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&rows).Error
+	if err != nil {
+		return err
+	}
+	return tx.Exec(`INSERT INTO link_tag (url_id, tag_id) SELECT ?, id FROM tag WHERE name IN ?`, id, tags).Error
+}
+
+func (u *UrlRepositoryPG) UpdateTags(ctx context.Context, domain string, ids []int64, add, remove []string) (updated []database.Link, found []int64, err error) {
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updated, found = nil, nil
+		var urls []Url
+		// The row locks hold concurrent tag updates of the links until this one commits.
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND domain = ?", ids, domain).Order("id").Find(&urls).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, url := range urls {
+			found = append(found, url.Id)
+			tags := database.EditTags(url.Tags, add, remove)
+			if slices.Equal(tags, url.Tags) {
+				continue
+			}
+			url.Tags, url.UpdatedAt = tags, now
+			if err := tx.Model(&url).Select("tags", "updated_at").Updates(&url).Error; err != nil {
+				return err
+			}
+			if err := setLinkTags(tx, url.Id, tags); err != nil {
+				return err
+			}
+			updated = append(updated, url.toLink())
+		}
+		for _, link := range updated {
+			if err := recordChange(tx, database.ChangeUpdated, link, []database.LinkField{database.LinkFieldTags}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to update link tags", zap.Int("links", len(ids)), zap_utils.Err(err))
+		return nil, nil, err
+	}
+	return updated, found, nil
+}
+
+func (u *UrlRepositoryPG) CampaignStats(ctx context.Context, domain string) (stats []database.CampaignStats, err error) {
+	db, _ := u.db.reader()
+	err = db.WithContext(ctx).Model(&Url{}).
+		Select("campaign, COUNT(*) AS links, COALESCE(SUM(clicks), 0) AS clicks").
+		Where("campaign <> '' AND domain = ?", domain).
+		Group("campaign").
+		Order("campaign").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (u *UrlRepositoryPG) RecordClick(ctx context.Context, id int64, at time.Time) (err error) {
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Url{}).Where("id = ?", id).UpdateColumn("clicks", gorm.Expr("clicks + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return service.ErrUrlNotFound
+		}
+		day := ClickDay{UrlId: id, Day: database.ClickDay(at), Clicks: 1}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "url_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]any{"clicks": gorm.Expr("click_day.clicks + 1")}),
+		}).Create(&day).Error
+	})
+	if err != nil && !errors.Is(err, service.ErrUrlNotFound) {
+		zap_utils.FromContext(ctx, nil).Error("failed to record click", zap.Int64("id", id), zap_utils.Err(err))
+	}
 	return err
 }
+
+func (u *UrlRepositoryPG) ClickHistory(ctx context.Context, id int64, since time.Time) (days []database.DailyClicks, err error) {
+	db, _ := u.db.reader()
+	rows, err := gorm.G[ClickDay](db).Where("url_id = ? AND day >= ?", id, database.ClickDay(since)).Order("day").Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	days = make([]database.DailyClicks, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, database.DailyClicks{Day: database.ClickDay(row.Day), Clicks: row.Clicks})
+	}
+	return days, nil
+}
+
+func (u *UrlRepositoryPG) SetClickHistory(ctx context.Context, id int64, days []database.DailyClicks) (err error) {
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("url_id = ?", id).Delete(&ClickDay{}).Error; err != nil {
+			return err
+		}
+		rows := make([]ClickDay, 0, len(days))
+		for _, day := range days {
+			rows = append(rows, ClickDay{UrlId: id, Day: database.ClickDay(day.Day), Clicks: day.Clicks})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to set click history", zap.Int64("id", id), zap_utils.Err(err))
+	}
+	return err
+}
+
+func (u *UrlRepositoryPG) ConsumeClick(ctx context.Context, id int64) (remaining int64, err error) {
+	// The conditional UPDATE serializes racing resolves on the row lock,
+	// so exactly max_clicks of them succeed. RETURNING reads the row as this UPDATE left it.
+	var (
+		url      Url
+		consumed bool
+	)
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&url).Clauses(clause.Returning{}).
+			Where("id = ? AND max_clicks > 0 AND remaining_clicks > 0", id).
+			UpdateColumn("remaining_clicks", gorm.Expr("remaining_clicks - 1"))
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		consumed = true
+		return recordChange(tx, database.ChangeUpdated, url.toLink(), []database.LinkField{database.LinkFieldRemainingClicks})
+	})
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to consume click", zap.Int64("id", id), zap_utils.Err(err))
+		return 0, err
+	}
+	if consumed {
+		return url.RemainingClicks, nil
+	}
+	link, err := u.GetLink(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if link.MaxClicks > 0 {
+		return 0, service.ErrLinkExhausted
+	}
+	return -1, nil
+}
+
+func (u *UrlRepositoryPG) UpdateRules(ctx context.Context, id int64, update func([]rules.Rule) ([]rules.Rule, error)) (updated database.Link, err error) {
+	var updateErr error
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The row lock holds concurrent rule updates of the link until this one commits.
+		var url Url
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&url).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return service.ErrUrlNotFound
+			}
+			return err
+		}
+		url.Rules, updateErr = update(url.Rules)
+		if updateErr != nil {
+			return updateErr
+		}
+		url.UpdatedAt = time.Now()
+		if err := tx.Model(&url).Select("rules", "updated_at").Updates(&url).Error; err != nil {
+			return err
+		}
+		updated = url.toLink()
+		return recordChange(tx, database.ChangeUpdated, updated, []database.LinkField{database.LinkFieldRules})
+	})
+	if err != nil {
+		if updateErr == nil && !errors.Is(err, service.ErrUrlNotFound) {
+			zap_utils.FromContext(ctx, nil).Error("failed to update link rules", zap.Int64("id", id), zap_utils.Err(err))
+		}
+		return database.Link{}, err
+	}
+	return updated, nil
+}
+
+func (u *UrlRepositoryPG) RecordVariantClick(ctx context.Context, id int64, variantID string) (err error) {
+	click := VariantClick{UrlId: id, VariantId: variantID, Clicks: 1}
+	err = u.db.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "url_id"}, {Name: "variant_id"}},
+		DoUpdates: clause.Assignments(map[string]any{"clicks": gorm.Expr("variant_click.clicks + 1")}),
+	}).Create(&click).Error
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to record variant click", zap.Int64("id", id), zap_utils.Err(err))
+	}
+	return err
+}
+
+func (u *UrlRepositoryPG) VariantClicks(ctx context.Context, id int64) (clicks map[string]int64, err error) {
+	db, _ := u.db.reader()
+	rows, err := gorm.G[VariantClick](db).Where("url_id = ?", id).Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clicks = make(map[string]int64, len(rows))
+	for _, row := range rows {
+		clicks[row.VariantId] = row.Clicks
+	}
+	return clicks, nil
+}
+
+func (u *UrlRepositoryPG) SetVariantClicks(ctx context.Context, id int64, clicks map[string]int64) (err error) {
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("url_id = ?", id).Delete(&VariantClick{}).Error; err != nil {
+			return err
+		}
+		rows := make([]VariantClick, 0, len(clicks))
+		for variantID, n := range clicks {
+			rows = append(rows, VariantClick{UrlId: id, VariantId: variantID, Clicks: n})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to set variant clicks", zap.Int64("id", id), zap_utils.Err(err))
+	}
+	return err
+}
+
+// lastID reads the ID sequence of links; pg_sequence_last_value is NULL until it is first used.
+const lastID = `SELECT COALESCE(pg_sequence_last_value(s::regclass), 0) FROM pg_get_serial_sequence('url', 'id') AS s`
+
+func (u *UrlRepositoryPG) LastID(ctx context.Context) (id int64, err error) {
+	err = u.db.db.WithContext(ctx).Raw(lastID).Scan(&id).Error
+	return id, err
+}
+
+func (u *UrlRepositoryPG) AdvanceIDs(ctx context.Context, id int64) (err error) {
+	if id <= 0 {
+		return nil
+	}
+	err = u.db.db.WithContext(ctx).Exec(advanceIDSequence, id).Error
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to advance the ID sequence", zap.Int64("id", id), zap_utils.Err(err))
+	}
+	return err
+}
+
+// urlIDRange names the range-claim row of link IDs.
+const urlIDRange = "url"
+
+// LeaseIDs claims a block from the range-claim row, which the row lock serializes between
+// processes. Blocks start above the ID sequence, which is then moved past them, so links created
+// or imported without a block never get a leased ID. A link created from the sequence while a
+// block is claimed can still take an ID of the block; inserting it again then fails with
+// service.ErrCodeTaken rather than storing a duplicate.
+func (u *UrlRepositoryPG) LeaseIDs(ctx context.Context, owner string, size int64) (block database.IDBlock, err error) {
+	err = u.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		claim := IdRange{Name: urlIDRange, NextId: 1}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&claim).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&claim, "name = ?", urlIDRange).Error; err != nil {
+			return err
+		}
+		var last int64
+		if err := tx.Raw(lastID).Scan(&last).Error; err != nil {
+			return err
+		}
+		block = database.IDBlock{Start: max(claim.NextId, last+1)}
+		block.End = block.Start + size
+		if err := tx.Model(&claim).Update("next_id", block.End).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(advanceIDSequence, block.End-1).Error; err != nil {
+			return err
+		}
+		return tx.Create(&IdLease{Start: block.Start, End: block.End, Owner: owner, LeasedAt: time.Now()}).Error
+	})
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to lease link IDs", zap.String("owner", owner), zap_utils.Err(err))
+		return database.IDBlock{}, err
+	}
+	return block, nil
+}
+
+func (u *UrlRepositoryPG) ListLinks(ctx context.Context, filter database.ListLinksFilter) (links []database.Link, err error) {
+	db, _ := u.db.reader()
+
+	// Keyset pagination: continue strictly after the cursor in sort order.
+	op, dir := ">", ""
+	if filter.Sort.Desc() {
+		op, dir = "<", " DESC"
+	}
+	var query gorm.ChainInterface[Url]
+	if filter.Sort.ByCreatedAt() {
+		query = gorm.G[Url](db).Order("created_at" + dir + ", id" + dir)
+		if filter.After != nil {
+			query = query.Where("(created_at, id) "+op+" (?, ?)", filter.After.CreatedAt, filter.After.ID)
+		}
+	} else {
+		query = gorm.G[Url](db).Order("id" + dir)
+		if filter.After != nil {
+			query = query.Where("id "+op+" ?", filter.After.ID)
+		}
+	}
+
+	if filter.Owner != "" {
+		query = query.Where("owner = ?", filter.Owner)
+	}
+	if filter.Tag != "" {
+		query = query.Where("id IN (SELECT link_tag.url_id FROM link_tag JOIN tag ON tag.id = link_tag.tag_id WHERE tag.name = ?)", filter.Tag)
+	}
+	if filter.Campaign != "" {
+		query = query.Where("campaign = ?", filter.Campaign)
+	}
+	if !filter.IncludeDisabled {
+		query = query.Where("disabled = ?", false)
+	}
+	if filter.TargetDomain != "" {
+		query = query.Where("target_domain = ?", strings.ToLower(filter.TargetDomain))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedBefore)
+	}
+	if filter.TargetContains != "" {
+		query = query.Where("full_url ILIKE ?", "%"+likeEscaper.Replace(filter.TargetContains)+"%")
+	}
+	if filter.Domain != nil {
+		query = query.Where("domain = ?", *filter.Domain)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	urls, err := query.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	links = make([]database.Link, 0, len(urls))
+	for _, url := range urls {
+		links = append(links, url.toLink())
+	}
+	return links, nil
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,150 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookStorePG struct {
+	db dbService
+}
+
+func NewWebhookStorePG(db dbService) *WebhookStorePG {
+	return &WebhookStorePG{db: db}
+}
+
+func (w *WebhookStorePG) CreateSubscription(ctx context.Context, sub webhooks.Subscription) error {
+	model := fromSubscription(sub)
+	err := gorm.G[WebhookSubscription](w.db.db).Create(ctx, &model)
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to create webhook subscription", zap_utils.Err(err))
+	}
+	return err
+}
+
+func (w *WebhookStorePG) GetSubscription(ctx context.Context, id string) (webhooks.Subscription, error) {
+	sub, err := gorm.G[WebhookSubscription](w.db.db).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return webhooks.Subscription{}, webhooks.ErrSubscriptionNotFound
+		}
+		return webhooks.Subscription{}, err
+	}
+	return sub.toSubscription(), nil
+}
+
+func (w *WebhookStorePG) ListSubscriptions(ctx context.Context) ([]webhooks.Subscription, error) {
+	models, err := gorm.G[WebhookSubscription](w.db.db).Order("created_at, id").Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subs := make([]webhooks.Subscription, 0, len(models))
+	for _, model := range models {
+		subs = append(subs, model.toSubscription())
+	}
+	return subs, nil
+}
+
+func (w *WebhookStorePG) DeleteSubscription(ctx context.Context, id string) error {
+	return w.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&WebhookSubscription{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return webhooks.ErrSubscriptionNotFound
+		}
+		return nil
+	})
+}
+
+func (w *WebhookStorePG) CreateDeliveries(ctx context.Context, deliveries []webhooks.Delivery) error {
+	models := make([]WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		models = append(models, fromDelivery(d))
+	}
+	err := w.db.db.WithContext(ctx).Create(&models).Error
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to create webhook deliveries", zap_utils.Err(err))
+	}
+	return err
+}
+
+func (w *WebhookStorePG) GetDelivery(ctx context.Context, id string) (webhooks.Delivery, error) {
+	d, err := gorm.G[WebhookDelivery](w.db.db).Where("id = ?", id).First(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return webhooks.Delivery{}, webhooks.ErrDeliveryNotFound
+		}
+		return webhooks.Delivery{}, err
+	}
+	return d.toDelivery(), nil
+}
+
+func (w *WebhookStorePG) ListDeliveries(ctx context.Context, subscriptionID string, status webhooks.DeliveryStatus, limit int) ([]webhooks.Delivery, error) {
+	query := w.db.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var models []WebhookDelivery
+	if err := query.Order("created_at DESC, id DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	deliveries := make([]webhooks.Delivery, 0, len(models))
+	for _, model := range models {
+		deliveries = append(deliveries, model.toDelivery())
+	}
+	return deliveries, nil
+}
+
+func (w *WebhookStorePG) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhooks.Delivery, error) {
+	var deliveries []webhooks.Delivery
+	err := w.db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent dispatchers claim disjoint batches.
+		var models []WebhookDelivery
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", webhooks.StatusPending, now).
+			Order("next_attempt_at, id").Limit(limit).Find(&models).Error
+		if err != nil || len(models) == 0 {
+			return err
+		}
+		ids := make([]string, 0, len(models))
+		for _, model := range models {
+			ids = append(ids, model.Id)
+			deliveries = append(deliveries, model.toDelivery())
+		}
+		return tx.Model(&WebhookDelivery{}).Where("id IN ?", ids).UpdateColumn("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		zap_utils.FromContext(ctx, nil).Error("failed to claim webhook deliveries", zap_utils.Err(err))
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (w *WebhookStorePG) UpdateDelivery(ctx context.Context, delivery webhooks.Delivery) error {
+	model := fromDelivery(delivery)
+	result := w.db.db.WithContext(ctx).Model(&model).
+		Select("status", "attempts", "next_attempt_at", "last_attempt_at", "last_status_code", "last_error").
+		Updates(&model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return webhooks.ErrDeliveryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+// Package domains is the registry of the domains short links are served from.
+//
+// Every link belongs to a domain, and its code is only valid on that domain. The default
+// domain is configured by BASE_URL and SECRET_ALPHABET and holds every link created before
+// domains existed; further domains are read from the JSON file at DOMAINS_PATH.
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sqids/sqids-go"
+)
+
+// Header is the HTTP header (and lower-cased gRPC metadata key) selecting the domain of an API
+// call explicitly. Without it the Host header or gRPC authority select it.
+const Header = "X-Link-Domain"
+
+// MetadataKey is the gRPC metadata key selecting the domain.
+const MetadataKey = "x-link-domain"
+
+var (
+	ErrUnknownDomain = errors.New("unknown domain")
+	ErrInvalidConfig = errors.New("invalid domain configuration")
+)
+
+// codeLength is the minimum length of generated codes.
+const codeLength = 10
+
+// alphabetPattern keeps codes within the characters allowed in short codes.
+var alphabetPattern = regexp.MustCompile(`^[0-9A-Za-z_-]*$`)
+
+// Domain is a host short links are served from.
+type Domain struct {
+	// Host is the host name requests for the domain arrive at, e.g. go.brand-a.com.
+	// It is empty for the default domain when BASE_URL is not set.
+	Host string `json:"host"`
+	// BaseURL is the public scheme and host of short URLs, https://Host if unset.
+	BaseURL string `json:"base_url,omitempty"`
+	// Alphabet is the sqids alphabet codes are encoded with, SECRET_ALPHABET if unset.
+	Alphabet string `json:"alphabet,omitempty"`
+	// RedirectStatus is the status of redirects: 301, 302 (default), 303, 307 or 308.
+	RedirectStatus int `json:"redirect_status,omitempty"`
+	// NotFoundPage is the path of an HTML page served for unknown codes instead of a JSON error.
+	NotFoundPage string `json:"not_found_page,omitempty"`
+
+	name     string
+	encoder  *sqids.Sqids
+	notFound []byte
+}
+
+// Name is the domain stored with links: empty for the default domain, else Host.
+func (d *Domain) Name() string {
+	return d.name
+}
+
+// Encode returns the code of a link ID on the domain.
+func (d *Domain) Encode(id int64) (string, error) {
+	return d.encoder.Encode([]uint64{uint64(id)})
+}
+
+// Decode returns the link ID of a code on the domain.
+func (d *Domain) Decode(code string) (int64, bool) {
+	numbers := d.encoder.Decode(code)
+	if len(numbers) == 0 {
+		return 0, false
+	}
+	return int64(numbers[0]), true
+}
+
+// ShortURL returns the public short URL of a code, or "" if the domain has no base URL.
+func (d *Domain) ShortURL(code string) string {
+	if d.BaseURL == "" {
+		return ""
+	}
+	return d.BaseURL + "/" + code
+}
+
+// NotFound returns the contents of the not found page, nil if the domain has none.
+func (d *Domain) NotFound() []byte {
+	return d.notFound
+}
+
+// Status returns the status of redirects to link targets.
+func (d *Domain) Status() int {
+	if d.RedirectStatus == 0 {
+		return http.StatusFound
+	}
+	return d.RedirectStatus
+}
+
+func (d *Domain) init() error {
+	if d.BaseURL != "" {
+		u, err := url.Parse(d.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: base_url of %q must be an absolute http(s) URL, got %q", ErrInvalidConfig, d.Host, d.BaseURL)
+		}
+		d.BaseURL = strings.TrimRight(d.BaseURL, "/")
+	}
+	if !alphabetPattern.MatchString(d.Alphabet) {
+		return fmt.Errorf("%w: alphabet of %q may only contain 0-9, A-Z, a-z, _ and -", ErrInvalidConfig, d.Host)
+	}
+	encoder, err := sqids.New(sqids.Options{Alphabet: d.Alphabet, MinLength: codeLength})
+	if err != nil {
+		return fmt.Errorf("%w: alphabet of %q: %w", ErrInvalidConfig, d.Host, err)
+	}
+	d.encoder = encoder
+	switch d.RedirectStatus {
+	case 0, http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return fmt.Errorf("%w: redirect_status of %q must be 301, 302, 303, 307 or 308", ErrInvalidConfig, d.Host)
+	}
+	if d.NotFoundPage != "" {
+		if d.notFound, err = os.ReadFile(d.NotFoundPage); err != nil {
+			return fmt.Errorf("%w: not_found_page of %q: %w", ErrInvalidConfig, d.Host, err)
+		}
+	}
+	return nil
+}
+
+// Registry holds the default domain and the domains registered in addition to it.
+type Registry struct {
+	def    *Domain
+	byHost map[string]*Domain
+}
+
+// NewRegistry checks the domains and builds their encoders. The default domain may have no host.
+func NewRegistry(def Domain, domains []Domain) (*Registry, error) {
+	r := &Registry{def: &def, byHost: map[string]*Domain{}}
+	def.Host = NormalizeHost(def.Host)
+	if err := def.init(); err != nil {
+		return nil, err
+	}
+	if def.Host != "" {
+		r.byHost[def.Host] = &def
+	}
+	for _, d := range domains {
+		d.Host = NormalizeHost(d.Host)
+		if d.Host == "" {
+			return nil, fmt.Errorf("%w: every domain needs a host", ErrInvalidConfig)
+		}
+		if _, ok := r.byHost[d.Host]; ok {
+			return nil, fmt.Errorf("%w: %q is registered twice", ErrInvalidConfig, d.Host)
+		}
+		if d.BaseURL == "" {
+			d.BaseURL = "https://" + d.Host
+		}
+		if d.Alphabet == "" {
+			d.Alphabet = def.Alphabet
+		}
+		d.name = d.Host
+		if err := d.init(); err != nil {
+			return nil, err
+		}
+		r.byHost[d.Host] = &d
+	}
+	return r, nil
+}
+
+// FromEnv builds the registry from BASE_URL, SECRET_ALPHABET and the domains listed in the
+// JSON file at DOMAINS_PATH, if set.
+func FromEnv() (*Registry, error) {
+	def := Domain{BaseURL: os.Getenv("BASE_URL"), Alphabet: os.Getenv("SECRET_ALPHABET")}
+	if def.BaseURL != "" {
+		u, err := url.Parse(def.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("BASE_URL must be an absolute http(s) URL, got %q", def.BaseURL)
+		}
+		def.Host = u.Host
+	}
+	var domains []Domain
+	if path := os.Getenv("DOMAINS_PATH"); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &domains); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidConfig, path, err)
+		}
+	}
+	return NewRegistry(def, domains)
+}
+
+// Default returns the default domain.
+func (r *Registry) Default() *Domain {
+	return r.def
+}
+
+// Lookup returns the domain served at host, falling back to the default domain.
+func (r *Registry) Lookup(host string) *Domain {
+	if d, ok := r.byHost[NormalizeHost(host)]; ok {
+		return d
+	}
+	return r.def
+}
+
+// Get returns the domain with the given name, the default domain for "".
+func (r *Registry) Get(name string) (*Domain, error) {
+	if name == "" {
+		return r.def, nil
+	}
+	if d, ok := r.byHost[NormalizeHost(name)]; ok {
+		return d, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownDomain, name)
+}
+
+// Select returns the domain selected in ctx. Unknown hosts fall back to the default domain,
+// unknown explicitly selected domains are an error.
+func (r *Registry) Select(ctx context.Context) (*Domain, error) {
+	sel, _ := ctx.Value(ctxKey{}).(selection)
+	if sel.explicit {
+		return r.Get(sel.host)
+	}
+	return r.Lookup(sel.host), nil
+}
+
+// Domains returns every domain, the default one first.
+func (r *Registry) Domains() []*Domain {
+	domains := []*Domain{r.def}
+	for _, d := range r.byHost {
+		if d != r.def {
+			domains = append(domains, d)
+		}
+	}
+	slices.SortFunc(domains[1:], func(a, b *Domain) int { return strings.Compare(a.Host, b.Host) })
+	return domains
+}
+
+// NormalizeHost lower-cases host and strips its port and trailing dot.
+func NormalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+type ctxKey struct{}
+
+type selection struct {
+	host     string
+	explicit bool
+}
+
+// WithHost returns a copy of ctx selecting the domain served at host, e.g. from the Host header.
+// Hosts that are not registered select the default domain.
+func WithHost(ctx context.Context, host string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, selection{host: host})
+}
+
+// WithName returns a copy of ctx selecting the domain with the given name explicitly.
+func WithName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, selection{host: name, explicit: true})
+}
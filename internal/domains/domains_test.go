@@ -0,0 +1,132 @@
+package domains
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	page := filepath.Join(t.TempDir(), "404.html")
+	require.NoError(t, os.WriteFile(page, []byte("<h1>Not here</h1>"), 0o600))
+
+	r, err := NewRegistry(Domain{Host: "sho.rt", BaseURL: "https://sho.rt/"}, []Domain{
+		{Host: "Go.Brand-A.com.", RedirectStatus: http.StatusMovedPermanently, NotFoundPage: page},
+		{Host: "br.nd", BaseURL: "http://br.nd:8080", Alphabet: "k3G7QAe51FCsPW92uEOyq4Bg6Sp8YzVTmnU0liwDdHXLajZrfxNhobJIRcMvKt"},
+	})
+	require.NoError(t, err)
+
+	def := r.Default()
+	assert.Equal(t, "", def.Name())
+	assert.Equal(t, "https://sho.rt/abc", def.ShortURL("abc"))
+	assert.Equal(t, http.StatusFound, def.Status())
+	assert.Nil(t, def.NotFound())
+
+	brandA := r.Lookup("go.brand-a.com:443")
+	assert.Equal(t, "go.brand-a.com", brandA.Name())
+	assert.Equal(t, "https://go.brand-a.com/abc", brandA.ShortURL("abc"))
+	assert.Equal(t, http.StatusMovedPermanently, brandA.Status())
+	assert.Equal(t, "<h1>Not here</h1>", string(brandA.NotFound()))
+
+	assert.Same(t, def, r.Lookup("sho.rt"))
+	assert.Same(t, def, r.Lookup("unknown.example.com"), "unknown hosts fall back to the default domain")
+	_, err = r.Get("unknown.example.com")
+	assert.ErrorIs(t, err, ErrUnknownDomain)
+
+	names := []string{}
+	for _, d := range r.Domains() {
+		names = append(names, d.Name())
+	}
+	assert.Equal(t, []string{"", "br.nd", "go.brand-a.com"}, names)
+
+	// Every domain has its own codes, unless it shares the alphabet.
+	brnd, err := r.Get("br.nd")
+	require.NoError(t, err)
+	code, err := brnd.Encode(42)
+	require.NoError(t, err)
+	id, ok := brnd.Decode(code)
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), id)
+	defCode, err := def.Encode(42)
+	require.NoError(t, err)
+	assert.NotEqual(t, defCode, code)
+	brandACode, err := brandA.Encode(42)
+	require.NoError(t, err)
+	assert.Equal(t, defCode, brandACode)
+}
+
+func TestRegistry_Select(t *testing.T) {
+	r, err := NewRegistry(Domain{}, []Domain{{Host: "go.brand-a.com"}})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	d, err := r.Select(ctx)
+	require.NoError(t, err)
+	assert.Same(t, r.Default(), d)
+
+	d, err = r.Select(WithHost(ctx, "GO.brand-a.com:8080"))
+	require.NoError(t, err)
+	assert.Equal(t, "go.brand-a.com", d.Name())
+	assert.Equal(t, "", r.Default().ShortURL("abc"), "no BASE_URL")
+
+	d, err = r.Select(WithHost(ctx, "localhost:8080"))
+	require.NoError(t, err)
+	assert.Same(t, r.Default(), d)
+
+	d, err = r.Select(WithName(ctx, "go.brand-a.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "go.brand-a.com", d.Name())
+	_, err = r.Select(WithName(ctx, "localhost"))
+	assert.ErrorIs(t, err, ErrUnknownDomain, "explicitly selected domains must exist")
+}
+
+func TestNewRegistry_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     Domain
+		domains []Domain
+	}{
+		{"no host", Domain{}, []Domain{{}}},
+		{"duplicate", Domain{Host: "sho.rt"}, []Domain{{Host: "SHO.RT"}}},
+		{"base url", Domain{}, []Domain{{Host: "br.nd", BaseURL: "br.nd"}}},
+		{"alphabet", Domain{Alphabet: "ab/cd"}, nil},
+		{"short alphabet", Domain{}, []Domain{{Host: "br.nd", Alphabet: "ab"}}},
+		{"status", Domain{}, []Domain{{Host: "br.nd", RedirectStatus: http.StatusOK}}},
+		{"page", Domain{}, []Domain{{Host: "br.nd", NotFoundPage: filepath.Join(t.TempDir(), "missing.html")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistry(tt.def, tt.domains)
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"host": "go.brand-a.com", "redirect_status": 308}]`), 0o600))
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("SECRET_ALPHABET", "")
+	t.Setenv("DOMAINS_PATH", path)
+
+	r, err := FromEnv()
+	require.NoError(t, err)
+	assert.Same(t, r.Default(), r.Lookup("sho.rt"), "the host of BASE_URL is the default domain")
+	d, err := r.Get("go.brand-a.com")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPermanentRedirect, d.Status())
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"host": "go.brand-a.com"}`), 0o600))
+	_, err = FromEnv()
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	t.Setenv("DOMAINS_PATH", "")
+	t.Setenv("BASE_URL", "sho.rt")
+	_, err = FromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// adminMethods change links or watch them change, and take the admin token in "authorization: Bearer <token>" metadata.
+var adminMethods = map[string]bool{
+	"/url_shortener.v2.UrlShortenerService/UpdateLink":          true,
+	"/url_shortener.v2.UrlShortenerService/DeleteLink":          true,
+	"/url_shortener.v2.UrlShortenerService/CreateLinkRule":      true,
+	"/url_shortener.v2.UrlShortenerService/UpdateLinkRule":      true,
+	"/url_shortener.v2.UrlShortenerService/DeleteLinkRule":      true,
+	"/url_shortener.v2.UrlShortenerService/BatchUpdateLinkTags": true,
+	"/url_shortener.v2.UrlShortenerService/WatchLinks":          true,
+}
+
+// authorize rejects calls of admin methods without the admin token with Unauthenticated, or with
+// Unimplemented if no token is set. Other calls without it don't see the targets of password
+// protected links.
+func authorize(ctx context.Context, method, token string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var given string
+	if v := md.Get("authorization"); len(v) > 0 {
+		given, _ = strings.CutPrefix(v[0], "Bearer ")
+	}
+	admin := token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+	switch {
+	case admin:
+		return ctx, nil
+	case !adminMethods[method]:
+		return service.WithConcealedTargets(ctx), nil
+	case token == "":
+		return nil, status.Error(codes.Unimplemented, "method is not served without ADMIN_TOKEN")
+	default:
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid token")
+	}
+}
+
+func AuthUnaryServerInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, info.FullMethod, token)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func AuthStreamServerInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod, token)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
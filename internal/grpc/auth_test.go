@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestAuthUnaryServerInterceptor(t *testing.T) {
+	ctx := context.Background()
+	for name, token := range map[string]string{"no token": "", "wrong token": "wrong"} {
+		t.Run(name, func(t *testing.T) {
+			client := url_shortener_v2.NewUrlShortenerServiceClient(newTestConnAs(t, token))
+
+			// creating and reading links stay open, but protected links don't show where they lead
+			link, err := client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: "https://example.com"}})
+			require.NoError(t, err)
+			read, err := client.GetLink(ctx, &url_shortener_v2.GetLinkRequest{Code: link.Code})
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com", read.Target)
+			protected, err := client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+				Target: "https://example.com/internal", Password: "hunter2",
+			}})
+			require.NoError(t, err)
+			read, err = client.GetLink(ctx, &url_shortener_v2.GetLinkRequest{Code: protected.Code})
+			require.NoError(t, err)
+			assert.Empty(t, read.Target)
+			list, err := client.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{})
+			require.NoError(t, err)
+			require.Len(t, list.Links, 2)
+			assert.Empty(t, list.Links[1].Target)
+
+			_, err = client.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+				Link:       &url_shortener_v2.Link{Code: link.Code, Disabled: true},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"disabled"}},
+			})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			_, err = client.DeleteLink(ctx, &url_shortener_v2.DeleteLinkRequest{Code: link.Code})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			_, err = client.CreateLinkRule(ctx, &url_shortener_v2.CreateLinkRuleRequest{Code: link.Code, Rule: &url_shortener_v2.Rule{
+				Target: "https://example.com/android",
+				Conditions: &url_shortener_v2.RuleConditions{
+					Platforms: []url_shortener_v2.RuleConditions_Platform{url_shortener_v2.RuleConditions_PLATFORM_ANDROID},
+				},
+			}})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			_, err = client.BatchUpdateLinkTags(ctx, &url_shortener_v2.BatchUpdateLinkTagsRequest{Codes: []string{link.Code}, AddTags: []string{"a"}})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			stream, err := client.WatchLinks(ctx, &url_shortener_v2.WatchLinksRequest{})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	const method = "/url_shortener.v2.UrlShortenerService/DeleteLink"
+	withToken := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer s3cret"))
+
+	_, err := authorize(withToken, method, "s3cret")
+	require.NoError(t, err)
+	_, err = authorize(context.Background(), "/url_shortener.v2.UrlShortenerService/GetLink", "s3cret")
+	require.NoError(t, err)
+
+	// without a token admin methods are not served, whatever the call sends
+	_, err = authorize(withToken, method, "")
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = authorize(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer ")), method, "")
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
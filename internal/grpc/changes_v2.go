@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/outbox"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var changeTypes = map[database.ChangeType]url_shortener_v2.LinkChange_Type{
+	database.ChangeCreated: url_shortener_v2.LinkChange_TYPE_CREATED,
+	database.ChangeUpdated: url_shortener_v2.LinkChange_TYPE_UPDATED,
+	database.ChangeDeleted: url_shortener_v2.LinkChange_TYPE_DELETED,
+}
+
+// changeFieldName returns the field mask path of a changed field, or the field itself for
+// fields that cannot be updated directly.
+func changeFieldName(field database.LinkField) string {
+	for path, f := range maskFields {
+		if f == field {
+			return path
+		}
+	}
+	return string(field)
+}
+
+func toProtoLinkChange(record outbox.Record) *url_shortener_v2.LinkChange {
+	fields := make([]string, 0, len(record.Fields))
+	for _, field := range record.Fields {
+		fields = append(fields, changeFieldName(field))
+	}
+	return &url_shortener_v2.LinkChange{
+		Seq:    record.Seq,
+		Type:   changeTypes[record.Type],
+		Time:   timestamppb.New(record.Time),
+		Link:   toProtoLink(service.Link{Link: record.Link, Code: record.Code, ShortURL: record.ShortURL}),
+		Fields: fields,
+	}
+}
+
+func (s *serverAPIv2) WatchLinks(req *url_shortener_v2.WatchLinksRequest, stream grpc.ServerStreamingServer[url_shortener_v2.LinkChange]) error {
+	if s.changes == nil {
+		return status.Error(codes.Unimplemented, "link change stream is disabled")
+	}
+	err := s.changes.Stream(stream.Context(), req.GetAfterSeq(), outbox.SinkFunc(func(_ context.Context, record outbox.Record) error {
+		return stream.Send(toProtoLinkChange(record))
+	}))
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	return toStatus(err)
+}
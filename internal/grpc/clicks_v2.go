@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"cmp"
+	"context"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *serverAPIv2) GetLinkClickHistory(ctx context.Context, req *url_shortener_v2.GetLinkClickHistoryRequest) (*url_shortener_v2.LinkClickHistory, error) {
+	history, err := s.urlShortener.ClickHistory(ctx, req.GetCode(), cmp.Or(int(req.GetDays()), service.DefaultClickHistoryDays))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &url_shortener_v2.LinkClickHistory{
+		Total: history.Total,
+		Days:  make([]*url_shortener_v2.DailyClicks, 0, len(history.Days)),
+	}
+	for _, d := range history.Days {
+		resp.Days = append(resp.Days, &url_shortener_v2.DailyClicks{Day: timestamppb.New(d.Day), Clicks: d.Clicks})
+	}
+	return resp, nil
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/qr"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps service errors to gRPC status errors.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, service.ErrUrlNotFound),
+		errors.Is(err, service.ErrRuleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidUrl),
+		errors.Is(err, service.ErrInvalidTarget),
+		errors.Is(err, service.ErrInvalidPageToken),
+		errors.Is(err, service.ErrInvalidSort),
+		errors.Is(err, service.ErrUnknownField),
+		errors.Is(err, service.ErrPasswordTooLong),
+		errors.Is(err, service.ErrInvalidMaxClicks),
+		errors.Is(err, service.ErrTooManyCodes),
+		errors.Is(err, service.ErrInvalidDays),
+		errors.Is(err, qr.ErrInvalidOptions),
+		errors.Is(err, rules.ErrInvalidRule),
+		errors.Is(err, split.ErrInvalidVariants),
+		errors.Is(err, passthrough.ErrInvalidOptions),
+		errors.Is(err, passthrough.ErrInvalidPath),
+		errors.Is(err, urltemplate.ErrInvalidTemplate),
+		errors.Is(err, urltemplate.ErrMissingVariable),
+		errors.Is(err, urltemplate.ErrInvalidValue),
+		errors.Is(err, urltemplate.ErrNoMatch),
+		errors.Is(err, domains.ErrUnknownDomain):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrLinkDisabled),
+		errors.Is(err, service.ErrLinkExpired),
+		errors.Is(err, service.ErrLinkExhausted),
+		errors.Is(err, service.ErrBaseURLNotConfigured),
+		errors.Is(err, service.ErrQRLogoNotConfigured),
+		errors.Is(err, service.ErrTooManyRules):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrPasswordRequired),
+		errors.Is(err, service.ErrInvalidPassword):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrTooManyAttempts):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, service.ErrUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"github.com/Parzival-05/url-shortener/internal/health"
+
+	"google.golang.org/grpc"
+	grpc_health "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// RegisterHealth registers the standard grpc.health.v1 service. The overall ("") status and
+// the status of every given service follow the readiness of the registry.
+func RegisterHealth(server *grpc.Server, registry *health.Registry, services ...string) *grpc_health.Server {
+	healthServer := grpc_health.NewServer()
+	services = append([]string{""}, services...)
+	for _, service := range services {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	registry.OnChange(func(ready bool) {
+		if registry.ShuttingDown() {
+			// Shutdown makes the status sticky: later updates are ignored.
+			healthServer.Shutdown()
+			return
+		}
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if ready {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+		for _, service := range services {
+			healthServer.SetServingStatus(service, status)
+		}
+	})
+	healthpb.RegisterHealthServer(server, healthServer)
+	return healthServer
+}
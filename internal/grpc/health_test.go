@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestRegisterHealth_ReadyBeforeRegistration(t *testing.T) {
+	registry := health.NewRegistry(time.Second)
+	require.True(t, registry.Check(context.Background()).Ready())
+
+	healthServer := RegisterHealth(grpc.NewServer(), registry, "svc")
+	for _, service := range []string{"", "svc"} {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status, service)
+	}
+
+	registry.Shutdown()
+	resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "svc"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/requestid"
+	"github.com/Parzival-05/url-shortener/internal/tlsconfig"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// resolveMethods are high-volume calls whose access logs are sampled.
+var resolveMethods = map[string]bool{
+	"/url_shortener.UrlShortenerService/GetOriginalURL": true,
+	"/url_shortener.v2.UrlShortenerService/ResolveLink": true,
+}
+
+func isResolveCall(_ context.Context, callMeta interceptors.CallMeta) bool {
+	return resolveMethods[callMeta.FullMethod()]
+}
+
+func isNotResolveCall(ctx context.Context, callMeta interceptors.CallMeta) bool {
+	return !isResolveCall(ctx, callMeta)
+}
+
+// requestIDFields adds the request ID to every log line written by the logging interceptor.
+func requestIDFields(ctx context.Context) logging.Fields {
+	if id := requestid.FromContext(ctx); id != "" {
+		return logging.Fields{"request_id", id}
+	}
+	return nil
+}
+
+// requestContext propagates the x-request-id metadata (or generates a new ID), sends it back
+// in the response header and stores it together with a request-scoped logger in the context.
+func requestContext(ctx context.Context, log *zap.Logger) context.Context {
+	var incoming string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(requestid.MetadataKey); len(v) > 0 {
+			incoming = v[0]
+		}
+	}
+	id := requestid.FromIncoming(incoming)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+
+	ctx = requestid.NewContext(ctx, id)
+	return zap_utils.ToContext(ctx, log.With(zap.String("request_id", id)))
+}
+
+func RequestIDUnaryServerInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(requestContext(ctx, log), req)
+	}
+}
+
+func RequestIDStreamServerInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: requestContext(ss.Context(), log)})
+	}
+}
+
+// selectDomain selects the domain of the call from the x-link-domain metadata, else from its authority.
+func selectDomain(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(domains.MetadataKey); len(v) > 0 && v[0] != "" {
+		return domains.WithName(ctx, v[0])
+	}
+	if v := md.Get(":authority"); len(v) > 0 {
+		return domains.WithHost(ctx, v[0])
+	}
+	return ctx
+}
+
+func DomainUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(selectDomain(ctx), req)
+	}
+}
+
+func DomainStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: selectDomain(ss.Context())})
+	}
+}
+
+// clientIdentity stores the identity of the verified client certificate of the call, if any,
+// in the context, and adds its common name to the request-scoped logger.
+func clientIdentity(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ctx
+	}
+	id, ok := tlsconfig.IdentityFromState(info.State)
+	if !ok {
+		return ctx
+	}
+	ctx = tlsconfig.NewContext(ctx, id)
+	return zap_utils.ToContext(ctx, zap_utils.FromContext(ctx, nil).With(zap.String("client", id.CommonName)))
+}
+
+func ClientIdentityUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(clientIdentity(ctx), req)
+	}
+}
+
+func ClientIdentityStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: clientIdentity(ss.Context())})
+	}
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/Parzival-05/url-shortener/internal/limiter"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// LimiterUnaryServerInterceptor admits calls through l, resolves with critical and the rest
+// with normal priority, and sheds the rest with Unavailable, a RetryInfo detail and a
+// retry-after header in seconds. Calls failing with Unavailable or DeadlineExceeded count as
+// overloaded. A nil l admits every call.
+func LimiterUnaryServerInterceptor(l *limiter.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if l == nil {
+			return handler(ctx, req)
+		}
+		priority := limiter.Normal
+		if resolveMethods[info.FullMethod] {
+			priority = limiter.Critical
+		}
+		token, ok := l.Acquire(priority)
+		if !ok {
+			zap_utils.FromContext(ctx, nil).Debug("Shedding call",
+				zap.Stringer("priority", priority), zap.Int("limit", l.Limit()))
+			return nil, overloaded(ctx, l)
+		}
+		resp, err := handler(ctx, req)
+		switch status.Code(toStatus(err)) {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			token.Dropped()
+		default:
+			token.Done()
+		}
+		return resp, err
+	}
+}
+
+func overloaded(ctx context.Context, l *limiter.Limiter) error {
+	retryAfter := l.RetryAfter()
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))))
+	st, err := status.New(codes.Unavailable, "server is overloaded, try again later").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return status.Error(codes.Unavailable, "server is overloaded, try again later")
+	}
+	return st.Err()
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	url_shortener_v1 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v1"
+	"github.com/Parzival-05/url-shortener/internal/limiter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestLimiterUnaryServerInterceptor(t *testing.T) {
+	ctx := context.Background()
+	l := limiter.New(limiter.Config{InitialLimit: 2, MinLimit: 1, RetryAfter: 3 * time.Second})
+	conn := newTestConn(t, grpc.ChainUnaryInterceptor(LimiterUnaryServerInterceptor(l)))
+	v1 := url_shortener_v1.NewUrlShortenerServiceClient(conn)
+
+	held, ok := l.Acquire(limiter.Critical)
+	require.True(t, ok)
+	defer held.Done()
+
+	var header metadata.MD
+	_, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com"}, grpc.Header(&header))
+	st := status.Convert(err)
+	require.Equal(t, codes.Unavailable, st.Code(), "creates take a share of the limit")
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, retryInfo.RetryDelay.AsDuration())
+	assert.Equal(t, []string{"3"}, header.Get("retry-after"))
+
+	_, err = v1.GetOriginalURL(ctx, &url_shortener_v1.GetOriginalURLRequest{ShortUrl: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "resolves take all of it")
+	assert.Equal(t, 1, l.Inflight())
+}
@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+)
+
+var queryPolicies = map[url_shortener_v2.Passthrough_QueryPolicy]passthrough.QueryPolicy{
+	url_shortener_v2.Passthrough_QUERY_POLICY_UNSPECIFIED: passthrough.QueryOff,
+	url_shortener_v2.Passthrough_QUERY_POLICY_KEEP:        passthrough.QueryKeep,
+	url_shortener_v2.Passthrough_QUERY_POLICY_OVERRIDE:    passthrough.QueryOverride,
+	url_shortener_v2.Passthrough_QUERY_POLICY_DROP:        passthrough.QueryDrop,
+}
+
+func toProtoPassthrough(opts passthrough.Options) *url_shortener_v2.Passthrough {
+	if opts.IsZero() {
+		return nil
+	}
+	pb := &url_shortener_v2.Passthrough{
+		Path: opts.Path,
+		Utm: &url_shortener_v2.Utm{
+			Source:   opts.UTM.Source,
+			Medium:   opts.UTM.Medium,
+			Campaign: opts.UTM.Campaign,
+			Term:     opts.UTM.Term,
+			Content:  opts.UTM.Content,
+		},
+	}
+	for policy, p := range queryPolicies {
+		if p == opts.Query {
+			pb.Query = policy
+		}
+	}
+	return pb
+}
+
+func fromProtoPassthrough(pb *url_shortener_v2.Passthrough) passthrough.Options {
+	utm := pb.GetUtm()
+	return passthrough.Options{
+		Query: queryPolicies[pb.GetQuery()],
+		Path:  pb.GetPath(),
+		UTM: passthrough.UTM{
+			Source:   utm.GetSource(),
+			Medium:   utm.GetMedium(),
+			Campaign: utm.GetCampaign(),
+			Term:     utm.GetTerm(),
+			Content:  utm.GetContent(),
+		},
+	}
+}
@@ -1,11 +1,15 @@
 package grpc
 
 import (
+	"cmp"
 	"context"
+	"net"
 
 	url_shortener_v1 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v1"
+	"github.com/Parzival-05/url-shortener/internal/database"
 	"github.com/Parzival-05/url-shortener/internal/service"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
 )
 
 type serverAPI struct {
@@ -23,11 +27,43 @@ func NewServerAPI(log *zap.Logger, urlShortener service.IUrlShortener) url_short
 }
 
 func (s *serverAPI) CreateShortURL(ctx context.Context, req *url_shortener_v1.CreateShortURLRequest) (*url_shortener_v1.CreateShortURLResponse, error) {
-	shortUrl, err := s.urlShortener.CreateUrl(ctx, req.Url)
-	return &url_shortener_v1.CreateShortURLResponse{ShortUrl: shortUrl}, err
+	if req.Password == "" && req.MaxClicks == 0 {
+		shortUrl, err := s.urlShortener.CreateUrl(ctx, req.Url)
+		return &url_shortener_v1.CreateShortURLResponse{ShortUrl: shortUrl}, err
+	}
+	// Protected and limited links are never shared with other callers shortening the same URL.
+	hash, err := service.HashPassword(req.Password)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	link, err := s.urlShortener.CreateLink(ctx, database.Link{Target: req.Url, PasswordHash: hash, MaxClicks: req.MaxClicks})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &url_shortener_v1.CreateShortURLResponse{ShortUrl: cmp.Or(link.ShortURL, link.Code)}, nil
 }
 
 func (s *serverAPI) GetOriginalURL(ctx context.Context, req *url_shortener_v1.GetOriginalURLRequest) (*url_shortener_v1.GetOriginalURLResponse, error) {
-	fullUrl, err := s.urlShortener.GetFullUrl(ctx, req.ShortUrl)
-	return &url_shortener_v1.GetOriginalURLResponse{Url: fullUrl}, err
+	resolved, err := s.urlShortener.Resolve(ctx, service.ResolveRequest{
+		Code:     req.ShortUrl,
+		Password: req.Password,
+		Client:   peerAddress(ctx),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &url_shortener_v1.GetOriginalURLResponse{Url: resolved.Target}, nil
+}
+
+// peerAddress returns the host of the caller's address, used to throttle password attempts.
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
 }
@@ -0,0 +1,287 @@
+package grpc
+
+import (
+	"context"
+	"net/url"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/outbox"
+	"github.com/Parzival-05/url-shortener/internal/qr"
+	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type serverAPIv2 struct {
+	url_shortener_v2.UnimplementedUrlShortenerServiceServer
+
+	log          *zap.Logger
+	urlShortener service.IUrlShortener
+	// changes serves WatchLinks, which is unimplemented if it is nil.
+	changes *outbox.Relay
+}
+
+func NewServerAPIv2(log *zap.Logger, urlShortener service.IUrlShortener, changes *outbox.Relay) url_shortener_v2.UrlShortenerServiceServer {
+	return &serverAPIv2{
+		log:          log,
+		urlShortener: urlShortener,
+		changes:      changes,
+	}
+}
+
+// maskFields maps v2 field mask paths to link fields.
+var maskFields = map[string]database.LinkField{
+	"target":      database.LinkFieldTarget,
+	"owner":       database.LinkFieldOwner,
+	"tags":        database.LinkFieldTags,
+	"campaign":    database.LinkFieldCampaign,
+	"disabled":    database.LinkFieldDisabled,
+	"expire_time": database.LinkFieldExpiresAt,
+	"password":    database.LinkFieldPassword,
+	"max_clicks":  database.LinkFieldMaxClicks,
+	"variants":    database.LinkFieldVariants,
+	"split_mode":  database.LinkFieldSplitMode,
+	"passthrough": database.LinkFieldPassthrough,
+	"template":    database.LinkFieldTemplate,
+}
+
+func toProtoLink(link service.Link) *url_shortener_v2.Link {
+	pb := &url_shortener_v2.Link{
+		Code:              link.Code,
+		ShortUrl:          link.ShortURL,
+		Domain:            link.Domain,
+		Target:            link.Target,
+		CreateTime:        timestamppb.New(link.CreatedAt),
+		UpdateTime:        timestamppb.New(link.UpdatedAt),
+		Owner:             link.Owner,
+		Tags:              link.Tags,
+		Campaign:          link.Campaign,
+		Clicks:            link.Clicks,
+		Disabled:          link.Disabled,
+		PasswordProtected: link.PasswordHash != "",
+		MaxClicks:         link.MaxClicks,
+		RemainingClicks:   link.RemainingClicks,
+		Variants:          toProtoVariants(link.Variants),
+		SplitMode:         toProtoSplitMode(link.SplitMode),
+		Passthrough:       toProtoPassthrough(link.Passthrough),
+	}
+	if link.ExpiresAt != nil {
+		pb.ExpireTime = timestamppb.New(*link.ExpiresAt)
+	}
+	if link.Template != nil {
+		pb.Template = &url_shortener_v2.LinkTemplate{Path: link.Template.Path}
+	}
+	return pb
+}
+
+func fromProtoLink(pb *url_shortener_v2.Link) (database.Link, error) {
+	hash, err := service.HashPassword(pb.GetPassword())
+	if err != nil {
+		return database.Link{}, err
+	}
+	link := database.Link{
+		Domain:       pb.GetDomain(),
+		Target:       pb.GetTarget(),
+		Owner:        pb.GetOwner(),
+		Tags:         pb.GetTags(),
+		Campaign:     pb.GetCampaign(),
+		Disabled:     pb.GetDisabled(),
+		PasswordHash: hash,
+		MaxClicks:    pb.GetMaxClicks(),
+		Variants:     fromProtoVariants(pb.GetVariants()),
+		SplitMode:    splitModes[pb.GetSplitMode()],
+		Passthrough:  fromProtoPassthrough(pb.GetPassthrough()),
+	}
+	if pb.GetExpireTime() != nil {
+		expiresAt := pb.GetExpireTime().AsTime()
+		link.ExpiresAt = &expiresAt
+	}
+	if pb.GetTemplate() != nil {
+		link.Template = &urltemplate.Template{Path: pb.GetTemplate().GetPath()}
+	}
+	return link, nil
+}
+
+func (s *serverAPIv2) CreateLink(ctx context.Context, req *url_shortener_v2.CreateLinkRequest) (*url_shortener_v2.Link, error) {
+	if req.GetLink() == nil {
+		return nil, status.Error(codes.InvalidArgument, "link is required")
+	}
+	link, err := fromProtoLink(req.GetLink())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	created, err := s.urlShortener.CreateLink(ctx, link)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoLink(created), nil
+}
+
+func (s *serverAPIv2) GetLink(ctx context.Context, req *url_shortener_v2.GetLinkRequest) (*url_shortener_v2.Link, error) {
+	link, err := s.urlShortener.GetLink(ctx, req.GetCode())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoLink(link), nil
+}
+
+func (s *serverAPIv2) ResolveLink(ctx context.Context, req *url_shortener_v2.ResolveLinkRequest) (*url_shortener_v2.ResolveLinkResponse, error) {
+	info := service.RequestInfo{
+		UserAgent:      req.GetUserAgent(),
+		AcceptLanguage: req.GetAcceptLanguage(),
+	}
+	if req.GetQuery() != "" {
+		query, err := url.ParseQuery(req.GetQuery())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+		}
+		info.Query = query
+	}
+	resolved, err := s.urlShortener.Resolve(ctx, service.ResolveRequest{
+		RequestInfo: info,
+		Code:        req.GetCode(),
+		Password:    req.GetPassword(),
+		Client:      peerAddress(ctx),
+		Visitor:     req.GetVisitorId(),
+		Path:        req.GetPath(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &url_shortener_v2.ResolveLinkResponse{Target: resolved.Target, VariantId: resolved.VariantID}, nil
+}
+
+func (s *serverAPIv2) UpdateLink(ctx context.Context, req *url_shortener_v2.UpdateLinkRequest) (*url_shortener_v2.Link, error) {
+	if req.GetLink() == nil {
+		return nil, status.Error(codes.InvalidArgument, "link is required")
+	}
+	var fields []database.LinkField
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		field, ok := maskFields[path]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "field %q can't be updated", path)
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		// Password is input only, so a full update keeps it unless a new one is given.
+		// Setting max_clicks restarts the count, so it has to be named in the mask.
+		for _, field := range database.LinkFields {
+			switch {
+			case field == database.LinkFieldPassword && req.GetLink().GetPassword() == "":
+			case field == database.LinkFieldMaxClicks:
+			default:
+				fields = append(fields, field)
+			}
+		}
+	}
+	update, err := fromProtoLink(req.GetLink())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	link, err := s.urlShortener.UpdateLink(ctx, req.GetLink().GetCode(), update, fields)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoLink(link), nil
+}
+
+func (s *serverAPIv2) DeleteLink(ctx context.Context, req *url_shortener_v2.DeleteLinkRequest) (*emptypb.Empty, error) {
+	if err := s.urlShortener.DeleteLink(ctx, req.GetCode()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// linkSorts maps ListLinksRequest.Sort to the repository order. Codes are derived
+// from IDs, so ordering by code means ordering by ID.
+var linkSorts = map[url_shortener_v2.ListLinksRequest_Sort]database.LinkSort{
+	url_shortener_v2.ListLinksRequest_SORT_UNSPECIFIED:      database.SortIDAsc,
+	url_shortener_v2.ListLinksRequest_SORT_CODE_DESC:        database.SortIDDesc,
+	url_shortener_v2.ListLinksRequest_SORT_CREATE_TIME_ASC:  database.SortCreatedAtAsc,
+	url_shortener_v2.ListLinksRequest_SORT_CREATE_TIME_DESC: database.SortCreatedAtDesc,
+}
+
+func (s *serverAPIv2) ListLinks(ctx context.Context, req *url_shortener_v2.ListLinksRequest) (*url_shortener_v2.ListLinksResponse, error) {
+	query := service.ListLinksQuery{
+		PageSize:        int(req.GetPageSize()),
+		PageToken:       req.GetPageToken(),
+		Sort:            linkSorts[req.GetSort()],
+		Owner:           req.GetOwner(),
+		Tag:             req.GetTag(),
+		Campaign:        req.GetCampaign(),
+		IncludeDisabled: req.GetShowDisabled(),
+		TargetDomain:    req.GetTargetDomain(),
+		TargetContains:  req.GetTargetContains(),
+	}
+	if req.CreateTimeAfter != nil {
+		query.CreatedAfter = req.CreateTimeAfter.AsTime()
+	}
+	if req.CreateTimeBefore != nil {
+		query.CreatedBefore = req.CreateTimeBefore.AsTime()
+	}
+	page, err := s.urlShortener.ListLinks(ctx, query)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &url_shortener_v2.ListLinksResponse{
+		Links:         make([]*url_shortener_v2.Link, 0, len(page.Links)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, link := range page.Links {
+		resp.Links = append(resp.Links, toProtoLink(link))
+	}
+	return resp, nil
+}
+
+var qrFormats = map[url_shortener_v2.GetLinkQRCodeRequest_Format]qr.Format{
+	url_shortener_v2.GetLinkQRCodeRequest_FORMAT_UNSPECIFIED: qr.FormatPNG,
+	url_shortener_v2.GetLinkQRCodeRequest_FORMAT_PNG:         qr.FormatPNG,
+	url_shortener_v2.GetLinkQRCodeRequest_FORMAT_SVG:         qr.FormatSVG,
+}
+
+var qrLevels = map[url_shortener_v2.GetLinkQRCodeRequest_ErrorCorrection]qr.Level{
+	url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_LOW:      qr.LevelLow,
+	url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_MEDIUM:   qr.LevelMedium,
+	url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_QUARTILE: qr.LevelQuartile,
+	url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_HIGH:     qr.LevelHigh,
+}
+
+func (s *serverAPIv2) GetLinkQRCode(ctx context.Context, req *url_shortener_v2.GetLinkQRCodeRequest) (*url_shortener_v2.QRCode, error) {
+	opts := service.QRCodeOptions{Options: qr.DefaultOptions(), Logo: req.GetLogo()}
+	opts.Format = qrFormats[req.GetFormat()]
+	opts.Level = qrLevels[req.GetErrorCorrection()]
+	if req.GetSize() != 0 {
+		opts.Size = int(req.GetSize())
+	}
+	if req.Margin != nil {
+		opts.Margin = int(req.GetMargin())
+	}
+	var err error
+	if req.GetForegroundColor() != "" {
+		if opts.Foreground, err = qr.ParseColor(req.GetForegroundColor()); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+	if req.GetBackgroundColor() != "" {
+		if opts.Background, err = qr.ParseColor(req.GetBackgroundColor()); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+	code, err := s.urlShortener.LinkQRCode(ctx, req.GetCode(), opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &url_shortener_v2.QRCode{
+		ContentType: code.ContentType,
+		Data:        code.Data,
+		Etag:        code.ETag,
+		Url:         code.URL,
+	}, nil
+}
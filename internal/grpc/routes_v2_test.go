@@ -0,0 +1,879 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/png"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	url_shortener_v1 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v1"
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/outbox"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testAdminToken is the admin token of the servers of newTestConn.
+const testAdminToken = "s3cret"
+
+// bearerToken sends a token in the authorization metadata of every call.
+type bearerToken string
+
+func (b bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(b)}, nil
+}
+
+func (bearerToken) RequireTransportSecurity() bool { return false }
+
+// newTestConn serves v1 and v2 from one UrlShortener and its change log over an in-memory listener,
+// through the interceptors of New without load shedding, to a client with the admin token. opts
+// add interceptors after them.
+func newTestConn(t *testing.T, opts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+	return newTestConnAs(t, testAdminToken, opts...)
+}
+
+// newTestConnAs is newTestConn for a client sending token, or no token if it is empty.
+func newTestConnAs(t *testing.T, token string, opts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+	log := zaptest.NewLogger(t)
+	db := inmemory.NewInMemoryDBService()
+	urlShortener := service.NewUrlShortener(db.NewUrlRepository(), log)
+	registry, err := domains.FromEnv()
+	require.NoError(t, err)
+	relay := outbox.NewRelay(db.NewChangeLog(), registry, log, outbox.Config{PollInterval: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go relay.Run(ctx)
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(append(serverInterceptors(log, nil, testAdminToken), opts...)...)
+	url_shortener_v1.RegisterUrlShortenerServiceServer(server, NewServerAPI(log, urlShortener))
+	url_shortener_v2.RegisterUrlShortenerServiceServer(server, NewServerAPIv2(log, urlShortener, relay))
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken(token)))
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestServerAPIv2_LinkLifecycle(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestConn(t)
+	v1 := url_shortener_v1.NewUrlShortenerServiceClient(conn)
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(conn)
+
+	created, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+		Target: "https://example.com/a",
+		Owner:  "alice",
+		Tags:   []string{"docs"},
+	}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Code)
+	assert.Equal(t, "alice", created.Owner)
+	assert.NotNil(t, created.CreateTime)
+
+	// v1 clients see the same link
+	original, err := v1.GetOriginalURL(ctx, &url_shortener_v1.GetOriginalURLRequest{ShortUrl: created.Code})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a", original.Url)
+
+	updated, err := v2.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link: &url_shortener_v2.Link{
+			Code:     created.Code,
+			Target:   "https://example.com/b",
+			Owner:    "ignored, not in mask",
+			Disabled: true,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"target", "disabled"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/b", updated.Target)
+	assert.Equal(t, "alice", updated.Owner)
+	assert.True(t, updated.Disabled)
+
+	_, err = v2.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: created.Code})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = v2.DeleteLink(ctx, &url_shortener_v2.DeleteLinkRequest{Code: created.Code})
+	require.NoError(t, err)
+	_, err = v2.GetLink(ctx, &url_shortener_v2.GetLinkRequest{Code: created.Code})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerAPIv2_V1CreateSharesPlainLinksOnly(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestConn(t)
+	v1 := url_shortener_v1.NewUrlShortenerServiceClient(conn)
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(conn)
+	const target = "https://example.com/shared"
+
+	for _, link := range []*url_shortener_v2.Link{
+		{Target: target, Disabled: true},
+		{Target: target, Password: "secret"},
+		{Target: target, MaxClicks: 1},
+		{Target: target, Owner: "alice"},
+		{Target: target, ExpireTime: timestamppb.New(time.Now().Add(time.Hour))},
+		{Target: target, Passthrough: &url_shortener_v2.Passthrough{Path: true}},
+	} {
+		_, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: link})
+		require.NoError(t, err)
+	}
+
+	created, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: target})
+	require.NoError(t, err)
+	original, err := v1.GetOriginalURL(ctx, &url_shortener_v1.GetOriginalURLRequest{ShortUrl: created.ShortUrl})
+	require.NoError(t, err, "v1 creates never hand out restricted links")
+	assert.Equal(t, target, original.Url)
+
+	again, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: target})
+	require.NoError(t, err)
+	assert.Equal(t, created.ShortUrl, again.ShortUrl, "plain links are shared")
+
+	plain, err := v2.GetLink(ctx, &url_shortener_v2.GetLinkRequest{Code: created.ShortUrl})
+	require.NoError(t, err)
+	plain.Disabled = true
+	_, err = v2.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{Link: plain, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"disabled"}}})
+	require.NoError(t, err)
+	replaced, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: target})
+	require.NoError(t, err)
+	assert.NotEqual(t, created.ShortUrl, replaced.ShortUrl, "a link disabled since is not handed out")
+}
+
+func TestServerAPIv2_CreateLinkValidation(t *testing.T) {
+	ctx := context.Background()
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	for _, target := range []string{"", "example.com", "ftp://example.com"} {
+		_, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: target}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), target)
+	}
+
+	expired, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+		Target:     "https://example.com",
+		ExpireTime: timestamppb.New(time.Now().Add(-time.Minute)),
+	}})
+	require.NoError(t, err)
+	_, err = v2.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: expired.Code})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestServerAPIv2_ListLinks(t *testing.T) {
+	ctx := context.Background()
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	for i := range 5 {
+		owner := "alice"
+		if i%2 == 1 {
+			owner = "bob"
+		}
+		_, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+			Target: "https://example.com",
+			Owner:  owner,
+		}})
+		require.NoError(t, err)
+	}
+
+	var codesSeen []string
+	req := &url_shortener_v2.ListLinksRequest{PageSize: 2}
+	for {
+		resp, err := v2.ListLinks(ctx, req)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(resp.Links), 2)
+		for _, link := range resp.Links {
+			codesSeen = append(codesSeen, link.Code)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	assert.Len(t, codesSeen, 5)
+
+	resp, err := v2.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{Owner: "bob"})
+	require.NoError(t, err)
+	assert.Len(t, resp.Links, 2)
+
+	_, err = v2.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{PageToken: "!!"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerAPIv2_ListLinksSortAndSearch(t *testing.T) {
+	ctx := context.Background()
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	targets := []string{
+		"https://example.com/docs/a",
+		"https://Blog.example.org/post",
+		"https://example.com/DOCS/b",
+		"http://example.com:8080/c",
+	}
+	var created []*url_shortener_v2.Link
+	for _, target := range targets {
+		link, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: target}})
+		require.NoError(t, err)
+		created = append(created, link)
+	}
+
+	listAll := func(req *url_shortener_v2.ListLinksRequest) []string {
+		t.Helper()
+		req.PageSize = 1
+		var codesSeen []string
+		for {
+			resp, err := v2.ListLinks(ctx, req)
+			require.NoError(t, err)
+			for _, link := range resp.Links {
+				codesSeen = append(codesSeen, link.Code)
+			}
+			if resp.NextPageToken == "" {
+				return codesSeen
+			}
+			req.PageToken = resp.NextPageToken
+		}
+	}
+	codesOf := func(idx ...int) []string {
+		var out []string
+		for _, i := range idx {
+			out = append(out, created[i].Code)
+		}
+		return out
+	}
+
+	assert.Equal(t, codesOf(0, 1, 2, 3), listAll(&url_shortener_v2.ListLinksRequest{}))
+	assert.Equal(t, codesOf(3, 2, 1, 0), listAll(&url_shortener_v2.ListLinksRequest{
+		Sort: url_shortener_v2.ListLinksRequest_SORT_CODE_DESC,
+	}))
+	assert.Equal(t, codesOf(3, 2, 1, 0), listAll(&url_shortener_v2.ListLinksRequest{
+		Sort: url_shortener_v2.ListLinksRequest_SORT_CREATE_TIME_DESC,
+	}))
+	assert.Equal(t, codesOf(0, 2, 3), listAll(&url_shortener_v2.ListLinksRequest{TargetDomain: "EXAMPLE.com"}))
+	assert.Equal(t, codesOf(0, 2), listAll(&url_shortener_v2.ListLinksRequest{TargetContains: "docs"}))
+	assert.Equal(t, codesOf(1), listAll(&url_shortener_v2.ListLinksRequest{TargetDomain: "blog.example.org"}))
+
+	future := timestamppb.New(time.Now().Add(time.Hour))
+	assert.Empty(t, listAll(&url_shortener_v2.ListLinksRequest{CreateTimeAfter: future}))
+	assert.Equal(t, codesOf(0, 1, 2, 3), listAll(&url_shortener_v2.ListLinksRequest{CreateTimeBefore: future}))
+
+	// A page token is bound to the sort it was issued for.
+	resp, err := v2.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{PageSize: 1})
+	require.NoError(t, err)
+	_, err = v2.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{
+		PageToken: resp.NextPageToken,
+		Sort:      url_shortener_v2.ListLinksRequest_SORT_CODE_DESC,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerAPIv2_GetLinkQRCode(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("BASE_URL", "")
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+	link, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: "https://example.com"}})
+	require.NoError(t, err)
+	_, err = v2.GetLinkQRCode(ctx, &url_shortener_v2.GetLinkQRCodeRequest{Code: link.Code})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The domains are read from the environment once per service.
+	t.Setenv("BASE_URL", "https://sho.rt/")
+	v2 = url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+	link, err = v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: "https://example.com"}})
+	require.NoError(t, err)
+	code, err := v2.GetLinkQRCode(ctx, &url_shortener_v2.GetLinkQRCodeRequest{
+		Code:            link.Code,
+		Size:            128,
+		ErrorCorrection: url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_HIGH,
+		BackgroundColor: "#ffffff00",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://sho.rt/"+link.Code, code.Url)
+	assert.Equal(t, "image/png", code.ContentType)
+	assert.NotEmpty(t, code.Etag)
+	img, err := png.Decode(bytes.NewReader(code.Data))
+	require.NoError(t, err)
+	assert.Equal(t, 128, img.Bounds().Dx())
+
+	svg, err := v2.GetLinkQRCode(ctx, &url_shortener_v2.GetLinkQRCodeRequest{
+		Code:   link.Code,
+		Format: url_shortener_v2.GetLinkQRCodeRequest_FORMAT_SVG,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", svg.ContentType)
+	assert.NotEqual(t, code.Etag, svg.Etag)
+
+	_, err = v2.GetLinkQRCode(ctx, &url_shortener_v2.GetLinkQRCodeRequest{Code: link.Code, Logo: true})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "logo is not configured")
+	_, err = v2.GetLinkQRCode(ctx, &url_shortener_v2.GetLinkQRCodeRequest{Code: link.Code, Size: 32})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_PasswordProtectedLinks(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestConn(t)
+	v1 := url_shortener_v1.NewUrlShortenerServiceClient(conn)
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(conn)
+
+	open, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/doc"})
+	require.NoError(t, err)
+	protected, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/doc", Password: "s3cret"})
+	require.NoError(t, err)
+	assert.NotEqual(t, open.ShortUrl, protected.ShortUrl, "protected links are never shared")
+
+	_, err = v1.GetOriginalURL(ctx, &url_shortener_v1.GetOriginalURLRequest{ShortUrl: protected.ShortUrl})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = v1.GetOriginalURL(ctx, &url_shortener_v1.GetOriginalURLRequest{ShortUrl: protected.ShortUrl, Password: "wrong"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	original, err := v1.GetOriginalURL(ctx, &url_shortener_v1.GetOriginalURLRequest{ShortUrl: protected.ShortUrl, Password: "s3cret"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/doc", original.Url)
+
+	link, err := v2.GetLink(ctx, &url_shortener_v2.GetLinkRequest{Code: protected.ShortUrl})
+	require.NoError(t, err)
+	assert.True(t, link.PasswordProtected)
+	assert.Empty(t, link.Password)
+	assert.Equal(t, "https://example.com/doc", link.Target, "the admin token shows the target")
+
+	// A full update keeps the password, an explicit one in the mask removes it.
+	link.Owner = "alice"
+	link, err = v2.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{Link: link})
+	require.NoError(t, err)
+	assert.True(t, link.PasswordProtected)
+	_, err = v2.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, Password: "s3cret"})
+	require.NoError(t, err)
+
+	link, err = v2.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link:       &url_shortener_v2.Link{Code: link.Code},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password"}},
+	})
+	require.NoError(t, err)
+	assert.False(t, link.PasswordProtected)
+	_, err = v2.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code})
+	require.NoError(t, err)
+}
+
+func TestServer_ClickLimitedLinks(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestConn(t)
+	v1 := url_shortener_v1.NewUrlShortenerServiceClient(conn)
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(conn)
+
+	const maxClicks, callers = 5, 50
+	link, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+		Target:    "https://example.com/once",
+		MaxClicks: maxClicks,
+	}})
+	require.NoError(t, err)
+	assert.EqualValues(t, maxClicks, link.RemainingClicks)
+
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int64
+		exhausted atomic.Int64
+	)
+	start := make(chan struct{})
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			// Both API versions share the counter.
+			var err error
+			if i%2 == 0 {
+				_, err = v1.GetOriginalURL(ctx, &url_shortener_v1.GetOriginalURLRequest{ShortUrl: link.Code})
+			} else {
+				_, err = v2.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code})
+			}
+			switch status.Code(err) {
+			case codes.OK:
+				succeeded.Add(1)
+			case codes.FailedPrecondition:
+				exhausted.Add(1)
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	assert.EqualValues(t, maxClicks, succeeded.Load())
+	assert.EqualValues(t, callers-maxClicks, exhausted.Load())
+
+	link, err = v2.GetLink(ctx, &url_shortener_v2.GetLinkRequest{Code: link.Code})
+	require.NoError(t, err)
+	assert.Zero(t, link.RemainingClicks)
+
+	// A full update leaves the counter alone, naming max_clicks in the mask restarts it.
+	link.Owner = "alice"
+	link, err = v2.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{Link: link})
+	require.NoError(t, err)
+	assert.Zero(t, link.RemainingClicks)
+	link, err = v2.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link:       &url_shortener_v2.Link{Code: link.Code, MaxClicks: 1},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"max_clicks"}},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, link.RemainingClicks)
+	_, err = v2.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code})
+	require.NoError(t, err)
+	_, err = v2.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Single-use links created through v1 are never shared with unlimited ones.
+	open, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/doc"})
+	require.NoError(t, err)
+	once, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/doc", MaxClicks: 1})
+	require.NoError(t, err)
+	assert.NotEqual(t, open.ShortUrl, once.ShortUrl)
+
+	_, err = v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/doc", MaxClicks: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServerAPIv2_LinkRules(t *testing.T) {
+	ctx := context.Background()
+	client := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	link, err := client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: "https://example.com/web"}})
+	require.NoError(t, err)
+
+	android, err := client.CreateLinkRule(ctx, &url_shortener_v2.CreateLinkRuleRequest{Code: link.Code, Rule: &url_shortener_v2.Rule{
+		Target: "https://play.google.com/store/apps/details?id=com.example",
+		Conditions: &url_shortener_v2.RuleConditions{
+			Platforms: []url_shortener_v2.RuleConditions_Platform{url_shortener_v2.RuleConditions_PLATFORM_ANDROID},
+		},
+	}})
+	require.NoError(t, err)
+	require.NotEmpty(t, android.Id)
+
+	index := int32(0)
+	ios, err := client.CreateLinkRule(ctx, &url_shortener_v2.CreateLinkRuleRequest{Code: link.Code, Index: &index, Rule: &url_shortener_v2.Rule{
+		Target: "https://apps.apple.com/app/id1",
+		Conditions: &url_shortener_v2.RuleConditions{
+			Platforms: []url_shortener_v2.RuleConditions_Platform{url_shortener_v2.RuleConditions_PLATFORM_IOS},
+			Days:      []url_shortener_v2.RuleConditions_Day{url_shortener_v2.RuleConditions_DAY_SUNDAY},
+		},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []url_shortener_v2.RuleConditions_Day{url_shortener_v2.RuleConditions_DAY_SUNDAY}, ios.Conditions.Days)
+
+	list, err := client.ListLinkRules(ctx, &url_shortener_v2.ListLinkRulesRequest{Code: link.Code})
+	require.NoError(t, err)
+	require.Len(t, list.Rules, 2)
+	assert.Equal(t, []string{ios.Id, android.Id}, []string{list.Rules[0].Id, list.Rules[1].Id})
+
+	// Dropping the day restriction and moving the rule to the end.
+	ios.Conditions.Days = nil
+	index = 1
+	ios, err = client.UpdateLinkRule(ctx, &url_shortener_v2.UpdateLinkRuleRequest{Code: link.Code, Rule: ios, Index: &index})
+	require.NoError(t, err)
+	list, err = client.ListLinkRules(ctx, &url_shortener_v2.ListLinkRulesRequest{Code: link.Code})
+	require.NoError(t, err)
+	assert.Equal(t, []string{android.Id, ios.Id}, []string{list.Rules[0].Id, list.Rules[1].Id})
+
+	eval, err := client.EvaluateLinkRules(ctx, &url_shortener_v2.EvaluateLinkRulesRequest{
+		Code:           link.Code,
+		UserAgent:      "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15",
+		AcceptLanguage: "de-DE,de;q=0.9",
+		Country:        "DE",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ios.Id, eval.GetRule().GetId())
+	assert.Equal(t, "https://apps.apple.com/app/id1", eval.Target)
+	assert.Equal(t, url_shortener_v2.RuleConditions_PLATFORM_IOS, eval.Platform)
+	assert.Equal(t, []string{"de-DE", "de"}, eval.Languages)
+	assert.Equal(t, "DE", eval.Country)
+
+	eval, err = client.EvaluateLinkRules(ctx, &url_shortener_v2.EvaluateLinkRulesRequest{Code: link.Code, UserAgent: "curl/8.5.0"})
+	require.NoError(t, err)
+	assert.Nil(t, eval.Rule)
+	assert.Equal(t, "https://example.com/web", eval.Target)
+
+	// gRPC callers have no browser platform, so a catch-all rule shows that rules apply on resolve.
+	catchAll, err := client.CreateLinkRule(ctx, &url_shortener_v2.CreateLinkRuleRequest{Code: link.Code, Rule: &url_shortener_v2.Rule{
+		Target: "https://example.com/other",
+	}})
+	require.NoError(t, err)
+	resolved, err := client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/other", resolved.Target)
+
+	_, err = client.DeleteLinkRule(ctx, &url_shortener_v2.DeleteLinkRuleRequest{Code: link.Code, RuleId: catchAll.Id})
+	require.NoError(t, err)
+	resolved, err = client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/web", resolved.Target)
+
+	_, err = client.DeleteLinkRule(ctx, &url_shortener_v2.DeleteLinkRuleRequest{Code: link.Code, RuleId: catchAll.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.ListLinkRules(ctx, &url_shortener_v2.ListLinkRulesRequest{Code: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	for name, rule := range map[string]*url_shortener_v2.Rule{
+		"target":    {Target: "ftp://example.com"},
+		"platform":  {Target: "https://example.com", Conditions: &url_shortener_v2.RuleConditions{Platforms: []url_shortener_v2.RuleConditions_Platform{0}}},
+		"time":      {Target: "https://example.com", Conditions: &url_shortener_v2.RuleConditions{From: "25:00"}},
+		"time zone": {Target: "https://example.com", Conditions: &url_shortener_v2.RuleConditions{TimeZone: "Nowhere/Else"}},
+	} {
+		_, err = client.CreateLinkRule(ctx, &url_shortener_v2.CreateLinkRuleRequest{Code: link.Code, Rule: rule})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+	}
+}
+
+func TestServerAPIv2_LinkVariants(t *testing.T) {
+	ctx := context.Background()
+	client := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	link, err := client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+		Target: "https://example.com/web",
+		Variants: []*url_shortener_v2.Variant{
+			{Id: "a", Target: "https://example.com/a", Weight: 1},
+			{Id: "b", Target: "https://example.com/b", Weight: 1},
+		},
+		SplitMode: url_shortener_v2.Link_SPLIT_MODE_STICKY,
+	}})
+	require.NoError(t, err)
+	require.Len(t, link.Variants, 2)
+	assert.Equal(t, url_shortener_v2.Link_SPLIT_MODE_STICKY, link.SplitMode)
+
+	visitors := map[string]string{}
+	for i := range 20 {
+		visitor := fmt.Sprintf("visitor-%d", i%4)
+		resolved, err := client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, VisitorId: visitor})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/"+resolved.VariantId, resolved.Target)
+		if prev, ok := visitors[visitor]; ok {
+			assert.Equal(t, prev, resolved.VariantId, "sticky visitors keep their variant")
+		}
+		visitors[visitor] = resolved.VariantId
+	}
+
+	// Pause b: everyone goes to a from now on.
+	link.Variants[1].Weight = 0
+	link, err = client.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link:       link,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"variants"}},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, link.Variants[1].Weight)
+	for i := range 5 {
+		resolved, err := client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, VisitorId: fmt.Sprint(i)})
+		require.NoError(t, err)
+		assert.Equal(t, "a", resolved.VariantId)
+	}
+
+	stats, err := client.GetLinkVariantStats(ctx, &url_shortener_v2.GetLinkVariantStatsRequest{Code: link.Code})
+	require.NoError(t, err)
+	require.Len(t, stats.Variants, 2)
+	assert.Equal(t, int64(25), stats.Variants[0].Clicks+stats.Variants[1].Clicks)
+
+	_, err = client.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link:       &url_shortener_v2.Link{Code: link.Code, Variants: []*url_shortener_v2.Variant{{Id: "c", Target: "https://example.com/c"}}},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"variants"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "at least one weight must be positive")
+	_, err = client.GetLinkVariantStats(ctx, &url_shortener_v2.GetLinkVariantStatsRequest{Code: "zzzzzz"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerAPIv2_TagsAndCampaigns(t *testing.T) {
+	ctx := context.Background()
+	client := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	var linkCodes []string
+	for i, campaign := range []string{"spring", "spring", "fall", ""} {
+		link, err := client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+			Target:   fmt.Sprintf("https://example.com/%d", i),
+			Tags:     []string{"promo"},
+			Campaign: campaign,
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, campaign, link.Campaign)
+		linkCodes = append(linkCodes, link.Code)
+	}
+	for range 3 {
+		_, err := client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: linkCodes[0]})
+		require.NoError(t, err)
+	}
+	_, err := client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: linkCodes[3]})
+	require.NoError(t, err)
+
+	list, err := client.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{Campaign: "spring"})
+	require.NoError(t, err)
+	require.Len(t, list.Links, 2)
+	assert.Equal(t, int64(3), list.Links[0].Clicks)
+
+	stats, err := client.ListCampaignStats(ctx, &url_shortener_v2.ListCampaignStatsRequest{})
+	require.NoError(t, err)
+	require.Len(t, stats.Campaigns, 2, "links outside campaigns are not listed")
+	assert.Equal(t, "fall", stats.Campaigns[0].Campaign)
+	assert.Equal(t, int64(2), stats.Campaigns[1].Links)
+	assert.Equal(t, int64(3), stats.Campaigns[1].Clicks)
+
+	batch, err := client.BatchUpdateLinkTags(ctx, &url_shortener_v2.BatchUpdateLinkTagsRequest{
+		Codes:      []string{linkCodes[0], linkCodes[1], "zzzzzz"},
+		AddTags:    []string{"q2"},
+		RemoveTags: []string{"promo"},
+	})
+	require.NoError(t, err)
+	require.Len(t, batch.Links, 2)
+	assert.Equal(t, []string{"q2"}, batch.Links[0].Tags)
+	assert.Equal(t, []string{"zzzzzz"}, batch.NotFoundCodes)
+
+	list, err = client.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{Tag: "promo"})
+	require.NoError(t, err)
+	require.Len(t, list.Links, 2)
+	assert.Equal(t, linkCodes[2], list.Links[0].Code)
+
+	link, err := client.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link:       &url_shortener_v2.Link{Code: linkCodes[2]},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"campaign"}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, link.Campaign)
+	list, err = client.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{Campaign: "fall"})
+	require.NoError(t, err)
+	assert.Empty(t, list.Links)
+}
+
+func TestServerAPIv2_ClickHistory(t *testing.T) {
+	ctx := context.Background()
+	client := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	link, err := client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: "https://example.com/history"}})
+	require.NoError(t, err)
+	for range 2 {
+		_, err := client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code})
+		require.NoError(t, err)
+	}
+
+	history, err := client.GetLinkClickHistory(ctx, &url_shortener_v2.GetLinkClickHistoryRequest{Code: link.Code, Days: 7})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), history.Total)
+	require.Len(t, history.Days, 7)
+	assert.Equal(t, int64(2), history.Days[6].Clicks, "today is last")
+	assert.Equal(t, time.Now().UTC().Truncate(24*time.Hour), history.Days[6].Day.AsTime())
+
+	history, err = client.GetLinkClickHistory(ctx, &url_shortener_v2.GetLinkClickHistoryRequest{Code: link.Code})
+	require.NoError(t, err)
+	assert.Len(t, history.Days, 30)
+
+	_, err = client.GetLinkClickHistory(ctx, &url_shortener_v2.GetLinkClickHistoryRequest{Code: link.Code, Days: 367})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.GetLinkClickHistory(ctx, &url_shortener_v2.GetLinkClickHistoryRequest{Code: "zzzzzz"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerAPIv2_LinkPassthrough(t *testing.T) {
+	ctx := context.Background()
+	client := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	link, err := client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+		Target: "https://example.com/docs?lang=en#top",
+		Passthrough: &url_shortener_v2.Passthrough{
+			Query: url_shortener_v2.Passthrough_QUERY_POLICY_KEEP,
+			Path:  true,
+			Utm:   &url_shortener_v2.Utm{Source: "grpc", Medium: "api"},
+		},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, url_shortener_v2.Passthrough_QUERY_POLICY_KEEP, link.Passthrough.GetQuery())
+
+	resolved, err := client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{
+		Code:  link.Code,
+		Path:  "guide/setup",
+		Query: "lang=de&ref=mail",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs/guide/setup?lang=en&utm_source=grpc&utm_medium=api&ref=mail#top", resolved.Target)
+
+	_, err = client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, Path: "../admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, Query: "a=%zz"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	link, err = client.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link:       &url_shortener_v2.Link{Code: link.Code},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"passthrough"}},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, link.Passthrough)
+	resolved, err = client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, Query: "ref=mail"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs?lang=en#top", resolved.Target)
+}
+
+func TestServerAPIv2_LinkTemplate(t *testing.T) {
+	ctx := context.Background()
+	client := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+
+	link, err := client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+		Target:   "https://example.com/docs/{version=latest}/{page}?user={uid=anonymous}",
+		Template: &url_shortener_v2.LinkTemplate{Path: "{version}/{page}"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "{version}/{page}", link.GetTemplate().GetPath())
+
+	resolved, err := client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, Path: "v2/intro%20page", Query: "uid=42"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs/v2/intro%20page?user=42", resolved.Target)
+	resolved, err = client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, Query: "page=setup"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs/latest/setup?user=anonymous", resolved.Target)
+
+	_, err = client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "page is required")
+	_, err = client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, Path: "v2/a/b"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+		Target:   "https://example.com/{id",
+		Template: &url_shortener_v2.LinkTemplate{},
+	}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	link, err = client.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link:       &url_shortener_v2.Link{Code: link.Code, Target: "https://example.com/{page}"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"target", "template"}},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, link.Template)
+	resolved, err = client.ResolveLink(ctx, &url_shortener_v2.ResolveLinkRequest{Code: link.Code, Query: "page=setup"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/{page}", resolved.Target, "exact links are left alone")
+}
+
+func TestServer_Domains(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"host": "go.brand-a.com"}]`), 0o600))
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", path)
+	ctx := context.Background()
+	brandA := metadata.AppendToOutgoingContext(ctx, domains.MetadataKey, "go.brand-a.com")
+	conn := newTestConn(t)
+	v1 := url_shortener_v1.NewUrlShortenerServiceClient(conn)
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(conn)
+
+	link, err := v2.CreateLink(brandA, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: "https://example.com/a"}})
+	require.NoError(t, err)
+	assert.Equal(t, "go.brand-a.com", link.Domain)
+	assert.Equal(t, "https://go.brand-a.com/"+link.Code, link.ShortUrl)
+
+	_, err = v2.GetLink(ctx, &url_shortener_v2.GetLinkRequest{Code: link.Code})
+	assert.Equal(t, codes.NotFound, status.Code(err), "the code belongs to another domain")
+	got, err := v2.GetLink(brandA, &url_shortener_v2.GetLinkRequest{Code: link.Code})
+	require.NoError(t, err)
+	assert.Equal(t, link.Target, got.Target)
+	resolved, err := v1.GetOriginalURL(ctx, &url_shortener_v1.GetOriginalURLRequest{ShortUrl: link.ShortUrl})
+	require.NoError(t, err, "a full short URL selects its domain")
+	assert.Equal(t, "https://example.com/a", resolved.Url)
+
+	// Codes are unique per domain: the same target gets a link of its own on every domain.
+	def, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/b"})
+	require.NoError(t, err)
+	branded, err := v1.CreateShortURL(brandA, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/b"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(def.ShortUrl, "https://sho.rt/"), def.ShortUrl)
+	assert.True(t, strings.HasPrefix(branded.ShortUrl, "https://go.brand-a.com/"), branded.ShortUrl)
+
+	list, err := v2.ListLinks(brandA, &url_shortener_v2.ListLinksRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Links, 2)
+	for _, l := range list.Links {
+		assert.Equal(t, "go.brand-a.com", l.Domain)
+	}
+	list, err = v2.ListLinks(ctx, &url_shortener_v2.ListLinksRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Links, 1)
+	assert.Equal(t, def.ShortUrl, list.Links[0].ShortUrl)
+
+	_, err = v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: "https://example.com", Domain: "br.nd"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = v2.UpdateLink(brandA, &url_shortener_v2.UpdateLinkRequest{
+		Link:       &url_shortener_v2.Link{Code: link.Code},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"domain"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "the domain of a link is immutable")
+}
+
+func TestServerAPIv2_WatchLinks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn := newTestConn(t)
+	v2 := url_shortener_v2.NewUrlShortenerServiceClient(conn)
+
+	created, err := v2.CreateLink(ctx, &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{Target: "https://example.com/a"}})
+	require.NoError(t, err)
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	stream, err := v2.WatchLinks(watchCtx, &url_shortener_v2.WatchLinksRequest{})
+	require.NoError(t, err)
+
+	// changes made while watching follow the backlog
+	_, err = v2.UpdateLink(ctx, &url_shortener_v2.UpdateLinkRequest{
+		Link:       &url_shortener_v2.Link{Code: created.Code, ExpireTime: timestamppb.New(time.Now().Add(time.Hour))},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"expire_time"}},
+	})
+	require.NoError(t, err)
+	_, err = v2.DeleteLink(ctx, &url_shortener_v2.DeleteLinkRequest{Code: created.Code})
+	require.NoError(t, err)
+
+	var changes []*url_shortener_v2.LinkChange
+	for range 3 {
+		change, err := stream.Recv()
+		require.NoError(t, err)
+		changes = append(changes, change)
+	}
+	stopWatch()
+
+	for i, change := range changes {
+		assert.Equal(t, int64(i+1), change.Seq)
+		assert.Equal(t, created.Code, change.Link.Code)
+	}
+	assert.Equal(t, url_shortener_v2.LinkChange_TYPE_CREATED, changes[0].Type)
+	assert.Equal(t, url_shortener_v2.LinkChange_TYPE_UPDATED, changes[1].Type)
+	assert.Equal(t, []string{"expire_time"}, changes[1].Fields)
+	assert.NotNil(t, changes[1].Link.ExpireTime)
+	assert.Equal(t, url_shortener_v2.LinkChange_TYPE_DELETED, changes[2].Type)
+
+	// resuming after a sequence number skips what was seen
+	resumed, err := v2.WatchLinks(ctx, &url_shortener_v2.WatchLinksRequest{AfterSeq: 2})
+	require.NoError(t, err)
+	change, err := resumed.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), change.Seq)
+
+	validated := url_shortener_v2.NewUrlShortenerServiceClient(newTestConn(t))
+	invalid, err := validated.WatchLinks(ctx, &url_shortener_v2.WatchLinksRequest{AfterSeq: -1})
+	require.NoError(t, err)
+	_, err = invalid.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,167 @@
+package grpc
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var rulePlatforms = map[url_shortener_v2.RuleConditions_Platform]rules.Platform{
+	url_shortener_v2.RuleConditions_PLATFORM_IOS:     rules.PlatformIOS,
+	url_shortener_v2.RuleConditions_PLATFORM_ANDROID: rules.PlatformAndroid,
+	url_shortener_v2.RuleConditions_PLATFORM_WINDOWS: rules.PlatformWindows,
+	url_shortener_v2.RuleConditions_PLATFORM_MACOS:   rules.PlatformMacOS,
+	url_shortener_v2.RuleConditions_PLATFORM_LINUX:   rules.PlatformLinux,
+	url_shortener_v2.RuleConditions_PLATFORM_OTHER:   rules.PlatformOther,
+}
+
+func toProtoPlatform(platform rules.Platform) url_shortener_v2.RuleConditions_Platform {
+	for pb, p := range rulePlatforms {
+		if p == platform {
+			return pb
+		}
+	}
+	return url_shortener_v2.RuleConditions_PLATFORM_UNSPECIFIED
+}
+
+// Days are numbered from Monday in the API and from Sunday in time.Weekday.
+func toProtoDay(day time.Weekday) url_shortener_v2.RuleConditions_Day {
+	if day == time.Sunday {
+		return url_shortener_v2.RuleConditions_DAY_SUNDAY
+	}
+	return url_shortener_v2.RuleConditions_Day(day)
+}
+
+func fromProtoDay(day url_shortener_v2.RuleConditions_Day) time.Weekday {
+	return time.Weekday(day % 7)
+}
+
+func toProtoRule(rule rules.Rule) *url_shortener_v2.Rule {
+	c := rule.Conditions
+	pb := &url_shortener_v2.RuleConditions{
+		Languages: c.Languages,
+		Countries: c.Countries,
+		From:      c.From,
+		To:        c.To,
+		TimeZone:  c.TimeZone,
+		Query:     c.Query,
+	}
+	for _, p := range c.Platforms {
+		pb.Platforms = append(pb.Platforms, toProtoPlatform(p))
+	}
+	for _, day := range c.Days {
+		pb.Days = append(pb.Days, toProtoDay(day))
+	}
+	return &url_shortener_v2.Rule{Id: rule.ID, Target: rule.Target, Conditions: pb}
+}
+
+func fromProtoRule(pb *url_shortener_v2.Rule) rules.Rule {
+	c := pb.GetConditions()
+	rule := rules.Rule{
+		ID:     pb.GetId(),
+		Target: pb.GetTarget(),
+		Conditions: rules.Conditions{
+			Languages: c.GetLanguages(),
+			Countries: c.GetCountries(),
+			From:      c.GetFrom(),
+			To:        c.GetTo(),
+			TimeZone:  c.GetTimeZone(),
+			Query:     c.GetQuery(),
+		},
+	}
+	for _, p := range c.GetPlatforms() {
+		rule.Conditions.Platforms = append(rule.Conditions.Platforms, rulePlatforms[p])
+	}
+	for _, day := range c.GetDays() {
+		rule.Conditions.Days = append(rule.Conditions.Days, fromProtoDay(day))
+	}
+	return rule
+}
+
+// ruleIndex maps an unset index to the negative index that appends or keeps a rule in place.
+func ruleIndex(index *int32) int {
+	if index == nil {
+		return -1
+	}
+	return int(*index)
+}
+
+func (s *serverAPIv2) ListLinkRules(ctx context.Context, req *url_shortener_v2.ListLinkRulesRequest) (*url_shortener_v2.ListLinkRulesResponse, error) {
+	rs, err := s.urlShortener.ListRules(ctx, req.GetCode())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &url_shortener_v2.ListLinkRulesResponse{Rules: make([]*url_shortener_v2.Rule, 0, len(rs))}
+	for _, rule := range rs {
+		resp.Rules = append(resp.Rules, toProtoRule(rule))
+	}
+	return resp, nil
+}
+
+func (s *serverAPIv2) CreateLinkRule(ctx context.Context, req *url_shortener_v2.CreateLinkRuleRequest) (*url_shortener_v2.Rule, error) {
+	rule, err := s.urlShortener.CreateRule(ctx, req.GetCode(), fromProtoRule(req.GetRule()), ruleIndex(req.Index))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoRule(rule), nil
+}
+
+func (s *serverAPIv2) UpdateLinkRule(ctx context.Context, req *url_shortener_v2.UpdateLinkRuleRequest) (*url_shortener_v2.Rule, error) {
+	rule, err := s.urlShortener.UpdateRule(ctx, req.GetCode(), fromProtoRule(req.GetRule()), ruleIndex(req.Index))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoRule(rule), nil
+}
+
+func (s *serverAPIv2) DeleteLinkRule(ctx context.Context, req *url_shortener_v2.DeleteLinkRuleRequest) (*emptypb.Empty, error) {
+	if err := s.urlShortener.DeleteRule(ctx, req.GetCode(), req.GetRuleId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPIv2) EvaluateLinkRules(ctx context.Context, req *url_shortener_v2.EvaluateLinkRulesRequest) (*url_shortener_v2.EvaluateLinkRulesResponse, error) {
+	evalReq := service.EvaluateRulesRequest{
+		RequestInfo: service.RequestInfo{
+			UserAgent:      req.GetUserAgent(),
+			AcceptLanguage: req.GetAcceptLanguage(),
+		},
+		Client:  req.GetIp(),
+		Country: req.GetCountry(),
+	}
+	if evalReq.Client == "" {
+		evalReq.Client = peerAddress(ctx)
+	}
+	if req.GetTime() != nil {
+		evalReq.Time = req.GetTime().AsTime()
+	}
+	if len(req.GetQuery()) > 0 {
+		evalReq.Query = url.Values{}
+		for key, value := range req.GetQuery() {
+			evalReq.Query.Set(key, value)
+		}
+	}
+	eval, err := s.urlShortener.EvaluateRules(ctx, req.GetCode(), evalReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &url_shortener_v2.EvaluateLinkRulesResponse{
+		Target:    eval.Target,
+		Platform:  toProtoPlatform(eval.Request.Platform),
+		Languages: eval.Request.Languages,
+		Country:   eval.Request.Country,
+		Time:      timestamppb.New(eval.Request.Time),
+	}
+	if eval.Rule != nil {
+		resp.Rule = toProtoRule(*eval.Rule)
+	}
+	return resp, nil
+}
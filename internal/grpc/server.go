@@ -7,10 +7,17 @@ import (
 	"os"
 
 	url_shortener_v1 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v1"
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/health"
+	"github.com/Parzival-05/url-shortener/internal/limiter"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/tlsconfig"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -52,23 +59,60 @@ func InterceptorLogger(l *zap.Logger) logging.Logger {
 	})
 }
 
-func New(log *zap.Logger, apiServer url_shortener_v1.UrlShortenerServiceServer) (*grpc.Server, net.Listener) {
+func New(log *zap.Logger, healthRegistry *health.Registry, apiServer url_shortener_v1.UrlShortenerServiceServer, apiServerV2 url_shortener_v2.UrlShortenerServiceServer) (*grpc.Server, net.Listener) {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", os.Getenv("PORT")))
 	if err != nil {
 		panic(fmt.Sprintf("failed to listen for gRPC: %v", err))
 	}
-	opts := []logging.Option{
-		logging.WithLogOnEvents(logging.StartCall, logging.FinishCall),
+	serverOpts := serverInterceptors(log, limiter.FromEnv(), os.Getenv("ADMIN_TOKEN"))
+	// Clients must present a certificate issued by GRPC_TLS_CLIENT_CA_FILE, if set.
+	certs, err := tlsconfig.FromEnv(os.Getenv("GRPC_TLS_CLIENT_CA_FILE"), log)
+	if err != nil {
+		panic(fmt.Sprintf("failed to configure TLS for gRPC: %v", err))
 	}
-	grpcServer := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			logging.UnaryServerInterceptor(InterceptorLogger(log), opts...),
-		),
-	)
+	if certs != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(certs.ServerConfig("h2"))))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 
 	url_shortener_v1.RegisterUrlShortenerServiceServer(grpcServer, apiServer)
+	url_shortener_v2.RegisterUrlShortenerServiceServer(grpcServer, apiServerV2)
+	RegisterHealth(grpcServer, healthRegistry,
+		url_shortener_v1.UrlShortenerService_ServiceDesc.ServiceName,
+		url_shortener_v2.UrlShortenerService_ServiceDesc.ServiceName,
+	)
 
 	reflection.Register(grpcServer)
 
 	return grpcServer, lis
 }
+
+// serverInterceptors returns the interceptor chains every call goes through, with unary calls shed by l
+// and admin methods taking adminToken.
+func serverInterceptors(log *zap.Logger, l *limiter.Limiter, adminToken string) []grpc.ServerOption {
+	opts := []logging.Option{
+		logging.WithLogOnEvents(logging.StartCall, logging.FinishCall),
+		logging.WithFieldsFromContext(requestIDFields),
+	}
+	sampledLog := zap_utils.SamplingPolicyFromEnv().Apply(log)
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			RequestIDUnaryServerInterceptor(log),
+			ClientIdentityUnaryServerInterceptor(),
+			DomainUnaryServerInterceptor(),
+			selector.UnaryServerInterceptor(logging.UnaryServerInterceptor(InterceptorLogger(log), opts...), selector.MatchFunc(isNotResolveCall)),
+			selector.UnaryServerInterceptor(logging.UnaryServerInterceptor(InterceptorLogger(sampledLog), opts...), selector.MatchFunc(isResolveCall)),
+			AuthUnaryServerInterceptor(adminToken),
+			LimiterUnaryServerInterceptor(l),
+			ValidationUnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			RequestIDStreamServerInterceptor(log),
+			ClientIdentityStreamServerInterceptor(),
+			DomainStreamServerInterceptor(),
+			logging.StreamServerInterceptor(InterceptorLogger(log), opts...),
+			AuthStreamServerInterceptor(adminToken),
+			ValidationStreamServerInterceptor(),
+		),
+	}
+}
@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/split"
+)
+
+var splitModes = map[url_shortener_v2.Link_SplitMode]split.Mode{
+	url_shortener_v2.Link_SPLIT_MODE_UNSPECIFIED: "",
+	url_shortener_v2.Link_SPLIT_MODE_RANDOM:      split.ModeRandom,
+	url_shortener_v2.Link_SPLIT_MODE_STICKY:      split.ModeSticky,
+}
+
+func toProtoSplitMode(mode split.Mode) url_shortener_v2.Link_SplitMode {
+	for pb, m := range splitModes {
+		if m == mode {
+			return pb
+		}
+	}
+	return url_shortener_v2.Link_SPLIT_MODE_UNSPECIFIED
+}
+
+func toProtoVariant(v split.Variant) *url_shortener_v2.Variant {
+	return &url_shortener_v2.Variant{Id: v.ID, Target: v.Target, Weight: int32(v.Weight)}
+}
+
+func toProtoVariants(variants []split.Variant) []*url_shortener_v2.Variant {
+	var pb []*url_shortener_v2.Variant
+	for _, v := range variants {
+		pb = append(pb, toProtoVariant(v))
+	}
+	return pb
+}
+
+func fromProtoVariants(pb []*url_shortener_v2.Variant) []split.Variant {
+	var variants []split.Variant
+	for _, v := range pb {
+		variants = append(variants, split.Variant{ID: v.GetId(), Target: v.GetTarget(), Weight: int(v.GetWeight())})
+	}
+	return variants
+}
+
+func (s *serverAPIv2) GetLinkVariantStats(ctx context.Context, req *url_shortener_v2.GetLinkVariantStatsRequest) (*url_shortener_v2.GetLinkVariantStatsResponse, error) {
+	stats, err := s.urlShortener.VariantStats(ctx, req.GetCode())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &url_shortener_v2.GetLinkVariantStatsResponse{}
+	for _, st := range stats {
+		resp.Variants = append(resp.Variants, &url_shortener_v2.VariantStats{
+			Variant: toProtoVariant(st.Variant),
+			Clicks:  st.Clicks,
+			Removed: st.Removed,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+)
+
+func (s *serverAPIv2) BatchUpdateLinkTags(ctx context.Context, req *url_shortener_v2.BatchUpdateLinkTagsRequest) (*url_shortener_v2.BatchUpdateLinkTagsResponse, error) {
+	result, err := s.urlShortener.TagLinks(ctx, req.GetCodes(), req.GetAddTags(), req.GetRemoveTags())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &url_shortener_v2.BatchUpdateLinkTagsResponse{
+		Links:         make([]*url_shortener_v2.Link, 0, len(result.Links)),
+		NotFoundCodes: result.NotFound,
+	}
+	for _, link := range result.Links {
+		resp.Links = append(resp.Links, toProtoLink(link))
+	}
+	return resp, nil
+}
+
+func (s *serverAPIv2) ListCampaignStats(ctx context.Context, req *url_shortener_v2.ListCampaignStatsRequest) (*url_shortener_v2.ListCampaignStatsResponse, error) {
+	stats, err := s.urlShortener.CampaignStats(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &url_shortener_v2.ListCampaignStatsResponse{}
+	for _, st := range stats {
+		resp.Campaigns = append(resp.Campaigns, &url_shortener_v2.CampaignStats{
+			Campaign: st.Campaign,
+			Links:    st.Links,
+			Clicks:   st.Clicks,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/tlsconfig"
+	"github.com/Parzival-05/url-shortener/internal/tlsconfig/tlstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := tlstest.NewCA(t, "server CA")
+	clientCA := tlstest.NewCA(t, "client CA")
+	certFile, keyFile := tlstest.WriteKeyPair(t, dir, "server", serverCA.Issue(t, "server"))
+	certs, err := tlsconfig.New(tlsconfig.Config{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: clientCA.WriteCA(t, dir, "clients.crt"),
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	var identity tlsconfig.Identity
+	var verified bool
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(certs.ServerConfig("h2"))),
+		grpc.ChainUnaryInterceptor(
+			ClientIdentityUnaryServerInterceptor(),
+			func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				identity, verified = tlsconfig.IdentityFromContext(ctx)
+				return handler(ctx, req)
+			},
+		),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	check := func(certs ...tls.Certificate) error {
+		creds := credentials.NewTLS(&tls.Config{RootCAs: serverCA.Pool(), ServerName: "localhost", Certificates: certs})
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(creds),
+		)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	assert.Error(t, check(), "clients without a certificate are refused")
+	assert.Error(t, check(tlstest.NewCA(t, "other CA").Issue(t, "stranger")), "clients with a certificate of another CA are refused")
+	require.NoError(t, check(clientCA.Issue(t, "billing", "billing.internal")))
+	require.True(t, verified)
+	assert.Equal(t, "billing", identity.CommonName)
+	assert.Contains(t, identity.DNSNames, "billing.internal")
+}
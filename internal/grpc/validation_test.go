@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	url_shortener_v1 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidationUnaryServerInterceptor(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestConn(t)
+	v1 := url_shortener_v1.NewUrlShortenerServiceClient(conn)
+
+	_, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: ""})
+	st := status.Convert(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.NotEmpty(t, badRequest.FieldViolations)
+	for _, violation := range badRequest.FieldViolations {
+		assert.Equal(t, "url", violation.Field)
+	}
+
+	resp, err := v1.CreateShortURL(ctx, &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.ShortUrl)
+}
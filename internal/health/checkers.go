@@ -0,0 +1,34 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+)
+
+// Database checks that the storage is reachable.
+func Database(db database.DBService) Checker {
+	return NewChecker("database", db.Ping)
+}
+
+// Migrations checks that the storage schema is at least the version this build expects.
+func Migrations(db database.DBService, want int64) Checker {
+	return NewChecker("migrations", func(ctx context.Context) error {
+		got, err := db.SchemaVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if got < want {
+			return fmt.Errorf("schema version %d is behind expected %d", got, want)
+		}
+		return nil
+	})
+}
+
+// Config checks the application configuration using validate.
+func Config(validate func() error) Checker {
+	return NewChecker("config", func(ctx context.Context) error {
+		return validate()
+	})
+}
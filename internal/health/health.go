@@ -0,0 +1,209 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrShuttingDown = errors.New("server is shutting down")
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Checker reports whether a single dependency is usable.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checkFunc struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (c checkFunc) Name() string {
+	return c.name
+}
+
+func (c checkFunc) Check(ctx context.Context) error {
+	return c.fn(ctx)
+}
+
+// NewChecker wraps a function into a Checker.
+func NewChecker(name string, fn func(ctx context.Context) error) Checker {
+	return checkFunc{name: name, fn: fn}
+}
+
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Ready reports whether every check passed.
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// Registry aggregates dependency checkers into a readiness report.
+// Once Shutdown is called the registry reports not ready regardless of the checkers,
+// so that load balancers stop routing traffic before listeners are closed.
+type Registry struct {
+	timeout time.Duration
+
+	mu        sync.RWMutex
+	checkers  []Checker
+	listeners []func(ready bool)
+	// notifyMu serializes readiness changes with the notifications they send, so that listeners
+	// see every change in order.
+	notifyMu sync.Mutex
+
+	shuttingDown atomic.Bool
+	lastReady    atomic.Bool
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+func (r *Registry) Register(checkers ...Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers = append(r.checkers, checkers...)
+}
+
+// OnChange calls fn with the current readiness and registers it as a listener called whenever
+// readiness flips, so that listeners added after the first check don't miss its result.
+func (r *Registry) OnChange(fn func(ready bool)) {
+	r.notifyMu.Lock()
+	defer r.notifyMu.Unlock()
+	r.mu.Lock()
+	r.listeners = append(r.listeners, fn)
+	r.mu.Unlock()
+	fn(r.Ready())
+}
+
+// Ready reports the readiness found by the last check.
+func (r *Registry) Ready() bool {
+	return r.lastReady.Load()
+}
+
+// Check runs all checkers concurrently, each bounded by the registry timeout.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checkers := append([]Checker(nil), r.checkers...)
+	r.mu.RUnlock()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(checkers)),
+	}
+	if r.shuttingDown.Load() {
+		report.Status = StatusDown
+		report.Checks["shutdown"] = CheckResult{Status: StatusDown, Error: ErrShuttingDown.Error()}
+		return report
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := r.runCheck(ctx, c)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.Name()] = result
+			if result.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+
+	r.setReady(report.Ready())
+	return report
+}
+
+func (r *Registry) runCheck(ctx context.Context, c Checker) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				errCh <- fmt.Errorf("check panicked: %v", p)
+			}
+		}()
+		errCh <- c.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+
+	result := CheckResult{Status: StatusUp, Duration: time.Since(start).String()}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Run re-evaluates readiness every interval until ctx is done, notifying listeners on changes.
+func (r *Registry) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		r.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown marks the registry as not ready for the rest of the process lifetime.
+func (r *Registry) Shutdown() {
+	r.shuttingDown.Store(true)
+	r.setReady(false)
+}
+
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+func (r *Registry) setReady(ready bool) {
+	r.notifyMu.Lock()
+	defer r.notifyMu.Unlock()
+	if r.shuttingDown.Load() {
+		ready = false
+	}
+	if r.lastReady.Swap(ready) == ready {
+		return
+	}
+	r.mu.RLock()
+	listeners := append([]func(ready bool){}, r.listeners...)
+	r.mu.RUnlock()
+	for _, fn := range listeners {
+		fn(ready)
+	}
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	ok := NewChecker("ok", func(ctx context.Context) error { return nil })
+	failing := NewChecker("failing", func(ctx context.Context) error { return errors.New("boom") })
+	slow := NewChecker("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	tests := []struct {
+		name     string
+		checkers []Checker
+		ready    bool
+		errors   map[string]string
+	}{
+		{
+			name:     "all checks pass",
+			checkers: []Checker{ok},
+			ready:    true,
+		},
+		{
+			name:     "failing check",
+			checkers: []Checker{ok, failing},
+			ready:    false,
+			errors:   map[string]string{"failing": "boom"},
+		},
+		{
+			name:     "check exceeding timeout",
+			checkers: []Checker{ok, slow},
+			ready:    false,
+			errors:   map[string]string{"slow": "check timed out: context deadline exceeded"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry(50 * time.Millisecond)
+			r.Register(tt.checkers...)
+
+			start := time.Now()
+			report := r.Check(context.Background())
+
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+			assert.Equal(t, tt.ready, report.Ready())
+			assert.Len(t, report.Checks, len(tt.checkers))
+			for name, result := range report.Checks {
+				if msg, failed := tt.errors[name]; failed {
+					assert.Equal(t, StatusDown, result.Status)
+					assert.Equal(t, msg, result.Error)
+				} else {
+					assert.Equal(t, StatusUp, result.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistry_Shutdown(t *testing.T) {
+	r := NewRegistry(time.Second)
+	r.Register(NewChecker("ok", func(ctx context.Context) error { return nil }))
+
+	var changes []bool
+	r.OnChange(func(ready bool) { changes = append(changes, ready) })
+
+	assert.True(t, r.Check(context.Background()).Ready())
+	assert.True(t, r.Check(context.Background()).Ready())
+
+	r.Shutdown()
+	report := r.Check(context.Background())
+	assert.False(t, report.Ready())
+	assert.Equal(t, ErrShuttingDown.Error(), report.Checks["shutdown"].Error)
+
+	assert.Equal(t, []bool{false, true, false}, changes)
+}
+
+func TestRegistry_OnChangeAfterCheck(t *testing.T) {
+	r := NewRegistry(time.Second)
+	r.Register(NewChecker("ok", func(ctx context.Context) error { return nil }))
+	assert.True(t, r.Check(context.Background()).Ready())
+
+	var changes []bool
+	r.OnChange(func(ready bool) { changes = append(changes, ready) })
+	assert.True(t, r.Ready())
+	assert.Equal(t, []bool{true}, changes, "a listener added late gets the current readiness")
+}
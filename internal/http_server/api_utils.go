@@ -1,11 +1,14 @@
 package http_server
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
 	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/validation"
 
 	"github.com/go-chi/render"
 	"go.uber.org/zap"
@@ -39,6 +42,10 @@ func errorResponse(rq RequestContext, errInfo ErrorInfo) {
 	code := errInfo.code
 	logLevel := errInfo.logLevel
 	msg := errInfo.msg
+	// Whatever the request, a storage that is unavailable is worth trying again later.
+	if code >= http.StatusInternalServerError && errors.Is(err, domain.ErrUnavailable) {
+		code, logLevel = http.StatusServiceUnavailable, zap.WarnLevel
+	}
 
 	zapErrMsg := zap_utils.Err(err)
 	switch logLevel {
@@ -61,6 +68,23 @@ func errorResponse(rq RequestContext, errInfo ErrorInfo) {
 	render.JSON(w, r, io_server.Error(output))
 }
 
+// validate runs the request validation rules and writes a 400 response with field violations on failure.
+// It reports whether the request is valid.
+func validate(rc RequestContext, req interface{ Validate() error }) bool {
+	err := req.Validate()
+	if err == nil {
+		return true
+	}
+	rc.log.Debug("Invalid request", zap_utils.Err(err))
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		verr = &validation.Error{Violations: []validation.FieldViolation{{Description: err.Error()}}}
+	}
+	rc.w.WriteHeader(http.StatusBadRequest)
+	render.JSON(rc.w, rc.r, io_server.ValidationError(verr))
+	return false
+}
+
 func okResponse(rc RequestContext, ri ResponseInfo) {
 	w := rc.w
 	r := rc.r
@@ -0,0 +1,64 @@
+package http_server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/outbox"
+
+	"go.uber.org/zap"
+)
+
+// @Summary		Stream link changes
+// @Description	Streams every link mutation after after_seq as newline-delimited JSON, in commit order, and
+// @Description	keeps following new ones until the client disconnects. Every line carries the sequence number
+// @Description	"seq", the "type" (created, updated or deleted), the changed "fields" of updates and the "link"
+// @Description	after the change, or before it for deletions. Clients resume by passing the last seq they saw.
+// @Tags			Links
+// @Produce		application/x-ndjson
+// @Security		AdminToken
+// @Param			after_seq	query		int									false	"Sequence number of the last change seen"
+// @Success		200			{string}	string								"Stream of changes"
+// @Failure		400			{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid sequence number"
+// @Failure		401			{object}	map[string]string					"Unauthorized"
+// @Router			/links/changes [get]
+func (s *Server) WatchLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.WatchLinksRequest
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode query: %s",
+		})
+		return
+	}
+	if !validate(rc, req) {
+		return
+	}
+
+	// The stream outlives the write timeout of the server.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		rc.log.Debug("Failed to clear the write deadline of a change stream", zap.Error(err))
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	err := s.changes.Stream(ctx, req.AfterSeq, outbox.NewNDJSONSink(w))
+	if err != nil && ctx.Err() == nil {
+		rc.log.Error("Change stream failed", zap.Int64("after_seq", req.AfterSeq), zap.Error(err))
+	}
+}
@@ -0,0 +1,119 @@
+package http_server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/outbox"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_WatchLinks(t *testing.T) {
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", "")
+	log := zaptest.NewLogger(t)
+	db := inmemory.NewInMemoryDBService()
+	registry, err := domains.FromEnv()
+	require.NoError(t, err)
+	relay := outbox.NewRelay(db.NewChangeLog(), registry, log, outbox.Config{PollInterval: 5 * time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go relay.Run(ctx)
+
+	server := Server{
+		log:          log,
+		urlShortener: service.NewUrlShortener(db.NewUrlRepository(), log),
+		changes:      relay,
+		adminToken:   "s3cret",
+	}
+	ts := httptest.NewServer(server.RegisterRoutes())
+	t.Cleanup(ts.Close)
+
+	post := func(target, body string) {
+		t.Helper()
+		resp, err := http.Post(ts.URL+target, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	watchAs := func(token, query string) (*http.Response, *bufio.Scanner) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/links/changes"+query, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp, bufio.NewScanner(resp.Body)
+	}
+	watch := func(query string) (*http.Response, *bufio.Scanner) {
+		t.Helper()
+		return watchAs("s3cret", query)
+	}
+	type change struct {
+		Seq      int64  `json:"seq"`
+		Type     string `json:"type"`
+		ShortURL string `json:"short_url"`
+		Link     struct {
+			Target string `json:"target"`
+		} `json:"link"`
+	}
+	next := func(lines *bufio.Scanner) change {
+		t.Helper()
+		require.True(t, lines.Scan(), lines.Err())
+		var c change
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &c))
+		return c
+	}
+
+	post("/shorten", `{"url": "https://example.com/a"}`)
+
+	resp, lines := watch("")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	first := next(lines)
+	assert.Equal(t, int64(1), first.Seq)
+	assert.Equal(t, "created", first.Type)
+	assert.True(t, strings.HasPrefix(first.ShortURL, "https://sho.rt/"), first.ShortURL)
+	assert.Equal(t, "https://example.com/a", first.Link.Target)
+
+	// the stream follows changes made after it started
+	post("/shorten", `{"url": "https://example.com/b"}`)
+	second := next(lines)
+	assert.Equal(t, int64(2), second.Seq)
+	assert.Equal(t, "https://example.com/b", second.Link.Target)
+
+	_, resumed := watch("?after_seq=1")
+	assert.Equal(t, int64(2), next(resumed).Seq)
+
+	invalid, _ := watch("?after_seq=-1")
+	assert.Equal(t, http.StatusBadRequest, invalid.StatusCode)
+
+	unauthorized, _ := watchAs("", "")
+	assert.Equal(t, http.StatusUnauthorized, unauthorized.StatusCode)
+	unauthorized, _ = watchAs("wrong", "")
+	assert.Equal(t, http.StatusUnauthorized, unauthorized.StatusCode)
+
+	// without the admin token the stream is not served
+	server.adminToken = ""
+	open := httptest.NewServer(server.RegisterRoutes())
+	t.Cleanup(open.Close)
+	notServed, err := http.Get(open.URL + "/links/changes")
+	require.NoError(t, err)
+	notServed.Body.Close()
+	assert.Equal(t, http.StatusNotFound, notServed.StatusCode)
+}
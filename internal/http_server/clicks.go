@@ -0,0 +1,65 @@
+package http_server
+
+import (
+	"cmp"
+	"net/http"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// @Summary		Get click history of a link
+// @Description	Returns the redirects of a link in total and on each of the last days, UTC, up to and including today.
+// @Tags			Links
+// @Produce		json
+// @Param			code	path		string								true	"Short code"
+// @Param			days	query		int									false	"Number of days (1-366, default 30)"
+// @Success		200		{object}	io_server.ClickHistoryResponse		"Click history"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid days"
+// @Failure		404		{object}	map[string]string					"Link not found"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/links/{code}/clicks [get]
+func (s *Server) GetClickHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.ClickHistoryRequest
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode query: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	if !validate(rc, req) {
+		return
+	}
+	history, err := s.urlShortener.ClickHistory(ctx, req.Code, cmp.Or(req.Days, domain.DefaultClickHistoryDays))
+	if err != nil {
+		linkErrorResponse(rc, err)
+		return
+	}
+	resp := io_server.ClickHistoryResponse{
+		Total: history.Total,
+		Days:  make([]io_server.DailyClicksResponse, 0, len(history.Days)),
+	}
+	for _, d := range history.Days {
+		resp.Days = append(resp.Days, io_server.DailyClicksResponse{Day: d.Day.Format(time.DateOnly), Clicks: d.Clicks})
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
@@ -0,0 +1,230 @@
+package http_server
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/dashboard"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+// dashboardCSP only lets the dashboard run its own scripts and styles and talk to its own origin.
+const dashboardCSP = "default-src 'self'; img-src 'self' data:; object-src 'none'; base-uri 'none'; frame-ancestors 'none'; form-action 'self'"
+
+// DashboardSessions verifies the dashboard session cookie of requests. Requests with a valid
+// session carry it in their context and, unless their method is safe, must echo its CSRF token
+// in the X-CSRF-Token header or are rejected with 403. The cookie of cross-origin requests is
+// ignored, so other sites can neither act nor read with it. Requests without a session, such as
+// those of API clients, are passed on as they are.
+func DashboardSessions(sessions *dashboard.Sessions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(dashboard.CookieName)
+			if err != nil || !sameOrigin(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			session, ok := sessions.Verify(cookie.Value, time.Now())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !isSafeMethod(r.Method) && !sessions.CheckCSRF(session, r.Header.Get(dashboard.CSRFHeader)) {
+				w.WriteHeader(http.StatusForbidden)
+				render.JSON(w, r, io_server.Error("missing or invalid CSRF token"))
+				return
+			}
+			ctx := dashboard.NewContext(r.Context(), session)
+			ctx = zap_utils.ToContext(ctx, zap_utils.FromContext(ctx, nil).With(zap.String("dashboard_user", session.User)))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireSession rejects requests without a dashboard session with 401, unless they carry the
+// admin token as RequireToken accepts it. It must run after DashboardSessions.
+func RequireSession(adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := dashboard.FromContext(r.Context()); !ok && !hasToken(r, adminToken) {
+				w.WriteHeader(http.StatusUnauthorized)
+				render.JSON(w, r, io_server.Error("sign in to the dashboard or use the admin token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ConcealTargets makes the service leave the targets of password protected links out of the
+// responses, unless the request has a dashboard session or the admin token. It must run after
+// DashboardSessions.
+func ConcealTargets(adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := dashboard.FromContext(r.Context()); !ok && !hasToken(r, adminToken) {
+				r = r.WithContext(domain.WithConcealedTargets(r.Context()))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// sameOrigin reports whether the request comes from a page of the origin it is sent to, as far
+// as the browser tells. Requests without an Origin header, such as same-origin GETs, count as
+// same-origin.
+func sameOrigin(r *http.Request) bool {
+	if site := r.Header.Get("Sec-Fetch-Site"); site == "cross-site" || site == "same-site" {
+		return false
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// dashboardAssets serves the files of the dashboard UI.
+func dashboardAssets() http.Handler {
+	files := http.StripPrefix("/dashboard", http.FileServerFS(dashboard.Assets()))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", dashboardCSP)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Referrer-Policy", "same-origin")
+		w.Header().Set("Cache-Control", "no-cache")
+		files.ServeHTTP(w, r)
+	})
+}
+
+// @Summary		Sign in to the dashboard
+// @Description	Checks the password of a dashboard user and sets a session cookie. Requests with the cookie that
+// @Description	change anything must send the returned CSRF token in the X-CSRF-Token header. Failed attempts are
+// @Description	limited per client.
+// @Tags			Dashboard
+// @Accept			json
+// @Produce		json
+// @Param			request	body		io_server.LoginRequest				true	"Credentials"
+// @Success		200		{object}	io_server.SessionResponse			"Session"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Missing credentials"
+// @Failure		401		{object}	map[string]string					"Invalid username or password"
+// @Failure		429		{object}	map[string]string					"Too many attempts"
+// @Router			/dashboard/login [post]
+func (s *Server) DashboardLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.LoginRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	if !validate(rc, req) {
+		return
+	}
+	token, session, err := s.dashboard.Login(req.Username, req.Password, clientAddress(r), time.Now())
+	if err != nil {
+		code := http.StatusUnauthorized
+		if errors.Is(err, dashboard.ErrTooManyAttempts) {
+			code = http.StatusTooManyRequests
+		}
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     code,
+			logLevel: zap.WarnLevel,
+			msg:      "Dashboard login failed: %s",
+		})
+		return
+	}
+	rc.log.Info("Dashboard login", zap.String("user", session.User))
+	http.SetCookie(w, &http.Cookie{
+		Name:     dashboard.CookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  session.Expires,
+		MaxAge:   int(time.Until(session.Expires).Seconds()),
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.Header().Set("Cache-Control", "no-store")
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toSessionResponse(session),
+	})
+}
+
+// @Summary		Get the dashboard session
+// @Description	Returns the user and CSRF token of the session cookie of the caller.
+// @Tags			Dashboard
+// @Produce		json
+// @Success		200	{object}	io_server.SessionResponse	"Session"
+// @Failure		401	{object}	map[string]string			"Not signed in"
+// @Router			/dashboard/session [get]
+func (s *Server) GetDashboardSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	session, ok := dashboard.FromContext(ctx)
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		render.JSON(w, r, io_server.Error("not signed in"))
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toSessionResponse(session),
+	})
+}
+
+// @Summary		Sign out of the dashboard
+// @Description	Drops the session cookie. Requires the CSRF token of the session.
+// @Tags			Dashboard
+// @Success		204	"Signed out"
+// @Failure		403	{object}	map[string]string	"Missing or invalid CSRF token"
+// @Router			/dashboard/logout [post]
+func (s *Server) DashboardLogout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     dashboard.CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toSessionResponse(session dashboard.Session) io_server.SessionResponse {
+	return io_server.SessionResponse{
+		User:      session.User,
+		CSRFToken: session.CSRFToken,
+		ExpiresAt: session.Expires,
+	}
+}
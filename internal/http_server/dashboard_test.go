@@ -0,0 +1,102 @@
+package http_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/dashboard"
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestServer_Dashboard(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("wonderland"), bcrypt.MinCost)
+	require.NoError(t, err)
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+		dashboard:    dashboard.New(dashboard.Config{Users: map[string]string{"alice": string(hash)}}),
+		adminToken:   "s3cret",
+	}
+	router := server.RegisterRoutes()
+
+	urlShortener.On("UpdateLink", mock.Anything, "abc", mock.Anything, mock.Anything).
+		Return(service.Link{Link: database.Link{Target: "https://example.com", Disabled: true}, Code: "abc"}, nil)
+
+	do := func(method, target, body string, headers map[string]string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		if cookie != nil {
+			r.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(http.MethodGet, "/dashboard/", "", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Links dashboard")
+	assert.Contains(t, w.Header().Get("Content-Security-Policy"), "default-src 'self'")
+	assert.Equal(t, http.StatusMovedPermanently, do(http.MethodGet, "/dashboard", "", nil, nil).Code)
+
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/dashboard/session", "", nil, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/dashboard/login", `{"username":"alice"}`, nil, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/dashboard/login", `{"username":"alice","password":"wrong"}`, nil, nil).Code)
+
+	w = do(http.MethodPost, "/dashboard/login", `{"username":"alice","password":"wonderland"}`, nil, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var login struct {
+		Data io_server.SessionResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &login))
+	assert.Equal(t, "alice", login.Data.User)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	cookie := cookies[0]
+	assert.Equal(t, dashboard.CookieName, cookie.Name)
+	assert.True(t, cookie.HttpOnly)
+	assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+
+	w = do(http.MethodGet, "/dashboard/session", "", nil, cookie)
+	require.Equal(t, http.StatusOK, w.Code)
+	var session struct {
+		Data io_server.SessionResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+	assert.Equal(t, login.Data.CSRFToken, session.Data.CSRFToken)
+
+	body := `{"fields":["disabled"],"disabled":true}`
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPatch, "/links/abc", body, nil, nil).Code, "changes need a session")
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPut, "/links/abc/tags", `{"tags":[]}`, nil, nil).Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodPatch, "/links/abc", body, map[string]string{"Authorization": "Bearer s3cret"}, nil).Code,
+		"or the admin token")
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPatch, "/links/abc", body, nil, cookie).Code, "no CSRF token")
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPatch, "/links/abc", body, map[string]string{dashboard.CSRFHeader: "forged"}, cookie).Code)
+	w = do(http.MethodPatch, "/links/abc", body, map[string]string{dashboard.CSRFHeader: login.Data.CSRFToken}, cookie)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Other sites can't use the cookie: it is ignored, so the request is anonymous.
+	crossSite := map[string]string{"Origin": "https://evil.example", dashboard.CSRFHeader: login.Data.CSRFToken}
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/dashboard/session", "", crossSite, cookie).Code)
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/dashboard/session", "", map[string]string{"Sec-Fetch-Site": "cross-site"}, cookie).Code)
+
+	w = do(http.MethodPost, "/dashboard/logout", "", map[string]string{dashboard.CSRFHeader: login.Data.CSRFToken}, cookie)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Len(t, w.Result().Cookies(), 1)
+	assert.Negative(t, w.Result().Cookies()[0].MaxAge)
+}
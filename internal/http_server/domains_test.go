@@ -0,0 +1,89 @@
+package http_server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_Domains(t *testing.T) {
+	dir := t.TempDir()
+	page := filepath.Join(dir, "404.html")
+	require.NoError(t, os.WriteFile(page, []byte("<h1>Brand A: no such link</h1>"), 0o600))
+	config := `[{"host": "go.brand-a.com", "redirect_status": 301, "not_found_page": "` + page + `"}]`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "domains.json"), []byte(config), 0o600))
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", filepath.Join(dir, "domains.json"))
+
+	// selects reports whether the domain with the given name is selected in the context.
+	selects := func(name string) any {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			registry, err := domains.FromEnv()
+			require.NoError(t, err)
+			d, err := registry.Select(ctx)
+			return err == nil && d.Name() == name
+		})
+	}
+	urlShortener := new(UrlShortenerMock)
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool { return req.Code == "open" })).
+		Return(service.Resolved{Link: service.Link{Link: database.Link{Target: "https://example.com"}, Code: "open"}}, nil)
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool { return req.Code == "missing" })).
+		Return(service.Resolved{}, service.ErrUrlNotFound)
+	urlShortener.On("CreateUrl", selects("go.brand-a.com"), "https://example.com").Return("https://go.brand-a.com/abc", nil)
+	urlShortener.On("CreateUrl", selects(""), "https://example.com").Return("https://sho.rt/abc", nil)
+	server := Server{log: zaptest.NewLogger(t), urlShortener: urlShortener}
+	router := server.RegisterRoutes()
+
+	do := func(r *http.Request, host string) *httptest.ResponseRecorder {
+		r.Host = host
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(httptest.NewRequest(http.MethodGet, "/open", nil), "go.brand-a.com")
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "https://example.com", w.Header().Get("Location"))
+	w = do(httptest.NewRequest(http.MethodGet, "/open", nil), "sho.rt")
+	assert.Equal(t, http.StatusFound, w.Code)
+
+	w = do(httptest.NewRequest(http.MethodGet, "/missing", nil), "go.brand-a.com:443")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "<h1>Brand A: no such link</h1>", w.Body.String())
+	w = do(httptest.NewRequest(http.MethodGet, "/missing", nil), "sho.rt")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	create := func(host, header string) string {
+		r := httptest.NewRequest(http.MethodPost, "/shorten", strings.NewReader(`{"url": "https://example.com"}`))
+		if header != "" {
+			r.Header.Set(domains.Header, header)
+		}
+		w := do(r, host)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp struct {
+			Data io_server.CreateUrlResponse `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp.Data.ShortenURL
+	}
+	assert.Equal(t, "https://go.brand-a.com/abc", create("go.brand-a.com", ""))
+	assert.Equal(t, "https://sho.rt/abc", create("localhost:8080", ""))
+	assert.Equal(t, "https://go.brand-a.com/abc", create("localhost:8080", "go.brand-a.com"), "the header selects the domain explicitly")
+}
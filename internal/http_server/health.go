@@ -0,0 +1,34 @@
+package http_server
+
+import (
+	"net/http"
+
+	"github.com/Parzival-05/url-shortener/internal/health"
+
+	"github.com/go-chi/render"
+)
+
+// @Summary      Liveness probe
+// @Description  Reports that the process is running. It doesn't check any dependencies.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} health.Report "Process is alive"
+// @Router       /livez [get]
+func (s *Server) livezHandler(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, health.Report{Status: health.StatusUp, Checks: map[string]health.CheckResult{}})
+}
+
+// @Summary      Readiness probe
+// @Description  Runs all registered dependency checks. Returns 503 if any of them fails or the server is shutting down.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} health.Report "Ready to serve traffic"
+// @Failure      503 {object} health.Report "Not ready"
+// @Router       /readyz [get]
+func (s *Server) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	report := s.health.Check(r.Context())
+	if !report.Ready() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, report)
+}
@@ -0,0 +1,53 @@
+package http_server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/health"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_readyzHandler(t *testing.T) {
+	dbUp := true
+	registry := health.NewRegistry(time.Second)
+	registry.Register(health.NewChecker("database", func(ctx context.Context) error {
+		if !dbUp {
+			return errors.New("connection refused")
+		}
+		return nil
+	}))
+	server := Server{health: registry}
+
+	get := func() (int, health.Report) {
+		w := httptest.NewRecorder()
+		server.readyzHandler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var report health.Report
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		return w.Code, report
+	}
+
+	code, report := get()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+
+	dbUp = false
+	code, report = get()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "connection refused", report.Checks["database"].Error)
+
+	dbUp = true
+	registry.Shutdown()
+	code, _ = get()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	w := httptest.NewRecorder()
+	server.livezHandler(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
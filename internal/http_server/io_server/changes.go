@@ -0,0 +1,20 @@
+package io_server
+
+import (
+	"github.com/Parzival-05/url-shortener/internal/validation"
+)
+
+type WatchLinksRequest struct {
+	// AfterSeq is the sequence number of the last change seen. 0 streams the whole log.
+	AfterSeq int64 `json:"after_seq" schema:"after_seq"`
+}
+
+func (r WatchLinksRequest) Validate() error {
+	if r.AfterSeq < 0 {
+		return &validation.Error{Violations: []validation.FieldViolation{{
+			Field:       "after_seq",
+			Description: "value must be greater than or equal to 0",
+		}}}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package io_server
+
+import (
+	"math"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/validation"
+)
+
+type ClickHistoryRequest struct {
+	Code string `json:"-" schema:"-"`
+	// Days is the number of UTC days up to and including today, 30 if unset.
+	Days int `json:"days" schema:"days"`
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r ClickHistoryRequest) Validate() error {
+	return validation.Validate(&url_shortener_v2.GetLinkClickHistoryRequest{Code: r.Code, Days: int32(min(max(r.Days, math.MinInt32), math.MaxInt32))})
+}
+
+type DailyClicksResponse struct {
+	// Day is the UTC date, e.g. 2024-05-31.
+	Day    string `json:"day"`
+	Clicks int64  `json:"clicks"`
+}
+
+type ClickHistoryResponse struct {
+	// Total counts the redirects of the link since it was created.
+	Total int64 `json:"total"`
+	// Days holds one entry per day, oldest first, including days without clicks.
+	Days []DailyClicksResponse `json:"days"`
+}
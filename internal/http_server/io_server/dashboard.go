@@ -0,0 +1,34 @@
+package io_server
+
+import (
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/validation"
+)
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (r LoginRequest) Validate() error {
+	var violations []validation.FieldViolation
+	if r.Username == "" {
+		violations = append(violations, validation.FieldViolation{Field: "username", Description: "value is required"})
+	}
+	if r.Password == "" {
+		violations = append(violations, validation.FieldViolation{Field: "password", Description: "value is required"})
+	}
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	return nil
+}
+
+// SessionResponse describes the dashboard session of the caller.
+type SessionResponse struct {
+	User string `json:"user"`
+	// CSRFToken must be sent in the X-CSRF-Token header on requests that change anything.
+	CSRFToken string    `json:"csrf_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -1,5 +1,7 @@
 package io_server
 
+import "github.com/Parzival-05/url-shortener/internal/validation"
+
 type Response struct {
 	Status string `json:"status"`
 	Error  string `json:"error,omitempty"`
@@ -24,3 +26,18 @@ func Error(msg string) Response {
 		Error:  msg,
 	}
 }
+
+// ValidationErrorResponse is returned with 400 when a request breaks the validation rules.
+type ValidationErrorResponse struct {
+	Status     string                      `json:"status"`
+	Error      string                      `json:"error"`
+	Violations []validation.FieldViolation `json:"violations"`
+}
+
+func ValidationError(err *validation.Error) ValidationErrorResponse {
+	return ValidationErrorResponse{
+		Status:     StatusError,
+		Error:      err.Error(),
+		Violations: err.Violations,
+	}
+}
@@ -0,0 +1,203 @@
+package io_server
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/validation"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ListLinksRequest struct {
+	PageSize      int       `json:"page_size" schema:"page_size"`
+	PageToken     string    `json:"page_token" schema:"page_token"`
+	Owner         string    `json:"owner" schema:"owner"`
+	Tag           string    `json:"tag" schema:"tag"`
+	Campaign      string    `json:"campaign" schema:"campaign"`
+	Domain        string    `json:"domain" schema:"domain"`
+	CreatedAfter  time.Time `json:"created_after" schema:"created_after"`
+	CreatedBefore time.Time `json:"created_before" schema:"created_before"`
+	Query         string    `json:"q" schema:"q"`
+	Sort          string    `json:"sort" schema:"sort"`
+	ShowDisabled  bool      `json:"show_disabled" schema:"show_disabled"`
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r ListLinksRequest) Validate() error {
+	if !database.LinkSort(r.Sort).Valid() {
+		return &validation.Error{Violations: []validation.FieldViolation{{
+			Field:       "sort",
+			Description: `value must be one of "id", "-id", "created_at", "-created_at"`,
+		}}}
+	}
+	req := &url_shortener_v2.ListLinksRequest{
+		PageSize:       int32(min(max(r.PageSize, math.MinInt32), math.MaxInt32)),
+		PageToken:      r.PageToken,
+		Owner:          r.Owner,
+		Tag:            r.Tag,
+		Campaign:       r.Campaign,
+		ShowDisabled:   r.ShowDisabled,
+		TargetDomain:   r.Domain,
+		TargetContains: r.Query,
+	}
+	if !r.CreatedAfter.IsZero() {
+		req.CreateTimeAfter = timestamppb.New(r.CreatedAfter)
+	}
+	if !r.CreatedBefore.IsZero() {
+		req.CreateTimeBefore = timestamppb.New(r.CreatedBefore)
+	}
+	return validation.Validate(req)
+}
+
+// UpdateLinkRequest sets the fields of a link named in Fields to the values in the request,
+// like the update mask of the gRPC UpdateLink. A named field left out of the body is cleared,
+// so that e.g. ["expires_at"] alone makes the link never expire.
+type UpdateLinkRequest struct {
+	Code string `json:"-"`
+	// Fields are some of target, owner, tags, campaign, disabled, expires_at, password and max_clicks.
+	Fields    []string   `json:"fields"`
+	Target    string     `json:"target,omitempty"`
+	Owner     string     `json:"owner,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Campaign  string     `json:"campaign,omitempty"`
+	Disabled  bool       `json:"disabled,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Password protects the link, an empty one removes the protection.
+	Password string `json:"password,omitempty"`
+	// MaxClicks restarts the count of a click-limited link, 0 lifts the limit.
+	MaxClicks int64 `json:"max_clicks,omitempty"`
+}
+
+// UpdateLinkFields lists the fields UpdateLinkRequest can set. Variants, passthrough options
+// and redirect rules have requests of their own.
+var UpdateLinkFields = []database.LinkField{
+	database.LinkFieldTarget,
+	database.LinkFieldOwner,
+	database.LinkFieldTags,
+	database.LinkFieldCampaign,
+	database.LinkFieldDisabled,
+	database.LinkFieldExpiresAt,
+	database.LinkFieldPassword,
+	database.LinkFieldMaxClicks,
+}
+
+// Validate applies the rules of the equivalent gRPC request to the fields named.
+func (r UpdateLinkRequest) Validate() error {
+	if err := validation.Validate(&url_shortener_v2.GetLinkRequest{Code: r.Code}); err != nil {
+		return err
+	}
+	if len(r.Fields) == 0 {
+		return &validation.Error{Violations: []validation.FieldViolation{{
+			Field:       "fields",
+			Description: "value must name at least one field",
+		}}}
+	}
+	for _, field := range r.Fields {
+		if !slices.Contains(UpdateLinkFields, database.LinkField(field)) {
+			return &validation.Error{Violations: []validation.FieldViolation{{
+				Field:       "fields",
+				Description: fmt.Sprintf("unknown field %q", field),
+			}}}
+		}
+	}
+	return validateLinkFields(&url_shortener_v2.Link{
+		Target:    r.Target,
+		Owner:     r.Owner,
+		Tags:      r.Tags,
+		Campaign:  r.Campaign,
+		Password:  r.Password,
+		MaxClicks: r.MaxClicks,
+	}, r.Fields...)
+}
+
+type LinkResponse struct {
+	Code     string `json:"code"`
+	ShortURL string `json:"short_url,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	// Target is left out for password protected links, unless the request has the admin token
+	// or a dashboard session.
+	Target          string       `json:"target,omitempty"`
+	Owner           string       `json:"owner,omitempty"`
+	Tags            []string     `json:"tags,omitempty"`
+	Campaign        string       `json:"campaign,omitempty"`
+	Clicks          int64        `json:"clicks,omitempty"`
+	Disabled        bool         `json:"disabled"`
+	ExpiresAt       *time.Time   `json:"expires_at,omitempty"`
+	MaxClicks       int64        `json:"max_clicks,omitempty"`
+	RemainingClicks int64        `json:"remaining_clicks,omitempty"`
+	Variants        []Variant    `json:"variants,omitempty"`
+	SplitMode       string       `json:"split_mode,omitempty"`
+	Passthrough     *Passthrough `json:"passthrough,omitempty"`
+	Template        *Template    `json:"template,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+type ListLinksResponse struct {
+	Links         []LinkResponse `json:"links"`
+	NextPageToken string         `json:"next_page_token,omitempty"`
+}
+
+type QRCodeRequest struct {
+	Code string `json:"-" schema:"-"`
+	// Format is png (default) or svg.
+	Format string `json:"format" schema:"format"`
+	Size   int    `json:"size" schema:"size"`
+	// Level is the error correction level: L, M, Q or H.
+	Level      string `json:"level" schema:"level"`
+	Margin     *int   `json:"margin" schema:"margin"`
+	Foreground string `json:"fg" schema:"fg"`
+	Background string `json:"bg" schema:"bg"`
+	Logo       bool   `json:"logo" schema:"logo"`
+}
+
+var (
+	qrFormats = map[string]url_shortener_v2.GetLinkQRCodeRequest_Format{
+		"":    url_shortener_v2.GetLinkQRCodeRequest_FORMAT_UNSPECIFIED,
+		"png": url_shortener_v2.GetLinkQRCodeRequest_FORMAT_PNG,
+		"svg": url_shortener_v2.GetLinkQRCodeRequest_FORMAT_SVG,
+	}
+	qrLevels = map[string]url_shortener_v2.GetLinkQRCodeRequest_ErrorCorrection{
+		"":  url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_UNSPECIFIED,
+		"L": url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_LOW,
+		"M": url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_MEDIUM,
+		"Q": url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_QUARTILE,
+		"H": url_shortener_v2.GetLinkQRCodeRequest_ERROR_CORRECTION_HIGH,
+	}
+)
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r QRCodeRequest) Validate() error {
+	var violations []validation.FieldViolation
+	format, ok := qrFormats[strings.ToLower(r.Format)]
+	if !ok {
+		violations = append(violations, validation.FieldViolation{Field: "format", Description: `value must be one of "png", "svg"`})
+	}
+	level, ok := qrLevels[strings.ToUpper(r.Level)]
+	if !ok {
+		violations = append(violations, validation.FieldViolation{Field: "level", Description: `value must be one of "L", "M", "Q", "H"`})
+	}
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	req := &url_shortener_v2.GetLinkQRCodeRequest{
+		Code:            r.Code,
+		Format:          format,
+		Size:            int32(min(max(r.Size, math.MinInt32), math.MaxInt32)),
+		ErrorCorrection: level,
+		ForegroundColor: r.Foreground,
+		BackgroundColor: r.Background,
+		Logo:            r.Logo,
+	}
+	if r.Margin != nil {
+		margin := int32(min(max(*r.Margin, math.MinInt32), math.MaxInt32))
+		req.Margin = &margin
+	}
+	return validation.Validate(req)
+}
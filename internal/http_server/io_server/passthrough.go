@@ -0,0 +1,65 @@
+package io_server
+
+import (
+	"errors"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/validation"
+)
+
+type UTM struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+	Term     string `json:"term,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+type Passthrough struct {
+	// Query merges the incoming query into the target: keep, override or drop decide about
+	// parameters the target already has. Unset doesn't forward the query.
+	Query string `json:"query,omitempty"`
+	// Path appends the trailing path of the short URL, /{code}/extra/path, to the target path.
+	Path bool `json:"path,omitempty"`
+	// UTM parameters are added to the target, replacing any it has.
+	UTM UTM `json:"utm"`
+}
+
+// PassthroughRequest replaces the passthrough options of a link. An empty body turns passthrough off.
+type PassthroughRequest struct {
+	Code string `json:"-"`
+	Passthrough
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r PassthroughRequest) Validate() error {
+	var violations []validation.FieldViolation
+	if err := validation.Validate(&url_shortener_v2.GetLinkVariantStatsRequest{Code: r.Code}); err != nil {
+		return err
+	}
+	if !passthrough.QueryPolicy(r.Query).Valid() {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "query",
+			Description: `value must be one of "keep", "override", "drop"`,
+		})
+	}
+	err := validation.Validate(&url_shortener_v2.Utm{
+		Source:   r.UTM.Source,
+		Medium:   r.UTM.Medium,
+		Campaign: r.UTM.Campaign,
+		Term:     r.UTM.Term,
+		Content:  r.UTM.Content,
+	})
+	var invalid *validation.Error
+	if errors.As(err, &invalid) {
+		for _, violation := range invalid.Violations {
+			violation.Field = "utm." + violation.Field
+			violations = append(violations, violation)
+		}
+	}
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	return nil
+}
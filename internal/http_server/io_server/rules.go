@@ -0,0 +1,133 @@
+package io_server
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/validation"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type RuleConditions struct {
+	// Platforms: ios, android, windows, macos, linux or other.
+	Platforms []string `json:"platforms,omitempty"`
+	// Languages are language tags such as "de" or "pt-BR".
+	Languages []string `json:"languages,omitempty"`
+	// Countries are ISO 3166-1 alpha-2 codes.
+	Countries []string `json:"countries,omitempty"`
+	// Days are lowercase English day names, e.g. "monday".
+	Days []string `json:"days,omitempty"`
+	// From and To bound the time of day as HH:MM.
+	From     string            `json:"from,omitempty"`
+	To       string            `json:"to,omitempty"`
+	TimeZone string            `json:"time_zone,omitempty"`
+	Query    map[string]string `json:"query,omitempty"`
+}
+
+type RuleRequest struct {
+	Code       string         `json:"-"`
+	ID         string         `json:"-"`
+	Target     string         `json:"target"`
+	Conditions RuleConditions `json:"conditions"`
+	// Index is the position of the rule. Unset appends a new rule or keeps an updated one in place.
+	Index *int `json:"index,omitempty"`
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r RuleRequest) Validate() error {
+	var violations []validation.FieldViolation
+	conditions := &url_shortener_v2.RuleConditions{
+		Languages: r.Conditions.Languages,
+		Countries: r.Conditions.Countries,
+		From:      r.Conditions.From,
+		To:        r.Conditions.To,
+		TimeZone:  r.Conditions.TimeZone,
+		Query:     r.Conditions.Query,
+	}
+	for i, p := range r.Conditions.Platforms {
+		platform, ok := url_shortener_v2.RuleConditions_Platform_value["PLATFORM_"+strings.ToUpper(p)]
+		if !ok || platform == 0 {
+			violations = append(violations, validation.FieldViolation{
+				Field:       fmt.Sprintf("conditions.platforms[%d]", i),
+				Description: `value must be one of "ios", "android", "windows", "macos", "linux", "other"`,
+			})
+		}
+		conditions.Platforms = append(conditions.Platforms, url_shortener_v2.RuleConditions_Platform(platform))
+	}
+	for i, d := range r.Conditions.Days {
+		day, ok := url_shortener_v2.RuleConditions_Day_value["DAY_"+strings.ToUpper(d)]
+		if !ok || day == 0 {
+			violations = append(violations, validation.FieldViolation{
+				Field:       fmt.Sprintf("conditions.days[%d]", i),
+				Description: "value must be a day of the week, e.g. \"monday\"",
+			})
+		}
+		conditions.Days = append(conditions.Days, url_shortener_v2.RuleConditions_Day(day))
+	}
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	rule := &url_shortener_v2.Rule{Id: r.ID, Target: r.Target, Conditions: conditions}
+	var index *int32
+	if r.Index != nil {
+		i := int32(min(max(*r.Index, math.MinInt32), math.MaxInt32))
+		index = &i
+	}
+	if r.ID == "" {
+		return validation.Validate(&url_shortener_v2.CreateLinkRuleRequest{Code: r.Code, Rule: rule, Index: index})
+	}
+	return validation.Validate(&url_shortener_v2.UpdateLinkRuleRequest{Code: r.Code, Rule: rule, Index: index})
+}
+
+type RuleResponse struct {
+	ID         string         `json:"id"`
+	Target     string         `json:"target"`
+	Conditions RuleConditions `json:"conditions"`
+}
+
+type ListRulesResponse struct {
+	Rules []RuleResponse `json:"rules"`
+}
+
+// EvaluateRulesRequest describes the request to evaluate the rules against. User agent,
+// languages and IP address default to those of the evaluate request itself.
+type EvaluateRulesRequest struct {
+	Code           string            `json:"-"`
+	UserAgent      string            `json:"user_agent"`
+	AcceptLanguage string            `json:"accept_language"`
+	IP             string            `json:"ip"`
+	Country        string            `json:"country"`
+	Time           time.Time         `json:"time"`
+	Query          map[string]string `json:"query"`
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r EvaluateRulesRequest) Validate() error {
+	req := &url_shortener_v2.EvaluateLinkRulesRequest{
+		Code:           r.Code,
+		UserAgent:      r.UserAgent,
+		AcceptLanguage: r.AcceptLanguage,
+		Ip:             r.IP,
+		Country:        r.Country,
+		Query:          r.Query,
+	}
+	if !r.Time.IsZero() {
+		req.Time = timestamppb.New(r.Time)
+	}
+	return validation.Validate(req)
+}
+
+type EvaluateRulesResponse struct {
+	// Rule is the first matching rule, null if none matched.
+	Rule *RuleResponse `json:"rule"`
+	// Target is where the link would redirect to.
+	Target    string    `json:"target"`
+	Platform  string    `json:"platform"`
+	Languages []string  `json:"languages"`
+	Country   string    `json:"country,omitempty"`
+	Time      time.Time `json:"time"`
+}
@@ -0,0 +1,76 @@
+package io_server
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/Parzival-05/url-shortener/internal/validation"
+)
+
+type Variant struct {
+	ID     string `json:"id"`
+	Target string `json:"target"`
+	// Weight is the relative share of the traffic, 0 pauses the variant.
+	Weight int `json:"weight"`
+}
+
+// SplitRequest replaces the split variants of a link. An empty list turns splitting off.
+type SplitRequest struct {
+	Code string `json:"-"`
+	// SplitMode is random (default) or sticky.
+	SplitMode string    `json:"split_mode"`
+	Variants  []Variant `json:"variants"`
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r SplitRequest) Validate() error {
+	var violations []validation.FieldViolation
+	if err := validation.Validate(&url_shortener_v2.GetLinkVariantStatsRequest{Code: r.Code}); err != nil {
+		return err
+	}
+	if !split.Mode(r.SplitMode).Valid() {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "split_mode",
+			Description: `value must be one of "random", "sticky"`,
+		})
+	}
+	if len(r.Variants) > split.MaxVariants {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "variants",
+			Description: fmt.Sprintf("value must contain no more than %d item(s)", split.MaxVariants),
+		})
+	}
+	for i, v := range r.Variants {
+		err := validation.Validate(&url_shortener_v2.Variant{
+			Id:     v.ID,
+			Target: v.Target,
+			Weight: int32(min(max(v.Weight, math.MinInt32), math.MaxInt32)),
+		})
+		var invalid *validation.Error
+		if errors.As(err, &invalid) {
+			for _, violation := range invalid.Violations {
+				violation.Field = fmt.Sprintf("variants[%d].%s", i, violation.Field)
+				violations = append(violations, violation)
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	return nil
+}
+
+type VariantStatsResponse struct {
+	Variant
+	// Clicks counts the resolves that picked the variant.
+	Clicks int64 `json:"clicks"`
+	// Removed variants have recorded clicks but are no longer configured. Only their id is set.
+	Removed bool `json:"removed,omitempty"`
+}
+
+type ListVariantStatsResponse struct {
+	Variants []VariantStatsResponse `json:"variants"`
+}
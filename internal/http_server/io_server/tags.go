@@ -0,0 +1,94 @@
+package io_server
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/validation"
+)
+
+// validateLinkFields applies the rules of the gRPC link to the given fields of link only, for
+// requests setting some fields of a link.
+func validateLinkFields(link *url_shortener_v2.Link, fields ...string) error {
+	err := validation.Validate(link)
+	var invalid *validation.Error
+	if !errors.As(err, &invalid) {
+		return err
+	}
+	violations := slices.DeleteFunc(invalid.Violations, func(v validation.FieldViolation) bool {
+		return !slices.ContainsFunc(fields, func(field string) bool {
+			return v.Field == field || strings.HasPrefix(v.Field, field+"[") || strings.HasPrefix(v.Field, field+".")
+		})
+	})
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	return nil
+}
+
+// TagsRequest replaces the tags of a link. An empty list removes them.
+type TagsRequest struct {
+	Code string   `json:"-"`
+	Tags []string `json:"tags"`
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r TagsRequest) Validate() error {
+	if err := validation.Validate(&url_shortener_v2.GetLinkRequest{Code: r.Code}); err != nil {
+		return err
+	}
+	return validateLinkFields(&url_shortener_v2.Link{Tags: r.Tags}, "tags")
+}
+
+// CampaignRequest files a link under a campaign. An empty campaign takes it out.
+type CampaignRequest struct {
+	Code     string `json:"-"`
+	Campaign string `json:"campaign"`
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r CampaignRequest) Validate() error {
+	if err := validation.Validate(&url_shortener_v2.GetLinkRequest{Code: r.Code}); err != nil {
+		return err
+	}
+	return validateLinkFields(&url_shortener_v2.Link{Campaign: r.Campaign}, "campaign")
+}
+
+// BatchTagsRequest adds tags to and removes tags from many links at once.
+type BatchTagsRequest struct {
+	Codes []string `json:"codes"`
+	// AddTags are added to the links that don't have them yet.
+	AddTags []string `json:"add_tags"`
+	// RemoveTags are removed from the links. A tag both added and removed is removed.
+	RemoveTags []string `json:"remove_tags"`
+}
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r BatchTagsRequest) Validate() error {
+	return validation.Validate(&url_shortener_v2.BatchUpdateLinkTagsRequest{
+		Codes:      r.Codes,
+		AddTags:    r.AddTags,
+		RemoveTags: r.RemoveTags,
+	})
+}
+
+type BatchTagsResponse struct {
+	// Links are the links whose tags changed.
+	Links []LinkResponse `json:"links"`
+	// NotFoundCodes name no link of the domain.
+	NotFoundCodes []string `json:"not_found_codes,omitempty"`
+}
+
+type CampaignStatsResponse struct {
+	Campaign string `json:"campaign"`
+	// Links counts the links in the campaign, disabled ones included.
+	Links int64 `json:"links"`
+	// Clicks counts the resolves of the links in the campaign.
+	Clicks int64 `json:"clicks"`
+}
+
+type ListCampaignStatsResponse struct {
+	Campaigns []CampaignStatsResponse `json:"campaigns"`
+}
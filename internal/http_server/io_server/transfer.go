@@ -0,0 +1,89 @@
+package io_server
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Parzival-05/url-shortener/internal/transfer"
+	"github.com/Parzival-05/url-shortener/internal/validation"
+)
+
+// Conflict policies of an import.
+const (
+	OnConflictRemap = "remap"
+	OnConflictSkip  = "skip"
+)
+
+type ImportLinksRequest struct {
+	// Format is csv, ndjson, bitly or yourls. Empty means ndjson.
+	Format string `schema:"format"`
+	// DryRun validates the file and reports what would be imported without creating links.
+	DryRun bool `schema:"dry_run"`
+	// OnConflict is remap to give links whose code is taken a new code, or skip to leave them
+	// out. Empty means remap.
+	OnConflict string `schema:"on_conflict"`
+}
+
+func (r ImportLinksRequest) Validate() error {
+	var violations []validation.FieldViolation
+	if r.Format != "" && !slices.Contains(transfer.ImportFormats, transfer.Format(r.Format)) {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "format",
+			Description: fmt.Sprintf("value must be one of %q", transfer.ImportFormats),
+		})
+	}
+	if r.OnConflict != "" && r.OnConflict != OnConflictRemap && r.OnConflict != OnConflictSkip {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "on_conflict",
+			Description: fmt.Sprintf("value must be one of %q", []string{OnConflictRemap, OnConflictSkip}),
+		})
+	}
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	return nil
+}
+
+type ImportLinksResponse struct {
+	DryRun   bool `json:"dry_run"`
+	Imported int  `json:"imported"`
+	Remapped int  `json:"remapped"`
+	Skipped  int  `json:"skipped"`
+	Invalid  int  `json:"invalid"`
+	// Rejected lists the rows that were skipped or invalid.
+	Rejected []RejectedRow `json:"rejected"`
+	// Mapping lists the old code or alias and the new code of every remapped or aliased link.
+	Mapping []CodeMapping `json:"mapping"`
+}
+
+type RejectedRow struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	Code   string `json:"code,omitempty"`
+	Alias  string `json:"alias,omitempty"`
+	Target string `json:"target,omitempty"`
+	Error  string `json:"error"`
+}
+
+type CodeMapping struct {
+	OldCode string `json:"old_code,omitempty"`
+	Alias   string `json:"alias,omitempty"`
+	// NewCode is empty for dry runs that would assign a new code.
+	NewCode  string `json:"new_code,omitempty"`
+	ShortURL string `json:"short_url,omitempty"`
+}
+
+type ExportLinksRequest struct {
+	// Format is csv or ndjson. Empty means ndjson.
+	Format string `schema:"format"`
+}
+
+func (r ExportLinksRequest) Validate() error {
+	if r.Format != "" && !slices.Contains(transfer.ExportFormats, transfer.Format(r.Format)) {
+		return &validation.Error{Violations: []validation.FieldViolation{{
+			Field:       "format",
+			Description: fmt.Sprintf("value must be one of %q", transfer.ExportFormats),
+		}}}
+	}
+	return nil
+}
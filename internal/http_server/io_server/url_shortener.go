@@ -1,8 +1,59 @@
 package io_server
 
+import (
+	"time"
+
+	url_shortener_v1 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v1"
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+	"github.com/Parzival-05/url-shortener/internal/validation"
+)
+
+// Template turns the URL of a link into a template with placeholders after the host:
+// {name}, {name=default} or {+name}, filled from the trailing path and the query of redirects.
+type Template struct {
+	// Path is the pattern of the trailing path, e.g. "{version}/{page}".
+	Path string `json:"path,omitempty" schema:"-"`
+}
+
 type CreateUrlRequest struct {
 	URL string `json:"url" validate:"required,url" schema:"url"`
+	// Password optionally protects the link. A protected link is always created anew.
+	Password string `json:"password,omitempty" schema:"password"`
+	// MaxClicks optionally limits how many times the link resolves, 1 for a single-use link.
+	// A limited link is always created anew.
+	MaxClicks int64 `json:"max_clicks,omitempty" schema:"max_clicks"`
+	// Template optionally makes URL a template. A templated link is always created anew.
+	Template *Template `json:"template,omitempty" schema:"-"`
+	// Tags and Campaign optionally organize the link. Such a link is always created anew.
+	Tags     []string `json:"tags,omitempty" schema:"-"`
+	Campaign string   `json:"campaign,omitempty" schema:"-"`
+	// Owner optionally records who the link belongs to. An owned link is always created anew.
+	Owner string `json:"owner,omitempty" schema:"-"`
+	// ExpiresAt optionally stops the link from resolving at that time. Such a link is always
+	// created anew.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" schema:"-"`
+}
+
+// Dedicated reports whether the link must be created anew rather than shared with other
+// callers shortening the same URL.
+func (r CreateUrlRequest) Dedicated() bool {
+	return r.Password != "" || r.MaxClicks != 0 || r.Template != nil || len(r.Tags) > 0 || r.Campaign != "" ||
+		r.Owner != "" || r.ExpiresAt != nil
 }
+
+// Validate applies the rules of the equivalent gRPC request.
+func (r CreateUrlRequest) Validate() error {
+	if err := validation.Validate(&url_shortener_v1.CreateShortURLRequest{Url: r.URL, Password: r.Password, MaxClicks: r.MaxClicks}); err != nil {
+		return err
+	}
+	if r.Template != nil {
+		if err := validation.Validate(&url_shortener_v2.LinkTemplate{Path: r.Template.Path}); err != nil {
+			return err
+		}
+	}
+	return validateLinkFields(&url_shortener_v2.Link{Tags: r.Tags, Campaign: r.Campaign, Owner: r.Owner}, "tags", "campaign", "owner")
+}
+
 type CreateUrlResponse struct {
 	ShortenURL string `json:"shorten_url" validate:"required" schema:"shorten_url"`
 }
@@ -11,6 +62,11 @@ type GetUrlRequest struct {
 	ShortenURL string `json:"shorten_url" validate:"required" schema:"shorten_url"`
 }
 
+// Validate applies the rules of the equivalent gRPC request.
+func (r GetUrlRequest) Validate() error {
+	return validation.Validate(&url_shortener_v1.GetOriginalURLRequest{ShortUrl: r.ShortenURL})
+}
+
 type GetUrlResponse struct {
 	URL string `json:"url" validate:"required,url" schema:"url"`
 }
@@ -0,0 +1,120 @@
+package io_server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/validation"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+)
+
+// MaxDeliveriesPageSize bounds the limit of a delivery log request.
+const MaxDeliveriesPageSize = 1000
+
+type CreateWebhookRequest struct {
+	// URL is the http(s) endpoint events are posted to.
+	URL string `json:"url"`
+	// Secret signs the deliveries. It is generated if empty.
+	Secret string `json:"secret,omitempty"`
+	// Events filters the delivered events: link.created, link.updated, link.deleted, link.expired
+	// or link.clicked. Empty means all of them.
+	Events []string `json:"events,omitempty"`
+}
+
+func (r CreateWebhookRequest) Validate() error {
+	var violations []validation.FieldViolation
+	if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "url",
+			Description: "value must be an absolute http(s) URL",
+		})
+	}
+	if r.Secret != "" && (len(r.Secret) < webhooks.MinSecretLength || len(r.Secret) > webhooks.MaxSecretLength) {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "secret",
+			Description: fmt.Sprintf("value length must be between %d and %d bytes inclusive", webhooks.MinSecretLength, webhooks.MaxSecretLength),
+		})
+	}
+	for i, event := range r.Events {
+		if !slices.Contains(webhooks.EventTypes, webhooks.EventType(event)) {
+			violations = append(violations, validation.FieldViolation{
+				Field:       fmt.Sprintf("events[%d]", i),
+				Description: fmt.Sprintf("value must be one of %q", webhooks.EventTypes),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	return nil
+}
+
+type WebhookResponse struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+	// Secret is only returned when the webhook is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+type ListDeliveriesRequest struct {
+	ID string `json:"-" schema:"-"`
+	// Status is pending, succeeded, dead or replayed. Empty means all.
+	Status string `json:"status" schema:"status"`
+	// Limit is the number of deliveries returned, 100 by default.
+	Limit int `json:"limit" schema:"limit"`
+}
+
+func (r ListDeliveriesRequest) Validate() error {
+	var violations []validation.FieldViolation
+	statuses := []webhooks.DeliveryStatus{webhooks.StatusPending, webhooks.StatusSucceeded, webhooks.StatusDead, webhooks.StatusReplayed}
+	if r.Status != "" && !slices.Contains(statuses, webhooks.DeliveryStatus(r.Status)) {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "status",
+			Description: fmt.Sprintf("value must be one of %q", statuses),
+		})
+	}
+	if r.Limit < 0 || r.Limit > MaxDeliveriesPageSize {
+		violations = append(violations, validation.FieldViolation{
+			Field:       "limit",
+			Description: fmt.Sprintf("value must be inside range [0, %d]", MaxDeliveriesPageSize),
+		})
+	}
+	if len(violations) > 0 {
+		return &validation.Error{Violations: violations}
+	}
+	return nil
+}
+
+type WebhookEvent struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+type DeliveryResponse struct {
+	ID        string       `json:"id"`
+	WebhookID string       `json:"webhook_id"`
+	Event     WebhookEvent `json:"event"`
+	Status    string       `json:"status"`
+	Attempts  int          `json:"attempts"`
+	// NextAttemptAt is set for pending deliveries.
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type ListDeliveriesResponse struct {
+	Deliveries []DeliveryResponse `json:"deliveries"`
+}
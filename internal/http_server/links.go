@@ -0,0 +1,381 @@
+package http_server
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/qr"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+func toLinkResponse(link domain.Link) io_server.LinkResponse {
+	resp := io_server.LinkResponse{
+		Code:            link.Code,
+		ShortURL:        link.ShortURL,
+		Domain:          link.Domain,
+		Target:          link.Target,
+		Owner:           link.Owner,
+		Tags:            link.Tags,
+		Campaign:        link.Campaign,
+		Clicks:          link.Clicks,
+		Disabled:        link.Disabled,
+		ExpiresAt:       link.ExpiresAt,
+		MaxClicks:       link.MaxClicks,
+		RemainingClicks: link.RemainingClicks,
+		CreatedAt:       link.CreatedAt,
+		UpdatedAt:       link.UpdatedAt,
+	}
+	if len(link.Variants) > 0 {
+		resp.SplitMode = string(cmp.Or(link.SplitMode, split.ModeRandom))
+		for _, v := range link.Variants {
+			resp.Variants = append(resp.Variants, io_server.Variant(v))
+		}
+	}
+	if link.Template != nil {
+		resp.Template = &io_server.Template{Path: link.Template.Path}
+	}
+	if !link.Passthrough.IsZero() {
+		resp.Passthrough = &io_server.Passthrough{
+			Query: string(link.Passthrough.Query),
+			Path:  link.Passthrough.Path,
+			UTM:   io_server.UTM(link.Passthrough.UTM),
+		}
+	}
+	return resp
+}
+
+// @Summary		List links
+// @Description	Lists links page by page. Pass next_page_token back as page_token to get the next page; a token is only valid with the same sort.
+// @Tags			Links
+// @Produce		json
+// @Param			page_size		query		int								false	"Maximum number of links to return (default 50, max 1000)"
+// @Param			page_token		query		string							false	"next_page_token from the previous page"
+// @Param			owner			query		string							false	"Only links with this owner"
+// @Param			tag				query		string							false	"Only links with this tag"
+// @Param			campaign		query		string							false	"Only links in this campaign"
+// @Param			domain			query		string							false	"Only links whose target host equals this domain"
+// @Param			created_after	query		string							false	"Only links created at or after this RFC 3339 time"
+// @Param			created_before	query		string							false	"Only links created before this RFC 3339 time"
+// @Param			q				query		string							false	"Case-insensitive substring of the target"
+// @Param			sort			query		string							false	"Sort order"	Enums(id, -id, created_at, -created_at)
+// @Param			show_disabled	query		bool							false	"Also list disabled links"
+// @Success		200				{object}	io_server.ListLinksResponse		"A page of links"
+// @Failure		400				{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid filter or page token"
+// @Failure		500				{object}	map[string]string				"Internal Server Error"
+// @Router			/links [get]
+func (s *Server) ListLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.ListLinksRequest
+	err := decoder.Decode(&req, r.URL.Query())
+	if err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode query: %s",
+		})
+		return
+	}
+	if !validate(rc, req) {
+		return
+	}
+	page, err := s.urlShortener.ListLinks(ctx, domain.ListLinksQuery{
+		PageSize:        req.PageSize,
+		PageToken:       req.PageToken,
+		Sort:            database.LinkSort(req.Sort),
+		Owner:           req.Owner,
+		Tag:             req.Tag,
+		Campaign:        req.Campaign,
+		IncludeDisabled: req.ShowDisabled,
+		TargetDomain:    req.Domain,
+		CreatedAfter:    req.CreatedAfter,
+		CreatedBefore:   req.CreatedBefore,
+		TargetContains:  req.Query,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPageToken) || errors.Is(err, domain.ErrInvalidSort) {
+			errorResponse(rc, ErrorInfo{
+				err:      err,
+				code:     http.StatusBadRequest,
+				logLevel: zap.DebugLevel,
+			})
+		} else {
+			errorResponse(rc, ErrorInfo{
+				err:      err,
+				code:     http.StatusInternalServerError,
+				logLevel: zap.ErrorLevel,
+				msg:      "Failed to list links: %s",
+			})
+		}
+		return
+	}
+	resp := io_server.ListLinksResponse{
+		Links:         make([]io_server.LinkResponse, 0, len(page.Links)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, link := range page.Links {
+		resp.Links = append(resp.Links, toLinkResponse(link))
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
+
+// @Summary		Get a link
+// @Tags			Links
+// @Produce		json
+// @Param			code	path		string					true	"Short code"
+// @Success		200		{object}	io_server.LinkResponse	"The link"
+// @Failure		404		{object}	map[string]string		"Link not found"
+// @Failure		500		{object}	map[string]string		"Internal Server Error"
+// @Router			/links/{code} [get]
+func (s *Server) GetLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	link, err := s.urlShortener.GetLink(ctx, chi.URLParam(r, "code"))
+	if err != nil {
+		linkErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toLinkResponse(link),
+	})
+}
+
+// @Summary		Update a link
+// @Description	Sets the fields of a link named in fields to the values in the request. A named field left out of
+// @Description	the body is cleared, e.g. fields ["expires_at"] alone makes the link never expire. Setting
+// @Description	max_clicks restarts the count.
+// @Tags			Links
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			code	path		string								true	"Short code"
+// @Param			request	body		io_server.UpdateLinkRequest			true	"Fields to set"
+// @Success		200		{object}	io_server.LinkResponse				"Updated link"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid fields"
+// @Failure		401		{object}	map[string]string					"Unauthorized"
+// @Failure		404		{object}	map[string]string					"Link not found"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/links/{code} [patch]
+func (s *Server) UpdateLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.UpdateLinkRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	if !validate(rc, req) {
+		return
+	}
+	update := database.Link{
+		Target:    req.Target,
+		Owner:     req.Owner,
+		Tags:      req.Tags,
+		Campaign:  req.Campaign,
+		Disabled:  req.Disabled,
+		ExpiresAt: req.ExpiresAt,
+		MaxClicks: req.MaxClicks,
+	}
+	fields := make([]database.LinkField, 0, len(req.Fields))
+	for _, field := range req.Fields {
+		fields = append(fields, database.LinkField(field))
+	}
+	if slices.Contains(fields, database.LinkFieldPassword) {
+		hash, err := domain.HashPassword(req.Password)
+		if err != nil {
+			linkErrorResponse(rc, err)
+			return
+		}
+		update.PasswordHash = hash
+	}
+	link, err := s.urlShortener.UpdateLink(ctx, req.Code, update, fields)
+	if err != nil {
+		linkErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toLinkResponse(link),
+	})
+}
+
+func linkErrorResponse(rc RequestContext, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUrlNotFound), errors.Is(err, domain.ErrInvalidUrl):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusNotFound,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, domain.ErrInvalidTarget), errors.Is(err, domain.ErrInvalidMaxClicks),
+		errors.Is(err, domain.ErrUnknownField), errors.Is(err, domain.ErrPasswordTooLong),
+		errors.Is(err, domain.ErrInvalidDays), errors.Is(err, passthrough.ErrInvalidOptions),
+		errors.Is(err, urltemplate.ErrInvalidTemplate):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+	default:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Failed to access link: %s",
+		})
+	}
+}
+
+// qrOptions converts a validated QR code request to rendering options.
+func qrOptions(req io_server.QRCodeRequest) (domain.QRCodeOptions, error) {
+	opts := domain.QRCodeOptions{Options: qr.DefaultOptions(), Logo: req.Logo}
+	if req.Format != "" {
+		opts.Format = qr.Format(strings.ToLower(req.Format))
+	}
+	if req.Size != 0 {
+		opts.Size = req.Size
+	}
+	if req.Margin != nil {
+		opts.Margin = *req.Margin
+	}
+	var err error
+	if req.Level != "" {
+		if opts.Level, err = qr.ParseLevel(req.Level); err != nil {
+			return opts, err
+		}
+	}
+	if req.Foreground != "" {
+		if opts.Foreground, err = qr.ParseColor(req.Foreground); err != nil {
+			return opts, err
+		}
+	}
+	if req.Background != "" {
+		if opts.Background, err = qr.ParseColor(req.Background); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// @Summary		Get QR code of a link
+// @Description	Renders a QR code of the public short URL (BASE_URL + code) as PNG or SVG. Responses carry an ETag and honor If-None-Match.
+// @Tags			Links
+// @Produce		png
+// @Produce		image/svg+xml
+// @Param			code	path		string	true	"Short code"
+// @Param			format	query		string	false	"Image format"	Enums(png, svg)
+// @Param			size	query		int		false	"Width and height in pixels (64-4096, default 256)"
+// @Param			level	query		string	false	"Error correction level (default M, or H with a logo)"	Enums(L, M, Q, H)
+// @Param			margin	query		int		false	"Quiet zone in modules (0-16, default 4)"
+// @Param			fg		query		string	false	"Foreground color, hex RRGGBB or RRGGBBAA (default 000000)"
+// @Param			bg		query		string	false	"Background color, hex RRGGBB or RRGGBBAA (default ffffff)"
+// @Param			logo	query		bool	false	"Draw the configured logo in the center"
+// @Success		200		{file}		binary	"QR code image"
+// @Success		304		"Not Modified"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid options"
+// @Failure		404		{object}	map[string]string					"Link not found"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/links/{code}/qr [get]
+func (s *Server) GetLinkQRCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.QRCodeRequest
+	err := decoder.Decode(&req, r.URL.Query())
+	if err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode query: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	if !validate(rc, req) {
+		return
+	}
+	opts, err := qrOptions(req)
+	if err != nil {
+		qrErrorResponse(rc, err)
+		return
+	}
+	code, err := s.urlShortener.LinkQRCode(ctx, req.Code, opts)
+	if err != nil {
+		qrErrorResponse(rc, err)
+		return
+	}
+	w.Header().Set("Content-Type", code.ContentType)
+	w.Header().Set("ETag", code.ETag)
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(code.Data))
+}
+
+func qrErrorResponse(rc RequestContext, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUrlNotFound), errors.Is(err, domain.ErrInvalidUrl):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusNotFound,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, qr.ErrInvalidOptions), errors.Is(err, domain.ErrQRLogoNotConfigured):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+	default:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Failed to render qr code: %s",
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package http_server
+
+import (
+	"encoding/json"
+	"image/color"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/qr"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_ListLinks(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	urlShortener.On("ListLinks", mock.Anything, service.ListLinksQuery{
+		PageSize:        2,
+		PageToken:       "token",
+		Sort:            database.SortCreatedAtDesc,
+		Owner:           "alice",
+		IncludeDisabled: true,
+		TargetDomain:    "example.com",
+		CreatedAfter:    created,
+		TargetContains:  "docs",
+	}).Return(service.ListLinksPage{
+		Links: []service.Link{{
+			Link: database.Link{ID: 1, Target: "https://example.com/docs", Owner: "alice", CreatedAt: created},
+			Code: "abc",
+		}},
+		NextPageToken: "next",
+	}, nil).Once()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/links?page_size=2&page_token=token&sort=-created_at&owner=alice"+
+		"&show_disabled=true&domain=example.com&created_after=2025-01-02T03:04:05Z&q=docs", nil)
+	server.ListLinks(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Data io_server.ListLinksResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "next", response.Data.NextPageToken)
+	require.Len(t, response.Data.Links, 1)
+	assert.Equal(t, "abc", response.Data.Links[0].Code)
+	assert.Equal(t, "https://example.com/docs", response.Data.Links[0].Target)
+	urlShortener.AssertExpectations(t)
+}
+
+func TestServer_ListLinks_BadRequest(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	urlShortener.On("ListLinks", mock.Anything, mock.Anything).Return(service.ListLinksPage{}, service.ErrInvalidPageToken)
+
+	for _, query := range []string{
+		"sort=name",
+		"page_size=1001",
+		"created_after=yesterday",
+		"unknown=1",
+		"page_token=stale",
+	} {
+		w := httptest.NewRecorder()
+		server.ListLinks(w, httptest.NewRequest(http.MethodGet, "/links?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestServer_GetLinkQRCode(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/links/{code}/qr", server.GetLinkQRCode)
+
+	want := service.QRCodeOptions{Options: qr.DefaultOptions(), Logo: true}
+	want.Format = qr.FormatSVG
+	want.Size = 512
+	want.Level = qr.LevelQuartile
+	want.Margin = 0
+	want.Foreground = color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}
+	urlShortener.On("LinkQRCode", mock.Anything, "abc", want).Return(service.QRCode{
+		Image: qr.Image{ContentType: "image/svg+xml", Data: []byte("<svg/>"), ETag: `"v1"`},
+		URL:   "https://sho.rt/abc",
+	}, nil)
+	urlShortener.On("LinkQRCode", mock.Anything, "missing", mock.Anything).Return(service.QRCode{}, service.ErrUrlNotFound)
+
+	target := "/links/abc/qr?format=svg&size=512&level=q&margin=0&fg=%23123456&logo=true"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+	assert.Equal(t, "<svg/>", w.Body.String())
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	r.Header.Set("If-None-Match", `"v1"`)
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.Bytes())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/links/missing/qr", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	for _, query := range []string{"format=gif", "size=10", "level=X", "margin=17", "fg=red", "bg=%23abc"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/links/abc/qr?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestServer_GetAndUpdateLink(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/links/{code}", server.GetLink)
+	router.Patch("/links/{code}", server.UpdateLink)
+
+	urlShortener.On("GetLink", mock.Anything, "abc").
+		Return(service.Link{Link: database.Link{Target: "https://example.com", Owner: "alice"}, Code: "abc"}, nil).Once()
+	urlShortener.On("GetLink", mock.Anything, "missing").Return(service.Link{}, service.ErrUrlNotFound).Once()
+	urlShortener.On("UpdateLink", mock.Anything, "abc",
+		database.Link{Target: "https://example.com/new", Disabled: true},
+		[]database.LinkField{database.LinkFieldTarget, database.LinkFieldDisabled, database.LinkFieldExpiresAt}).
+		Return(service.Link{Link: database.Link{Target: "https://example.com/new", Disabled: true}, Code: "abc"}, nil).Once()
+	urlShortener.On("UpdateLink", mock.Anything, "abc", mock.MatchedBy(func(link database.Link) bool {
+		return link.PasswordHash != "" && link.PasswordHash != "secret"
+	}), []database.LinkField{database.LinkFieldPassword}).
+		Return(service.Link{Link: database.Link{Target: "https://example.com"}, Code: "abc"}, nil).Once()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(http.MethodGet, "/links/abc", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var got struct {
+		Data io_server.LinkResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, "alice", got.Data.Owner)
+
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/links/missing", "").Code)
+
+	w = do(http.MethodPatch, "/links/abc", `{"fields":["target","disabled","expires_at"],"target":"https://example.com/new","disabled":true}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.True(t, got.Data.Disabled)
+
+	w = do(http.MethodPatch, "/links/abc", `{"fields":["password"],"password":"secret"}`)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	for _, body := range []string{
+		`{"fields":[]}`,
+		`{"fields":["clicks"]}`,
+		`{"fields":["target"],"target":"not a url"}`,
+	} {
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPatch, "/links/abc", body).Code, body)
+	}
+	urlShortener.AssertExpectations(t)
+}
+
+func TestServer_GetClickHistory(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/links/{code}/clicks", server.GetClickHistory)
+
+	today := database.ClickDay(time.Now())
+	urlShortener.On("ClickHistory", mock.Anything, "abc", 2).Return(service.ClickHistory{
+		Total: 9,
+		Days:  []database.DailyClicks{{Day: today.AddDate(0, 0, -1), Clicks: 3}, {Day: today, Clicks: 1}},
+	}, nil).Once()
+	urlShortener.On("ClickHistory", mock.Anything, "abc", service.DefaultClickHistoryDays).Return(service.ClickHistory{}, nil).Once()
+	urlShortener.On("ClickHistory", mock.Anything, "missing", mock.Anything).Return(service.ClickHistory{}, service.ErrUrlNotFound).Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/links/abc/clicks?days=2", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response struct {
+		Data io_server.ClickHistoryResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(9), response.Data.Total)
+	assert.Equal(t, []io_server.DailyClicksResponse{
+		{Day: today.AddDate(0, 0, -1).Format(time.DateOnly), Clicks: 3},
+		{Day: today.Format(time.DateOnly), Clicks: 1},
+	}, response.Data.Days)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/links/abc/clicks", nil))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/links/missing/clicks", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	for _, query := range []string{"days=0x", "days=367", "days=-1"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/links/abc/clicks?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	urlShortener.AssertExpectations(t)
+}
+
+func TestServer_LinkChangesRequireToken(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	urlShortener.On("UpdateLink", mock.Anything, "abc", mock.Anything, mock.Anything).
+		Return(service.Link{Link: database.Link{Target: "https://example.com"}, Code: "abc"}, nil)
+	patch := func(router http.Handler, token string) int {
+		r := httptest.NewRequest(http.MethodPatch, "/links/abc", strings.NewReader(`{"fields":["disabled"],"disabled":true}`))
+		r.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// without the admin token and the dashboard nobody may change links
+	open := Server{log: zaptest.NewLogger(t), urlShortener: urlShortener}
+	assert.Equal(t, http.StatusMethodNotAllowed, patch(open.RegisterRoutes(), ""))
+
+	server := Server{log: zaptest.NewLogger(t), urlShortener: urlShortener, adminToken: "s3cret"}
+	router := server.RegisterRoutes()
+	assert.Equal(t, http.StatusUnauthorized, patch(router, ""))
+	assert.Equal(t, http.StatusUnauthorized, patch(router, "wrong"))
+	assert.Equal(t, http.StatusOK, patch(router, "s3cret"))
+	urlShortener.AssertNumberOfCalls(t, "UpdateLink", 1)
+}
+
+func TestServer_GetProtectedLink(t *testing.T) {
+	t.Setenv("DOMAINS_PATH", "")
+	log := zaptest.NewLogger(t)
+	urlShortener := service.NewUrlShortener(inmemory.NewInMemoryDBService().NewUrlRepository(), log)
+	hash, err := service.HashPassword("hunter2")
+	require.NoError(t, err)
+	link, err := urlShortener.CreateLink(t.Context(), database.Link{Target: "https://example.com/internal", PasswordHash: hash})
+	require.NoError(t, err)
+	server := Server{log: log, urlShortener: urlShortener, adminToken: "s3cret"}
+	router := server.RegisterRoutes()
+	get := func(token string) io_server.LinkResponse {
+		r := httptest.NewRequest(http.MethodGet, "/links/"+link.Code, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data io_server.LinkResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data
+	}
+
+	assert.Empty(t, get("").Target)
+	assert.Empty(t, get("wrong").Target)
+	assert.Equal(t, "https://example.com/internal", get("s3cret").Target)
+}
@@ -0,0 +1,153 @@
+package http_server
+
+import (
+	"crypto/subtle"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/limiter"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/requestid"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+// RequestID takes the X-Request-ID header from the request (or generates a new ID),
+// echoes it in the response and stores it together with a request-scoped logger in the context.
+func RequestID(log *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := requestid.FromIncoming(r.Header.Get(requestid.Header))
+			w.Header().Set(requestid.Header, id)
+
+			ctx := requestid.NewContext(r.Context(), id)
+			ctx = zap_utils.ToContext(ctx, log.With(zap.String("request_id", id)))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// SelectDomain selects the domain of the request in its context: the one named by the
+// X-Link-Domain header, else the one served at the Host header.
+func SelectDomain(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := domains.WithHost(r.Context(), r.Host)
+		if name := r.Header.Get(domains.Header); name != "" {
+			ctx = domains.WithName(r.Context(), name)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireToken rejects requests whose Authorization header is not "Bearer <token>" with 401.
+func RequireToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasToken(r, token) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				w.WriteHeader(http.StatusUnauthorized)
+				render.JSON(w, r, io_server.Error("missing or invalid token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// hasToken reports whether the Authorization header of r is "Bearer <token>" for a non-empty token.
+func hasToken(r *http.Request, token string) bool {
+	given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// Shed admits requests of priority p through l, shedding the rest with 503 and a Retry-After
+// header. Requests answered with 503 themselves, e.g. by an unavailable storage, count as
+// overloaded. A nil l admits every request.
+func Shed(l *limiter.Limiter, p limiter.Priority) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if l == nil {
+			return next
+		}
+		retryAfter := strconv.Itoa(int(math.Ceil(l.RetryAfter().Seconds())))
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := l.Acquire(p)
+			if !ok {
+				zap_utils.FromContext(r.Context(), nil).Debug("Shedding request",
+					zap.Stringer("priority", p), zap.Int("limit", l.Limit()))
+				w.Header().Set("Retry-After", retryAfter)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				render.JSON(w, r, io_server.Error("server is overloaded, try again later"))
+				return
+			}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				if ww.Status() == http.StatusServiceUnavailable {
+					token.Dropped()
+				} else {
+					token.Done()
+				}
+			}()
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+// AccessLog writes one "finished call" entry per request with fields mirroring the gRPC logging interceptor.
+// Requests for which isSampled returns true are written through the sampled logger.
+func AccessLog(log *zap.Logger, sampled *zap.Logger, isSampled func(r *http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			logger := log
+			// Server errors are never sampled away.
+			if status < http.StatusInternalServerError && isSampled(r) {
+				logger = sampled
+			}
+			fields := []zap.Field{
+				zap.String("protocol", "http"),
+				zap.String("http.component", "server"),
+				zap.String("http.method", r.Method),
+				zap.String("http.path", r.URL.Path),
+				zap.String("http.route", routePattern(r)),
+				zap.String("peer.address", r.RemoteAddr),
+				zap.String("http.start_time", start.Format(time.RFC3339)),
+				zap.Int("http.code", status),
+				zap.Int("http.bytes_written", ww.BytesWritten()),
+				zap.Float32("http.time_ms", float32(time.Since(start).Microseconds())/1000),
+				zap.String("request_id", w.Header().Get(requestid.Header)),
+			}
+			switch {
+			case status >= http.StatusInternalServerError:
+				logger.Error("finished call", fields...)
+			case status >= http.StatusBadRequest:
+				logger.Warn("finished call", fields...)
+			default:
+				logger.Info("finished call", fields...)
+			}
+		})
+	}
+}
+
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return ""
+	}
+	return rctx.RoutePattern()
+}
@@ -0,0 +1,135 @@
+package http_server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/limiter"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/requestid"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestID(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	log := zap.New(core)
+
+	var ctxID string
+	handler := RequestID(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = requestid.FromContext(r.Context())
+		zap_utils.FromContext(r.Context(), nil).Info("inside handler")
+	}))
+
+	t.Run("propagates incoming ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(requestid.Header, "abc-123")
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, "abc-123", ctxID)
+		assert.Equal(t, "abc-123", w.Header().Get(requestid.Header))
+		entry := logs.TakeAll()[0]
+		assert.Equal(t, "abc-123", entry.ContextMap()["request_id"])
+	})
+
+	t.Run("generates ID when missing or malformed", func(t *testing.T) {
+		for _, incoming := range []string{"", "bad id\nwith newline"} {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(requestid.Header, incoming)
+			handler.ServeHTTP(w, r)
+
+			assert.NotEmpty(t, ctxID)
+			assert.NotEqual(t, incoming, ctxID)
+			assert.Equal(t, ctxID, w.Header().Get(requestid.Header))
+		}
+	})
+}
+
+func TestAccessLog(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	log := zap.New(core)
+	policy := zap_utils.SamplingPolicy{Tick: 1 << 40, First: 2, Thereafter: 1000}
+
+	r := chi.NewRouter()
+	r.Use(RequestID(log))
+	r.Use(AccessLog(log, policy.Apply(log), isResolveRequest))
+	r.Get("/shorten", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	r.Post("/shorten", func(w http.ResponseWriter, r *http.Request) {})
+
+	do := func(method, target string) {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, target, nil))
+	}
+
+	for range 10 {
+		do(http.MethodGet, "/shorten")
+	}
+	assert.Equal(t, 2, logs.Len(), "resolve logs should be sampled")
+
+	do(http.MethodGet, "/shorten?fail=1")
+	for range 5 {
+		do(http.MethodPost, "/shorten")
+	}
+	entries := logs.TakeAll()
+	assert.Len(t, entries, 8, "errors and non-resolve calls should never be sampled")
+
+	failed := entries[2].ContextMap()
+	assert.Equal(t, zap.ErrorLevel, entries[2].Level)
+	assert.Equal(t, "finished call", entries[2].Message)
+	assert.EqualValues(t, http.StatusInternalServerError, failed["http.code"])
+	assert.Equal(t, "/shorten", failed["http.route"])
+	assert.NotEmpty(t, failed["request_id"])
+}
+
+func TestShed(t *testing.T) {
+	l := limiter.New(limiter.Config{InitialLimit: 2, MinLimit: 1, Backoff: 0.5, RetryAfter: 1500 * time.Millisecond})
+	held, ok := l.Acquire(limiter.Critical)
+	require.True(t, ok)
+
+	status := http.StatusOK
+	r := chi.NewRouter()
+	r.With(Shed(l, limiter.Normal)).Post("/shorten", func(w http.ResponseWriter, r *http.Request) {})
+	r.With(Shed(l, limiter.Critical)).Get("/{code}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	})
+	do := func(method, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		return w
+	}
+
+	w := do(http.MethodPost, "/shorten")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "normal requests take a share of the limit")
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "overloaded")
+
+	w = do(http.MethodGet, "/abc")
+	assert.Equal(t, http.StatusOK, w.Code, "redirects take all of it")
+	assert.Equal(t, 1, l.Inflight())
+	assert.Equal(t, 2, l.Limit())
+
+	status = http.StatusServiceUnavailable
+	do(http.MethodGet, "/abc")
+	assert.Equal(t, 1, l.Limit(), "unavailable responses count as overload")
+	held.Done()
+	assert.Zero(t, l.Inflight())
+
+	t.Run("nil limiter", func(t *testing.T) {
+		r := chi.NewRouter()
+		r.With(Shed(nil, limiter.Low)).Get("/", func(w http.ResponseWriter, r *http.Request) {})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
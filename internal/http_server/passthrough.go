@@ -0,0 +1,94 @@
+package http_server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+// @Summary		Set passthrough options
+// @Description	Replaces what a redirect carries over to the target of a link: the incoming query, merged with the
+// @Description	keep, override or drop policy for parameters the target already has, the trailing path of
+// @Description	/{code}/extra/path and static UTM parameters. An empty body turns passthrough off.
+// @Tags			Links
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			code	path		string								true	"Short code"
+// @Param			request	body		io_server.PassthroughRequest		true	"Passthrough options"
+// @Success		200		{object}	io_server.LinkResponse				"Updated link"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid options"
+// @Failure		401		{object}	map[string]string					"Unauthorized"
+// @Failure		404		{object}	map[string]string					"Link not found"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/links/{code}/passthrough [put]
+func (s *Server) SetPassthrough(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.PassthroughRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	if !validate(rc, req) {
+		return
+	}
+	update := database.Link{Passthrough: passthrough.Options{
+		Query: passthrough.QueryPolicy(req.Query),
+		Path:  req.Path,
+		UTM:   passthrough.UTM(req.UTM),
+	}}
+	link, err := s.urlShortener.UpdateLink(ctx, req.Code, update, []database.LinkField{database.LinkFieldPassthrough})
+	if err != nil {
+		passthroughErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toLinkResponse(link),
+	})
+}
+
+func passthroughErrorResponse(rc RequestContext, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUrlNotFound), errors.Is(err, domain.ErrInvalidUrl):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusNotFound,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, passthrough.ErrInvalidOptions):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+	default:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Failed to update passthrough options: %s",
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package http_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_SetPassthrough(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Put("/links/{code}/passthrough", server.SetPassthrough)
+
+	options := passthrough.Options{Query: passthrough.QueryDrop, Path: true, UTM: passthrough.UTM{Source: "qr", Campaign: "spring"}}
+	fields := []database.LinkField{database.LinkFieldPassthrough}
+	urlShortener.On("UpdateLink", mock.Anything, "abc", database.Link{Passthrough: options}, fields).
+		Return(service.Link{Link: database.Link{Target: "https://example.com", Passthrough: options}, Code: "abc"}, nil).Once()
+	urlShortener.On("UpdateLink", mock.Anything, "abc", database.Link{}, fields).
+		Return(service.Link{Link: database.Link{Target: "https://example.com"}, Code: "abc"}, nil).Once()
+	urlShortener.On("UpdateLink", mock.Anything, "missing", mock.Anything, fields).
+		Return(service.Link{}, service.ErrUrlNotFound).Once()
+
+	do := func(target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPut, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do("/links/abc/passthrough", `{"query":"drop","path":true,"utm":{"source":"qr","campaign":"spring"}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var updated struct {
+		Data io_server.LinkResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	require.NotNil(t, updated.Data.Passthrough)
+	assert.Equal(t, "drop", updated.Data.Passthrough.Query)
+	assert.Equal(t, "spring", updated.Data.Passthrough.UTM.Campaign)
+
+	w = do("/links/abc/passthrough", `{}`)
+	assert.Equal(t, http.StatusOK, w.Code, "an empty body turns passthrough off")
+	assert.NotContains(t, w.Body.String(), "passthrough")
+	assert.Equal(t, http.StatusNotFound, do("/links/missing/passthrough", `{}`).Code)
+
+	w = do("/links/abc/passthrough", `{"query":"merge","utm":{"source":"`+strings.Repeat("x", passthrough.MaxUTMLength+1)+`"}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var invalid io_server.ValidationErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invalid))
+	var names []string
+	for _, v := range invalid.Violations {
+		names = append(names, v.Field)
+	}
+	assert.Equal(t, []string{"query", "utm.source"}, names)
+	urlShortener.AssertExpectations(t)
+}
+
+func TestServer_RedirectTrailingPath(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/{code}", server.Redirect)
+	router.Get("/{code}/*", server.Redirect)
+
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool {
+		return req.Code == "abc" && req.Path == "docs/a%2Fb/" && req.Query.Get("gclid") == "1"
+	})).Return(service.Resolved{Link: service.Link{Link: database.Link{Target: "https://example.com/docs/a%2Fb/?gclid=1"}, Code: "abc"}}, nil).Once()
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool {
+		return req.Code == "abc" && req.Path == "secret"
+	})).Return(service.Resolved{}, passthrough.ErrInvalidPath).Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc/docs/a%2Fb/?gclid=1", nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/docs/a%2Fb/?gclid=1", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc/secret", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	urlShortener.AssertExpectations(t)
+}
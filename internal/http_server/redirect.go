@@ -0,0 +1,234 @@
+package http_server
+
+import (
+	"errors"
+	"html/template"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// accessCookie holds the signed proof that the password of a link was entered.
+// It is scoped to the link path, so every protected link has its own.
+const accessCookie = "link_access"
+
+// maxPasswordFormSize bounds the password form body.
+const maxPasswordFormSize = 4 << 10
+
+var passwordForm = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+<style>
+body{font-family:system-ui,sans-serif;display:flex;min-height:100vh;margin:0;align-items:center;justify-content:center;background:#f5f5f5}
+form{background:#fff;padding:2rem;border-radius:8px;box-shadow:0 1px 4px rgba(0,0,0,.1);display:flex;flex-direction:column;gap:.75rem;min-width:260px}
+input,button{font-size:1rem;padding:.5rem}
+.error{color:#b00020}
+</style>
+</head>
+<body>
+<form method="post">
+<label for="password">This link is password protected.</label>
+<input id="password" name="password" type="password" autocomplete="current-password" required autofocus>
+{{if .}}<p class="error">{{.}}</p>{{end}}
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// clientAddress returns the host of the caller's address, used to throttle password attempts.
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// trailingPath returns the escaped path after the short code, e.g. "extra/path" for /abc/extra/path.
+func trailingPath(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+	_, rest, _ := strings.Cut(path, "/")
+	return rest
+}
+
+// @Summary		Follow a short link
+// @Description	Redirects to the target of the first matching redirect rule of the link, or else of a split variant
+// @Description	or the link target. Sticky split links set a visitor cookie. Depending on the link, the query and a
+// @Description	trailing path (/{code}/extra/path) are forwarded to the target and UTM parameters are added.
+// @Description	Templated links fill the placeholders of their target from the trailing path and the query.
+// @Description	Password protected links serve a password form instead, unless the request carries the access
+// @Description	cookie set after a successful password check.
+// @Description	The link is looked up on the domain of the Host header, which also decides the redirect status
+// @Description	(302 unless configured otherwise) and may serve an HTML page for unknown codes.
+// @Tags			Redirect
+// @Produce		html
+// @Param			code	path	string	true	"Short code"
+// @Success		200		"Password form"
+// @Success		302		"Redirect to the target, 301, 303, 307 or 308 if the domain says so"
+// @Failure		400		{object}	map[string]string	"A template variable is missing or invalid"
+// @Failure		404		{object}	map[string]string	"Link not found"
+// @Failure		410		{object}	map[string]string	"Link is disabled, expired or has no clicks left"
+// @Router			/{code} [get]
+func (s *Server) Redirect(w http.ResponseWriter, r *http.Request) {
+	var accessToken string
+	if cookie, err := r.Cookie(accessCookie); err == nil {
+		accessToken = cookie.Value
+	}
+	s.resolve(w, r, domain.ResolveRequest{
+		RequestInfo: requestInfo(r),
+		Code:        chi.URLParam(r, "code"),
+		AccessToken: accessToken,
+		Client:      clientAddress(r),
+		Visitor:     visitorID(r),
+		Path:        trailingPath(r),
+	})
+}
+
+// @Summary		Unlock a password protected link
+// @Description	Checks the submitted password and redirects to the target, setting a short-lived signed access cookie.
+// @Description	Failed attempts are throttled per link and client.
+// @Tags			Redirect
+// @Accept			x-www-form-urlencoded
+// @Produce		html
+// @Param			code		path		string	true	"Short code"
+// @Param			password	formData	string	true	"Link password"
+// @Success		303			"Redirect to the target"
+// @Failure		403			"Wrong password, the form is served again"
+// @Failure		404			{object}	map[string]string	"Link not found"
+// @Failure		410			{object}	map[string]string	"Link is disabled, expired or has no clicks left"
+// @Failure		429			"Too many attempts"
+// @Router			/{code} [post]
+func (s *Server) UnlockRedirect(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxPasswordFormSize)
+	s.resolve(w, r, domain.ResolveRequest{
+		RequestInfo: requestInfo(r),
+		Code:        chi.URLParam(r, "code"),
+		Password:    r.PostFormValue("password"),
+		Client:      clientAddress(r),
+		Visitor:     visitorID(r),
+		Path:        trailingPath(r),
+	})
+}
+
+func (s *Server) resolve(w http.ResponseWriter, r *http.Request, req domain.ResolveRequest) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	resolved, err := s.urlShortener.Resolve(ctx, req)
+	if err != nil {
+		if isNotFound(err) && s.renderNotFoundPage(rc) {
+			return
+		}
+		resolveErrorResponse(rc, err)
+		return
+	}
+	setVisitorCookie(w, r, resolved, req.Visitor)
+
+	if resolved.PasswordHash != "" {
+		// Neither the form nor the redirect to a protected target may be cached.
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	if resolved.AccessToken != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     accessCookie,
+			Value:    resolved.AccessToken,
+			Path:     "/" + resolved.Code,
+			Expires:  resolved.AccessTokenExpiry,
+			MaxAge:   int(time.Until(resolved.AccessTokenExpiry).Seconds()),
+			Secure:   r.TLS != nil,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		// See Other turns the form POST into a GET of the target.
+		http.Redirect(w, r, resolved.Target, http.StatusSeeOther)
+		return
+	}
+	status := http.StatusFound
+	if d, err := s.urlShortener.Domain(ctx); err == nil {
+		status = d.Status()
+	}
+	http.Redirect(w, r, resolved.Target, status)
+}
+
+// isNotFound reports whether err means that there is no link to resolve.
+func isNotFound(err error) bool {
+	return errors.Is(err, domain.ErrUrlNotFound) || errors.Is(err, domain.ErrInvalidUrl) ||
+		errors.Is(err, passthrough.ErrInvalidPath) || errors.Is(err, urltemplate.ErrNoMatch)
+}
+
+// renderNotFoundPage serves the not found page of the domain of the request, if it has one.
+func (s *Server) renderNotFoundPage(rc RequestContext) bool {
+	d, err := s.urlShortener.Domain(rc.r.Context())
+	if err != nil || d.NotFound() == nil {
+		return false
+	}
+	rc.w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rc.w.WriteHeader(http.StatusNotFound)
+	if _, err := rc.w.Write(d.NotFound()); err != nil {
+		rc.log.Debug("Failed to write not found page", zap_utils.Err(err))
+	}
+	return true
+}
+
+func resolveErrorResponse(rc RequestContext, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPasswordRequired):
+		renderPasswordForm(rc, http.StatusOK, "")
+	case errors.Is(err, domain.ErrInvalidPassword):
+		renderPasswordForm(rc, http.StatusForbidden, "Wrong password.")
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		renderPasswordForm(rc, http.StatusTooManyRequests, "Too many attempts, try again later.")
+	case isNotFound(err):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusNotFound,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, urltemplate.ErrMissingVariable), errors.Is(err, urltemplate.ErrInvalidValue):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, domain.ErrLinkDisabled), errors.Is(err, domain.ErrLinkExpired), errors.Is(err, domain.ErrLinkExhausted):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusGone,
+			logLevel: zap.DebugLevel,
+		})
+	default:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Failed to resolve link: %s",
+		})
+	}
+}
+
+func renderPasswordForm(rc RequestContext, code int, message string) {
+	rc.w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rc.w.Header().Set("Cache-Control", "no-store")
+	rc.w.WriteHeader(code)
+	if err := passwordForm.Execute(rc.w, message); err != nil {
+		rc.log.Error("Failed to render password form", zap_utils.Err(err))
+	}
+}
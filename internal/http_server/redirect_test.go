@@ -0,0 +1,106 @@
+package http_server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_Redirect(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/{code}", server.Redirect)
+	router.Post("/{code}", server.UnlockRedirect)
+
+	open := service.Link{Link: database.Link{Target: "https://example.com/open"}, Code: "open"}
+	protected := service.Link{Link: database.Link{Target: "https://example.com/internal", PasswordHash: "hash"}, Code: "locked"}
+	expiry := time.Now().Add(time.Minute)
+	// Without a visitor cookie the visitor is derived from the client address.
+	visitor := visitorID(&http.Request{RemoteAddr: "192.0.2.1:1234"})
+
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "open", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{Link: open}, nil)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "gone", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{}, service.ErrLinkExpired)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "used", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{}, service.ErrLinkExhausted)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "missing", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{}, service.ErrUrlNotFound)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "down", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{}, service.ErrUnavailable)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "locked", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{}, service.ErrPasswordRequired)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "locked", AccessToken: "token", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{Link: protected}, nil)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "locked", Password: "wrong", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{}, service.ErrInvalidPassword)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "locked", Password: "many", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{}, service.ErrTooManyAttempts)
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{Code: "locked", Password: "s3cret", Client: "192.0.2.1", Visitor: visitor}).
+		Return(service.Resolved{Link: protected, AccessToken: "token", AccessTokenExpiry: expiry}, nil)
+
+	do := func(r *http.Request) *httptest.ResponseRecorder {
+		r.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+	post := func(code, password string) *httptest.ResponseRecorder {
+		form := url.Values{"password": {password}}
+		r := httptest.NewRequest(http.MethodPost, "/"+code, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return do(r)
+	}
+
+	w := do(httptest.NewRequest(http.MethodGet, "/open", nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/open", w.Header().Get("Location"))
+
+	assert.Equal(t, http.StatusGone, do(httptest.NewRequest(http.MethodGet, "/gone", nil)).Code)
+	assert.Equal(t, http.StatusGone, do(httptest.NewRequest(http.MethodGet, "/used", nil)).Code)
+	assert.Equal(t, http.StatusNotFound, do(httptest.NewRequest(http.MethodGet, "/missing", nil)).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, do(httptest.NewRequest(http.MethodGet, "/down", nil)).Code)
+
+	w = do(httptest.NewRequest(http.MethodGet, "/locked", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<form method="post">`)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	w = post("locked", "wrong")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Wrong password.")
+	assert.Equal(t, http.StatusTooManyRequests, post("locked", "many").Code)
+
+	w = post("locked", "s3cret")
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "https://example.com/internal", w.Header().Get("Location"))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, accessCookie, cookies[0].Name)
+	assert.Equal(t, "token", cookies[0].Value)
+	assert.Equal(t, "/locked", cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+
+	r := httptest.NewRequest(http.MethodGet, "/locked", nil)
+	r.AddCookie(cookies[0])
+	w = do(r)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/internal", w.Header().Get("Location"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+}
@@ -1,45 +1,134 @@
 package http_server
 
 import (
-	"encoding/json"
-	"fmt"
+	"expvar"
 	"net/http"
 
+	"github.com/Parzival-05/url-shortener/internal/dashboard"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/limiter"
+	"github.com/Parzival-05/url-shortener/internal/requestid"
+
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 func (s *Server) RegisterRoutes() http.Handler {
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(RequestID(s.log))
+	r.Use(AccessLog(s.log, s.samplingPolicy.Apply(s.log), isResolveRequest))
+	r.Use(SelectDomain)
+	if s.dashboard != nil {
+		r.Use(DashboardSessions(s.dashboard))
+	}
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", requestid.Header, domains.Header, dashboard.CSRFHeader},
+		ExposedHeaders:   []string{requestid.Header},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
-	r.Post("/shorten", s.CreateUrl)
-	r.Get("/shorten", s.GetUrl)
+	// Under load admin calls are shed first and redirects last.
+	r.Group(func(r chi.Router) {
+		r.Use(Shed(s.limiter, limiter.Normal))
+		r.Post("/shorten", s.CreateUrl)
+		r.Get("/links/campaigns", s.GetCampaignStats)
+		r.Get("/links/{code}/clicks", s.GetClickHistory)
+		r.Get("/links/{code}/qr", s.GetLinkQRCode)
+		// Anyone may read links, but only admins see where protected ones lead.
+		r.Group(func(r chi.Router) {
+			r.Use(ConcealTargets(s.adminToken))
+			r.Get("/links", s.ListLinks)
+			r.Get("/links/{code}", s.GetLink)
+			r.Get("/links/{code}/rules", s.ListRules)
+			r.Post("/links/{code}/rules/evaluate", s.EvaluateRules)
+			r.Get("/links/{code}/variants", s.GetVariantStats)
+		})
+		// Changing links takes the admin token, or a session with the dashboard, and is not served
+		// without either.
+		if s.dashboard != nil || s.adminToken != "" {
+			r.Group(func(r chi.Router) {
+				if s.dashboard != nil {
+					r.Use(RequireSession(s.adminToken))
+				} else {
+					r.Use(RequireToken(s.adminToken))
+				}
+				r.Post("/links/tags", s.BatchTags)
+				r.Patch("/links/{code}", s.UpdateLink)
+				r.Post("/links/{code}/rules", s.CreateRule)
+				r.Put("/links/{code}/rules/{id}", s.UpdateRule)
+				r.Delete("/links/{code}/rules/{id}", s.DeleteRule)
+				r.Put("/links/{code}/variants", s.SetVariants)
+				r.Put("/links/{code}/passthrough", s.SetPassthrough)
+				r.Put("/links/{code}/tags", s.SetTags)
+				r.Put("/links/{code}/campaign", s.SetCampaign)
+			})
+		}
+		if s.dashboard != nil {
+			r.Post("/dashboard/login", s.DashboardLogin)
+			r.Get("/dashboard/session", s.GetDashboardSession)
+			r.Post("/dashboard/logout", s.DashboardLogout)
+		}
+	})
+	if s.adminToken != "" {
+		r.Group(func(r chi.Router) {
+			r.Use(RequireToken(s.adminToken))
+			r.Use(Shed(s.limiter, limiter.Low))
+			r.Post("/admin/links/import", s.ImportLinks)
+			r.Get("/admin/links/export", s.ExportLinks)
+			// Subscribers see every link and click, and the delivery log reports what endpoints answer.
+			if s.webhooks != nil {
+				r.Post("/webhooks", s.CreateWebhook)
+				r.Get("/webhooks", s.ListWebhooks)
+				r.Get("/webhooks/{id}", s.GetWebhook)
+				r.Delete("/webhooks/{id}", s.DeleteWebhook)
+				r.Get("/webhooks/{id}/deliveries", s.ListWebhookDeliveries)
+				r.Post("/webhooks/{id}/replay", s.ReplayDeadWebhookDeliveries)
+				r.Post("/webhooks/deliveries/{id}/replay", s.ReplayWebhookDelivery)
+			}
+		})
+	}
+	// Streams last as long as their clients want, they are not limited. They show every link as
+	// it changes, so they are admin only.
+	if s.changes != nil && s.adminToken != "" {
+		r.With(RequireToken(s.adminToken)).Get("/links/changes", s.WatchLinks)
+	}
 
-	r.Get("/health", s.healthHandler)
+	r.Get("/livez", s.livezHandler)
+	r.Get("/readyz", s.readyzHandler)
+	if s.dashboard != nil {
+		r.Get("/dashboard", http.RedirectHandler("/dashboard/", http.StatusMovedPermanently).ServeHTTP)
+		r.Get("/dashboard/*", dashboardAssets().ServeHTTP)
+	}
+	// The counters tell about the storage and the load of the server, they are admin only too.
+	if s.adminToken != "" {
+		r.With(RequireToken(s.adminToken)).Get("/debug/vars", expvar.Handler().ServeHTTP)
+	}
 	r.Get("/swagger/*", httpSwagger.Handler(
-		httpSwagger.URL(fmt.Sprintf("http://localhost:%d/swagger/doc.json", s.port)), //The url pointing to API definition
+		httpSwagger.URL("/swagger/doc.json"), // Relative, so the UI works on every domain and port
 	))
+
+	r.Group(func(r chi.Router) {
+		r.Use(Shed(s.limiter, limiter.Critical))
+		r.Get("/shorten", s.GetUrl)
+		// Static routes above take precedence over short codes.
+		r.Get("/{code}", s.Redirect)
+		r.Post("/{code}", s.UnlockRedirect)
+		// Trailing paths are forwarded by links that allow it.
+		r.Get("/{code}/*", s.Redirect)
+		r.Post("/{code}/*", s.UnlockRedirect)
+	})
 	return r
 }
 
-// @Summary      Show the status of server
-// @Description  get the status of server
-// @Tags         Health
-// @Accept       */*
-// @Produce      json
-// @Success      200 {object} map[string]interface{} "Successful health check"
-// @Failure      503 {object} map[string]interface{} "Service is unavailable"
-// @Router       /health [get]
-func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
-	jsonResp, _ := json.Marshal(s.db.Health())
-	_, _ = w.Write(jsonResp)
+// isResolveRequest reports whether the request is high-volume resolve traffic
+// whose access logs are subject to sampling.
+func isResolveRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+	route := routePattern(r)
+	return route == "/shorten" || route == "/{code}" || route == "/{code}/*"
 }
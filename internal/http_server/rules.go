@@ -0,0 +1,333 @@
+package http_server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+// requestInfo returns what redirect rules are evaluated against for r.
+func requestInfo(r *http.Request) domain.RequestInfo {
+	info := domain.RequestInfo{
+		UserAgent:      r.UserAgent(),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+	}
+	if r.URL.RawQuery != "" {
+		info.Query = r.URL.Query()
+	}
+	return info
+}
+
+func toRuleResponse(rule rules.Rule) io_server.RuleResponse {
+	c := rule.Conditions
+	resp := io_server.RuleResponse{
+		ID:     rule.ID,
+		Target: rule.Target,
+		Conditions: io_server.RuleConditions{
+			Languages: c.Languages,
+			Countries: c.Countries,
+			From:      c.From,
+			To:        c.To,
+			TimeZone:  c.TimeZone,
+			Query:     c.Query,
+		},
+	}
+	for _, p := range c.Platforms {
+		resp.Conditions.Platforms = append(resp.Conditions.Platforms, string(p))
+	}
+	for _, day := range c.Days {
+		resp.Conditions.Days = append(resp.Conditions.Days, strings.ToLower(day.String()))
+	}
+	return resp
+}
+
+// fromRuleRequest converts a validated rule request.
+func fromRuleRequest(req io_server.RuleRequest) rules.Rule {
+	c := req.Conditions
+	rule := rules.Rule{
+		ID:     req.ID,
+		Target: req.Target,
+		Conditions: rules.Conditions{
+			Languages: c.Languages,
+			Countries: c.Countries,
+			From:      c.From,
+			To:        c.To,
+			TimeZone:  c.TimeZone,
+			Query:     c.Query,
+		},
+	}
+	for _, p := range c.Platforms {
+		rule.Conditions.Platforms = append(rule.Conditions.Platforms, rules.Platform(strings.ToLower(p)))
+	}
+	for _, name := range c.Days {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(day.String(), name) {
+				rule.Conditions.Days = append(rule.Conditions.Days, day)
+			}
+		}
+	}
+	return rule
+}
+
+func ruleIndex(index *int) int {
+	if index == nil {
+		return -1
+	}
+	return *index
+}
+
+// @Summary		List redirect rules
+// @Description	Lists the redirect rules of a link in evaluation order.
+// @Tags			Rules
+// @Produce		json
+// @Param			code	path		string						true	"Short code"
+// @Success		200		{object}	io_server.ListRulesResponse	"Redirect rules"
+// @Failure		404		{object}	map[string]string			"Link not found"
+// @Failure		500		{object}	map[string]string			"Internal Server Error"
+// @Router			/links/{code}/rules [get]
+func (s *Server) ListRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	rs, err := s.urlShortener.ListRules(ctx, chi.URLParam(r, "code"))
+	if err != nil {
+		ruleErrorResponse(rc, err)
+		return
+	}
+	resp := io_server.ListRulesResponse{Rules: make([]io_server.RuleResponse, 0, len(rs))}
+	for _, rule := range rs {
+		resp.Rules = append(resp.Rules, toRuleResponse(rule))
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
+
+// @Summary		Create a redirect rule
+// @Description	Adds a redirect rule to a link. Rules are evaluated in order on every redirect and the first
+// @Description	matching one overrides the link target. All conditions of a rule must hold; a list holds if any
+// @Description	of its values matches.
+// @Tags			Rules
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			code	path		string							true	"Short code"
+// @Param			request	body		io_server.RuleRequest			true	"Rule"
+// @Success		201		{object}	io_server.RuleResponse			"Created rule"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid rule"
+// @Failure		401		{object}	map[string]string				"Unauthorized"
+// @Failure		404		{object}	map[string]string				"Link not found"
+// @Failure		409		{object}	map[string]string				"Too many rules"
+// @Failure		500		{object}	map[string]string				"Internal Server Error"
+// @Router			/links/{code}/rules [post]
+func (s *Server) CreateRule(w http.ResponseWriter, r *http.Request) {
+	s.saveRule(w, r, http.StatusCreated, s.urlShortener.CreateRule)
+}
+
+// @Summary		Update a redirect rule
+// @Description	Replaces a redirect rule. With an index the rule is also moved to that position.
+// @Tags			Rules
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			code	path		string							true	"Short code"
+// @Param			id		path		string							true	"Rule ID"
+// @Param			request	body		io_server.RuleRequest			true	"Rule"
+// @Success		200		{object}	io_server.RuleResponse			"Updated rule"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid rule"
+// @Failure		401		{object}	map[string]string				"Unauthorized"
+// @Failure		404		{object}	map[string]string				"Link or rule not found"
+// @Failure		500		{object}	map[string]string				"Internal Server Error"
+// @Router			/links/{code}/rules/{id} [put]
+func (s *Server) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	s.saveRule(w, r, http.StatusOK, s.urlShortener.UpdateRule)
+}
+
+type saveRuleFunc func(ctx context.Context, code string, rule rules.Rule, index int) (rules.Rule, error)
+
+func (s *Server) saveRule(w http.ResponseWriter, r *http.Request, code int, save saveRuleFunc) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.RuleRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	req.ID = chi.URLParam(r, "id")
+	if !validate(rc, req) {
+		return
+	}
+	rule, err := save(ctx, req.Code, fromRuleRequest(req), ruleIndex(req.Index))
+	if err != nil {
+		ruleErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: code,
+		data: toRuleResponse(rule),
+	})
+}
+
+// @Summary		Delete a redirect rule
+// @Tags			Rules
+// @Security		AdminToken
+// @Param			code	path	string	true	"Short code"
+// @Param			id		path	string	true	"Rule ID"
+// @Success		204		"Deleted"
+// @Failure		401		{object}	map[string]string	"Unauthorized"
+// @Failure		404		{object}	map[string]string	"Link or rule not found"
+// @Failure		500		{object}	map[string]string	"Internal Server Error"
+// @Router			/links/{code}/rules/{id} [delete]
+func (s *Server) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	if err := s.urlShortener.DeleteRule(ctx, chi.URLParam(r, "code"), chi.URLParam(r, "id")); err != nil {
+		ruleErrorResponse(rc, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		Evaluate redirect rules
+// @Description	Dry run for debugging: reports which rule a request would match and where it would redirect to,
+// @Description	without resolving the link. User agent, languages and IP address default to those of this request.
+// @Tags			Rules
+// @Accept			json
+// @Produce		json
+// @Param			code	path		string							true	"Short code"
+// @Param			request	body		io_server.EvaluateRulesRequest	true	"Request context"
+// @Success		200		{object}	io_server.EvaluateRulesResponse	"Evaluation"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid request context"
+// @Failure		404		{object}	map[string]string				"Link not found"
+// @Failure		500		{object}	map[string]string				"Internal Server Error"
+// @Router			/links/{code}/rules/evaluate [post]
+func (s *Server) EvaluateRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.EvaluateRulesRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	if !validate(rc, req) {
+		return
+	}
+	evalReq := domain.EvaluateRulesRequest{
+		RequestInfo: domain.RequestInfo{
+			UserAgent:      req.UserAgent,
+			AcceptLanguage: req.AcceptLanguage,
+		},
+		Client:  req.IP,
+		Country: req.Country,
+		Time:    req.Time,
+	}
+	if evalReq.UserAgent == "" {
+		evalReq.UserAgent = r.UserAgent()
+	}
+	if evalReq.AcceptLanguage == "" {
+		evalReq.AcceptLanguage = r.Header.Get("Accept-Language")
+	}
+	if evalReq.Client == "" {
+		evalReq.Client = clientAddress(r)
+	}
+	if len(req.Query) > 0 {
+		evalReq.Query = url.Values{}
+		for key, value := range req.Query {
+			evalReq.Query.Set(key, value)
+		}
+	}
+	eval, err := s.urlShortener.EvaluateRules(ctx, req.Code, evalReq)
+	if err != nil {
+		ruleErrorResponse(rc, err)
+		return
+	}
+	resp := io_server.EvaluateRulesResponse{
+		Target:    eval.Target,
+		Platform:  string(eval.Request.Platform),
+		Languages: eval.Request.Languages,
+		Country:   eval.Request.Country,
+		Time:      eval.Request.Time,
+	}
+	if eval.Rule != nil {
+		rule := toRuleResponse(*eval.Rule)
+		resp.Rule = &rule
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
+
+func ruleErrorResponse(rc RequestContext, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUrlNotFound), errors.Is(err, domain.ErrInvalidUrl), errors.Is(err, domain.ErrRuleNotFound):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusNotFound,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, rules.ErrInvalidRule), errors.Is(err, domain.ErrInvalidTarget):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, domain.ErrTooManyRules):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusConflict,
+			logLevel: zap.DebugLevel,
+		})
+	default:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Failed to handle redirect rules: %s",
+		})
+	}
+}
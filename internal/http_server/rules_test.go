@@ -0,0 +1,184 @@
+package http_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_Rules(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/links/{code}/rules", server.ListRules)
+	router.Post("/links/{code}/rules", server.CreateRule)
+	router.Post("/links/{code}/rules/evaluate", server.EvaluateRules)
+	router.Put("/links/{code}/rules/{id}", server.UpdateRule)
+	router.Delete("/links/{code}/rules/{id}", server.DeleteRule)
+
+	weekend := rules.Rule{
+		Target: "https://example.com/weekend",
+		Conditions: rules.Conditions{
+			Platforms: []rules.Platform{rules.PlatformIOS},
+			Days:      []time.Weekday{time.Saturday, time.Sunday},
+			TimeZone:  "Europe/Berlin",
+		},
+	}
+	saved := weekend
+	saved.ID = "r1"
+	urlShortener.On("CreateRule", mock.Anything, "abc", weekend, -1).Return(saved, nil).Once()
+	urlShortener.On("UpdateRule", mock.Anything, "abc", saved, 0).Return(saved, nil).Once()
+	urlShortener.On("UpdateRule", mock.Anything, "abc", rules.Rule{ID: "gone", Target: "https://example.com"}, -1).
+		Return(rules.Rule{}, service.ErrRuleNotFound).Once()
+	urlShortener.On("CreateRule", mock.Anything, "full", rules.Rule{Target: "https://example.com"}, -1).
+		Return(rules.Rule{}, service.ErrTooManyRules).Once()
+	urlShortener.On("ListRules", mock.Anything, "abc").Return([]rules.Rule{saved}, nil).Once()
+	urlShortener.On("DeleteRule", mock.Anything, "abc", "r1").Return(nil).Once()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("User-Agent", "Mozilla/5.0 (Linux; Android 14; Pixel 8)")
+		r.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+	ruleJSON := `{"target":"https://example.com/weekend","conditions":{"platforms":["ios"],"days":["saturday","Sunday"],"time_zone":"Europe/Berlin"}}`
+
+	w := do(http.MethodPost, "/links/abc/rules", ruleJSON)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created struct {
+		Data io_server.RuleResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "r1", created.Data.ID)
+	assert.Equal(t, []string{"saturday", "sunday"}, created.Data.Conditions.Days)
+
+	w = do(http.MethodPut, "/links/abc/rules/r1", strings.Replace(ruleJSON, "{", `{"index":0,`, 1))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPut, "/links/abc/rules/gone", `{"target":"https://example.com"}`).Code)
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/links/full/rules", `{"target":"https://example.com"}`).Code)
+
+	w = do(http.MethodGet, "/links/abc/rules", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"platforms":["ios"]`)
+
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/links/abc/rules/r1", "").Code)
+
+	w = do(http.MethodPost, "/links/abc/rules", `{"target":"https://example.com","conditions":{"platforms":["beos"],"days":["someday"]}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var invalid io_server.ValidationErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invalid))
+	require.Len(t, invalid.Violations, 2)
+	assert.Equal(t, "conditions.platforms[0]", invalid.Violations[0].Field)
+	assert.Equal(t, "conditions.days[0]", invalid.Violations[1].Field)
+
+	w = do(http.MethodPost, "/links/abc/rules", `{"target":"https://example.com","conditions":{"from":"9am"}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	urlShortener.AssertExpectations(t)
+}
+
+func TestServer_EvaluateRules(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Post("/links/{code}/rules/evaluate", server.EvaluateRules)
+
+	at := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	rule := rules.Rule{ID: "r1", Target: "https://play.google.com/app", Conditions: rules.Conditions{Platforms: []rules.Platform{rules.PlatformAndroid}}}
+	// Unset fields default to the evaluate request itself.
+	urlShortener.On("EvaluateRules", mock.Anything, "abc", service.EvaluateRulesRequest{
+		RequestInfo: service.RequestInfo{
+			UserAgent:      "Mozilla/5.0 (Linux; Android 14; Pixel 8)",
+			AcceptLanguage: "de",
+			Query:          url.Values{"src": {"qr"}},
+		},
+		Client: "192.0.2.1",
+		Time:   at,
+	}).Return(service.RuleEvaluation{
+		Request: rules.Request{Platform: rules.PlatformAndroid, Languages: []string{"de"}, Country: "DE", Time: at},
+		Rule:    &rule,
+		Target:  rule.Target,
+	}, nil).Once()
+
+	r := httptest.NewRequest(http.MethodPost, "/links/abc/rules/evaluate", strings.NewReader(`{"time":"2025-01-04T12:00:00Z","query":{"src":"qr"}}`))
+	r.Header.Set("User-Agent", "Mozilla/5.0 (Linux; Android 14; Pixel 8)")
+	r.Header.Set("Accept-Language", "de")
+	r.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data io_server.EvaluateRulesResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Data.Rule)
+	assert.Equal(t, "r1", resp.Data.Rule.ID)
+	assert.Equal(t, "https://play.google.com/app", resp.Data.Target)
+	assert.Equal(t, "android", resp.Data.Platform)
+	assert.Equal(t, "DE", resp.Data.Country)
+
+	r = httptest.NewRequest(http.MethodPost, "/links/abc/rules/evaluate", strings.NewReader(`{"ip":"not an ip"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	urlShortener.AssertExpectations(t)
+}
+
+func TestServer_RedirectRules(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/{code}", server.Redirect)
+
+	urlShortener.On("Resolve", mock.Anything, service.ResolveRequest{
+		RequestInfo: service.RequestInfo{
+			UserAgent:      "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X)",
+			AcceptLanguage: "en-US",
+			Query:          url.Values{"src": {"qr"}},
+		},
+		Code:    "app",
+		Client:  "192.0.2.1",
+		Visitor: visitorID(&http.Request{RemoteAddr: "192.0.2.1:1234"}),
+	}).Return(service.Resolved{
+		Link:   service.Link{Link: database.Link{Target: "https://apps.apple.com/app"}, Code: "app"},
+		RuleID: "ios",
+	}, nil).Once()
+
+	r := httptest.NewRequest(http.MethodGet, "/app?src=qr", nil)
+	r.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X)")
+	r.Header.Set("Accept-Language", "en-US")
+	r.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://apps.apple.com/app", w.Header().Get("Location"))
+	urlShortener.AssertExpectations(t)
+}
@@ -7,28 +7,56 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Parzival-05/url-shortener/internal/dashboard"
 	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/health"
+	"github.com/Parzival-05/url-shortener/internal/limiter"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/outbox"
 	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/tlsconfig"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
 
 	_ "github.com/joho/godotenv/autoload"
 	"go.uber.org/zap"
 )
 
 type Server struct {
-	port         int
-	log          *zap.Logger
-	db           database.DBService
-	urlShortener service.IUrlShortener
+	port           int
+	log            *zap.Logger
+	samplingPolicy zap_utils.SamplingPolicy
+	db             database.DBService
+	health         *health.Registry
+	urlShortener   service.IUrlShortener
+	webhooks       *webhooks.Dispatcher
+	changes        *outbox.Relay
+	// adminToken authorizes the admin routes, which are not served if it is empty.
+	adminToken string
+	// limiter sheds requests under load, nil to serve every request.
+	limiter *limiter.Limiter
+	// dashboard signs in the users of the web UI, nil if it is not served.
+	dashboard *dashboard.Sessions
 }
 
-func NewServer(log *zap.Logger, db database.DBService, urlShortener service.IUrlShortener) *http.Server {
+func NewServer(log *zap.Logger, db database.DBService, healthRegistry *health.Registry, urlShortener service.IUrlShortener, dispatcher *webhooks.Dispatcher, changes *outbox.Relay) *http.Server {
 	port, _ := strconv.Atoi(os.Getenv("PORT"))
+	sessions, err := dashboard.FromEnv(log)
+	if err != nil {
+		log.Fatal("Failed to configure the dashboard", zap.Error(err))
+	}
 
 	NewServer := &Server{
-		port:         port,
-		log:          log,
-		db:           db,
-		urlShortener: urlShortener,
+		port:           port,
+		log:            log,
+		samplingPolicy: zap_utils.SamplingPolicyFromEnv(),
+		db:             db,
+		health:         healthRegistry,
+		urlShortener:   urlShortener,
+		webhooks:       dispatcher,
+		changes:        changes,
+		adminToken:     os.Getenv("ADMIN_TOKEN"),
+		limiter:        limiter.FromEnv(),
+		dashboard:      sessions,
 	}
 
 	// Declare Server config
@@ -39,6 +67,14 @@ func NewServer(log *zap.Logger, db database.DBService, urlShortener service.IUrl
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 30 * time.Second,
 	}
+	certs, err := tlsconfig.FromEnv("", log)
+	if err != nil {
+		log.Fatal("Failed to configure TLS", zap.Error(err))
+	}
+	if certs != nil {
+		// Served with ListenAndServeTLS("", "").
+		server.TLSConfig = certs.ServerConfig("h2", "http/1.1")
+	}
 
 	return server
 }
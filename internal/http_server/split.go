@@ -0,0 +1,170 @@
+package http_server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/split"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+// visitorCookie keeps visitors of sticky split links on the same variant.
+const visitorCookie = "visitor_id"
+
+// visitorCookieMaxAge is how long a visitor keeps its variant without coming back.
+const visitorCookieMaxAge = 365 * 24 * 60 * 60
+
+// visitorID returns the key sticky split links hash the caller by: its visitor cookie, or for
+// new visitors a value derived from the client address, which the cookie then keeps.
+func visitorID(r *http.Request) string {
+	if cookie, err := r.Cookie(visitorCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	sum := sha256.Sum256([]byte(clientAddress(r)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// setVisitorCookie remembers the visitor of a sticky split link if it is new.
+func setVisitorCookie(w http.ResponseWriter, r *http.Request, resolved domain.Resolved, visitor string) {
+	if resolved.VariantID == "" || resolved.SplitMode != split.ModeSticky {
+		return
+	}
+	if _, err := r.Cookie(visitorCookie); err == nil {
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     visitorCookie,
+		Value:    visitor,
+		Path:     "/",
+		MaxAge:   visitorCookieMaxAge,
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// @Summary		Get split variant stats
+// @Description	Lists the split variants of a link with the number of redirects that picked each one.
+// @Description	Variants that were removed but have recorded clicks are listed last with removed set.
+// @Tags			Split
+// @Produce		json
+// @Param			code	path		string								true	"Short code"
+// @Success		200		{object}	io_server.ListVariantStatsResponse	"Variant stats"
+// @Failure		404		{object}	map[string]string					"Link not found"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/links/{code}/variants [get]
+func (s *Server) GetVariantStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	stats, err := s.urlShortener.VariantStats(ctx, chi.URLParam(r, "code"))
+	if err != nil {
+		splitErrorResponse(rc, err)
+		return
+	}
+	resp := io_server.ListVariantStatsResponse{Variants: make([]io_server.VariantStatsResponse, 0, len(stats))}
+	for _, st := range stats {
+		resp.Variants = append(resp.Variants, io_server.VariantStatsResponse{
+			Variant: io_server.Variant(st.Variant),
+			Clicks:  st.Clicks,
+			Removed: st.Removed,
+		})
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
+
+// @Summary		Set split variants
+// @Description	Replaces the split variants of a link. Unless a redirect rule matches, every redirect picks a variant
+// @Description	in proportion to the weights: at random, or in sticky mode by hashing the visitor cookie or address,
+// @Description	so returning visitors keep their variant. An empty list turns splitting off.
+// @Tags			Split
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			code	path		string							true	"Short code"
+// @Param			request	body		io_server.SplitRequest			true	"Variants"
+// @Success		200		{object}	io_server.LinkResponse			"Updated link"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid variants"
+// @Failure		401		{object}	map[string]string				"Unauthorized"
+// @Failure		404		{object}	map[string]string				"Link not found"
+// @Failure		500		{object}	map[string]string				"Internal Server Error"
+// @Router			/links/{code}/variants [put]
+func (s *Server) SetVariants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.SplitRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	if !validate(rc, req) {
+		return
+	}
+	update := database.Link{SplitMode: split.Mode(req.SplitMode)}
+	for _, v := range req.Variants {
+		update.Variants = append(update.Variants, split.Variant(v))
+	}
+	link, err := s.urlShortener.UpdateLink(ctx, req.Code, update, []database.LinkField{
+		database.LinkFieldVariants,
+		database.LinkFieldSplitMode,
+	})
+	if err != nil {
+		splitErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toLinkResponse(link),
+	})
+}
+
+func splitErrorResponse(rc RequestContext, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUrlNotFound), errors.Is(err, domain.ErrInvalidUrl):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusNotFound,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, split.ErrInvalidVariants), errors.Is(err, domain.ErrInvalidTarget):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+	default:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Failed to handle split variants: %s",
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package http_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/split"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_Variants(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/links/{code}/variants", server.GetVariantStats)
+	router.Put("/links/{code}/variants", server.SetVariants)
+
+	variants := []split.Variant{
+		{ID: "a", Target: "https://example.com/a", Weight: 80},
+		{ID: "b", Target: "https://example.com/b", Weight: 20},
+	}
+	fields := []database.LinkField{database.LinkFieldVariants, database.LinkFieldSplitMode}
+	urlShortener.On("UpdateLink", mock.Anything, "abc", database.Link{Variants: variants, SplitMode: split.ModeSticky}, fields).
+		Return(service.Link{Link: database.Link{Target: "https://example.com", Variants: variants, SplitMode: split.ModeSticky}, Code: "abc"}, nil).Once()
+	urlShortener.On("UpdateLink", mock.Anything, "abc", database.Link{}, fields).
+		Return(service.Link{Link: database.Link{Target: "https://example.com"}, Code: "abc"}, nil).Once()
+	urlShortener.On("UpdateLink", mock.Anything, "missing", mock.Anything, fields).
+		Return(service.Link{}, service.ErrUrlNotFound).Once()
+	urlShortener.On("VariantStats", mock.Anything, "abc").Return([]service.VariantStats{
+		{Variant: variants[0], Clicks: 40},
+		{Variant: variants[1], Clicks: 9},
+		{Variant: split.Variant{ID: "old"}, Clicks: 3, Removed: true},
+	}, nil).Once()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(http.MethodPut, "/links/abc/variants", `{"split_mode":"sticky","variants":[`+
+		`{"id":"a","target":"https://example.com/a","weight":80},{"id":"b","target":"https://example.com/b","weight":20}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var updated struct {
+		Data io_server.LinkResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, "sticky", updated.Data.SplitMode)
+	assert.Len(t, updated.Data.Variants, 2)
+
+	w = do(http.MethodPut, "/links/abc/variants", `{}`)
+	assert.Equal(t, http.StatusOK, w.Code, "an empty list turns splitting off")
+	assert.NotContains(t, w.Body.String(), "split_mode")
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPut, "/links/missing/variants", `{}`).Code)
+
+	w = do(http.MethodPut, "/links/abc/variants", `{"split_mode":"round-robin","variants":[{"id":"a b","target":"https://example.com","weight":-1}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var invalid io_server.ValidationErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invalid))
+	var names []string
+	for _, v := range invalid.Violations {
+		names = append(names, v.Field)
+	}
+	assert.Equal(t, []string{"split_mode", "variants[0].id", "variants[0].weight"}, names)
+
+	w = do(http.MethodGet, "/links/abc/variants", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var stats struct {
+		Data io_server.ListVariantStatsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	require.Len(t, stats.Data.Variants, 3)
+	assert.Equal(t, int64(40), stats.Data.Variants[0].Clicks)
+	assert.True(t, stats.Data.Variants[2].Removed)
+	urlShortener.AssertExpectations(t)
+}
+
+func TestServer_RedirectStickyVisitor(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/{code}", server.Redirect)
+
+	link := service.Link{Link: database.Link{Target: "https://example.com/a", SplitMode: split.ModeSticky}, Code: "ab"}
+	newVisitor := visitorID(&http.Request{RemoteAddr: "192.0.2.1:1234"})
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool {
+		return req.Code == "ab" && req.Visitor == newVisitor
+	})).Return(service.Resolved{Link: link, VariantID: "a"}, nil).Once()
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool {
+		return req.Code == "ab" && req.Visitor == "returning"
+	})).Return(service.Resolved{Link: link, VariantID: "a"}, nil).Once()
+
+	r := httptest.NewRequest(http.MethodGet, "/ab", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusFound, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, visitorCookie, cookies[0].Name)
+	assert.Equal(t, newVisitor, cookies[0].Value, "the cookie keeps the variant picked for the address")
+
+	r = httptest.NewRequest(http.MethodGet, "/ab", nil)
+	r.RemoteAddr = "198.51.100.7:1234"
+	r.AddCookie(&http.Cookie{Name: visitorCookie, Value: "returning"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Empty(t, w.Result().Cookies(), "returning visitors keep their cookie")
+	urlShortener.AssertExpectations(t)
+}
@@ -0,0 +1,217 @@
+package http_server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	domain "github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+// @Summary		Set link tags
+// @Description	Replaces the tags of a link. An empty list removes them.
+// @Tags			Links
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			code	path		string								true	"Short code"
+// @Param			request	body		io_server.TagsRequest				true	"Tags"
+// @Success		200		{object}	io_server.LinkResponse				"Updated link"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid tags"
+// @Failure		401		{object}	map[string]string					"Unauthorized"
+// @Failure		404		{object}	map[string]string					"Link not found"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/links/{code}/tags [put]
+func (s *Server) SetTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.TagsRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	if !validate(rc, req) {
+		return
+	}
+	link, err := s.urlShortener.UpdateLink(ctx, req.Code, database.Link{Tags: req.Tags}, []database.LinkField{database.LinkFieldTags})
+	if err != nil {
+		tagsErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toLinkResponse(link),
+	})
+}
+
+// @Summary		Set link campaign
+// @Description	Files a link under a campaign or folder, whose links are listed with the campaign filter and
+// @Description	counted in the campaign stats. Redirects of links in a campaign are counted. An empty campaign
+// @Description	takes the link out.
+// @Tags			Links
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			code	path		string								true	"Short code"
+// @Param			request	body		io_server.CampaignRequest			true	"Campaign"
+// @Success		200		{object}	io_server.LinkResponse				"Updated link"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid campaign"
+// @Failure		401		{object}	map[string]string					"Unauthorized"
+// @Failure		404		{object}	map[string]string					"Link not found"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/links/{code}/campaign [put]
+func (s *Server) SetCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.CampaignRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	req.Code = chi.URLParam(r, "code")
+	if !validate(rc, req) {
+		return
+	}
+	link, err := s.urlShortener.UpdateLink(ctx, req.Code, database.Link{Campaign: req.Campaign}, []database.LinkField{database.LinkFieldCampaign})
+	if err != nil {
+		tagsErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toLinkResponse(link),
+	})
+}
+
+// @Summary		Tag links in bulk
+// @Description	Adds tags to and removes tags from up to 1000 links of the domain at once. Codes that name no link
+// @Description	are reported in not_found_codes rather than failing the request.
+// @Tags			Links
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			request	body		io_server.BatchTagsRequest			true	"Codes and tags"
+// @Success		200		{object}	io_server.BatchTagsResponse			"Links whose tags changed"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid codes or tags"
+// @Failure		401		{object}	map[string]string					"Unauthorized"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/links/tags [post]
+func (s *Server) BatchTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.BatchTagsRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	if !validate(rc, req) {
+		return
+	}
+	result, err := s.urlShortener.TagLinks(ctx, req.Codes, req.AddTags, req.RemoveTags)
+	if err != nil {
+		tagsErrorResponse(rc, err)
+		return
+	}
+	resp := io_server.BatchTagsResponse{
+		Links:         make([]io_server.LinkResponse, 0, len(result.Links)),
+		NotFoundCodes: result.NotFound,
+	}
+	for _, link := range result.Links {
+		resp.Links = append(resp.Links, toLinkResponse(link))
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
+
+// @Summary		Get campaign stats
+// @Description	Lists every campaign of the domain with the number of its links and of their redirects.
+// @Tags			Links
+// @Produce		json
+// @Success		200	{object}	io_server.ListCampaignStatsResponse	"Campaign stats"
+// @Failure		500	{object}	map[string]string					"Internal Server Error"
+// @Router			/links/campaigns [get]
+func (s *Server) GetCampaignStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	stats, err := s.urlShortener.CampaignStats(ctx)
+	if err != nil {
+		tagsErrorResponse(rc, err)
+		return
+	}
+	resp := io_server.ListCampaignStatsResponse{Campaigns: make([]io_server.CampaignStatsResponse, 0, len(stats))}
+	for _, st := range stats {
+		resp.Campaigns = append(resp.Campaigns, io_server.CampaignStatsResponse(st))
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
+
+func tagsErrorResponse(rc RequestContext, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUrlNotFound), errors.Is(err, domain.ErrInvalidUrl):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusNotFound,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, domain.ErrTooManyCodes):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+	default:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Failed to organize links: %s",
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package http_server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_TagsAndCampaigns(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/links/campaigns", server.GetCampaignStats)
+	router.Post("/links/tags", server.BatchTags)
+	router.Put("/links/{code}/tags", server.SetTags)
+	router.Put("/links/{code}/campaign", server.SetCampaign)
+
+	urlShortener.On("UpdateLink", mock.Anything, "abc", database.Link{Tags: []string{"docs", "q2"}}, []database.LinkField{database.LinkFieldTags}).
+		Return(service.Link{Link: database.Link{Target: "https://example.com", Tags: []string{"docs", "q2"}}, Code: "abc"}, nil).Once()
+	urlShortener.On("UpdateLink", mock.Anything, "abc", database.Link{Campaign: "Spring sale"}, []database.LinkField{database.LinkFieldCampaign}).
+		Return(service.Link{Link: database.Link{Target: "https://example.com", Campaign: "Spring sale"}, Code: "abc"}, nil).Once()
+	urlShortener.On("UpdateLink", mock.Anything, "missing", mock.Anything, mock.Anything).
+		Return(service.Link{}, service.ErrUrlNotFound).Once()
+	urlShortener.On("TagLinks", mock.Anything, []string{"abc", "zzz"}, []string{"q2"}, []string(nil)).
+		Return(service.TagLinksResult{
+			Links:    []service.Link{{Link: database.Link{Target: "https://example.com", Tags: []string{"q2"}}, Code: "abc"}},
+			NotFound: []string{"zzz"},
+		}, nil).Once()
+	urlShortener.On("CampaignStats", mock.Anything).
+		Return([]database.CampaignStats{{Campaign: "Spring sale", Links: 2, Clicks: 7}}, nil).Once()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do(http.MethodPut, "/links/abc/tags", `{"tags":["docs","q2"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var updated struct {
+		Data io_server.LinkResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, []string{"docs", "q2"}, updated.Data.Tags)
+
+	w = do(http.MethodPut, "/links/abc/campaign", `{"campaign":"Spring sale"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, "Spring sale", updated.Data.Campaign)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPut, "/links/missing/campaign", `{}`).Code)
+
+	w = do(http.MethodPut, "/links/abc/tags", `{"tags":["two words","docs","docs"]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var invalid io_server.ValidationErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invalid))
+	require.NotEmpty(t, invalid.Violations)
+	for _, v := range invalid.Violations {
+		assert.True(t, strings.HasPrefix(v.Field, "tags"), v.Field)
+	}
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/links/abc/campaign", `{"campaign":"tab\t"}`).Code)
+
+	w = do(http.MethodPost, "/links/tags", `{"codes":["abc","zzz"],"add_tags":["q2"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var batch struct {
+		Data io_server.BatchTagsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &batch))
+	require.Len(t, batch.Data.Links, 1)
+	assert.Equal(t, []string{"zzz"}, batch.Data.NotFoundCodes)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/links/tags", `{"codes":[],"add_tags":["q2"]}`).Code)
+
+	w = do(http.MethodGet, "/links/campaigns", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var stats struct {
+		Data io_server.ListCampaignStatsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, []io_server.CampaignStatsResponse{{Campaign: "Spring sale", Links: 2, Clicks: 7}}, stats.Data.Campaigns)
+	urlShortener.AssertExpectations(t)
+}
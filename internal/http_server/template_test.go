@@ -0,0 +1,83 @@
+package http_server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_CreateUrl_Template(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	target := "https://example.com/docs/{version}"
+	urlShortener.On("CreateLink", mock.Anything, database.Link{Target: target, Template: &urltemplate.Template{Path: "{version}"}}).
+		Return(service.Link{Code: "docs"}, nil).Once()
+	urlShortener.On("CreateLink", mock.Anything, database.Link{Target: "https://example.com/", Template: &urltemplate.Template{Path: "{id}"}}).
+		Return(service.Link{}, urltemplate.ErrInvalidTemplate).Once()
+
+	post := func(req io_server.CreateUrlRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		server.CreateUrl(w, httptest.NewRequest("POST", "/shorten", bytes.NewReader(body)))
+		return w
+	}
+
+	// A templated link is always a new link, never the shared one from CreateUrl.
+	w := post(io_server.CreateUrlRequest{URL: target, Template: &io_server.Template{Path: "{version}"}})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"shorten_url":"docs"`)
+	w = post(io_server.CreateUrlRequest{URL: "https://example.com/", Template: &io_server.Template{Path: "{id}"}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = post(io_server.CreateUrlRequest{URL: "https://{sub}.example.com/", Template: &io_server.Template{}})
+	assert.Equal(t, http.StatusBadRequest, w.Code, "placeholders in the host are no valid URL")
+	urlShortener.AssertExpectations(t)
+}
+
+func TestServer_RedirectTemplate(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	router := chi.NewRouter()
+	router.Get("/{code}", server.Redirect)
+	router.Get("/{code}/*", server.Redirect)
+
+	link := service.Link{Link: database.Link{Target: "https://example.com/docs/v2"}, Code: "docs"}
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool {
+		return req.Path == "v2"
+	})).Return(service.Resolved{Link: link}, nil).Once()
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool {
+		return req.Path == "v2/extra"
+	})).Return(service.Resolved{}, urltemplate.ErrNoMatch).Once()
+	urlShortener.On("Resolve", mock.Anything, mock.MatchedBy(func(req service.ResolveRequest) bool {
+		return req.Path == ""
+	})).Return(service.Resolved{}, urltemplate.ErrMissingVariable).Once()
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+	w := get("/docs/v2")
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/docs/v2", w.Header().Get("Location"))
+	assert.Equal(t, http.StatusNotFound, get("/docs/v2/extra").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/docs").Code)
+	urlShortener.AssertExpectations(t)
+}
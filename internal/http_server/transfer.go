@@ -0,0 +1,241 @@
+package http_server
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/transfer"
+
+	"go.uber.org/zap"
+)
+
+// maxImportSize bounds the size of an uploaded import file.
+const maxImportSize = 32 << 20
+
+// @Summary		Import links
+// @Description	Creates the links of an uploaded CSV, NDJSON, Bitly or YOURLS file on the domain of the request.
+// @Description	The file is the request body or the "file" field of a multipart form. Links keep their code
+// @Description	when it is a free code of the domain; on_conflict decides whether links whose code is taken get
+// @Description	a new one or are skipped. Invalid rows are skipped and reported. Requires the admin token.
+// @Tags			Admin
+// @Accept			text/csv
+// @Accept			application/x-ndjson
+// @Accept			multipart/form-data
+// @Produce		json
+// @Security		AdminToken
+// @Param			format		query		string								false	"File format (default ndjson)"	Enums(csv, ndjson, bitly, yourls)
+// @Param			dry_run		query		bool								false	"Only report what would be imported"
+// @Param			on_conflict	query		string								false	"What to do with links whose code is taken (default remap)"	Enums(remap, skip)
+// @Success		200			{object}	io_server.ImportLinksResponse		"Import summary, rejected rows and code mapping"
+// @Failure		400			{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid parameters or file"
+// @Failure		401			{object}	map[string]string					"Unauthorized"
+// @Failure		413			{object}	map[string]string					"Request Entity Too Large"
+// @Failure		500			{object}	map[string]string					"Internal Server Error"
+// @Router			/admin/links/import [post]
+func (s *Server) ImportLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.ImportLinksRequest
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode query: %s",
+		})
+		return
+	}
+	if !validate(rc, req) {
+		return
+	}
+
+	// Large files take longer to upload and import than the read timeout of the server.
+	rcon := http.NewResponseController(w)
+	if err := rcon.SetReadDeadline(time.Time{}); err != nil {
+		rc.log.Debug("Failed to clear the read deadline of an import", zap.Error(err))
+	}
+	if err := rcon.SetWriteDeadline(time.Time{}); err != nil {
+		rc.log.Debug("Failed to clear the write deadline of an import", zap.Error(err))
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	file, err := importFile(r)
+	if err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to read the uploaded file: %s",
+		})
+		return
+	}
+
+	resp := io_server.ImportLinksResponse{Rejected: []io_server.RejectedRow{}, Mapping: []io_server.CodeMapping{}}
+	opts := transfer.Options{
+		Format:    transfer.Format(cmp.Or(req.Format, string(transfer.FormatNDJSON))),
+		DryRun:    req.DryRun,
+		SkipTaken: req.OnConflict == io_server.OnConflictSkip,
+	}
+	summary, err := transfer.Import(ctx, s.urlShortener, file, opts, func(result transfer.Result) error {
+		if result.Status == transfer.StatusSkipped || result.Status == transfer.StatusInvalid {
+			resp.Rejected = append(resp.Rejected, io_server.RejectedRow{
+				Line:   result.Line,
+				Status: string(result.Status),
+				Code:   result.Code,
+				Alias:  result.Alias,
+				Target: result.Target,
+				Error:  result.Error,
+			})
+		}
+		if result.Mapped() {
+			resp.Mapping = append(resp.Mapping, io_server.CodeMapping{
+				OldCode:  result.Code,
+				Alias:    result.Alias,
+				NewCode:  result.NewCode,
+				ShortURL: result.ShortURL,
+			})
+		}
+		return nil
+	})
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		errorResponse(rc, ErrorInfo{
+			err:      fmt.Errorf("file is larger than %d bytes", tooLarge.Limit),
+			code:     http.StatusRequestEntityTooLarge,
+			logLevel: zap.DebugLevel,
+		})
+		return
+	case errors.Is(err, transfer.ErrBadHeader), errors.Is(err, domains.ErrUnknownDomain):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+		return
+	case err != nil:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Failed to import links: %s",
+		})
+		return
+	}
+	rc.log.Info("Imported links",
+		zap.Bool("dry_run", summary.DryRun),
+		zap.Int("imported", summary.Imported),
+		zap.Int("remapped", summary.Remapped),
+		zap.Int("skipped", summary.Skipped),
+		zap.Int("invalid", summary.Invalid))
+	resp.DryRun = summary.DryRun
+	resp.Imported, resp.Remapped = summary.Imported, summary.Remapped
+	resp.Skipped, resp.Invalid = summary.Skipped, summary.Invalid
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
+
+// importFile returns the uploaded file: the "file" field of a multipart form, else the body.
+func importFile(r *http.Request) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, nil
+	}
+	form, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := form.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New(`the form has no "file" field`)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
+
+// @Summary		Export links
+// @Description	Downloads every link of the domain of the request, disabled ones included, as CSV or NDJSON.
+// @Description	The codes of an export are kept when it is imported into an instance where they are free.
+// @Description	Requires the admin token.
+// @Tags			Admin
+// @Produce		text/csv
+// @Produce		application/x-ndjson
+// @Security		AdminToken
+// @Param			format	query		string								false	"File format (default ndjson)"	Enums(csv, ndjson)
+// @Success		200		{string}	string								"The links"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid format"
+// @Failure		401		{object}	map[string]string					"Unauthorized"
+// @Router			/admin/links/export [get]
+func (s *Server) ExportLinks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.ExportLinksRequest
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode query: %s",
+		})
+		return
+	}
+	if !validate(rc, req) {
+		return
+	}
+	d, err := s.urlShortener.Domain(ctx)
+	if err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+		return
+	}
+
+	// Large exports take longer to download than the write timeout of the server.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		rc.log.Debug("Failed to clear the write deadline of an export", zap.Error(err))
+	}
+	format := transfer.Format(cmp.Or(req.Format, string(transfer.FormatNDJSON)))
+	w.Header().Set("Content-Type", format.ContentType())
+	filename := "links"
+	if d.Host != "" {
+		filename += "-" + d.Host
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": fmt.Sprintf("%s.%s", filename, format),
+	}))
+	w.WriteHeader(http.StatusOK)
+	// The status is sent, so failures can only cut the file short.
+	count, err := transfer.Export(ctx, s.urlShortener, w, format)
+	if err != nil {
+		rc.log.Error("Failed to export links", zap.Int("exported", count), zap.Error(err))
+		return
+	}
+	rc.log.Info("Exported links", zap.Int("exported", count))
+}
@@ -0,0 +1,139 @@
+package http_server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestServer_ImportExportLinks(t *testing.T) {
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", "")
+	log := zaptest.NewLogger(t)
+	newServer := func() *httptest.Server {
+		server := Server{
+			log:          log,
+			urlShortener: service.NewUrlShortener(inmemory.NewInMemoryDBService().NewUrlRepository(), log),
+			adminToken:   "s3cret",
+		}
+		ts := httptest.NewServer(server.RegisterRoutes())
+		t.Cleanup(ts.Close)
+		return ts
+	}
+	do := func(ts *httptest.Server, method, target, token, contentType string, body io.Reader) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, ts.URL+target, body)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		client := http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+	importLinks := func(ts *httptest.Server, query, contentType string, body io.Reader) io_server.ImportLinksResponse {
+		t.Helper()
+		resp := do(ts, http.MethodPost, "/admin/links/import"+query, "s3cret", contentType, body)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var out struct {
+			Data io_server.ImportLinksResponse `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return out.Data
+	}
+	source := newServer()
+
+	t.Run("requires the token", func(t *testing.T) {
+		for _, target := range []string{"/admin/links/export", "/debug/vars"} {
+			for _, token := range []string{"", "wrong"} {
+				resp := do(source, http.MethodGet, target, token, "", nil)
+				assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, target)
+				assert.Equal(t, "Bearer", resp.Header.Get("WWW-Authenticate"), target)
+			}
+		}
+		resp := do(source, http.MethodGet, "/debug/vars", "s3cret", "", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	csv := "code,target,alias\n,https://example.com/a,docs\n,ftp://example.com,\nforeign-code,https://example.com/b,\n"
+	dry := importLinks(source, "?format=csv&dry_run=true", "text/csv", strings.NewReader(csv))
+	assert.True(t, dry.DryRun)
+	assert.Equal(t, 1, dry.Imported)
+	assert.Equal(t, 1, dry.Remapped)
+	assert.Equal(t, 1, dry.Invalid)
+
+	imported := importLinks(source, "?format=csv", "text/csv", strings.NewReader(csv))
+	assert.Equal(t, 1, imported.Imported)
+	require.Len(t, imported.Rejected, 1)
+	assert.Equal(t, 3, imported.Rejected[0].Line)
+	assert.Equal(t, "invalid", imported.Rejected[0].Status)
+	require.Len(t, imported.Mapping, 2)
+	assert.Equal(t, "docs", imported.Mapping[0].Alias)
+	assert.Equal(t, "https://sho.rt/"+imported.Mapping[0].NewCode, imported.Mapping[0].ShortURL)
+	assert.Equal(t, "foreign-code", imported.Mapping[1].OldCode)
+
+	resp := do(source, http.MethodGet, "/admin/links/export?format=csv", "s3cret", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=links-sho.rt.csv`, resp.Header.Get("Content-Disposition"))
+	exported, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(exported), "\n"))
+
+	// a multipart upload of the export into another instance keeps the codes
+	var form bytes.Buffer
+	mw := multipart.NewWriter(&form)
+	require.NoError(t, mw.WriteField("comment", "ignored"))
+	part, err := mw.CreateFormFile("file", "links.csv")
+	require.NoError(t, err)
+	_, err = part.Write(exported)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+	target := newServer()
+	moved := importLinks(target, "?format=csv&on_conflict=skip", mw.FormDataContentType(), &form)
+	assert.Equal(t, 2, moved.Imported)
+	assert.Empty(t, moved.Mapping)
+	resp = do(target, http.MethodGet, "/"+imported.Mapping[0].NewCode, "", "", nil)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, "https://example.com/a", resp.Header.Get("Location"))
+
+	t.Run("bad requests", func(t *testing.T) {
+		for _, tc := range []struct {
+			method, target, contentType, body string
+		}{
+			{http.MethodPost, "/admin/links/import?format=xml", "", ""},
+			{http.MethodPost, "/admin/links/import?on_conflict=fail", "", ""},
+			{http.MethodPost, "/admin/links/import?format=csv", "text/csv", "code,url\n"},
+			{http.MethodPost, "/admin/links/import", "multipart/form-data; boundary=x", "--x--\r\n"},
+			{http.MethodGet, "/admin/links/export?format=bitly", "", ""},
+		} {
+			resp := do(source, tc.method, tc.target, "s3cret", tc.contentType, strings.NewReader(tc.body))
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, tc.target)
+		}
+	})
+
+	t.Run("not served without a token", func(t *testing.T) {
+		server := Server{log: log, urlShortener: service.NewUrlShortener(inmemory.NewInMemoryDBService().NewUrlRepository(), log)}
+		w := httptest.NewRecorder()
+		server.RegisterRoutes().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/links/export", nil))
+		assert.NotEqual(t, http.StatusOK, w.Code)
+	})
+}
@@ -1,11 +1,17 @@
 package http_server
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"net/http"
 
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/domains"
 	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
 	domain "github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
 
 	"github.com/go-chi/render"
 	"github.com/gorilla/schema"
@@ -15,13 +21,18 @@ import (
 var decoder = schema.NewDecoder()
 
 // @Summary		Create a short URL
-// @Description	Creates a new short link for a given URL. If the URL already exists, it returns the existing short link.
+// @Description	Creates a new short link for a given URL on the domain of the Host or X-Link-Domain header and returns
+// @Description	its full short URL, or the bare code if the domain has no base URL. If the URL already exists on the
+// @Description	domain, it returns the existing short link, unless a password, max_clicks, a template, tags, a
+// @Description	campaign, an owner or an expiry is given: such links are always created anew.
+// @Description	With a template the URL may contain placeholders after the host, {name}, {name=default} or {+name},
+// @Description	filled on redirect from the trailing path (matched against template.path) and the query.
 // @Tags			URL Shortener
 // @Accept			json
 // @Produce		json
 // @Param			request	body		io_server.CreateUrlRequest	true	"URL to be shortened"
 // @Success		200		{object}	io_server.CreateUrlResponse	"Successfully created or retrieved the short URL"
-// @Failure		400		{object}	map[string]string			"Bad Request - Invalid JSON format"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid JSON format, URL or template"
 // @Failure		500		{object}	map[string]string			"Internal Server Error"
 // @Router			/shorten [post]
 func (s *Server) CreateUrl(w http.ResponseWriter, r *http.Request) {
@@ -30,7 +41,7 @@ func (s *Server) CreateUrl(w http.ResponseWriter, r *http.Request) {
 	rc := RequestContext{
 		w:   w,
 		r:   r,
-		log: s.log,
+		log: zap_utils.FromContext(ctx, s.log),
 	}
 	urlShortener := s.urlShortener
 	var req io_server.CreateUrlRequest
@@ -44,8 +55,24 @@ func (s *Server) CreateUrl(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	shortenUrl, err := urlShortener.CreateUrl(ctx, req.URL)
-	if err != nil {
+	if !validate(rc, req) {
+		return
+	}
+	var shortenUrl string
+	if !req.Dedicated() {
+		shortenUrl, err = urlShortener.CreateUrl(ctx, req.URL)
+	} else {
+		shortenUrl, err = createDedicatedUrl(ctx, urlShortener, req)
+	}
+	switch {
+	case errors.Is(err, urltemplate.ErrInvalidTemplate), errors.Is(err, domains.ErrUnknownDomain):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+		return
+	case err != nil:
 		errorResponse(rc,
 			ErrorInfo{
 				err:      err,
@@ -64,12 +91,15 @@ func (s *Server) CreateUrl(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary		Get original URL
-// @Description	Retrieves the original, full URL for a given short link code.
+// @Description	Retrieves the original, full URL for a given short link code on the domain of the request,
+// @Description	or for a full short URL such as https://go.example.com/{code}.
 // @Tags			URL Shortener
 // @Produce		json
-// @Param			shorten_url	query		string						true	"The 10-character short code"	Format(string)
+// @Param			shorten_url	query		string						true	"The short code or full short URL"	Format(string)
 // @Success		200			{object}	io_server.GetUrlResponse	"Successfully retrieved the original URL"
 // @Failure		400			{object}	map[string]string			"Bad Request - The short code is invalid or was not found"
+// @Failure		401			{object}	map[string]string			"The link is password protected"
+// @Failure		410			{object}	map[string]string			"The link is disabled, expired or has no clicks left"
 // @Failure		500			{object}	map[string]string			"Internal Server Error"
 // @Router			/shorten [get]
 func (s *Server) GetUrl(w http.ResponseWriter, r *http.Request) {
@@ -78,7 +108,7 @@ func (s *Server) GetUrl(w http.ResponseWriter, r *http.Request) {
 	rc := RequestContext{
 		w:   w,
 		r:   r,
-		log: s.log,
+		log: zap_utils.FromContext(ctx, s.log),
 	}
 	urlShortener := s.urlShortener
 	var req io_server.GetUrlRequest
@@ -92,6 +122,9 @@ func (s *Server) GetUrl(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if !validate(rc, req) {
+		return
+	}
 	fullUrl, err := urlShortener.GetFullUrl(ctx, req.ShortenURL)
 	if err != nil {
 		if errors.Is(err, domain.ErrUrlNotFound) {
@@ -100,6 +133,18 @@ func (s *Server) GetUrl(w http.ResponseWriter, r *http.Request) {
 				code:     http.StatusBadRequest,
 				logLevel: zap.DebugLevel,
 			})
+		} else if errors.Is(err, domain.ErrPasswordRequired) {
+			errorResponse(rc, ErrorInfo{
+				err:      err,
+				code:     http.StatusUnauthorized,
+				logLevel: zap.DebugLevel,
+			})
+		} else if errors.Is(err, domain.ErrLinkDisabled) || errors.Is(err, domain.ErrLinkExpired) || errors.Is(err, domain.ErrLinkExhausted) {
+			errorResponse(rc, ErrorInfo{
+				err:      err,
+				code:     http.StatusGone,
+				logLevel: zap.DebugLevel,
+			})
 		} else {
 			errorResponse(rc, ErrorInfo{
 				err:      err,
@@ -118,3 +163,29 @@ func (s *Server) GetUrl(w http.ResponseWriter, r *http.Request) {
 		data: resp,
 	})
 }
+
+// createDedicatedUrl always creates a new link, so that the password, click limit or
+// organization of one caller never applies to a link handed out to others.
+func createDedicatedUrl(ctx context.Context, urlShortener domain.IUrlShortener, req io_server.CreateUrlRequest) (string, error) {
+	hash, err := domain.HashPassword(req.Password)
+	if err != nil {
+		return "", err
+	}
+	link := database.Link{
+		Target:       req.URL,
+		Owner:        req.Owner,
+		PasswordHash: hash,
+		MaxClicks:    req.MaxClicks,
+		Tags:         req.Tags,
+		Campaign:     req.Campaign,
+		ExpiresAt:    req.ExpiresAt,
+	}
+	if req.Template != nil {
+		link.Template = &urltemplate.Template{Path: req.Template.Path}
+	}
+	created, err := urlShortener.CreateLink(ctx, link)
+	if err != nil {
+		return "", err
+	}
+	return cmp.Or(created.ShortURL, created.Code), nil
+}
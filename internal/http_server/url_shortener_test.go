@@ -11,7 +11,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/domains"
 	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/rules"
 	"github.com/Parzival-05/url-shortener/internal/service"
 
 	"github.com/stretchr/testify/assert"
@@ -38,11 +41,105 @@ func (m *UrlShortenerMock) GetFullUrl(ctx context.Context, shortenUrl string) (s
 	return arg.String(0), arg.Error(1)
 }
 
+func (m *UrlShortenerMock) Resolve(ctx context.Context, req service.ResolveRequest) (service.Resolved, error) {
+	arg := m.Called(ctx, req)
+	return arg.Get(0).(service.Resolved), arg.Error(1)
+}
+
 func (m *UrlShortenerMock) CreateUrl(ctx context.Context, fullUrl string) (string, error) {
 	arg := m.Called(ctx, fullUrl)
 	return arg.String(0), arg.Error(1)
 }
 
+func (m *UrlShortenerMock) CreateLink(ctx context.Context, link database.Link) (service.Link, error) {
+	arg := m.Called(ctx, link)
+	return arg.Get(0).(service.Link), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) GetLink(ctx context.Context, code string) (service.Link, error) {
+	arg := m.Called(ctx, code)
+	return arg.Get(0).(service.Link), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) UpdateLink(ctx context.Context, code string, link database.Link, fields []database.LinkField) (service.Link, error) {
+	arg := m.Called(ctx, code, link, fields)
+	return arg.Get(0).(service.Link), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) DeleteLink(ctx context.Context, code string) error {
+	arg := m.Called(ctx, code)
+	return arg.Error(0)
+}
+
+func (m *UrlShortenerMock) ListLinks(ctx context.Context, query service.ListLinksQuery) (service.ListLinksPage, error) {
+	arg := m.Called(ctx, query)
+	return arg.Get(0).(service.ListLinksPage), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) ImportLink(ctx context.Context, link database.Link, code string, opts service.ImportOptions) (service.ImportedLink, error) {
+	arg := m.Called(ctx, link, code, opts)
+	return arg.Get(0).(service.ImportedLink), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) LinkQRCode(ctx context.Context, code string, opts service.QRCodeOptions) (service.QRCode, error) {
+	arg := m.Called(ctx, code, opts)
+	return arg.Get(0).(service.QRCode), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) ListRules(ctx context.Context, code string) ([]rules.Rule, error) {
+	arg := m.Called(ctx, code)
+	return arg.Get(0).([]rules.Rule), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) CreateRule(ctx context.Context, code string, rule rules.Rule, index int) (rules.Rule, error) {
+	arg := m.Called(ctx, code, rule, index)
+	return arg.Get(0).(rules.Rule), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) UpdateRule(ctx context.Context, code string, rule rules.Rule, index int) (rules.Rule, error) {
+	arg := m.Called(ctx, code, rule, index)
+	return arg.Get(0).(rules.Rule), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) DeleteRule(ctx context.Context, code string, ruleID string) error {
+	arg := m.Called(ctx, code, ruleID)
+	return arg.Error(0)
+}
+
+func (m *UrlShortenerMock) EvaluateRules(ctx context.Context, code string, req service.EvaluateRulesRequest) (service.RuleEvaluation, error) {
+	arg := m.Called(ctx, code, req)
+	return arg.Get(0).(service.RuleEvaluation), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) VariantStats(ctx context.Context, code string) ([]service.VariantStats, error) {
+	arg := m.Called(ctx, code)
+	return arg.Get(0).([]service.VariantStats), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) TagLinks(ctx context.Context, codes []string, add, remove []string) (service.TagLinksResult, error) {
+	arg := m.Called(ctx, codes, add, remove)
+	return arg.Get(0).(service.TagLinksResult), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) CampaignStats(ctx context.Context) ([]database.CampaignStats, error) {
+	arg := m.Called(ctx)
+	return arg.Get(0).([]database.CampaignStats), arg.Error(1)
+}
+
+func (m *UrlShortenerMock) ClickHistory(ctx context.Context, code string, days int) (service.ClickHistory, error) {
+	arg := m.Called(ctx, code, days)
+	return arg.Get(0).(service.ClickHistory), arg.Error(1)
+}
+
+// Domain is not mocked: tests configure domains through the environment.
+func (m *UrlShortenerMock) Domain(ctx context.Context) (*domains.Domain, error) {
+	registry, err := domains.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return registry.Select(ctx)
+}
+
 func structToMapJSON(obj interface{}) (map[string]interface{}, error) {
 	var result map[string]interface{}
 	jsonBytes, err := json.Marshal(obj)
@@ -177,6 +274,53 @@ func TestServer_CreateUrl(t *testing.T) {
 	}
 }
 
+func TestServer_CreateUrl_Validation(t *testing.T) {
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: new(UrlShortenerMock),
+	}
+	for _, fullUrl := range []string{"", "not a url", "ftp://example.com"} {
+		body, _ := json.Marshal(io_server.CreateUrlRequest{URL: fullUrl})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/shorten", bytes.NewReader(body))
+		server.CreateUrl(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, fullUrl)
+		var response io_server.ValidationErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "url", response.Violations[0].Field)
+	}
+}
+
+func TestServer_CreateUrl_MaxClicks(t *testing.T) {
+	urlShortener := new(UrlShortenerMock)
+	server := Server{
+		log:          zaptest.NewLogger(t),
+		urlShortener: urlShortener,
+	}
+	urlShortener.On("CreateLink", mock.Anything, database.Link{Target: "https://example.com/once", MaxClicks: 1}).
+		Return(service.Link{Code: "once"}, nil).Once()
+
+	post := func(req io_server.CreateUrlRequest) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		server.CreateUrl(w, httptest.NewRequest("POST", "/shorten", bytes.NewReader(body)))
+		return w
+	}
+
+	// A click-limited link is always a new link, never the shared one from CreateUrl.
+	w := post(io_server.CreateUrlRequest{URL: "https://example.com/once", MaxClicks: 1})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"shorten_url":"once"`)
+	urlShortener.AssertExpectations(t)
+
+	w = post(io_server.CreateUrlRequest{URL: "https://example.com/once", MaxClicks: -1})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response io_server.ValidationErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "max_clicks", response.Violations[0].Field)
+}
+
 func TestServer_GetUrl(t *testing.T) {
 	mockLog := zaptest.NewLogger(t)
 	getRW := func(req io_server.GetUrlRequest) (w *httptest.ResponseRecorder, r *http.Request) {
@@ -0,0 +1,331 @@
+package http_server
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+// defaultDeliveriesPageSize is the number of deliveries listed when no limit is given.
+const defaultDeliveriesPageSize = 100
+
+func toWebhookResponse(sub webhooks.Subscription) io_server.WebhookResponse {
+	resp := io_server.WebhookResponse{ID: sub.ID, URL: sub.URL, CreatedAt: sub.CreatedAt}
+	for _, event := range sub.Events {
+		resp.Events = append(resp.Events, string(event))
+	}
+	return resp
+}
+
+func toDeliveryResponse(d webhooks.Delivery) io_server.DeliveryResponse {
+	resp := io_server.DeliveryResponse{
+		ID:        d.ID,
+		WebhookID: d.SubscriptionID,
+		Event: io_server.WebhookEvent{
+			ID:   d.Event.ID,
+			Type: string(d.Event.Type),
+			Time: d.Event.Time,
+			Data: d.Event.Data,
+		},
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == webhooks.StatusPending {
+		next := d.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+	return resp
+}
+
+func toDeliveriesResponse(deliveries []webhooks.Delivery) io_server.ListDeliveriesResponse {
+	resp := io_server.ListDeliveriesResponse{Deliveries: make([]io_server.DeliveryResponse, 0, len(deliveries))}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, toDeliveryResponse(d))
+	}
+	return resp
+}
+
+// @Summary		Create a webhook
+// @Description	Subscribes an endpoint to link events. Every event is posted as JSON with the headers
+// @Description	X-Webhook-Event, X-Webhook-ID (the event ID, unchanged across retries), X-Webhook-Delivery,
+// @Description	X-Webhook-Timestamp (Unix seconds) and X-Webhook-Signature: "sha256=" and the hex HMAC-SHA256
+// @Description	of the timestamp, a dot and the body, keyed with the secret. Deliveries without a 2xx response
+// @Description	are retried with exponential backoff and end up dead after the last attempt.
+// @Description	The secret is only returned here. Endpoints must not be private, loopback or link-local addresses.
+// @Tags			Webhooks
+// @Accept			json
+// @Produce		json
+// @Security		AdminToken
+// @Param			request	body		io_server.CreateWebhookRequest		true	"Webhook"
+// @Success		201		{object}	io_server.WebhookResponse			"Created webhook"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid webhook"
+// @Failure		401		{object}	map[string]string					"Unauthorized"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/webhooks [post]
+func (s *Server) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.CreateWebhookRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode request body: %s",
+		})
+		return
+	}
+	if !validate(rc, req) {
+		return
+	}
+	sub := webhooks.Subscription{URL: req.URL, Secret: req.Secret}
+	for _, event := range req.Events {
+		sub.Events = append(sub.Events, webhooks.EventType(event))
+	}
+	sub, err := s.webhooks.Subscribe(ctx, sub)
+	if err != nil {
+		webhookErrorResponse(rc, err)
+		return
+	}
+	resp := toWebhookResponse(sub)
+	resp.Secret = sub.Secret
+	okResponse(rc, ResponseInfo{
+		code: http.StatusCreated,
+		data: resp,
+	})
+}
+
+// @Summary		List webhooks
+// @Tags			Webhooks
+// @Produce		json
+// @Security		AdminToken
+// @Success		200	{object}	io_server.ListWebhooksResponse	"Webhooks"
+// @Failure		401	{object}	map[string]string				"Unauthorized"
+// @Failure		500	{object}	map[string]string				"Internal Server Error"
+// @Router			/webhooks [get]
+func (s *Server) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	subs, err := s.webhooks.Subscriptions(ctx)
+	if err != nil {
+		webhookErrorResponse(rc, err)
+		return
+	}
+	resp := io_server.ListWebhooksResponse{Webhooks: make([]io_server.WebhookResponse, 0, len(subs))}
+	for _, sub := range subs {
+		resp.Webhooks = append(resp.Webhooks, toWebhookResponse(sub))
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: resp,
+	})
+}
+
+// @Summary		Get a webhook
+// @Tags			Webhooks
+// @Produce		json
+// @Security		AdminToken
+// @Param			id	path		string						true	"Webhook ID"
+// @Success		200	{object}	io_server.WebhookResponse	"Webhook"
+// @Failure		401	{object}	map[string]string			"Unauthorized"
+// @Failure		404	{object}	map[string]string			"Webhook not found"
+// @Failure		500	{object}	map[string]string			"Internal Server Error"
+// @Router			/webhooks/{id} [get]
+func (s *Server) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	sub, err := s.webhooks.Subscription(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		webhookErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toWebhookResponse(sub),
+	})
+}
+
+// @Summary		Delete a webhook
+// @Description	Unsubscribes the endpoint and deletes its delivery log. Pending deliveries are dropped.
+// @Tags			Webhooks
+// @Security		AdminToken
+// @Param			id	path	string	true	"Webhook ID"
+// @Success		204	"Deleted"
+// @Failure		401	{object}	map[string]string	"Unauthorized"
+// @Failure		404	{object}	map[string]string	"Webhook not found"
+// @Failure		500	{object}	map[string]string	"Internal Server Error"
+// @Router			/webhooks/{id} [delete]
+func (s *Server) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	if err := s.webhooks.Unsubscribe(ctx, chi.URLParam(r, "id")); err != nil {
+		webhookErrorResponse(rc, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary		List webhook deliveries
+// @Description	Returns the delivery log of a webhook, newest first.
+// @Tags			Webhooks
+// @Produce		json
+// @Security		AdminToken
+// @Param			id		path		string							true	"Webhook ID"
+// @Param			status	query		string							false	"pending, succeeded, dead or replayed"
+// @Param			limit	query		int								false	"Number of deliveries, 100 by default"
+// @Success		200		{object}	io_server.ListDeliveriesResponse	"Deliveries"
+// @Failure		400		{object}	io_server.ValidationErrorResponse	"Bad Request - Invalid status or limit"
+// @Failure		401		{object}	map[string]string					"Unauthorized"
+// @Failure		404		{object}	map[string]string					"Webhook not found"
+// @Failure		500		{object}	map[string]string					"Internal Server Error"
+// @Router			/webhooks/{id}/deliveries [get]
+func (s *Server) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	var req io_server.ListDeliveriesRequest
+	if err := decoder.Decode(&req, r.URL.Query()); err != nil {
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+			msg:      "Failed to decode query: %s",
+		})
+		return
+	}
+	req.ID = chi.URLParam(r, "id")
+	if !validate(rc, req) {
+		return
+	}
+	limit := cmp.Or(req.Limit, defaultDeliveriesPageSize)
+	deliveries, err := s.webhooks.Deliveries(ctx, req.ID, webhooks.DeliveryStatus(req.Status), limit)
+	if err != nil {
+		webhookErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusOK,
+		data: toDeliveriesResponse(deliveries),
+	})
+}
+
+// @Summary		Replay a webhook delivery
+// @Description	Sends the event of a delivery again as a new delivery, whatever its status. A replayed dead
+// @Description	delivery becomes "replayed".
+// @Tags			Webhooks
+// @Produce		json
+// @Security		AdminToken
+// @Param			id	path		string						true	"Delivery ID"
+// @Success		202	{object}	io_server.DeliveryResponse	"New delivery"
+// @Failure		401	{object}	map[string]string			"Unauthorized"
+// @Failure		404	{object}	map[string]string			"Delivery not found"
+// @Failure		500	{object}	map[string]string			"Internal Server Error"
+// @Router			/webhooks/deliveries/{id}/replay [post]
+func (s *Server) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	delivery, err := s.webhooks.Replay(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		webhookErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusAccepted,
+		data: toDeliveryResponse(delivery),
+	})
+}
+
+// @Summary		Replay dead webhook deliveries
+// @Description	Replays every dead delivery of a webhook, e.g. after the endpoint was fixed.
+// @Tags			Webhooks
+// @Produce		json
+// @Security		AdminToken
+// @Param			id	path		string							true	"Webhook ID"
+// @Success		202	{object}	io_server.ListDeliveriesResponse	"New deliveries"
+// @Failure		401	{object}	map[string]string				"Unauthorized"
+// @Failure		404	{object}	map[string]string				"Webhook not found"
+// @Failure		500	{object}	map[string]string				"Internal Server Error"
+// @Router			/webhooks/{id}/replay [post]
+func (s *Server) ReplayDeadWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rc := RequestContext{
+		w:   w,
+		r:   r,
+		log: zap_utils.FromContext(ctx, s.log),
+	}
+	deliveries, err := s.webhooks.ReplayDead(ctx, chi.URLParam(r, "id"))
+	if err != nil {
+		webhookErrorResponse(rc, err)
+		return
+	}
+	okResponse(rc, ResponseInfo{
+		code: http.StatusAccepted,
+		data: toDeliveriesResponse(deliveries),
+	})
+}
+
+func webhookErrorResponse(rc RequestContext, err error) {
+	switch {
+	case errors.Is(err, webhooks.ErrSubscriptionNotFound), errors.Is(err, webhooks.ErrDeliveryNotFound):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusNotFound,
+			logLevel: zap.DebugLevel,
+		})
+	case errors.Is(err, webhooks.ErrInvalidSubscription):
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusBadRequest,
+			logLevel: zap.DebugLevel,
+		})
+	default:
+		errorResponse(rc, ErrorInfo{
+			err:      err,
+			code:     http.StatusInternalServerError,
+			logLevel: zap.ErrorLevel,
+			msg:      "Webhook request failed: %s",
+		})
+	}
+}
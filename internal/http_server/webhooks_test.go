@@ -0,0 +1,212 @@
+package http_server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/http_server/io_server"
+	"github.com/Parzival-05/url-shortener/internal/service"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// webhookReceiver records the events posted to it, failing the first attempt of every event,
+// or every attempt while it is broken.
+type webhookReceiver struct {
+	t      *testing.T
+	secret string
+	broken atomic.Bool
+
+	mu     sync.Mutex
+	seen   map[string]bool
+	events []webhooks.Event
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(rcv.t, err)
+	assert.True(rcv.t, webhooks.Verify(rcv.secret, r.Header.Get(webhooks.TimestampHeader), r.Header.Get(webhooks.SignatureHeader), body))
+	var event webhooks.Event
+	require.NoError(rcv.t, json.Unmarshal(body, &event))
+	assert.Equal(rcv.t, event.ID, r.Header.Get(webhooks.IDHeader))
+	assert.Equal(rcv.t, string(event.Type), r.Header.Get(webhooks.EventHeader))
+
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	if rcv.broken.Load() || !rcv.seen[event.ID] {
+		rcv.seen[event.ID] = true
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	rcv.events = append(rcv.events, event)
+}
+
+func (rcv *webhookReceiver) received() []webhooks.Event {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	return append([]webhooks.Event(nil), rcv.events...)
+}
+
+func TestServer_Webhooks(t *testing.T) {
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", "")
+	log := zaptest.NewLogger(t)
+	db := inmemory.NewInMemoryDBService()
+	dispatcher := webhooks.NewDispatcher(db.NewWebhookStore(), log, webhooks.Config{
+		MaxAttempts:  3,
+		MinBackoff:   5 * time.Millisecond,
+		MaxBackoff:   20 * time.Millisecond,
+		PollInterval: 5 * time.Millisecond,
+		// The receivers listen on loopback.
+		AllowPrivateEndpoints: true,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	server := Server{
+		log:          log,
+		urlShortener: service.NewUrlShortener(db.NewUrlRepository(), log).WithPublisher(dispatcher),
+		webhooks:     dispatcher,
+		adminToken:   "s3cret",
+	}
+	router := server.RegisterRoutes()
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		if strings.HasPrefix(target, "/webhooks") {
+			r.Header.Set("Authorization", "Bearer s3cret")
+		}
+		router.ServeHTTP(w, r)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder, data any) {
+		t.Helper()
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&struct {
+			Data any `json:"data"`
+		}{data}))
+	}
+	subscribe := func(rcv *webhookReceiver, events string) io_server.WebhookResponse {
+		t.Helper()
+		receiver := httptest.NewServer(rcv)
+		t.Cleanup(receiver.Close)
+		w := do(http.MethodPost, "/webhooks", `{"url": "`+receiver.URL+`", "secret": "`+rcv.secret+`", "events": `+events+`}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var hook io_server.WebhookResponse
+		decode(w, &hook)
+		assert.Equal(t, rcv.secret, hook.Secret)
+		return hook
+	}
+	deliveries := func(id, query string) []io_server.DeliveryResponse {
+		t.Helper()
+		w := do(http.MethodGet, "/webhooks/"+id+"/deliveries"+query, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp io_server.ListDeliveriesResponse
+		decode(w, &resp)
+		return resp.Deliveries
+	}
+
+	flaky := &webhookReceiver{t: t, secret: "flaky-receiver-secret", seen: map[string]bool{}}
+	flakyHook := subscribe(flaky, `["link.created", "link.clicked"]`)
+	down := &webhookReceiver{t: t, secret: "down-receiver-secret", seen: map[string]bool{}}
+	down.broken.Store(true)
+	downHook := subscribe(down, `["link.created"]`)
+
+	w := do(http.MethodPost, "/shorten", `{"url": "https://example.com"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created io_server.CreateUrlResponse
+	decode(w, &created)
+	code := path.Base(created.ShortenURL)
+	w = do(http.MethodGet, "/"+code, "")
+	require.Equal(t, http.StatusFound, w.Code)
+
+	// Every event is retried once and then delivered.
+	require.Eventually(t, func() bool { return len(flaky.received()) == 2 }, 5*time.Second, 5*time.Millisecond)
+	types := map[webhooks.EventType]map[string]any{}
+	for _, event := range flaky.received() {
+		var data map[string]any
+		require.NoError(t, json.Unmarshal(event.Data, &data))
+		types[event.Type] = data
+	}
+	assert.Equal(t, code, types[webhooks.EventLinkCreated]["code"])
+	assert.Equal(t, created.ShortenURL, types[webhooks.EventLinkCreated]["short_url"])
+	assert.Equal(t, "https://example.com", types[webhooks.EventLinkClicked]["target"])
+	require.Eventually(t, func() bool {
+		succeeded := deliveries(flakyHook.ID, "?status=succeeded")
+		return len(succeeded) == 2 && succeeded[0].Attempts == 2 && succeeded[1].Attempts == 2
+	}, 5*time.Second, 5*time.Millisecond)
+
+	// A delivery that fails every attempt is dead-lettered until it is replayed.
+	require.Eventually(t, func() bool { return len(deliveries(downHook.ID, "?status=dead")) == 1 }, 5*time.Second, 5*time.Millisecond)
+	dead := deliveries(downHook.ID, "")[0]
+	assert.Equal(t, 3, dead.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, dead.LastStatusCode)
+	assert.Nil(t, dead.NextAttemptAt)
+	assert.Equal(t, webhooks.EventLinkCreated, webhooks.EventType(dead.Event.Type))
+
+	down.broken.Store(false)
+	w = do(http.MethodPost, "/webhooks/"+downHook.ID+"/replay", "")
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	var replayed io_server.ListDeliveriesResponse
+	decode(w, &replayed)
+	require.Len(t, replayed.Deliveries, 1)
+	assert.Equal(t, dead.Event.ID, replayed.Deliveries[0].Event.ID, "replays keep the event ID for deduplication")
+	require.Eventually(t, func() bool { return len(down.received()) == 1 }, 5*time.Second, 5*time.Millisecond)
+	history := deliveries(downHook.ID, "")
+	require.Len(t, history, 2)
+	assert.Equal(t, "replayed", history[1].Status)
+	w = do(http.MethodPost, "/webhooks/"+downHook.ID+"/replay", "")
+	decode(w, &replayed)
+	assert.Empty(t, replayed.Deliveries, "dead deliveries are replayed once")
+
+	w = do(http.MethodPost, "/webhooks/deliveries/"+dead.ID+"/replay", "")
+	assert.Equal(t, http.StatusAccepted, w.Code, "single deliveries are replayed on demand")
+	w = do(http.MethodPost, "/webhooks/deliveries/whd_missing/replay", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = do(http.MethodGet, "/webhooks", "")
+	var list io_server.ListWebhooksResponse
+	decode(w, &list)
+	require.Len(t, list.Webhooks, 2)
+	assert.Empty(t, list.Webhooks[0].Secret, "secrets are only returned on creation")
+	assert.Equal(t, []string{"link.created", "link.clicked"}, list.Webhooks[0].Events)
+
+	w = do(http.MethodPost, "/webhooks", `{"url": "ftp://example.com", "secret": "short", "events": ["link.opened"]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var verr io_server.ValidationErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&verr))
+	assert.Len(t, verr.Violations, 3)
+	w = do(http.MethodGet, "/webhooks/"+flakyHook.ID+"/deliveries?status=lost", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodDelete, "/webhooks/"+flakyHook.ID, "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = do(http.MethodGet, "/webhooks/"+flakyHook.ID, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do(http.MethodGet, "/webhooks/"+flakyHook.ID+"/deliveries", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "webhooks are admin routes")
+}
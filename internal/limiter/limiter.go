@@ -0,0 +1,246 @@
+// Package limiter sheds load before the service degrades as a whole: it bounds the number of
+// requests served at once by a limit that adapts to their latency, growing it additively while
+// requests are fast and cutting it multiplicatively (AIMD) once they slow down or fail for
+// overload. Redirects get the whole limit, other requests only a share of it, so under load
+// those are shed first.
+package limiter
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Priority ranks requests for shedding.
+type Priority int
+
+const (
+	// Low are bulk and admin requests, shed first. Their latency is not sampled, since it says
+	// more about the amount of work than about load.
+	Low Priority = iota
+	// Normal are creates, updates and reads through the API.
+	Normal
+	// Critical are redirects, shed last.
+	Critical
+)
+
+func (p Priority) String() string {
+	switch p {
+	case Low:
+		return "low"
+	case Critical:
+		return "critical"
+	default:
+		return "normal"
+	}
+}
+
+// Config tunes a Limiter. Zero fields take the value of DefaultConfig.
+type Config struct {
+	// InitialLimit is the limit the limiter starts with, which stays within MinLimit and MaxLimit.
+	InitialLimit int
+	MinLimit     int
+	MaxLimit     int
+	// LatencyTarget is the latency beyond which a request counts as a sign of overload.
+	LatencyTarget time.Duration
+	// Backoff is the factor the limit is multiplied with on overload, between 0 and 1.
+	Backoff float64
+	// NormalShare and LowShare are the shares of the limit requests of those priorities may
+	// take. Critical requests may take all of it.
+	NormalShare float64
+	LowShare    float64
+	// RetryAfter is the time shed requests are told to wait before trying again.
+	RetryAfter time.Duration
+}
+
+var DefaultConfig = Config{
+	InitialLimit:  100,
+	MinLimit:      10,
+	MaxLimit:      1000,
+	LatencyTarget: 250 * time.Millisecond,
+	Backoff:       0.9,
+	NormalShare:   0.8,
+	LowShare:      0.5,
+	RetryAfter:    time.Second,
+}
+
+// ConfigFromEnv reads the config from LIMITER_INITIAL_LIMIT, LIMITER_MIN_LIMIT,
+// LIMITER_MAX_LIMIT, LIMITER_LATENCY_TARGET and LIMITER_RETRY_AFTER, using DefaultConfig for
+// unset or malformed values.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig
+	envInt := func(key string, v *int) {
+		if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+			*v = n
+		}
+	}
+	envDuration := func(key string, v *time.Duration) {
+		if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+			*v = d
+		}
+	}
+	envInt("LIMITER_INITIAL_LIMIT", &cfg.InitialLimit)
+	envInt("LIMITER_MIN_LIMIT", &cfg.MinLimit)
+	envInt("LIMITER_MAX_LIMIT", &cfg.MaxLimit)
+	envDuration("LIMITER_LATENCY_TARGET", &cfg.LatencyTarget)
+	envDuration("LIMITER_RETRY_AFTER", &cfg.RetryAfter)
+	return cfg
+}
+
+// FromEnv returns a limiter configured by ConfigFromEnv, nil if LIMITER_ENABLED is false.
+func FromEnv() *Limiter {
+	if enabled, err := strconv.ParseBool(os.Getenv("LIMITER_ENABLED")); err == nil && !enabled {
+		return nil
+	}
+	return New(ConfigFromEnv())
+}
+
+func (c Config) withDefaults() Config {
+	def := DefaultConfig
+	if c.MinLimit <= 0 {
+		c.MinLimit = def.MinLimit
+	}
+	if c.MaxLimit <= 0 {
+		c.MaxLimit = max(def.MaxLimit, c.MinLimit)
+	}
+	if c.InitialLimit <= 0 {
+		c.InitialLimit = def.InitialLimit
+	}
+	c.InitialLimit = min(max(c.InitialLimit, c.MinLimit), c.MaxLimit)
+	if c.LatencyTarget <= 0 {
+		c.LatencyTarget = def.LatencyTarget
+	}
+	if c.Backoff <= 0 || c.Backoff >= 1 {
+		c.Backoff = def.Backoff
+	}
+	if c.NormalShare <= 0 || c.NormalShare > 1 {
+		c.NormalShare = def.NormalShare
+	}
+	if c.LowShare <= 0 || c.LowShare > 1 {
+		c.LowShare = def.LowShare
+	}
+	if c.RetryAfter <= 0 {
+		c.RetryAfter = def.RetryAfter
+	}
+	return c
+}
+
+// Clock tells the time, a fake one in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Limiter admits requests up to its adaptive limit. It is safe for concurrent use.
+type Limiter struct {
+	cfg   Config
+	clock Clock
+
+	mu       sync.Mutex
+	limit    float64
+	inflight int
+	// decreasedAt is when the limit was last cut. Requests admitted before then do not cut it
+	// again, so that one burst of slow requests cuts it once rather than once per request.
+	decreasedAt time.Time
+}
+
+func New(cfg Config) *Limiter {
+	return NewWithClock(cfg, systemClock{})
+}
+
+// NewWithClock returns a limiter that measures latencies with clock.
+func NewWithClock(cfg Config, clock Clock) *Limiter {
+	cfg = cfg.withDefaults()
+	return &Limiter{cfg: cfg, clock: clock, limit: float64(cfg.InitialLimit)}
+}
+
+// RetryAfter is the time shed requests are told to wait before trying again.
+func (l *Limiter) RetryAfter() time.Duration {
+	return l.cfg.RetryAfter
+}
+
+// Limit returns the current limit.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// Inflight returns the number of requests admitted and not done yet.
+func (l *Limiter) Inflight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inflight
+}
+
+// Acquire admits a request of priority p, unless the requests in flight take up its share of
+// the limit. The request must be ended with Done or Dropped on the token.
+func (l *Limiter) Acquire(p Priority) (*Token, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if float64(l.inflight) >= l.share(p) {
+		return nil, false
+	}
+	l.inflight++
+	return &Token{limiter: l, priority: p, start: l.clock.Now()}, true
+}
+
+// share returns the number of requests in flight up to which requests of priority p are admitted.
+// Must be called with mu held.
+func (l *Limiter) share(p Priority) float64 {
+	switch p {
+	case Critical:
+		return math.Floor(l.limit)
+	case Low:
+		return max(1, math.Floor(l.limit*l.cfg.LowShare))
+	default:
+		return max(1, math.Floor(l.limit*l.cfg.NormalShare))
+	}
+}
+
+// release ends a request admitted at start. A request that overloaded the service cuts the
+// limit, one that was served in time grows it while the limit is in use.
+func (l *Limiter) release(t *Token, overloaded bool) {
+	now := l.clock.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	inflight := l.inflight
+	l.inflight--
+	if t.priority == Low && !overloaded {
+		return
+	}
+	switch {
+	case overloaded || now.Sub(t.start) > l.cfg.LatencyTarget:
+		if t.start.Before(l.decreasedAt) {
+			return
+		}
+		l.limit = max(float64(l.cfg.MinLimit), l.limit*l.cfg.Backoff)
+		l.decreasedAt = now
+	case float64(inflight)*2 >= l.limit:
+		// A limit that is far from used says nothing about whether a higher one would hold up.
+		l.limit = min(float64(l.cfg.MaxLimit), l.limit+1/l.limit)
+	}
+}
+
+// Token is a request admitted by a Limiter.
+type Token struct {
+	limiter  *Limiter
+	priority Priority
+	start    time.Time
+	once     sync.Once
+}
+
+// Done ends the request, sampling its latency.
+func (t *Token) Done() {
+	t.once.Do(func() { t.limiter.release(t, false) })
+}
+
+// Dropped ends a request that failed for overload, e.g. timed out, cutting the limit.
+func (t *Token) Dropped() {
+	t.once.Do(func() { t.limiter.release(t, true) })
+}
@@ -0,0 +1,119 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newLimiter(cfg Config) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	return NewWithClock(cfg, clock), clock
+}
+
+// serve admits n requests of priority p at once and ends them after latency.
+func serve(t *testing.T, l *Limiter, clock *fakeClock, p Priority, n int, latency time.Duration) {
+	t.Helper()
+	tokens := make([]*Token, 0, n)
+	for range n {
+		token, ok := l.Acquire(p)
+		require.True(t, ok)
+		tokens = append(tokens, token)
+	}
+	clock.Advance(latency)
+	for _, token := range tokens {
+		token.Done()
+	}
+}
+
+func TestLimiter_Priorities(t *testing.T) {
+	l, _ := newLimiter(Config{InitialLimit: 10, MinLimit: 1, NormalShare: 0.8, LowShare: 0.5})
+
+	var tokens []*Token
+	acquire := func(p Priority) bool {
+		token, ok := l.Acquire(p)
+		if ok {
+			tokens = append(tokens, token)
+		}
+		return ok
+	}
+	for range 5 {
+		require.True(t, acquire(Low))
+	}
+	assert.False(t, acquire(Low), "low priority requests take half of the limit")
+	for range 3 {
+		require.True(t, acquire(Normal))
+	}
+	assert.False(t, acquire(Normal), "normal priority requests take 80% of the limit")
+	require.True(t, acquire(Critical))
+	require.True(t, acquire(Critical))
+	assert.False(t, acquire(Critical), "the limit is reached")
+	assert.Equal(t, 10, l.Inflight())
+
+	tokens[0].Done()
+	tokens[0].Done()
+	assert.Equal(t, 9, l.Inflight(), "ending a request twice has no effect")
+	assert.True(t, acquire(Critical))
+}
+
+func TestLimiter_AIMD(t *testing.T) {
+	l, clock := newLimiter(Config{InitialLimit: 10, MinLimit: 5, MaxLimit: 12, LatencyTarget: 100 * time.Millisecond, Backoff: 0.5})
+
+	// fast requests using the limit grow it, by 1/limit for every request that ends while at
+	// least half of it is in use
+	serve(t, l, clock, Critical, 10, 10*time.Millisecond)
+	serve(t, l, clock, Critical, 10, 10*time.Millisecond)
+	assert.Equal(t, 10, l.Limit())
+	serve(t, l, clock, Critical, 10, 10*time.Millisecond)
+	assert.Equal(t, 11, l.Limit())
+	for range 10 {
+		serve(t, l, clock, Critical, 11, 10*time.Millisecond)
+	}
+	assert.Equal(t, 12, l.Limit(), "the limit stays below MaxLimit")
+
+	// a burst of slow requests cuts the limit once
+	serve(t, l, clock, Critical, 12, 500*time.Millisecond)
+	assert.Equal(t, 6, l.Limit())
+	// the next one again, but not below MinLimit
+	serve(t, l, clock, Critical, 6, 500*time.Millisecond)
+	assert.Equal(t, 5, l.Limit())
+}
+
+func TestLimiter_IdleLimitDoesNotGrow(t *testing.T) {
+	l, clock := newLimiter(Config{InitialLimit: 20, LatencyTarget: 100 * time.Millisecond})
+	for range 100 {
+		serve(t, l, clock, Critical, 1, time.Millisecond)
+	}
+	assert.Equal(t, 20, l.Limit())
+}
+
+func TestLimiter_Dropped(t *testing.T) {
+	l, clock := newLimiter(Config{InitialLimit: 10, MinLimit: 1, Backoff: 0.5})
+	token, ok := l.Acquire(Normal)
+	require.True(t, ok)
+	clock.Advance(time.Millisecond)
+	token.Dropped()
+	assert.Equal(t, 5, l.Limit(), "requests failing for overload cut the limit however fast they are")
+	assert.Zero(t, l.Inflight())
+}
+
+func TestLimiter_LowPriorityLatencyIsNotSampled(t *testing.T) {
+	l, clock := newLimiter(Config{InitialLimit: 10, LatencyTarget: 100 * time.Millisecond})
+	serve(t, l, clock, Low, 5, time.Minute)
+	assert.Equal(t, 10, l.Limit())
+}
+
+func TestConfig_Defaults(t *testing.T) {
+	cfg := Config{InitialLimit: 5000, MaxLimit: 50}.withDefaults()
+	assert.Equal(t, 50, cfg.InitialLimit)
+	assert.Equal(t, DefaultConfig.MinLimit, cfg.MinLimit)
+	assert.Equal(t, DefaultConfig.Backoff, cfg.Backoff)
+}
@@ -0,0 +1,52 @@
+package zap_utils
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingPolicy describes how high-volume access logs (redirects/resolves) are sampled:
+// the first First entries in every Tick are logged, then every Thereafter-th one.
+// Thereafter <= 0 disables sampling.
+type SamplingPolicy struct {
+	Tick       time.Duration
+	First      int
+	Thereafter int
+}
+
+var DefaultSamplingPolicy = SamplingPolicy{
+	Tick:       time.Second,
+	First:      100,
+	Thereafter: 100,
+}
+
+// SamplingPolicyFromEnv reads the policy from LOG_REDIRECT_SAMPLE_TICK,
+// LOG_REDIRECT_SAMPLE_FIRST and LOG_REDIRECT_SAMPLE_THEREAFTER, using
+// DefaultSamplingPolicy for unset or malformed values.
+func SamplingPolicyFromEnv() SamplingPolicy {
+	policy := DefaultSamplingPolicy
+	if v, err := time.ParseDuration(os.Getenv("LOG_REDIRECT_SAMPLE_TICK")); err == nil && v > 0 {
+		policy.Tick = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LOG_REDIRECT_SAMPLE_FIRST")); err == nil && v >= 0 {
+		policy.First = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LOG_REDIRECT_SAMPLE_THEREAFTER")); err == nil {
+		policy.Thereafter = v
+	}
+	return policy
+}
+
+// Apply returns a logger that samples its entries according to the policy.
+func (p SamplingPolicy) Apply(log *zap.Logger) *zap.Logger {
+	if p.Thereafter <= 0 {
+		return log
+	}
+	return log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, p.Tick, p.First, p.Thereafter)
+	}))
+}
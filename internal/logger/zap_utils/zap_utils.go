@@ -1,9 +1,30 @@
 package zap_utils
 
 import (
+	"context"
+
 	"go.uber.org/zap"
 )
 
+type loggerCtxKey struct{}
+
 func Err(err error) zap.Field {
 	return zap.String("error", err.Error())
 }
+
+// ToContext returns a copy of ctx carrying the request-scoped logger.
+func ToContext(ctx context.Context, log *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, log)
+}
+
+// FromContext returns the request-scoped logger stored in ctx.
+// If there is none, fallback is returned (or a no-op logger when fallback is nil).
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if log, ok := ctx.Value(loggerCtxKey{}).(*zap.Logger); ok && log != nil {
+		return log
+	}
+	if fallback == nil {
+		return zap.NewNop()
+	}
+	return fallback
+}
@@ -0,0 +1,245 @@
+// Package outbox relays the change log of link mutations to sinks, such as streaming API
+// clients or a local file.
+//
+// Storages write every link mutation to their change log in the same transaction as the
+// mutation itself (the transactional outbox). A Relay follows the log and hands the changes
+// to every sink in sequence order. Sinks resume by starting after the last sequence number
+// they have seen.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+
+	"go.uber.org/zap"
+)
+
+// Record is a change of the log together with the public code of its link.
+type Record struct {
+	database.Change
+	// Code and ShortURL are empty if the domain of the link is no longer configured.
+	Code     string
+	ShortURL string
+}
+
+// LinkSnapshot is the state of the link in the JSON form of a record.
+type LinkSnapshot struct {
+	ID                int64      `json:"id"`
+	Domain            string     `json:"domain,omitempty"`
+	Target            string     `json:"target"`
+	Owner             string     `json:"owner,omitempty"`
+	Tags              []string   `json:"tags,omitempty"`
+	Disabled          bool       `json:"disabled"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	PasswordProtected bool       `json:"password_protected"`
+	MaxClicks         int64      `json:"max_clicks,omitempty"`
+	RemainingClicks   int64      `json:"remaining_clicks,omitempty"`
+	Rules             int        `json:"rules,omitempty"`
+	Variants          int        `json:"variants,omitempty"`
+	Templated         bool       `json:"templated,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// MarshalJSON encodes the record as one line of the NDJSON stream.
+func (r Record) MarshalJSON() ([]byte, error) {
+	link := r.Link
+	fields := make([]string, 0, len(r.Fields))
+	for _, field := range r.Fields {
+		fields = append(fields, string(field))
+	}
+	return json.Marshal(struct {
+		Seq      int64               `json:"seq"`
+		Type     database.ChangeType `json:"type"`
+		Time     time.Time           `json:"time"`
+		Code     string              `json:"code,omitempty"`
+		ShortURL string              `json:"short_url,omitempty"`
+		Fields   []string            `json:"fields,omitempty"`
+		Link     LinkSnapshot        `json:"link"`
+	}{
+		Seq:      r.Seq,
+		Type:     r.Type,
+		Time:     r.Time,
+		Code:     r.Code,
+		ShortURL: r.ShortURL,
+		Fields:   fields,
+		Link: LinkSnapshot{
+			ID:                link.ID,
+			Domain:            link.Domain,
+			Target:            link.Target,
+			Owner:             link.Owner,
+			Tags:              link.Tags,
+			Disabled:          link.Disabled,
+			ExpiresAt:         link.ExpiresAt,
+			PasswordProtected: link.PasswordHash != "",
+			MaxClicks:         link.MaxClicks,
+			RemainingClicks:   link.RemainingClicks,
+			Rules:             len(link.Rules),
+			Variants:          len(link.Variants),
+			Templated:         link.Template != nil,
+			CreatedAt:         link.CreatedAt,
+			UpdatedAt:         link.UpdatedAt,
+		},
+	})
+}
+
+// Sink receives the records of a stream in sequence order.
+type Sink interface {
+	// Publish hands over a record. An error ends the stream.
+	Publish(ctx context.Context, record Record) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, record Record) error
+
+func (f SinkFunc) Publish(ctx context.Context, record Record) error {
+	return f(ctx, record)
+}
+
+// Config tunes a Relay. Zero fields take the value of DefaultConfig.
+type Config struct {
+	// PollInterval is how often the change log is checked for new changes.
+	PollInterval time.Duration
+	// BatchSize is the number of changes read from the log at once.
+	BatchSize int
+}
+
+var DefaultConfig = Config{
+	PollInterval: 500 * time.Millisecond,
+	BatchSize:    500,
+}
+
+// ConfigFromEnv reads the config from OUTBOX_POLL_INTERVAL and OUTBOX_BATCH_SIZE,
+// using DefaultConfig for unset or malformed values.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig
+	if d, err := time.ParseDuration(os.Getenv("OUTBOX_POLL_INTERVAL")); err == nil && d > 0 {
+		cfg.PollInterval = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("OUTBOX_BATCH_SIZE")); err == nil && n > 0 {
+		cfg.BatchSize = n
+	}
+	return cfg
+}
+
+// Relay follows a change log and streams it to sinks.
+type Relay struct {
+	changes  database.IChangeLog
+	registry *domains.Registry
+	log      *zap.Logger
+	cfg      Config
+
+	mu   sync.Mutex
+	last int64
+	// advanced is closed and replaced whenever the log gets new changes.
+	advanced chan struct{}
+}
+
+// NewRelay returns a relay of the change log. Codes of links are encoded with the registry, if any.
+func NewRelay(changes database.IChangeLog, registry *domains.Registry, log *zap.Logger, cfg Config) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultConfig.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultConfig.BatchSize
+	}
+	return &Relay{
+		changes:  changes,
+		registry: registry,
+		log:      log,
+		cfg:      cfg,
+		advanced: make(chan struct{}),
+	}
+}
+
+// Run polls the change log and wakes up the streams when it advances, until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		seq, err := r.changes.LastSeq(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.log.Error("Failed to poll the change log", zap.Error(err))
+			}
+		} else {
+			r.mu.Lock()
+			if seq > r.last {
+				r.last = seq
+				close(r.advanced)
+				r.advanced = make(chan struct{})
+			}
+			r.mu.Unlock()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// wait returns a channel closed on the next advance of the log.
+func (r *Relay) wait() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.advanced
+}
+
+// Stream publishes every change after afterSeq to the sink in order, then follows the log
+// until ctx is done or the sink fails. It returns the error of the sink or ctx.
+func (r *Relay) Stream(ctx context.Context, afterSeq int64, sink Sink) error {
+	cursor := afterSeq
+	for {
+		// Taken before reading, so that an advance during the read is not missed.
+		advanced := r.wait()
+		changes, err := r.changes.Changes(ctx, cursor, r.cfg.BatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		for _, change := range changes {
+			if err := sink.Publish(ctx, r.record(change)); err != nil {
+				return err
+			}
+			cursor = change.Seq
+		}
+		if len(changes) == r.cfg.BatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-advanced:
+		}
+	}
+}
+
+func (r *Relay) record(change database.Change) Record {
+	record := Record{Change: change}
+	if r.registry == nil {
+		return record
+	}
+	d, err := r.registry.Get(change.Link.Domain)
+	if err != nil {
+		if !errors.Is(err, domains.ErrUnknownDomain) {
+			r.log.Warn("Failed to get the domain of a change", zap.Int64("seq", change.Seq), zap.Error(err))
+		}
+		return record
+	}
+	if code, err := d.Encode(change.Link.ID); err == nil {
+		record.Code = code
+		record.ShortURL = d.ShortURL(code)
+	}
+	return record
+}
@@ -0,0 +1,159 @@
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// collector is a sink that records what it receives.
+type collector struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func (c *collector) Publish(ctx context.Context, record Record) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = append(c.records, record)
+	return nil
+}
+
+func (c *collector) seqs() []int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var seqs []int64
+	for _, r := range c.records {
+		seqs = append(seqs, r.Seq)
+	}
+	return seqs
+}
+
+func TestRelay_Stream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	db := inmemory.NewInMemoryDBService()
+	repo := db.NewUrlRepository()
+	relay := NewRelay(db.NewChangeLog(), nil, zaptest.NewLogger(t), Config{PollInterval: 5 * time.Millisecond, BatchSize: 2})
+	go relay.Run(ctx)
+
+	link := database.Link{Target: "https://example.com/a"}
+	require.NoError(t, repo.CreateLink(ctx, &link))
+	link.Target = "https://example.com/b"
+	_, err := repo.UpdateLink(ctx, link, []database.LinkField{database.LinkFieldTarget})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateLink(ctx, &database.Link{Target: "https://example.com/c"}))
+
+	// a stream from the start reads the backlog in batches and then follows the log
+	sink := &collector{}
+	streamCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- relay.Stream(streamCtx, 0, sink) }()
+	require.Eventually(t, func() bool { return len(sink.seqs()) == 3 }, 5*time.Second, time.Millisecond)
+
+	require.NoError(t, repo.DeleteLink(ctx, link.ID))
+	require.Eventually(t, func() bool { return len(sink.seqs()) == 4 }, 5*time.Second, time.Millisecond)
+	stop()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	assert.Equal(t, []int64{1, 2, 3, 4}, sink.seqs())
+	records := sink.records
+	assert.Equal(t, database.ChangeCreated, records[0].Type)
+	assert.Equal(t, database.ChangeUpdated, records[1].Type)
+	assert.Equal(t, []database.LinkField{database.LinkFieldTarget}, records[1].Fields)
+	assert.Equal(t, "https://example.com/b", records[1].Link.Target)
+	assert.Equal(t, database.ChangeDeleted, records[3].Type)
+	assert.Equal(t, link.ID, records[3].Link.ID)
+
+	// a resumed stream starts after the given sequence number
+	resumed := &collector{}
+	streamCtx, stop = context.WithCancel(ctx)
+	go func() { done <- relay.Stream(streamCtx, 2, resumed) }()
+	require.Eventually(t, func() bool { return len(resumed.seqs()) == 2 }, 5*time.Second, time.Millisecond)
+	stop()
+	<-done
+	assert.Equal(t, []int64{3, 4}, resumed.seqs())
+
+	// sink errors end the stream
+	errSink := errors.New("sink failed")
+	err = relay.Stream(ctx, 0, SinkFunc(func(context.Context, Record) error { return errSink }))
+	assert.ErrorIs(t, err, errSink)
+}
+
+func TestRecord_MarshalJSON(t *testing.T) {
+	expires := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	raw, err := json.Marshal(Record{
+		Change: database.Change{
+			Seq:    7,
+			Type:   database.ChangeUpdated,
+			Fields: []database.LinkField{database.LinkFieldExpiresAt},
+			Link:   database.Link{ID: 3, Target: "https://example.com", PasswordHash: "hash", ExpiresAt: &expires},
+		},
+		Code:     "abc",
+		ShortURL: "https://sho.rt/abc",
+	})
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(raw, &got))
+	assert.EqualValues(t, 7, got["seq"])
+	assert.Equal(t, "updated", got["type"])
+	assert.Equal(t, "https://sho.rt/abc", got["short_url"])
+	assert.Equal(t, []any{"expires_at"}, got["fields"])
+	link := got["link"].(map[string]any)
+	assert.Equal(t, true, link["password_protected"])
+	assert.NotContains(t, link, "password_hash")
+	assert.Equal(t, "2030-01-01T00:00:00Z", link["expires_at"])
+}
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "changes.ndjson")
+
+	sink, last, err := OpenFileSink(path)
+	require.NoError(t, err)
+	assert.Zero(t, last)
+	for seq := int64(1); seq <= 3; seq++ {
+		require.NoError(t, sink.Publish(ctx, Record{Change: database.Change{Seq: seq, Type: database.ChangeCreated}}))
+	}
+	require.NoError(t, sink.Close())
+
+	// a record cut short by a crash is dropped on reopen
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":4,"type":"crea`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	sink, last, err = OpenFileSink(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), last)
+	require.NoError(t, sink.Publish(ctx, Record{Change: database.Change{Seq: 4, Type: database.ChangeDeleted}}))
+	require.NoError(t, sink.Close())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var seqs []int64
+	lines := bufio.NewScanner(bytes.NewReader(raw))
+	for lines.Scan() {
+		var record struct {
+			Seq int64 `json:"seq"`
+		}
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &record), lines.Text())
+		seqs = append(seqs, record.Seq)
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4}, seqs)
+}
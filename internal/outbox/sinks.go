@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// NDJSONSink writes records as newline-delimited JSON. Writers that are http.Flushers are flushed
+// after every record, so that streaming HTTP clients get it right away.
+type NDJSONSink struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+func NewNDJSONSink(w io.Writer) *NDJSONSink {
+	return &NDJSONSink{w: w, enc: json.NewEncoder(w)}
+}
+
+func (s *NDJSONSink) Publish(ctx context.Context, record Record) error {
+	if err := s.enc.Encode(record); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// maxRecordSize bounds the size of a record when the last one of a file is looked up.
+const maxRecordSize = 1 << 20
+
+// FileSink appends records to a local NDJSON file.
+type FileSink struct {
+	file *os.File
+	*NDJSONSink
+}
+
+// OpenFileSink opens the NDJSON file at path for appending, creating it if needed, and returns
+// the sequence number of its last record to resume the stream after. A record cut short by a
+// crash is removed.
+func OpenFileSink(path string) (*FileSink, int64, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, 0, err
+	}
+	seq, err := resumeFile(file)
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("resume %s: %w", path, err)
+	}
+	return &FileSink{file: file, NDJSONSink: NewNDJSONSink(file)}, seq, nil
+}
+
+// resumeFile truncates a trailing partial line, positions the file at its end and
+// returns the sequence number of the last record.
+func resumeFile(file *os.File) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	offset := max(size-maxRecordSize, 0)
+	tail := make([]byte, size-offset)
+	if _, err := file.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return 0, err
+	}
+	end := bytes.LastIndexByte(tail, '\n') + 1
+	if end < len(tail) {
+		if err := file.Truncate(offset + int64(end)); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := file.Seek(offset+int64(end), io.SeekStart); err != nil {
+		return 0, err
+	}
+	lines := bytes.TrimRight(tail[:end], "\n")
+	if len(lines) == 0 {
+		return 0, nil
+	}
+	var last struct {
+		Seq int64 `json:"seq"`
+	}
+	if err := json.Unmarshal(lines[bytes.LastIndexByte(lines, '\n')+1:], &last); err != nil {
+		return 0, err
+	}
+	return last.Seq, nil
+}
+
+// Close flushes the file to disk and closes it.
+func (s *FileSink) Close() error {
+	if err := s.file.Sync(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,241 @@
+// Package passthrough carries the query string and trailing path of a short link request
+// over to its destination.
+package passthrough
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidOptions = errors.New("invalid passthrough options")
+	// ErrInvalidPath is returned for trailing paths that can't be forwarded, e.g. with dot segments.
+	ErrInvalidPath = errors.New("invalid trailing path")
+)
+
+// QueryPolicy controls whether incoming query parameters are merged into the destination
+// and what happens when the destination already has a parameter of the same name.
+type QueryPolicy string
+
+const (
+	// QueryOff doesn't forward the query.
+	QueryOff QueryPolicy = ""
+	// QueryKeep keeps the value of the destination.
+	QueryKeep QueryPolicy = "keep"
+	// QueryOverride replaces the value of the destination with the incoming one.
+	QueryOverride QueryPolicy = "override"
+	// QueryDrop removes the parameter altogether.
+	QueryDrop QueryPolicy = "drop"
+)
+
+// Valid reports whether p is a known policy.
+func (p QueryPolicy) Valid() bool {
+	switch p {
+	case QueryOff, QueryKeep, QueryOverride, QueryDrop:
+		return true
+	}
+	return false
+}
+
+// UTM are static campaign parameters added to the destination, replacing any it has.
+type UTM struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+	Term     string `json:"term,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+// params returns the set UTM parameters in their conventional order.
+func (u UTM) params() []param {
+	var params []param
+	for _, p := range []param{
+		{"utm_source", u.Source},
+		{"utm_medium", u.Medium},
+		{"utm_campaign", u.Campaign},
+		{"utm_term", u.Term},
+		{"utm_content", u.Content},
+	} {
+		if p.value != "" {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// MaxUTMLength bounds each UTM value.
+const MaxUTMLength = 256
+
+// Options are the passthrough settings of a link. The zero value forwards nothing.
+type Options struct {
+	Query QueryPolicy `json:"query,omitempty"`
+	// Path appends the trailing path of the request, /code/extra/path, to the destination path.
+	Path bool `json:"path,omitempty"`
+	UTM  UTM  `json:"utm,omitempty"`
+}
+
+// IsZero reports whether o leaves destinations unchanged.
+func (o Options) IsZero() bool {
+	return o == Options{}
+}
+
+func (o Options) Validate() error {
+	if !o.Query.Valid() {
+		return fmt.Errorf("%w: unknown query policy %q", ErrInvalidOptions, o.Query)
+	}
+	for _, p := range o.UTM.params() {
+		if len(p.value) > MaxUTMLength {
+			return fmt.Errorf("%w: %s is longer than %d bytes", ErrInvalidOptions, p.key, MaxUTMLength)
+		}
+	}
+	return nil
+}
+
+// CheckPath reports whether the escaped trailing path can be forwarded with o. The empty path always can.
+func (o Options) CheckPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if !o.Path {
+		return fmt.Errorf("%w: the link doesn't forward paths", ErrInvalidPath)
+	}
+	_, err := segments(path)
+	return err
+}
+
+// segments splits an escaped path into its segments, rejecting ones that would
+// move out of the destination path once it is cleaned.
+func segments(path string) ([]string, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for _, part := range parts {
+		s, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPath, err)
+		}
+		if s == "." || s == ".." {
+			return nil, fmt.Errorf("%w: dot segments are not allowed", ErrInvalidPath)
+		}
+	}
+	return parts, nil
+}
+
+type param struct {
+	key, value string
+}
+
+// Apply builds the destination for a request with the escaped trailing path and query.
+// Parameter order of the destination is preserved and its fragment is kept.
+func (o Options) Apply(target, path string, query url.Values) (string, error) {
+	if o.IsZero() {
+		return target, nil
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+	if o.Path && strings.Trim(path, "/") != "" {
+		parts, err := segments(path)
+		if err != nil {
+			return "", err
+		}
+		if strings.HasSuffix(path, "/") {
+			parts[len(parts)-1] += "/"
+		}
+		u = u.JoinPath(parts...)
+	}
+
+	params, err := parseQuery(u.RawQuery)
+	if err != nil {
+		return "", err
+	}
+	changed := false
+	for _, p := range o.UTM.params() {
+		params = set(params, p)
+		changed = true
+	}
+	if o.Query != QueryOff && len(query) > 0 {
+		params = merge(params, query, o.Query)
+		changed = true
+	}
+	if changed {
+		u.RawQuery = encodeQuery(params)
+		u.ForceQuery = false
+	}
+	return u.String(), nil
+}
+
+// parseQuery is url.ParseQuery keeping the order of the parameters.
+func parseQuery(raw string) ([]param, error) {
+	var params []param
+	for _, part := range strings.Split(raw, "&") {
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		key, err := url.QueryUnescape(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err = url.QueryUnescape(value)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param{key, value})
+	}
+	return params, nil
+}
+
+func encodeQuery(params []param) string {
+	var b strings.Builder
+	for i, p := range params {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(url.QueryEscape(p.key))
+		b.WriteByte('=')
+		b.WriteString(url.QueryEscape(p.value))
+	}
+	return b.String()
+}
+
+// set replaces the values of p.key with p.value, in place of the first one.
+func set(params []param, p param) []param {
+	i := slices.IndexFunc(params, func(q param) bool { return q.key == p.key })
+	if i < 0 {
+		return append(params, p)
+	}
+	params[i] = p
+	rest := slices.DeleteFunc(params[i+1:], func(q param) bool { return q.key == p.key })
+	return params[:i+1+len(rest)]
+}
+
+func merge(params []param, query url.Values, policy QueryPolicy) []param {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		exists := slices.ContainsFunc(params, func(p param) bool { return p.key == key })
+		switch {
+		case !exists:
+			for _, value := range query[key] {
+				params = append(params, param{key, value})
+			}
+		case policy == QueryOverride:
+			i := slices.IndexFunc(params, func(p param) bool { return p.key == key })
+			params = slices.DeleteFunc(params, func(p param) bool { return p.key == key })
+			incoming := make([]param, 0, len(query[key]))
+			for _, value := range query[key] {
+				incoming = append(incoming, param{key, value})
+			}
+			params = slices.Insert(params, i, incoming...)
+		case policy == QueryDrop:
+			params = slices.DeleteFunc(params, func(p param) bool { return p.key == key })
+		}
+	}
+	return params
+}
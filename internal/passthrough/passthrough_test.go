@@ -0,0 +1,65 @@
+package passthrough
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Apply(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   Options
+		target string
+		path   string
+		query  string
+		want   string
+	}{
+		{"off", Options{}, "https://example.com/a?x=1", "extra", "gclid=1", "https://example.com/a?x=1"},
+		{"merge", Options{Query: QueryKeep}, "https://example.com/a?x=1#top", "", "gclid=abc&b=2", "https://example.com/a?x=1&b=2&gclid=abc#top"},
+		{"keep", Options{Query: QueryKeep}, "https://example.com/?x=1&y=2", "", "x=9", "https://example.com/?x=1&y=2"},
+		{"override", Options{Query: QueryOverride}, "https://example.com/?x=1&y=2&x=3", "", "x=9&x=8", "https://example.com/?x=9&x=8&y=2"},
+		{"drop", Options{Query: QueryDrop}, "https://example.com/?x=1&y=2", "", "x=9&z=3", "https://example.com/?y=2&z=3"},
+		{"escaping", Options{Query: QueryKeep}, "https://example.com/?q=a+b", "", "next=" + url.QueryEscape("https://x.com/?a=1&b=2#f"), "https://example.com/?q=a+b&next=https%3A%2F%2Fx.com%2F%3Fa%3D1%26b%3D2%23f"},
+		{"path", Options{Path: true}, "https://example.com/docs?v=2#intro", "guide/install", "", "https://example.com/docs/guide/install?v=2#intro"},
+		{"path with trailing slash", Options{Path: true}, "https://example.com/docs/", "/a/b/", "", "https://example.com/docs/a/b/"},
+		{"escaped path", Options{Path: true}, "https://example.com", "a%20b/c%2Fd", "", "https://example.com/a%20b/c%2Fd"},
+		{"empty path", Options{Path: true}, "https://example.com/docs", "", "", "https://example.com/docs"},
+		{"utm", Options{UTM: UTM{Source: "newsletter", Campaign: "spring sale"}}, "https://example.com/?utm_source=x&a=1&utm_source=y", "", "", "https://example.com/?utm_source=newsletter&a=1&utm_campaign=spring+sale"},
+		{"utm before incoming", Options{Query: QueryOverride, UTM: UTM{Source: "ad"}}, "https://example.com/", "", "utm_source=other", "https://example.com/?utm_source=other"},
+		{"all", Options{Query: QueryKeep, Path: true, UTM: UTM{Medium: "cpc"}}, "https://example.com/shop#cart", "shoes/42", "gclid=1", "https://example.com/shop/shoes/42?utm_medium=cpc&gclid=1#cart"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			got, err := tt.opts.Apply(tt.target, tt.path, query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOptions_CheckPath(t *testing.T) {
+	forward := Options{Path: true}
+	assert.NoError(t, forward.CheckPath(""))
+	assert.NoError(t, forward.CheckPath("a/b"))
+	assert.NoError(t, Options{}.CheckPath(""))
+	for _, path := range []string{"..", "a/../../b", "%2e%2E/etc", "./a", "a/%zz"} {
+		assert.True(t, errors.Is(forward.CheckPath(path), ErrInvalidPath), path)
+	}
+	assert.True(t, errors.Is(Options{}.CheckPath("a"), ErrInvalidPath), "the link doesn't forward paths")
+
+	_, err := forward.Apply("https://example.com/base", "../secret", nil)
+	assert.ErrorIs(t, err, ErrInvalidPath)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	assert.NoError(t, Options{Query: QueryDrop, UTM: UTM{Source: "x"}}.Validate())
+	assert.ErrorIs(t, Options{Query: "merge"}.Validate(), ErrInvalidOptions)
+	long := make([]byte, MaxUTMLength+1)
+	assert.ErrorIs(t, Options{UTM: UTM{Term: string(long)}}.Validate(), ErrInvalidOptions)
+}
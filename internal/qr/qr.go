@@ -0,0 +1,277 @@
+// Package qr renders QR codes as PNG or SVG images.
+package qr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+	"golang.org/x/image/draw"
+)
+
+var ErrInvalidOptions = errors.New("invalid qr code options")
+
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+func (f Format) ContentType() string {
+	if f == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Level is the error correction level: the share of the code that can be damaged
+// (or covered by a logo) and still be read.
+type Level string
+
+const (
+	LevelLow      Level = "L" // 7%
+	LevelMedium   Level = "M" // 15%
+	LevelQuartile Level = "Q" // 25%
+	LevelHigh     Level = "H" // 30%
+)
+
+var recoveryLevels = map[Level]qrcode.RecoveryLevel{
+	LevelLow:      qrcode.Low,
+	LevelMedium:   qrcode.Medium,
+	LevelQuartile: qrcode.High,
+	LevelHigh:     qrcode.Highest,
+}
+
+const (
+	MinSize     = 64
+	MaxSize     = 4096
+	DefaultSize = 256
+	MaxMargin   = 16
+	// DefaultMargin is the quiet zone required by the QR specification, in modules.
+	DefaultMargin = 4
+	// logoRatio is the logo width relative to the symbol width. It stays well below
+	// what High error correction can recover from.
+	logoRatio = 0.2
+)
+
+type Options struct {
+	Format Format
+	// Size is the width and height of the image in pixels.
+	Size int
+	// Level defaults to LevelMedium, or LevelHigh when a logo covers part of the symbol.
+	Level Level
+	// Margin is the quiet zone around the symbol, in modules.
+	Margin     int
+	Foreground color.RGBA
+	Background color.RGBA
+	// Logo is drawn in the center of the symbol if set.
+	Logo image.Image
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Format:     FormatPNG,
+		Size:       DefaultSize,
+		Margin:     DefaultMargin,
+		Foreground: color.RGBA{A: 0xff},
+		Background: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	}
+}
+
+func (o Options) Validate() error {
+	if o.Format != FormatPNG && o.Format != FormatSVG {
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidOptions, o.Format)
+	}
+	if o.Size < MinSize || o.Size > MaxSize {
+		return fmt.Errorf("%w: size must be between %d and %d", ErrInvalidOptions, MinSize, MaxSize)
+	}
+	if _, ok := recoveryLevels[o.Level]; !ok && o.Level != "" {
+		return fmt.Errorf("%w: unknown error correction level %q", ErrInvalidOptions, o.Level)
+	}
+	if o.Margin < 0 || o.Margin > MaxMargin {
+		return fmt.Errorf("%w: margin must be between 0 and %d", ErrInvalidOptions, MaxMargin)
+	}
+	return nil
+}
+
+// ParseLevel parses an error correction level: L, M, Q or H.
+func ParseLevel(s string) (Level, error) {
+	level := Level(strings.ToUpper(s))
+	if _, ok := recoveryLevels[level]; !ok {
+		return "", fmt.Errorf("%w: unknown error correction level %q", ErrInvalidOptions, s)
+	}
+	return level, nil
+}
+
+// ParseColor parses a hex color: RRGGBB or RRGGBBAA, optionally prefixed with '#'.
+func ParseColor(s string) (color.RGBA, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(s, "#"))
+	if err != nil || (len(raw) != 3 && len(raw) != 4) {
+		return color.RGBA{}, fmt.Errorf("%w: invalid color %q", ErrInvalidOptions, s)
+	}
+	c := color.RGBA{R: raw[0], G: raw[1], B: raw[2], A: 0xff}
+	if len(raw) == 4 {
+		c.A = raw[3]
+	}
+	return c, nil
+}
+
+// Image is a rendered QR code.
+type Image struct {
+	ContentType string
+	Data        []byte
+	// ETag is a strong entity tag of Data, quoted as in the HTTP header.
+	ETag string
+}
+
+// Render encodes content as a QR code image.
+func Render(content string, opts Options) (Image, error) {
+	if err := opts.Validate(); err != nil {
+		return Image{}, err
+	}
+	if opts.Level == "" {
+		opts.Level = LevelMedium
+		if opts.Logo != nil {
+			opts.Level = LevelHigh
+		}
+	}
+	code, err := qrcode.New(content, recoveryLevels[opts.Level])
+	if err != nil {
+		return Image{}, fmt.Errorf("%w: %v", ErrInvalidOptions, err)
+	}
+	code.DisableBorder = true
+	s := newSymbol(code.Bitmap(), opts)
+	if s.scale < 1 {
+		return Image{}, fmt.Errorf("%w: size %d is too small for %d modules", ErrInvalidOptions, opts.Size, s.width)
+	}
+
+	var data []byte
+	if opts.Format == FormatSVG {
+		data, err = s.svg()
+	} else {
+		data, err = s.png()
+	}
+	if err != nil {
+		return Image{}, err
+	}
+	sum := sha256.Sum256(data)
+	return Image{
+		ContentType: opts.Format.ContentType(),
+		Data:        data,
+		ETag:        `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`,
+	}, nil
+}
+
+// symbol lays out the module bitmap on a Size x Size canvas.
+type symbol struct {
+	modules [][]bool
+	opts    Options
+	// width is the symbol width in modules, including the margin.
+	width int
+	// scale is the module size in pixels and offset centers the symbol on the canvas.
+	scale  int
+	offset int
+}
+
+func newSymbol(modules [][]bool, opts Options) symbol {
+	width := len(modules) + 2*opts.Margin
+	scale := opts.Size / width
+	return symbol{
+		modules: modules,
+		opts:    opts,
+		width:   width,
+		scale:   scale,
+		offset:  (opts.Size - scale*width) / 2,
+	}
+}
+
+// logoRect returns the logo area in pixels, or an empty rectangle without a logo.
+func (s symbol) logoRect() image.Rectangle {
+	if s.opts.Logo == nil || s.opts.Logo.Bounds().Empty() {
+		return image.Rectangle{}
+	}
+	bounds := s.opts.Logo.Bounds()
+	maxSide := int(float64(len(s.modules)*s.scale) * logoRatio)
+	w, h := maxSide, maxSide*bounds.Dy()/bounds.Dx()
+	if h > maxSide {
+		w, h = maxSide*bounds.Dx()/bounds.Dy(), maxSide
+	}
+	center := s.opts.Size / 2
+	return image.Rect(center-w/2, center-h/2, center-w/2+w, center-h/2+h)
+}
+
+func (s symbol) png() ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, s.opts.Size, s.opts.Size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(s.opts.Background), image.Point{}, draw.Src)
+	fg := image.NewUniform(s.opts.Foreground)
+	for y, row := range s.modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			px := s.offset + (x+s.opts.Margin)*s.scale
+			py := s.offset + (y+s.opts.Margin)*s.scale
+			draw.Draw(img, image.Rect(px, py, px+s.scale, py+s.scale), fg, image.Point{}, draw.Src)
+		}
+	}
+	if logo := s.logoRect(); !logo.Empty() {
+		pad := s.scale
+		draw.Draw(img, logo.Inset(-pad), image.NewUniform(s.opts.Background), image.Point{}, draw.Src)
+		draw.CatmullRom.Scale(img, logo, s.opts.Logo, s.opts.Logo.Bounds(), draw.Over, nil)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s symbol) svg() ([]byte, error) {
+	var buf bytes.Buffer
+	size := s.opts.Size
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, size, size)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="%s"/>`, size, size, svgColor(s.opts.Background))
+
+	buf.WriteString(`<path fill="` + svgColor(s.opts.Foreground) + `" d="`)
+	for y, row := range s.modules {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, "M%d %dh%dv%dh-%dz",
+					s.offset+(x+s.opts.Margin)*s.scale, s.offset+(y+s.opts.Margin)*s.scale, s.scale, s.scale, s.scale)
+			}
+		}
+	}
+	buf.WriteString(`"/>`)
+
+	if logo := s.logoRect(); !logo.Empty() {
+		var logoPNG bytes.Buffer
+		if err := png.Encode(&logoPNG, s.opts.Logo); err != nil {
+			return nil, err
+		}
+		bg := logo.Inset(-s.scale)
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
+			bg.Min.X, bg.Min.Y, bg.Dx(), bg.Dy(), svgColor(s.opts.Background))
+		fmt.Fprintf(&buf, `<image x="%d" y="%d" width="%d" height="%d" href="data:image/png;base64,%s"/>`,
+			logo.Min.X, logo.Min.Y, logo.Dx(), logo.Dy(), base64.StdEncoding.EncodeToString(logoPNG.Bytes()))
+	}
+	buf.WriteString(`</svg>`)
+	return buf.Bytes(), nil
+}
+
+func svgColor(c color.RGBA) string {
+	if c.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
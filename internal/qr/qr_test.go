@@ -0,0 +1,132 @@
+package qr
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodePNG(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img
+}
+
+func rgba(c color.Color) color.RGBA {
+	r, g, b, a := c.RGBA()
+	return color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)}
+}
+
+func TestRender_PNG(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Size = 300
+	opts.Foreground = color.RGBA{R: 0x11, G: 0x22, B: 0x33, A: 0xff}
+	opts.Background = color.RGBA{R: 0xfe, G: 0xdc, B: 0xba, A: 0xff}
+
+	img, err := Render("https://sho.rt/abcdefghij", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", img.ContentType)
+
+	decoded := decodePNG(t, img.Data)
+	assert.Equal(t, image.Rect(0, 0, 300, 300), decoded.Bounds())
+	assert.Equal(t, opts.Background, rgba(decoded.At(0, 0)), "quiet zone")
+
+	// The top-left module is always the corner of a finder pattern.
+	s := newSymbol(make([][]bool, 25), opts)
+	corner := s.offset + opts.Margin*s.scale
+	assert.Equal(t, opts.Foreground, rgba(decoded.At(corner, corner)))
+}
+
+func TestRender_SVG(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Format = FormatSVG
+	opts.Foreground = color.RGBA{R: 0xff, A: 0x80}
+
+	img, err := Render("https://sho.rt/abcdefghij", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", img.ContentType)
+	svg := string(img.Data)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="256" height="256"`))
+	assert.Contains(t, svg, `<path fill="#ff000080" d="M`)
+	assert.True(t, strings.HasSuffix(svg, "</svg>"))
+}
+
+func TestRender_Logo(t *testing.T) {
+	logo := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	red := color.RGBA{R: 0xff, A: 0xff}
+	for x := range 10 {
+		for y := range 10 {
+			logo.Set(x, y, red)
+		}
+	}
+	opts := DefaultOptions()
+	opts.Logo = logo
+
+	img, err := Render("https://sho.rt/abcdefghij", opts)
+	require.NoError(t, err)
+	decoded := decodePNG(t, img.Data)
+	assert.Equal(t, red, rgba(decoded.At(opts.Size/2, opts.Size/2)))
+
+	opts.Format = FormatSVG
+	img, err = Render("https://sho.rt/abcdefghij", opts)
+	require.NoError(t, err)
+	assert.Contains(t, string(img.Data), `<image `)
+}
+
+func TestRender_ETag(t *testing.T) {
+	opts := DefaultOptions()
+	a, err := Render("https://sho.rt/a", opts)
+	require.NoError(t, err)
+	b, err := Render("https://sho.rt/a", opts)
+	require.NoError(t, err)
+	assert.Equal(t, a.ETag, b.ETag)
+
+	opts.Margin = 1
+	c, err := Render("https://sho.rt/a", opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, a.ETag, c.ETag)
+}
+
+func TestRender_InvalidOptions(t *testing.T) {
+	for name, modify := range map[string]func(*Options){
+		"format":    func(o *Options) { o.Format = "gif" },
+		"too small": func(o *Options) { o.Size = 8 },
+		"too large": func(o *Options) { o.Size = MaxSize + 1 },
+		"level":     func(o *Options) { o.Level = "X" },
+		"margin":    func(o *Options) { o.Margin = -1 },
+	} {
+		opts := DefaultOptions()
+		modify(&opts)
+		_, err := Render("https://sho.rt/a", opts)
+		assert.True(t, errors.Is(err, ErrInvalidOptions), name)
+	}
+
+	// 64 pixels can't fit a long URL with a wide margin.
+	opts := DefaultOptions()
+	opts.Size, opts.Margin = MinSize, MaxMargin
+	_, err := Render("https://sho.rt/"+strings.Repeat("a", 200), opts)
+	assert.True(t, errors.Is(err, ErrInvalidOptions))
+}
+
+func TestParseColor(t *testing.T) {
+	c, err := ParseColor("#102030")
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff}, c)
+
+	c, err = ParseColor("10203040")
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 0x10, G: 0x20, B: 0x30, A: 0x40}, c)
+
+	for _, s := range []string{"", "#fff", "zzzzzz", "1020304050"} {
+		_, err := ParseColor(s)
+		assert.Error(t, err, s)
+	}
+}
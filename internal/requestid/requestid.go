@@ -0,0 +1,45 @@
+package requestid
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// Header is the HTTP header (and lower-cased gRPC metadata key) used to
+// propagate request IDs between services.
+const Header = "X-Request-ID"
+
+// MetadataKey is the gRPC metadata key for the request ID.
+const MetadataKey = "x-request-id"
+
+type ctxKey struct{}
+
+// validID limits propagated IDs to a sane charset and length so that
+// clients can't inject arbitrary data into our logs and headers.
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// New generates a new random request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// FromIncoming returns the incoming ID if it is acceptable, or a new one otherwise.
+func FromIncoming(incoming string) string {
+	if validID.MatchString(incoming) {
+		return incoming
+	}
+	return New()
+}
+
+// NewContext returns a copy of ctx carrying the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
@@ -0,0 +1,43 @@
+package rules
+
+import (
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// GeoIP looks up the country of an IP address in a local MaxMind DB file,
+// a GeoLite2/GeoIP2 Country or City database.
+type GeoIP struct {
+	db *geoip2.Reader
+}
+
+func OpenGeoIP(path string) (*GeoIP, error) {
+	db, err := geoip2.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Country(net.IPv4zero); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &GeoIP{db: db}, nil
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country of ip, or an empty string
+// if ip is not an IP address or not in the database.
+func (g *GeoIP) Country(ip string) (string, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return "", nil
+	}
+	record, err := g.db.Country(addr)
+	if err != nil {
+		return "", err
+	}
+	return record.Country.IsoCode, nil
+}
+
+func (g *GeoIP) Close() error {
+	return g.db.Close()
+}
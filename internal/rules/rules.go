@@ -0,0 +1,346 @@
+// Package rules evaluates conditional redirect rules: ordered (conditions, target) pairs
+// that send a request to a different target depending on who asks and when.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	// Time zones of rules must load on hosts without a zoneinfo database too.
+	_ "time/tzdata"
+)
+
+var ErrInvalidRule = errors.New("invalid redirect rule")
+
+// Platform is the operating system family of a client, derived from its User-Agent.
+type Platform string
+
+const (
+	PlatformIOS     Platform = "ios"
+	PlatformAndroid Platform = "android"
+	PlatformWindows Platform = "windows"
+	PlatformMacOS   Platform = "macos"
+	PlatformLinux   Platform = "linux"
+	// PlatformOther is any client not recognized as one of the above, e.g. bots and CLI tools.
+	PlatformOther Platform = "other"
+)
+
+// Platforms lists every platform a condition can name.
+var Platforms = []Platform{PlatformIOS, PlatformAndroid, PlatformWindows, PlatformMacOS, PlatformLinux, PlatformOther}
+
+const (
+	// MaxValues bounds every list in Conditions.
+	MaxValues = 32
+	// timeLayout is the layout of Conditions.From and Conditions.To.
+	timeLayout = "15:04"
+)
+
+var (
+	languagePattern = regexp.MustCompile(`^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$`)
+	countryPattern  = regexp.MustCompile(`^[A-Za-z]{2}$`)
+)
+
+// Rule redirects requests matching Conditions to Target.
+type Rule struct {
+	ID         string     `json:"id"`
+	Target     string     `json:"target"`
+	Conditions Conditions `json:"conditions"`
+}
+
+// Conditions must all hold for a rule to match. Each list matches if any of its values does,
+// and an empty list or field doesn't restrict the match.
+type Conditions struct {
+	Platforms []Platform `json:"platforms,omitempty"`
+	// Languages are language tags such as "de" or "pt-BR", matched against the preferred
+	// language of the request. A tag also matches its subtags, "de" matches "de-AT".
+	Languages []string `json:"languages,omitempty"`
+	// Countries are ISO 3166-1 alpha-2 codes, matched against the GeoIP country of the client.
+	Countries []string `json:"countries,omitempty"`
+	// Days are the days of the week in TimeZone.
+	Days []time.Weekday `json:"days,omitempty"`
+	// From and To bound the time of day in TimeZone as "HH:MM", From inclusive and To exclusive.
+	// A window with From after To spans midnight. Either may be empty for an open bound.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// TimeZone is an IANA time zone name for Days, From and To. Empty means UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+	// Query requires the request to have these query parameters. An empty value only requires
+	// the parameter to be present.
+	Query map[string]string `json:"query,omitempty"`
+}
+
+// Clone returns a deep copy of r.
+func (r Rule) Clone() Rule {
+	c := r.Conditions
+	c.Platforms = slices.Clone(c.Platforms)
+	c.Languages = slices.Clone(c.Languages)
+	c.Countries = slices.Clone(c.Countries)
+	c.Days = slices.Clone(c.Days)
+	c.Query = maps.Clone(c.Query)
+	r.Conditions = c
+	return r
+}
+
+// Clone returns a deep copy of rules.
+func Clone(rules []Rule) []Rule {
+	if rules == nil {
+		return nil
+	}
+	cloned := make([]Rule, len(rules))
+	for i, r := range rules {
+		cloned[i] = r.Clone()
+	}
+	return cloned
+}
+
+// Validate checks the conditions of r. The target is validated by the caller,
+// together with link targets.
+func (r Rule) Validate() error {
+	c := r.Conditions
+	for _, list := range []struct {
+		name string
+		n    int
+	}{
+		{"platforms", len(c.Platforms)},
+		{"languages", len(c.Languages)},
+		{"countries", len(c.Countries)},
+		{"days", len(c.Days)},
+		{"query parameters", len(c.Query)},
+	} {
+		if list.n > MaxValues {
+			return fmt.Errorf("%w: at most %d %s", ErrInvalidRule, MaxValues, list.name)
+		}
+	}
+	for _, p := range c.Platforms {
+		if !slices.Contains(Platforms, p) {
+			return fmt.Errorf("%w: unknown platform %q", ErrInvalidRule, p)
+		}
+	}
+	for _, lang := range c.Languages {
+		if !languagePattern.MatchString(lang) {
+			return fmt.Errorf("%w: invalid language tag %q", ErrInvalidRule, lang)
+		}
+	}
+	for _, country := range c.Countries {
+		if !countryPattern.MatchString(country) {
+			return fmt.Errorf("%w: invalid country code %q", ErrInvalidRule, country)
+		}
+	}
+	for _, day := range c.Days {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("%w: invalid day of week %d", ErrInvalidRule, day)
+		}
+	}
+	for _, bound := range []string{c.From, c.To} {
+		if _, err := parseClock(bound); err != nil {
+			return fmt.Errorf("%w: invalid time of day %q, want HH:MM", ErrInvalidRule, bound)
+		}
+	}
+	if c.From != "" && c.From == c.To {
+		return fmt.Errorf("%w: empty time window %s-%s", ErrInvalidRule, c.From, c.To)
+	}
+	if _, err := location(c.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidRule, c.TimeZone)
+	}
+	for key := range c.Query {
+		if key == "" {
+			return fmt.Errorf("%w: empty query parameter name", ErrInvalidRule)
+		}
+	}
+	return nil
+}
+
+// locations caches the time zones of rules by name. time.LoadLocation reads and parses the
+// zone data on every call; there are only a few hundred zones, and Validate has loaded those
+// of every stored rule already.
+var locations sync.Map
+
+// location returns the time zone with the given IANA name, UTC for an empty one.
+func location(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// parseClock returns the minutes since midnight of an "HH:MM" time, or -1 for an empty one.
+func parseClock(s string) (int, error) {
+	if s == "" {
+		return -1, nil
+	}
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Request is what rules are evaluated against.
+type Request struct {
+	Platform Platform
+	// Languages are the accepted languages of the client, most preferred first.
+	Languages []string
+	// Country is the ISO 3166-1 alpha-2 code of the client, empty if unknown.
+	Country string
+	Time    time.Time
+	Query   url.Values
+}
+
+// Evaluate returns the first rule matching req.
+func Evaluate(rules []Rule, req Request) (Rule, bool) {
+	for _, r := range rules {
+		if r.Matches(req) {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Matches reports whether all conditions of r hold for req.
+func (r Rule) Matches(req Request) bool {
+	c := r.Conditions
+	if len(c.Platforms) > 0 && !slices.Contains(c.Platforms, req.Platform) {
+		return false
+	}
+	if len(c.Languages) > 0 && !matchLanguage(c.Languages, req.Languages) {
+		return false
+	}
+	if len(c.Countries) > 0 && !slices.ContainsFunc(c.Countries, func(country string) bool {
+		return req.Country != "" && strings.EqualFold(country, req.Country)
+	}) {
+		return false
+	}
+	if !matchTime(c, req.Time) {
+		return false
+	}
+	for key, value := range c.Query {
+		values, ok := req.Query[key]
+		if !ok || (value != "" && !slices.Contains(values, value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchLanguage matches the preferred language of the client only, so that a fallback
+// language far down the Accept-Language list doesn't pick the rule.
+func matchLanguage(tags, accepted []string) bool {
+	if len(accepted) == 0 {
+		return false
+	}
+	preferred := strings.ToLower(accepted[0])
+	for _, tag := range tags {
+		tag = strings.ToLower(tag)
+		if preferred == tag || strings.HasPrefix(preferred, tag+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+func matchTime(c Conditions, t time.Time) bool {
+	if len(c.Days) == 0 && c.From == "" && c.To == "" {
+		return true
+	}
+	loc, err := location(c.TimeZone)
+	if err != nil {
+		return false
+	}
+	t = t.In(loc)
+	if len(c.Days) > 0 && !slices.Contains(c.Days, t.Weekday()) {
+		return false
+	}
+	from, errFrom := parseClock(c.From)
+	to, errTo := parseClock(c.To)
+	if errFrom != nil || errTo != nil {
+		return false
+	}
+	now := t.Hour()*60 + t.Minute()
+	switch {
+	case from < 0 && to < 0:
+		return true
+	case from < 0:
+		return now < to
+	case to < 0:
+		return now >= from
+	case from < to:
+		return now >= from && now < to
+	default:
+		return now >= from || now < to
+	}
+}
+
+// ParsePlatform derives the platform of a client from its User-Agent header.
+func ParsePlatform(userAgent string) Platform {
+	ua := strings.ToLower(userAgent)
+	switch {
+	// Android comes first: some Android browsers also claim to be "like iPhone".
+	case strings.Contains(ua, "android"):
+		return PlatformAndroid
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return PlatformIOS
+	case strings.Contains(ua, "windows"):
+		return PlatformWindows
+	case strings.Contains(ua, "macintosh"), strings.Contains(ua, "mac os x"):
+		return PlatformMacOS
+	case strings.Contains(ua, "linux"), strings.Contains(ua, "cros"), strings.Contains(ua, "x11"):
+		return PlatformLinux
+	}
+	return PlatformOther
+}
+
+// ParseAcceptLanguage returns the languages of an Accept-Language header, most preferred
+// first. The wildcard and languages with q=0 are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var langs []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" || !languagePattern.MatchString(tag) {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		langs = append(langs, weighted{tag: tag, q: q})
+	}
+	// Stable, so that equally weighted languages keep the order the client sent them in.
+	slices.SortStableFunc(langs, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+	tags := make([]string, len(langs))
+	for i, l := range langs {
+		tags[i] = l.tag
+	}
+	return tags
+}
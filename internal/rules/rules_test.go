@@ -0,0 +1,155 @@
+package rules
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_Matches(t *testing.T) {
+	// Monday 2025-01-06 09:30 UTC, 10:30 in Berlin.
+	monday := time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC)
+	req := Request{
+		Platform:  PlatformIOS,
+		Languages: []string{"de-AT", "en"},
+		Country:   "AT",
+		Time:      monday,
+		Query:     url.Values{"src": {"qr"}, "debug": {""}},
+	}
+
+	tests := []struct {
+		name       string
+		conditions Conditions
+		want       bool
+	}{
+		{"no conditions", Conditions{}, true},
+		{"platform", Conditions{Platforms: []Platform{PlatformAndroid, PlatformIOS}}, true},
+		{"other platform", Conditions{Platforms: []Platform{PlatformAndroid}}, false},
+		{"language prefix", Conditions{Languages: []string{"de"}}, true},
+		{"language case", Conditions{Languages: []string{"DE-at"}}, true},
+		{"fallback language", Conditions{Languages: []string{"en"}}, false},
+		{"language is not a prefix of a tag", Conditions{Languages: []string{"d"}}, false},
+		{"country", Conditions{Countries: []string{"de", "at"}}, true},
+		{"other country", Conditions{Countries: []string{"CH"}}, false},
+		{"day", Conditions{Days: []time.Weekday{time.Monday}}, true},
+		{"other day", Conditions{Days: []time.Weekday{time.Saturday, time.Sunday}}, false},
+		{"day in time zone", Conditions{Days: []time.Weekday{time.Sunday}, TimeZone: "Pacific/Honolulu"}, true},
+		{"window", Conditions{From: "09:00", To: "10:00"}, true},
+		{"window end is exclusive", Conditions{From: "09:00", To: "09:30"}, false},
+		{"window in time zone", Conditions{From: "10:00", To: "11:00", TimeZone: "Europe/Berlin"}, true},
+		{"window across midnight", Conditions{From: "22:00", To: "10:00"}, true},
+		{"outside window across midnight", Conditions{From: "22:00", To: "09:00"}, false},
+		{"open start", Conditions{To: "12:00"}, true},
+		{"open end", Conditions{From: "12:00"}, false},
+		{"query value", Conditions{Query: map[string]string{"src": "qr"}}, true},
+		{"query presence", Conditions{Query: map[string]string{"debug": ""}}, true},
+		{"other query value", Conditions{Query: map[string]string{"src": "mail"}}, false},
+		{"missing query", Conditions{Query: map[string]string{"utm": ""}}, false},
+		{"all", Conditions{Platforms: []Platform{PlatformIOS}, Countries: []string{"AT"}, Days: []time.Weekday{time.Monday}}, true},
+		{"all but one", Conditions{Platforms: []Platform{PlatformIOS}, Countries: []string{"DE"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Rule{Conditions: tt.conditions}.Matches(req))
+		})
+	}
+
+	assert.False(t, Rule{Conditions: Conditions{Countries: []string{"AT"}}}.Matches(Request{}), "unknown country")
+	assert.False(t, Rule{Conditions: Conditions{Languages: []string{"de"}}}.Matches(Request{}), "no languages")
+}
+
+func TestEvaluate(t *testing.T) {
+	rules := []Rule{
+		{ID: "ios", Target: "https://apps.apple.com/app", Conditions: Conditions{Platforms: []Platform{PlatformIOS}}},
+		{ID: "android", Target: "https://play.google.com/app", Conditions: Conditions{Platforms: []Platform{PlatformAndroid}}},
+		{ID: "mobile", Target: "https://m.example.com", Conditions: Conditions{Platforms: []Platform{PlatformIOS, PlatformAndroid}}},
+	}
+	rule, ok := Evaluate(rules, Request{Platform: PlatformAndroid})
+	assert.True(t, ok)
+	assert.Equal(t, "android", rule.ID, "first match wins")
+
+	_, ok = Evaluate(rules, Request{Platform: PlatformWindows})
+	assert.False(t, ok)
+}
+
+func TestRule_Validate(t *testing.T) {
+	valid := Conditions{
+		Platforms: []Platform{PlatformIOS},
+		Languages: []string{"pt-BR"},
+		Countries: []string{"br"},
+		Days:      []time.Weekday{time.Saturday},
+		From:      "22:00",
+		To:        "06:00",
+		TimeZone:  "America/Sao_Paulo",
+		Query:     map[string]string{"src": ""},
+	}
+	assert.NoError(t, Rule{Conditions: valid}.Validate())
+
+	for name, c := range map[string]Conditions{
+		"platform":  {Platforms: []Platform{"beos"}},
+		"language":  {Languages: []string{"en_US"}},
+		"country":   {Countries: []string{"AUT"}},
+		"day":       {Days: []time.Weekday{7}},
+		"from":      {From: "24:00"},
+		"to":        {To: "9:00am"},
+		"window":    {From: "09:00", To: "09:00"},
+		"time zone": {TimeZone: "Mars/Olympus_Mons"},
+		"query":     {Query: map[string]string{"": "x"}},
+		"too many":  {Countries: make([]string, MaxValues+1)},
+	} {
+		err := Rule{Conditions: c}.Validate()
+		assert.True(t, errors.Is(err, ErrInvalidRule), "%s: %v", name, err)
+	}
+}
+
+func TestLocation(t *testing.T) {
+	loc, err := location("Europe/Berlin")
+	assert.NoError(t, err)
+	again, err := location("Europe/Berlin")
+	assert.NoError(t, err)
+	assert.Same(t, loc, again, "zones are loaded once")
+	utc, err := location("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, utc)
+
+	_, err = location("Mars/Olympus_Mons")
+	assert.Error(t, err)
+	_, cached := locations.Load("Mars/Olympus_Mons")
+	assert.False(t, cached)
+}
+
+func TestRule_Clone(t *testing.T) {
+	rule := Rule{Conditions: Conditions{Countries: []string{"DE"}, Query: map[string]string{"a": "1"}}}
+	cloned := rule.Clone()
+	cloned.Conditions.Countries[0] = "FR"
+	cloned.Conditions.Query["a"] = "2"
+	assert.Equal(t, "DE", rule.Conditions.Countries[0])
+	assert.Equal(t, "1", rule.Conditions.Query["a"])
+}
+
+func TestParsePlatform(t *testing.T) {
+	for ua, want := range map[string]Platform{
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148":        PlatformIOS,
+		"Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko)":           PlatformIOS,
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/124.0 Mobile Safari/537.36":    PlatformAndroid,
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/124.0 Safari/537.36":          PlatformWindows,
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4) AppleWebKit/605.1.15 Version/17.4 Safari/605.1.15":   PlatformMacOS,
+		"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0":                   PlatformLinux,
+		"Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0": PlatformLinux,
+		"curl/8.5.0": PlatformOther,
+		"":           PlatformOther,
+	} {
+		assert.Equal(t, want, ParsePlatform(ua), ua)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, []string{"fr-CH", "fr", "en", "de"}, ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5"))
+	assert.Equal(t, []string{"de", "en"}, ParseAcceptLanguage("en;q=0.5, de"), "ordered by weight")
+	assert.Equal(t, []string{"en", "de"}, ParseAcceptLanguage("en, de"), "equal weights keep their order")
+	assert.Equal(t, []string{"en"}, ParseAcceptLanguage("ru;q=0, en;q=0.1, x_bad, pl;q=abc"))
+	assert.Empty(t, ParseAcceptLanguage(""))
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultClickHistoryDays = 30
+	MaxClickHistoryDays     = 366
+)
+
+var ErrInvalidDays = errors.New("days must be between 1 and 366")
+
+// ClickHistory is the number of resolves of a link in total and on each of the last days.
+type ClickHistory struct {
+	Total int64
+	// Days holds one entry per UTC day, oldest first and ending today, including days without clicks.
+	Days []database.DailyClicks
+}
+
+// recordClick counts a resolve of the link. A failure to count the click shouldn't fail the redirect.
+func (u *UrlShortener) recordClick(ctx context.Context, link Link, at time.Time) {
+	if err := u.urlRepo.RecordClick(ctx, link.ID, at); err != nil {
+		u.logger(ctx).Warn("failed to record click", zap.Int64("id", link.ID), zap_utils.Err(err))
+	}
+}
+
+// ClickHistory returns the resolves of the link with the given code in total and on each of
+// the last days UTC days, today included.
+func (u *UrlShortener) ClickHistory(ctx context.Context, code string, days int) (ClickHistory, error) {
+	if days < 1 || days > MaxClickHistoryDays {
+		return ClickHistory{}, ErrInvalidDays
+	}
+	link, _, err := u.lookupLink(ctx, code)
+	if err != nil {
+		return ClickHistory{}, err
+	}
+	today := database.ClickDay(time.Now())
+	since := today.AddDate(0, 0, 1-days)
+	recorded, err := u.urlRepo.ClickHistory(ctx, link.ID, since)
+	if err != nil {
+		u.logger(ctx).Error("failed to get click history", zap.Int64("id", link.ID), zap_utils.Err(err))
+		return ClickHistory{}, err
+	}
+	history := ClickHistory{Total: link.Clicks, Days: make([]database.DailyClicks, days)}
+	for i := range history.Days {
+		history.Days[i].Day = since.AddDate(0, 0, i)
+	}
+	for _, d := range recorded {
+		if i := int(d.Day.Sub(since) / (24 * time.Hour)); i >= 0 && i < days {
+			history.Days[i].Clicks = d.Clicks
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestUrlShortener_ResolveRecordsClick(t *testing.T) {
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("GetLink", mock.Anything, int64(9)).Return(database.Link{ID: 9, Target: "https://example.com/a"}, nil)
+	urlRepo.On("RecordClick", mock.Anything, int64(9), mock.AnythingOfType("time.Time")).Return(errors.New("db down")).Once()
+	urlRepo.On("RecordClick", mock.Anything, int64(9), mock.AnythingOfType("time.Time")).Return(nil).Once()
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	code, err := encodeID(9)
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err = u.Resolve(context.Background(), ResolveRequest{Code: code})
+		require.NoError(t, err, "failing to count a click doesn't fail the redirect")
+	}
+	urlRepo.AssertExpectations(t)
+}
+
+func TestUrlShortener_ClickHistory(t *testing.T) {
+	today := database.ClickDay(time.Now())
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("GetLink", mock.Anything, int64(9)).Return(database.Link{ID: 9, Target: "https://example.com", Clicks: 12}, nil)
+	urlRepo.On("ClickHistory", mock.Anything, int64(9), today.AddDate(0, 0, -2)).Return([]database.DailyClicks{
+		{Day: today.AddDate(0, 0, -2), Clicks: 4},
+		{Day: today, Clicks: 1},
+	}, nil)
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	code, err := encodeID(9)
+	require.NoError(t, err)
+
+	history, err := u.ClickHistory(context.Background(), code, 3)
+	require.NoError(t, err)
+	assert.Equal(t, ClickHistory{Total: 12, Days: []database.DailyClicks{
+		{Day: today.AddDate(0, 0, -2), Clicks: 4},
+		{Day: today.AddDate(0, 0, -1)},
+		{Day: today, Clicks: 1},
+	}}, history, "days without clicks are filled in")
+
+	for _, days := range []int{0, MaxClickHistoryDays + 1} {
+		_, err = u.ClickHistory(context.Background(), code, days)
+		assert.ErrorIs(t, err, ErrInvalidDays)
+	}
+}
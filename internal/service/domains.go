@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+)
+
+// domainRegistry lazily builds the domain registry from the environment once.
+type domainRegistry struct {
+	once     sync.Once
+	registry *domains.Registry
+	err      error
+}
+
+func (r *domainRegistry) get() (*domains.Registry, error) {
+	r.once.Do(func() {
+		r.registry, r.err = domains.FromEnv()
+	})
+	return r.registry, r.err
+}
+
+// Domain returns the domain selected in ctx by the transport.
+func (u *UrlShortener) Domain(ctx context.Context) (*domains.Domain, error) {
+	registry, err := u.domains.get()
+	if err != nil {
+		return nil, err
+	}
+	return registry.Select(ctx)
+}
+
+// splitShortURL selects the domain of a fully qualified short URL, such as
+// https://go.brand-a.com/abc, in ctx and returns its code. Bare codes are returned as is.
+func splitShortURL(ctx context.Context, code string) (context.Context, string, error) {
+	if !strings.Contains(code, "://") {
+		return ctx, code, nil
+	}
+	u, err := url.Parse(code)
+	if err != nil || u.Host == "" {
+		return ctx, "", ErrInvalidUrl
+	}
+	return domains.WithName(ctx, u.Host), strings.TrimPrefix(u.Path, "/"), nil
+}
+
+// lookupLink returns the link with the given code on the domain selected in ctx.
+// Codes of other domains are not found, even if they decode to an existing link.
+func (u *UrlShortener) lookupLink(ctx context.Context, code string) (database.Link, *domains.Domain, error) {
+	ctx, code, err := splitShortURL(ctx, code)
+	if err != nil {
+		return database.Link{}, nil, err
+	}
+	d, err := u.Domain(ctx)
+	if err != nil {
+		if errors.Is(err, domains.ErrUnknownDomain) {
+			return database.Link{}, nil, ErrUrlNotFound
+		}
+		return database.Link{}, nil, err
+	}
+	id, ok := d.Decode(code)
+	if !ok {
+		return database.Link{}, nil, ErrInvalidUrl
+	}
+	link, err := u.urlRepo.GetLink(ctx, id)
+	if err != nil {
+		return database.Link{}, nil, err
+	}
+	if link.Domain != d.Name() {
+		return database.Link{}, nil, ErrUrlNotFound
+	}
+	return link, d, nil
+}
+
+// lookupID returns the ID of the link with the given code on the domain selected in ctx.
+func (u *UrlShortener) lookupID(ctx context.Context, code string) (int64, error) {
+	link, _, err := u.lookupLink(ctx, code)
+	return link.ID, err
+}
+
+// withCode encodes the code and short URL of link on its domain d.
+func withCode(d *domains.Domain, link database.Link) (Link, error) {
+	code, err := d.Encode(link.ID)
+	if err != nil {
+		return Link{}, err
+	}
+	return Link{Link: link, Code: code, ShortURL: d.ShortURL(code)}, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// setDomains serves the default domain at sho.rt and registers go.brand-a.com next to it.
+func setDomains(t *testing.T) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "domains.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"host": "go.brand-a.com"}]`), 0o600))
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", path)
+}
+
+func TestUrlShortener_DomainLinks(t *testing.T) {
+	setDomains(t)
+	brandA := domains.WithHost(context.Background(), "go.brand-a.com")
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	urlRepo.On("GetLink", mock.Anything, int64(7)).Return(database.Link{ID: 7, Domain: "go.brand-a.com", Target: "https://example.com"}, nil)
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	code, err := encodeID(7)
+	require.NoError(t, err)
+
+	link, err := u.GetLink(brandA, code)
+	require.NoError(t, err)
+	assert.Equal(t, "https://go.brand-a.com/"+code, link.ShortURL)
+
+	_, err = u.GetLink(domains.WithHost(context.Background(), "sho.rt"), code)
+	assert.ErrorIs(t, err, ErrUrlNotFound, "codes only resolve on the domain of their link")
+	err = u.DeleteLink(context.Background(), code)
+	assert.ErrorIs(t, err, ErrUrlNotFound)
+
+	target, err := u.GetFullUrl(context.Background(), "https://go.brand-a.com/"+code)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", target)
+	_, err = u.GetFullUrl(context.Background(), "https://unknown.example.com/"+code)
+	assert.ErrorIs(t, err, ErrUrlNotFound)
+}
+
+func TestUrlShortener_DomainCreate(t *testing.T) {
+	setDomains(t)
+	brandA := domains.WithHost(context.Background(), "go.brand-a.com")
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("GetID", mock.Anything, "go.brand-a.com", "https://example.com").Return(3, nil)
+	urlRepo.On("CreateLink", mock.Anything, mock.Anything).Return(nil)
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	code, err := encodeID(3)
+	require.NoError(t, err)
+
+	shortURL, err := u.CreateUrl(brandA, "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://go.brand-a.com/"+code, shortURL)
+
+	link, err := u.CreateLink(brandA, database.Link{Target: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "go.brand-a.com", link.Domain)
+	link, err = u.CreateLink(brandA, database.Link{Target: "https://example.com", Domain: "sho.rt"})
+	require.NoError(t, err)
+	assert.Equal(t, "", link.Domain, "the default domain is stored as empty")
+	assert.Equal(t, "https://sho.rt/"+link.Code, link.ShortURL)
+
+	_, err = u.CreateLink(brandA, database.Link{Target: "https://example.com", Domain: "br.nd"})
+	assert.ErrorIs(t, err, domains.ErrUnknownDomain)
+	_, err = u.CreateUrl(domains.WithName(context.Background(), "br.nd"), "https://example.com")
+	assert.ErrorIs(t, err, domains.ErrUnknownDomain)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+
+	"go.uber.org/zap"
+)
+
+// ImportOptions control how ImportLink treats a link.
+type ImportOptions struct {
+	// DryRun validates the link and reports the outcome without storing it.
+	DryRun bool
+	// RemapTaken gives links whose code is taken a new code instead of failing with ErrCodeTaken.
+	RemapTaken bool
+}
+
+// ImportedLink is the outcome of ImportLink.
+type ImportedLink struct {
+	// Link is the stored link. Its Code is empty for dry runs that would assign a new code.
+	Link
+	// Remapped is why the link did not keep its code, nil if it did or had none.
+	Remapped error
+}
+
+// ImportLink creates a link migrated from elsewhere, keeping its CreatedAt if set. The link keeps
+// code if it is a code of its domain and the ID it encodes is free, which is what makes the codes
+// of links exported from another instance survive. Otherwise the link gets a new code, except
+// that taken codes fail with ErrCodeTaken unless opts.RemapTaken is set.
+func (u *UrlShortener) ImportLink(ctx context.Context, link database.Link, code string, opts ImportOptions) (ImportedLink, error) {
+	if err := validateNewLink(link); err != nil {
+		return ImportedLink{}, err
+	}
+	d, err := u.linkDomain(ctx, link.Domain)
+	if err != nil {
+		return ImportedLink{}, err
+	}
+	link.ID = 0
+	link.Domain = d.Name()
+	link.RemainingClicks = link.MaxClicks
+
+	var remapped error
+	if code != "" {
+		// Only canonical codes are kept, other encodings of the ID would not resolve.
+		id, ok := d.Decode(code)
+		if canonical, err := d.Encode(id); !ok || id <= 0 || err != nil || canonical != code {
+			remapped = ErrForeignCode
+		} else {
+			link.ID = id
+		}
+	}
+	err = u.storeImport(ctx, &link, opts.DryRun)
+	if errors.Is(err, ErrCodeTaken) && opts.RemapTaken {
+		remapped = ErrCodeTaken
+		link.ID = 0
+		err = u.storeImport(ctx, &link, opts.DryRun)
+	}
+	if err != nil {
+		return ImportedLink{}, err
+	}
+	if opts.DryRun && link.ID == 0 {
+		return ImportedLink{Link: Link{Link: link}, Remapped: remapped}, nil
+	}
+	imported, err := withCode(d, link)
+	if err != nil {
+		return ImportedLink{}, err
+	}
+	if !opts.DryRun {
+		u.logger(ctx).Debug("imported link", zap.Int64("id", link.ID), zap.String("domain", link.Domain), zap.Bool("remapped", remapped != nil))
+		u.publishLink(ctx, webhooks.EventLinkCreated, imported)
+	}
+	return ImportedLink{Link: imported, Remapped: remapped}, nil
+}
+
+// storeImport stores an imported link, or only checks that its ID is free for dry runs.
+func (u *UrlShortener) storeImport(ctx context.Context, link *database.Link, dryRun bool) error {
+	if !dryRun {
+		return u.urlRepo.ImportLink(ctx, link)
+	}
+	if link.ID == 0 {
+		return nil
+	}
+	_, err := u.urlRepo.GetLink(ctx, link.ID)
+	switch {
+	case err == nil:
+		return ErrCodeTaken
+	case errors.Is(err, ErrUrlNotFound):
+		return nil
+	default:
+		return err
+	}
+}
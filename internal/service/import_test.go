@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestUrlShortener_ImportLink(t *testing.T) {
+	t.Setenv("BASE_URL", "https://sho.rt")
+	t.Setenv("DOMAINS_PATH", "")
+	ctx := context.Background()
+	free, err := encodeID(5)
+	require.NoError(t, err)
+	taken, err := encodeID(6)
+	require.NoError(t, err)
+
+	urlRepo := new(UrlRepositoryMock)
+	withID := func(id int64) any {
+		return mock.MatchedBy(func(link *database.Link) bool { return link.ID == id })
+	}
+	urlRepo.On("ImportLink", mock.Anything, withID(5)).Return(nil)
+	urlRepo.On("ImportLink", mock.Anything, withID(6)).Return(ErrCodeTaken)
+	urlRepo.On("ImportLink", mock.Anything, withID(0)).Run(func(args mock.Arguments) {
+		args.Get(1).(*database.Link).ID = 42
+	}).Return(nil)
+	urlRepo.On("GetLink", mock.Anything, int64(5)).Return(database.Link{}, ErrUrlNotFound)
+	urlRepo.On("GetLink", mock.Anything, int64(6)).Return(database.Link{ID: 6}, nil)
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	newCode, err := encodeID(42)
+	require.NoError(t, err)
+	link := database.Link{Target: "https://example.com"}
+
+	imported, err := u.ImportLink(ctx, link, free, ImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, free, imported.Code)
+	assert.NoError(t, imported.Remapped)
+
+	_, err = u.ImportLink(ctx, link, taken, ImportOptions{})
+	assert.ErrorIs(t, err, ErrCodeTaken)
+	imported, err = u.ImportLink(ctx, link, taken, ImportOptions{RemapTaken: true})
+	require.NoError(t, err)
+	assert.Equal(t, newCode, imported.Code)
+	assert.ErrorIs(t, imported.Remapped, ErrCodeTaken)
+
+	imported, err = u.ImportLink(ctx, link, "bit.ly-alias", ImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, newCode, imported.Code)
+	assert.ErrorIs(t, imported.Remapped, ErrForeignCode)
+
+	_, err = u.ImportLink(ctx, database.Link{Target: "ftp://example.com"}, free, ImportOptions{})
+	assert.ErrorIs(t, err, ErrInvalidTarget)
+
+	// dry runs only look up the code
+	calls := len(urlRepo.Calls)
+	imported, err = u.ImportLink(ctx, link, free, ImportOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, free, imported.Code)
+	imported, err = u.ImportLink(ctx, link, taken, ImportOptions{DryRun: true, RemapTaken: true})
+	require.NoError(t, err)
+	assert.Empty(t, imported.Code, "new codes are only assigned when the link is stored")
+	assert.ErrorIs(t, imported.Remapped, ErrCodeTaken)
+	for _, call := range urlRepo.Calls[calls:] {
+		assert.Equal(t, "GetLink", call.Method)
+	}
+}
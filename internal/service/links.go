@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidTarget    = errors.New("target must be an absolute http(s) URL")
+	ErrLinkDisabled     = errors.New("link is disabled")
+	ErrLinkExpired      = errors.New("link has expired")
+	ErrLinkExhausted    = errors.New("link has no clicks left")
+	ErrInvalidMaxClicks = errors.New("max clicks must not be negative")
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrUnknownField     = errors.New("unknown link field")
+	ErrInvalidSort      = errors.New("invalid sort order")
+	ErrCodeTaken        = errors.New("code is taken by another link")
+	ErrForeignCode      = errors.New("not a code of the domain")
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+// Link is a stored link together with its public short code.
+type Link struct {
+	database.Link
+	Code string
+	// ShortURL is the public short URL on the domain of the link, empty if it has no base URL.
+	ShortURL string
+}
+
+type ListLinksQuery struct {
+	PageSize        int
+	PageToken       string
+	Sort            database.LinkSort
+	Owner           string
+	Tag             string
+	Campaign        string
+	IncludeDisabled bool
+	TargetDomain    string
+	CreatedAfter    time.Time
+	CreatedBefore   time.Time
+	TargetContains  string
+}
+
+type ListLinksPage struct {
+	Links         []Link
+	NextPageToken string
+}
+
+func validateTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidTarget
+	}
+	return nil
+}
+
+// checkActive returns an error if the link must not be resolved anymore.
+func checkActive(link database.Link, now time.Time) error {
+	if link.Disabled {
+		return ErrLinkDisabled
+	}
+	if link.ExpiresAt != nil && !now.Before(*link.ExpiresAt) {
+		return ErrLinkExpired
+	}
+	if link.Exhausted() {
+		return ErrLinkExhausted
+	}
+	return nil
+}
+
+// pageToken is the opaque keyset cursor handed out as NextPageToken.
+// It records the sort it was issued for so it can't be replayed against another order.
+type pageToken struct {
+	Sort      database.LinkSort `json:"s,omitempty"`
+	ID        int64             `json:"i"`
+	CreatedAt time.Time         `json:"c"`
+}
+
+func encodePageToken(sort database.LinkSort, last database.Link) string {
+	raw, _ := json.Marshal(pageToken{Sort: sort, ID: last.ID, CreatedAt: last.CreatedAt})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodePageToken(token string, sort database.LinkSort) (*database.LinkCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var t pageToken
+	if err := json.Unmarshal(raw, &t); err != nil || t.ID < 0 || t.Sort != sort {
+		return nil, ErrInvalidPageToken
+	}
+	return &database.LinkCursor{ID: t.ID, CreatedAt: t.CreatedAt}, nil
+}
+
+// validateNewLink checks the fields of a link to be created.
+func validateNewLink(link database.Link) error {
+	if err := validateLinkTarget(link); err != nil {
+		return err
+	}
+	if link.MaxClicks < 0 {
+		return ErrInvalidMaxClicks
+	}
+	if err := validateVariants(link.Variants); err != nil {
+		return err
+	}
+	if err := validateSplitMode(link.SplitMode); err != nil {
+		return err
+	}
+	return link.Passthrough.Validate()
+}
+
+func (u *UrlShortener) CreateLink(ctx context.Context, link database.Link) (Link, error) {
+	if err := validateNewLink(link); err != nil {
+		return Link{}, err
+	}
+	d, err := u.linkDomain(ctx, link.Domain)
+	if err != nil {
+		return Link{}, err
+	}
+	link.ID = 0
+	link.Domain = d.Name()
+	link.RemainingClicks = link.MaxClicks
+	link.Clicks = 0
+	if err := u.urlRepo.CreateLink(ctx, &link); err != nil {
+		return Link{}, err
+	}
+	u.logger(ctx).Debug("created link", zap.Int64("id", link.ID), zap.String("owner", link.Owner), zap.String("domain", link.Domain))
+	created, err := withCode(d, link)
+	if err != nil {
+		return Link{}, err
+	}
+	u.publishLink(ctx, webhooks.EventLinkCreated, created)
+	return created, nil
+}
+
+// linkDomain returns the domain a new link is created on: the one named, else the one selected in ctx.
+func (u *UrlShortener) linkDomain(ctx context.Context, name string) (*domains.Domain, error) {
+	if name == "" {
+		return u.Domain(ctx)
+	}
+	registry, err := u.domains.get()
+	if err != nil {
+		return nil, err
+	}
+	return registry.Get(name)
+}
+
+func (u *UrlShortener) GetLink(ctx context.Context, code string) (Link, error) {
+	link, err := u.getLink(ctx, code)
+	if err != nil {
+		return Link{}, err
+	}
+	if concealsTargets(ctx) {
+		link.Link = link.Concealed()
+	}
+	return link, nil
+}
+
+// getLink is GetLink with the targets of the link, whatever ctx says.
+func (u *UrlShortener) getLink(ctx context.Context, code string) (Link, error) {
+	link, d, err := u.lookupLink(ctx, code)
+	if err != nil {
+		return Link{}, err
+	}
+	return withCode(d, link)
+}
+
+func (u *UrlShortener) UpdateLink(ctx context.Context, code string, link database.Link, fields []database.LinkField) (Link, error) {
+	for _, field := range fields {
+		switch field {
+		case database.LinkFieldMaxClicks:
+			if link.MaxClicks < 0 {
+				return Link{}, ErrInvalidMaxClicks
+			}
+		case database.LinkFieldVariants:
+			if err := validateVariants(link.Variants); err != nil {
+				return Link{}, err
+			}
+		case database.LinkFieldSplitMode:
+			if err := validateSplitMode(link.SplitMode); err != nil {
+				return Link{}, err
+			}
+		case database.LinkFieldPassthrough:
+			if err := link.Passthrough.Validate(); err != nil {
+				return Link{}, err
+			}
+		case database.LinkFieldTarget, database.LinkFieldTemplate:
+			// Checked below, together with the passthrough options.
+		case database.LinkFieldOwner, database.LinkFieldTags, database.LinkFieldCampaign, database.LinkFieldDisabled,
+			database.LinkFieldExpiresAt, database.LinkFieldPassword:
+		default:
+			return Link{}, ErrUnknownField
+		}
+	}
+	stored, d, err := u.lookupLink(ctx, code)
+	if err != nil {
+		return Link{}, err
+	}
+	id := stored.ID
+	if err := validateTargetUpdate(stored, link, fields); err != nil {
+		return Link{}, err
+	}
+	link.ID = id
+	updated, err := u.urlRepo.UpdateLink(ctx, link, fields)
+	if err != nil {
+		return Link{}, err
+	}
+	u.logger(ctx).Debug("updated link", zap.Int64("id", id), zap.Any("fields", fields))
+	result, err := withCode(d, updated)
+	if err != nil {
+		return Link{}, err
+	}
+	u.publishUpdated(ctx, result, fields)
+	return result, nil
+}
+
+func (u *UrlShortener) DeleteLink(ctx context.Context, code string) error {
+	stored, d, err := u.lookupLink(ctx, code)
+	if err != nil {
+		return err
+	}
+	id := stored.ID
+	if err := u.urlRepo.DeleteLink(ctx, id); err != nil {
+		return err
+	}
+	u.logger(ctx).Debug("deleted link", zap.Int64("id", id))
+	u.expired.forget(id)
+	if deleted, err := withCode(d, stored); err == nil {
+		u.publishLink(ctx, webhooks.EventLinkDeleted, deleted)
+	}
+	return nil
+}
+
+func (u *UrlShortener) ListLinks(ctx context.Context, query ListLinksQuery) (ListLinksPage, error) {
+	if !query.Sort.Valid() {
+		return ListLinksPage{}, ErrInvalidSort
+	}
+	after, err := decodePageToken(query.PageToken, query.Sort)
+	if err != nil {
+		return ListLinksPage{}, err
+	}
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+	d, err := u.Domain(ctx)
+	if err != nil {
+		return ListLinksPage{}, err
+	}
+	domain := d.Name()
+
+	// Fetch one extra link to know whether there is a next page.
+	links, err := u.urlRepo.ListLinks(ctx, database.ListLinksFilter{
+		After:           after,
+		Limit:           pageSize + 1,
+		Sort:            query.Sort,
+		Owner:           query.Owner,
+		Tag:             query.Tag,
+		Campaign:        query.Campaign,
+		IncludeDisabled: query.IncludeDisabled,
+		TargetDomain:    query.TargetDomain,
+		CreatedAfter:    query.CreatedAfter,
+		CreatedBefore:   query.CreatedBefore,
+		TargetContains:  query.TargetContains,
+		Domain:          &domain,
+	})
+	if err != nil {
+		u.logger(ctx).Error("failed to list links", zap_utils.Err(err))
+		return ListLinksPage{}, err
+	}
+
+	var page ListLinksPage
+	if len(links) > pageSize {
+		links = links[:pageSize]
+		page.NextPageToken = encodePageToken(query.Sort, links[len(links)-1])
+	}
+	page.Links = make([]Link, 0, len(links))
+	for _, link := range links {
+		if concealsTargets(ctx) {
+			link = link.Concealed()
+		}
+		l, err := withCode(d, link)
+		if err != nil {
+			return ListLinksPage{}, err
+		}
+		page.Links = append(page.Links, l)
+	}
+	return page, nil
+}
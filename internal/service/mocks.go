@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/rules"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -10,8 +13,8 @@ type UrlRepositoryMock struct {
 	mock.Mock
 }
 
-func (u *UrlRepositoryMock) GetID(ctx context.Context, fullUrl string) (id int64, err error) {
-	args := u.Called(ctx, fullUrl)
+func (u *UrlRepositoryMock) GetID(ctx context.Context, domain, fullUrl string) (id int64, err error) {
+	args := u.Called(ctx, domain, fullUrl)
 	return int64(args.Int(0)), args.Error(1)
 }
 
@@ -20,7 +23,92 @@ func (u *UrlRepositoryMock) GetUrlByID(ctx context.Context, id int64) (fullUrl s
 	return args.String(0), args.Error(1)
 }
 
-func (u *UrlRepositoryMock) SaveUrl(ctx context.Context, fullUrl string) (err error) {
-	args := u.Called(ctx, fullUrl)
+func (u *UrlRepositoryMock) SaveUrl(ctx context.Context, domain, fullUrl string) (err error) {
+	args := u.Called(ctx, domain, fullUrl)
+	return args.Error(0)
+}
+
+func (u *UrlRepositoryMock) CreateLink(ctx context.Context, link *database.Link) (err error) {
+	args := u.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (u *UrlRepositoryMock) ImportLink(ctx context.Context, link *database.Link) (err error) {
+	args := u.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (u *UrlRepositoryMock) CreateLinkWithID(ctx context.Context, link *database.Link) (err error) {
+	args := u.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (u *UrlRepositoryMock) GetLink(ctx context.Context, id int64) (link database.Link, err error) {
+	args := u.Called(ctx, id)
+	return args.Get(0).(database.Link), args.Error(1)
+}
+
+func (u *UrlRepositoryMock) UpdateLink(ctx context.Context, link database.Link, fields []database.LinkField) (updated database.Link, err error) {
+	args := u.Called(ctx, link, fields)
+	return args.Get(0).(database.Link), args.Error(1)
+}
+
+func (u *UrlRepositoryMock) DeleteLink(ctx context.Context, id int64) (err error) {
+	args := u.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (u *UrlRepositoryMock) ListLinks(ctx context.Context, filter database.ListLinksFilter) (links []database.Link, err error) {
+	args := u.Called(ctx, filter)
+	return args.Get(0).([]database.Link), args.Error(1)
+}
+
+func (u *UrlRepositoryMock) UpdateTags(ctx context.Context, domain string, ids []int64, add, remove []string) (updated []database.Link, found []int64, err error) {
+	args := u.Called(ctx, domain, ids, add, remove)
+	return args.Get(0).([]database.Link), args.Get(1).([]int64), args.Error(2)
+}
+
+func (u *UrlRepositoryMock) CampaignStats(ctx context.Context, domain string) (stats []database.CampaignStats, err error) {
+	args := u.Called(ctx, domain)
+	return args.Get(0).([]database.CampaignStats), args.Error(1)
+}
+
+func (u *UrlRepositoryMock) RecordClick(ctx context.Context, id int64, at time.Time) (err error) {
+	args := u.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func (u *UrlRepositoryMock) ClickHistory(ctx context.Context, id int64, since time.Time) (days []database.DailyClicks, err error) {
+	args := u.Called(ctx, id, since)
+	return args.Get(0).([]database.DailyClicks), args.Error(1)
+}
+
+func (u *UrlRepositoryMock) SetClickHistory(ctx context.Context, id int64, days []database.DailyClicks) (err error) {
+	args := u.Called(ctx, id, days)
+	return args.Error(0)
+}
+
+func (u *UrlRepositoryMock) ConsumeClick(ctx context.Context, id int64) (remaining int64, err error) {
+	args := u.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (u *UrlRepositoryMock) UpdateRules(ctx context.Context, id int64, update func([]rules.Rule) ([]rules.Rule, error)) (updated database.Link, err error) {
+	args := u.Called(ctx, id, update)
+	return args.Get(0).(database.Link), args.Error(1)
+}
+
+func (u *UrlRepositoryMock) RecordVariantClick(ctx context.Context, id int64, variantID string) (err error) {
+	args := u.Called(ctx, id, variantID)
+	return args.Error(0)
+}
+
+func (u *UrlRepositoryMock) VariantClicks(ctx context.Context, id int64) (clicks map[string]int64, err error) {
+	args := u.Called(ctx, id)
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func (u *UrlRepositoryMock) SetVariantClicks(ctx context.Context, id int64, clicks map[string]int64) (err error) {
+	args := u.Called(ctx, id, clicks)
 	return args.Error(0)
 }
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestUrlShortener_ResolvePassthrough(t *testing.T) {
+	ctx := context.Background()
+	u, _, code := newSplitLink(t, database.Link{Passthrough: passthrough.Options{
+		Query: passthrough.QueryKeep,
+		Path:  true,
+		UTM:   passthrough.UTM{Source: "newsletter"},
+	}})
+
+	resolved, err := u.Resolve(ctx, ResolveRequest{
+		Code:        code,
+		Path:        "docs/intro",
+		RequestInfo: RequestInfo{Query: map[string][]string{"gclid": {"1"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/web/docs/intro?utm_source=newsletter&gclid=1", resolved.Target)
+
+	_, err = u.Resolve(ctx, ResolveRequest{Code: code, Path: "%2e%2e/admin"})
+	assert.ErrorIs(t, err, passthrough.ErrInvalidPath)
+
+	u, _, code = newSplitLink(t, database.Link{})
+	_, err = u.Resolve(ctx, ResolveRequest{Code: code, Path: "docs"})
+	assert.ErrorIs(t, err, passthrough.ErrInvalidPath, "links without path passthrough have no sub-paths")
+}
+
+func TestUrlShortener_ResolvePassthroughSplit(t *testing.T) {
+	u, _, code := newSplitLink(t, database.Link{
+		Variants:    abVariants,
+		SplitMode:   split.ModeSticky,
+		Passthrough: passthrough.Options{Query: passthrough.QueryOverride},
+	})
+	resolved, err := u.Resolve(context.Background(), ResolveRequest{
+		Code:        code,
+		Visitor:     "cookie-1",
+		RequestInfo: RequestInfo{Query: map[string][]string{"ref": {"ad"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/"+resolved.VariantID+"?ref=ad", resolved.Target, "the query goes to the picked variant")
+}
+
+func TestUrlShortener_ResolvePassthroughFailureKeepsClick(t *testing.T) {
+	// The variant target can't take the UTM parameters, so the redirect is refused.
+	u, urlRepo, code := newSplitLink(t, database.Link{
+		MaxClicks:       3,
+		RemainingClicks: 3,
+		Variants:        []split.Variant{{ID: "a", Target: "https://example.com/a?x=%zz", Weight: 1}},
+		Passthrough:     passthrough.Options{UTM: passthrough.UTM{Source: "newsletter"}},
+	})
+	_, err := u.Resolve(context.Background(), ResolveRequest{Code: code})
+	require.Error(t, err)
+	urlRepo.AssertNotCalled(t, "ConsumeClick", mock.Anything, mock.Anything)
+	urlRepo.AssertNotCalled(t, "RecordVariantClick", mock.Anything, mock.Anything, mock.Anything)
+	urlRepo.AssertNotCalled(t, "RecordClick", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUrlShortener_PassthroughValidation(t *testing.T) {
+	u := NewUrlShortener(new(UrlRepositoryMock), zaptest.NewLogger(t))
+	_, err := u.CreateLink(context.Background(), database.Link{
+		Target:      "https://example.com",
+		Passthrough: passthrough.Options{Query: "merge"},
+	})
+	assert.ErrorIs(t, err, passthrough.ErrInvalidOptions)
+}
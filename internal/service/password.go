@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/attempts"
+	"github.com/Parzival-05/url-shortener/internal/database"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrPasswordRequired = errors.New("link is password protected")
+	ErrInvalidPassword  = errors.New("invalid link password")
+	ErrTooManyAttempts  = errors.New("too many password attempts, try again later")
+	ErrPasswordTooLong  = errors.New("password must be at most 72 bytes")
+)
+
+const (
+	// MaxPasswordLength is the bcrypt input limit in bytes.
+	MaxPasswordLength    = 72
+	DefaultAccessTTL     = 15 * time.Minute
+	defaultMaxAttempts   = 5
+	defaultAttemptWindow = time.Minute
+)
+
+type concealKey struct{}
+
+// WithConcealedTargets marks ctx for callers not trusted with the targets of password protected
+// links: links, rules, variants and rule evaluations returned for it leave them out.
+func WithConcealedTargets(ctx context.Context) context.Context {
+	return context.WithValue(ctx, concealKey{}, true)
+}
+
+func concealsTargets(ctx context.Context) bool {
+	conceal, _ := ctx.Value(concealKey{}).(bool)
+	return conceal
+}
+
+// HashPassword returns the hash to store in Link.PasswordHash. An empty password
+// returns an empty hash, which removes the protection.
+func HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	if len(password) > MaxPasswordLength {
+		return "", ErrPasswordTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// newAttemptLimiter reads PASSWORD_MAX_ATTEMPTS and PASSWORD_ATTEMPT_WINDOW.
+func newAttemptLimiter() *attempts.Limiter {
+	maxAttempts, window := defaultMaxAttempts, defaultAttemptWindow
+	if n, err := strconv.Atoi(os.Getenv("PASSWORD_MAX_ATTEMPTS")); err == nil && n > 0 {
+		maxAttempts = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("PASSWORD_ATTEMPT_WINDOW")); err == nil && d > 0 {
+		window = d
+	}
+	return attempts.New(maxAttempts, window)
+}
+
+// checkPassword verifies req.Password with attempt throttling and returns a new access token.
+func (u *UrlShortener) checkPassword(ctx context.Context, link Link, req ResolveRequest, now time.Time) (string, time.Time, error) {
+	if req.Password == "" {
+		return "", time.Time{}, ErrPasswordRequired
+	}
+
+	key := link.Code + "|" + req.Client
+	if !u.attempts.Attempt(key, now) {
+		u.logger(ctx).Warn("password attempts throttled", zap.String("code", link.Code), zap.String("client", req.Client))
+		return "", time.Time{}, ErrTooManyAttempts
+	}
+	// bcrypt compares in constant time.
+	if bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(req.Password)) != nil {
+		u.logger(ctx).Debug("invalid link password", zap.String("code", link.Code))
+		return "", time.Time{}, ErrInvalidPassword
+	}
+	u.attempts.Reset(key)
+	token, expiry := u.access.issue(link.Link, now)
+	return token, expiry, nil
+}
+
+// accessSigner issues and verifies tokens proving that the password of a link was entered.
+// Tokens are bound to the password hash, so changing the password revokes them.
+type accessSigner struct {
+	key []byte
+	ttl time.Duration
+}
+
+// newAccessSigner signs with LINK_ACCESS_SECRET, or with a random key when it is unset,
+// in which case tokens don't survive restarts and aren't shared between replicas.
+func newAccessSigner(log *zap.Logger) accessSigner {
+	ttl := DefaultAccessTTL
+	if d, err := time.ParseDuration(os.Getenv("LINK_ACCESS_TTL")); err == nil && d > 0 {
+		ttl = d
+	}
+	key := []byte(os.Getenv("LINK_ACCESS_SECRET"))
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+		log.Warn("LINK_ACCESS_SECRET is not set, password protected link access won't survive restarts")
+	}
+	return accessSigner{key: key, ttl: ttl}
+}
+
+func (s accessSigner) mac(link database.Link, expiry string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(strconv.FormatInt(link.ID, 10) + "|" + expiry + "|" + link.PasswordHash))
+	return h.Sum(nil)
+}
+
+func (s accessSigner) issue(link database.Link, now time.Time) (string, time.Time) {
+	expiry := now.Add(s.ttl)
+	exp := strconv.FormatInt(expiry.Unix(), 10)
+	return exp + "." + base64.RawURLEncoding.EncodeToString(s.mac(link, exp)), expiry
+}
+
+func (s accessSigner) verify(link database.Link, token string, now time.Time) bool {
+	exp, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || !now.Before(time.Unix(unix, 0)) {
+		return false
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, s.mac(link, exp))
+}
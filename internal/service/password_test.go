@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newProtectedLink(t *testing.T, password string) (*UrlRepositoryMock, string) {
+	t.Helper()
+	hash, err := HashPassword(password)
+	require.NoError(t, err)
+	code, err := encodeID(42)
+	require.NoError(t, err)
+
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	urlRepo.On("GetLink", mock.Anything, int64(42)).Return(database.Link{
+		ID:           42,
+		Target:       "https://example.com/internal",
+		PasswordHash: hash,
+	}, nil)
+	return urlRepo, code
+}
+
+func TestUrlShortener_ResolvePassword(t *testing.T) {
+	ctx := context.Background()
+	urlRepo, code := newProtectedLink(t, "s3cret")
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+
+	_, err := u.GetFullUrl(ctx, code)
+	assert.ErrorIs(t, err, ErrPasswordRequired)
+	_, err = u.Resolve(ctx, ResolveRequest{Code: code})
+	assert.ErrorIs(t, err, ErrPasswordRequired)
+	_, err = u.Resolve(ctx, ResolveRequest{Code: code, Password: "wrong"})
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: code, Password: "s3cret"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/internal", resolved.Target)
+	require.NotEmpty(t, resolved.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(DefaultAccessTTL), resolved.AccessTokenExpiry, time.Minute)
+
+	// The access token skips the password check.
+	again, err := u.Resolve(ctx, ResolveRequest{Code: code, AccessToken: resolved.AccessToken})
+	require.NoError(t, err)
+	assert.Empty(t, again.AccessToken)
+
+	for _, token := range []string{"garbage", "1.abc", resolved.AccessToken + "x"} {
+		_, err = u.Resolve(ctx, ResolveRequest{Code: code, AccessToken: token})
+		assert.ErrorIs(t, err, ErrPasswordRequired, token)
+	}
+}
+
+func TestUrlShortener_ConcealedTargets(t *testing.T) {
+	hash, err := HashPassword("s3cret")
+	require.NoError(t, err)
+	code, err := encodeID(42)
+	require.NoError(t, err)
+	protected := database.Link{
+		ID:           42,
+		Target:       "https://example.com/internal",
+		PasswordHash: hash,
+		Rules:        []rules.Rule{{ID: "r1", Target: "https://example.com/android"}},
+		Variants:     []split.Variant{{ID: "a", Target: "https://example.com/a", Weight: 1}},
+	}
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("GetLink", mock.Anything, int64(42)).Return(protected, nil)
+	urlRepo.On("ListLinks", mock.Anything, mock.Anything).Return([]database.Link{protected}, nil)
+	urlRepo.On("VariantClicks", mock.Anything, int64(42)).Return(map[string]int64{"a": 3}, nil)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	urlRepo.On("RecordVariantClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	ctx := WithConcealedTargets(context.Background())
+
+	link, err := u.GetLink(ctx, code)
+	require.NoError(t, err)
+	assert.Empty(t, link.Target)
+	assert.Empty(t, link.Rules[0].Target)
+	assert.Empty(t, link.Variants[0].Target)
+	page, err := u.ListLinks(ctx, ListLinksQuery{})
+	require.NoError(t, err)
+	require.Len(t, page.Links, 1)
+	assert.Empty(t, page.Links[0].Target)
+	rs, err := u.ListRules(ctx, code)
+	require.NoError(t, err)
+	assert.Empty(t, rs[0].Target)
+	eval, err := u.EvaluateRules(ctx, code, EvaluateRulesRequest{Country: "DE"})
+	require.NoError(t, err)
+	assert.Empty(t, eval.Target)
+	stats, err := u.VariantStats(ctx, code)
+	require.NoError(t, err)
+	assert.Empty(t, stats[0].Target)
+	assert.Equal(t, int64(3), stats[0].Clicks)
+
+	// the stored link keeps its targets, and resolves to them with the password
+	assert.Equal(t, "https://example.com/android", protected.Rules[0].Target)
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: code, Password: "s3cret"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/android", resolved.Target)
+
+	link, err = u.GetLink(context.Background(), code)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/internal", link.Target)
+}
+
+func TestAccessSigner(t *testing.T) {
+	signer := accessSigner{key: []byte("key"), ttl: time.Minute}
+	now := time.Now()
+	link := database.Link{ID: 1, PasswordHash: "hash"}
+	token, _ := signer.issue(link, now)
+
+	assert.True(t, signer.verify(link, token, now))
+	assert.False(t, signer.verify(link, token, now.Add(2*time.Minute)), "expired")
+	assert.False(t, signer.verify(database.Link{ID: 2, PasswordHash: "hash"}, token, now), "other link")
+	assert.False(t, signer.verify(database.Link{ID: 1, PasswordHash: "changed"}, token, now), "password changed")
+	assert.False(t, accessSigner{key: []byte("other"), ttl: time.Minute}.verify(link, token, now), "other key")
+}
+
+func TestUrlShortener_ResolvePasswordThrottling(t *testing.T) {
+	t.Setenv("PASSWORD_MAX_ATTEMPTS", "3")
+	ctx := context.Background()
+	urlRepo, code := newProtectedLink(t, "s3cret")
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+
+	// Concurrent guesses can't exceed the limit.
+	var wrong atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := u.Resolve(ctx, ResolveRequest{Code: code, Password: "guess", Client: "10.0.0.1"})
+			if errors.Is(err, ErrInvalidPassword) {
+				wrong.Add(1)
+			} else {
+				assert.ErrorIs(t, err, ErrTooManyAttempts)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 3, wrong.Load())
+
+	// Even the right password is rejected while throttled, but other clients are not affected.
+	_, err := u.Resolve(ctx, ResolveRequest{Code: code, Password: "s3cret", Client: "10.0.0.1"})
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+	_, err = u.Resolve(ctx, ResolveRequest{Code: code, Password: "s3cret", Client: "10.0.0.2"})
+	assert.NoError(t, err)
+}
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("")
+	require.NoError(t, err)
+	assert.Empty(t, hash)
+
+	_, err = HashPassword(string(make([]byte, MaxPasswordLength+1)))
+	assert.ErrorIs(t, err, ErrPasswordTooLong)
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"sync"
+
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/qr"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrBaseURLNotConfigured = errors.New("BASE_URL is not configured")
+	ErrQRLogoNotConfigured  = errors.New("QR_LOGO_PATH is not configured")
+)
+
+type QRCodeOptions struct {
+	qr.Options
+	// Logo draws the logo from QR_LOGO_PATH in the center of the code.
+	Logo bool
+}
+
+// QRCode is a rendered QR code together with the short URL it encodes.
+type QRCode struct {
+	qr.Image
+	URL string
+}
+
+func loadLogo(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	logo, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode QR logo %s: %w", path, err)
+	}
+	return logo, nil
+}
+
+// qrLogo lazily loads the logo from QR_LOGO_PATH once.
+type qrLogo struct {
+	once sync.Once
+	img  image.Image
+	err  error
+}
+
+func (l *qrLogo) get() (image.Image, error) {
+	l.once.Do(func() {
+		path := os.Getenv("QR_LOGO_PATH")
+		if path == "" {
+			l.err = ErrQRLogoNotConfigured
+			return
+		}
+		l.img, l.err = loadLogo(path)
+	})
+	return l.img, l.err
+}
+
+func (u *UrlShortener) LinkQRCode(ctx context.Context, code string, opts QRCodeOptions) (QRCode, error) {
+	link, err := u.GetLink(ctx, code)
+	if err != nil {
+		return QRCode{}, err
+	}
+	shortURL := link.ShortURL
+	if shortURL == "" {
+		return QRCode{}, ErrBaseURLNotConfigured
+	}
+	if opts.Logo {
+		if opts.Options.Logo, err = u.logo.get(); err != nil {
+			u.logger(ctx).Error("failed to load QR logo", zap_utils.Err(err))
+			return QRCode{}, err
+		}
+	}
+	img, err := qr.Render(shortURL, opts.Options)
+	if err != nil {
+		return QRCode{}, err
+	}
+	u.logger(ctx).Debug("rendered qr code", zap.String("code", link.Code), zap.String("format", string(opts.Format)))
+	return QRCode{Image: img, URL: shortURL}, nil
+}
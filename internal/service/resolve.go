@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// ResolveRequest identifies a link to resolve together with the caller's credentials
+// for password protected links and the request redirect rules are evaluated against.
+type ResolveRequest struct {
+	RequestInfo
+	Code     string
+	Password string
+	// AccessToken is a token from a previous successful password check, if any.
+	AccessToken string
+	// Client identifies the caller, e.g. its IP address, for password attempt throttling
+	// and country redirect rules.
+	Client string
+	// Visitor identifies a returning visitor, e.g. by a cookie, for sticky split links.
+	// Client is used in its place if it is empty.
+	Visitor string
+	// Path is the escaped trailing path after the code. Only links forwarding paths or with a
+	// template path pattern accept one.
+	Path string
+}
+
+// Resolved is the resolved link. Its Target is the target of the matching redirect rule, if any,
+// or else of the picked split variant or the expanded link template, with the passthrough options
+// of the link applied.
+type Resolved struct {
+	Link
+	// RuleID is the ID of the redirect rule that matched, empty if none did.
+	RuleID string
+	// VariantID is the ID of the picked split variant, empty if the link has none or a rule matched.
+	VariantID string
+	// AccessToken is set when a password was verified. Presenting it in later requests
+	// skips the password check until AccessTokenExpiry.
+	AccessToken       string
+	AccessTokenExpiry time.Time
+}
+
+func (u *UrlShortener) Resolve(ctx context.Context, req ResolveRequest) (Resolved, error) {
+	link, err := u.getLink(ctx, req.Code)
+	if err != nil {
+		return Resolved{}, err
+	}
+	now := time.Now()
+	if err := checkActive(link.Link, now); err != nil {
+		u.publishExpired(ctx, link, err)
+		return Resolved{}, err
+	}
+	target, path, err := expandTemplate(link.Link, req)
+	if err != nil {
+		return Resolved{}, err
+	}
+	if err := link.Passthrough.CheckPath(path); err != nil {
+		return Resolved{}, err
+	}
+	resolved := Resolved{Link: link}
+	resolved.Target = target
+	if link.PasswordHash != "" && !u.access.verify(link.Link, req.AccessToken, now) {
+		if resolved.AccessToken, resolved.AccessTokenExpiry, err = u.checkPassword(ctx, link, req, now); err != nil {
+			return Resolved{}, err
+		}
+	}
+	u.applyRules(ctx, &resolved, req, now)
+	u.applySplit(ctx, &resolved, req)
+	if resolved.Target, err = link.Passthrough.Apply(resolved.Target, path, req.Query); err != nil {
+		return Resolved{}, err
+	}
+	// Clicks are taken last, once the redirect will be served, so that showing the password
+	// form or refusing the request doesn't use one up.
+	remaining := int64(-1)
+	if link.MaxClicks > 0 {
+		if remaining, err = u.urlRepo.ConsumeClick(ctx, link.ID); err != nil {
+			u.publishExpired(ctx, link, err)
+			return Resolved{}, err
+		}
+	}
+	u.recordVariantClick(ctx, resolved)
+	u.recordClick(ctx, link, now)
+	u.publishClicked(ctx, resolved)
+	if remaining == 0 {
+		// This resolve took the last click.
+		exhausted := link
+		exhausted.RemainingClicks = 0
+		u.publishExpired(ctx, exhausted, ErrLinkExhausted)
+	}
+	return resolved, nil
+}
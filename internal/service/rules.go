@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRuleNotFound       = errors.New("redirect rule not found")
+	ErrTooManyRules       = errors.New("link has too many redirect rules")
+	ErrGeoIPNotConfigured = errors.New("GEOIP_DB_PATH is not configured")
+)
+
+// MaxRules bounds the redirect rules of a link, as they are evaluated on every resolve.
+const MaxRules = 32
+
+// RequestInfo describes the request a link is resolved for. Redirect rules are evaluated against it.
+type RequestInfo struct {
+	UserAgent      string
+	AcceptLanguage string
+	Query          url.Values
+}
+
+// EvaluateRulesRequest is the request context of a dry run of the redirect rules of a link.
+type EvaluateRulesRequest struct {
+	RequestInfo
+	// Client is the IP address to look up the country of, unless Country is set.
+	Client  string
+	Country string
+	// Time defaults to now.
+	Time time.Time
+}
+
+type RuleEvaluation struct {
+	// Request is what the rules were evaluated against.
+	Request rules.Request
+	// Rule is the first matching rule, nil if none matched.
+	Rule *rules.Rule
+	// Target is where the link would redirect to.
+	Target string
+}
+
+type countryLookup interface {
+	Country(ip string) (string, error)
+}
+
+// geoIP lazily opens the GeoIP database from GEOIP_DB_PATH once.
+type geoIP struct {
+	once   sync.Once
+	lookup countryLookup
+	err    error
+}
+
+func (g *geoIP) get() (countryLookup, error) {
+	g.once.Do(func() {
+		path := os.Getenv("GEOIP_DB_PATH")
+		if path == "" {
+			g.err = ErrGeoIPNotConfigured
+			return
+		}
+		db, err := rules.OpenGeoIP(path)
+		if err != nil {
+			g.err = err
+			return
+		}
+		g.lookup = db
+	})
+	return g.lookup, g.err
+}
+
+// country returns the country of the client, or an empty string if it is unknown.
+func (u *UrlShortener) country(ctx context.Context, client string) string {
+	if client == "" {
+		return ""
+	}
+	lookup, err := u.geo.get()
+	if err != nil {
+		if !errors.Is(err, ErrGeoIPNotConfigured) {
+			u.logger(ctx).Warn("failed to open GeoIP database", zap_utils.Err(err))
+		}
+		return ""
+	}
+	country, err := lookup.Country(client)
+	if err != nil {
+		u.logger(ctx).Warn("failed to look up client country", zap.String("client", client), zap_utils.Err(err))
+		return ""
+	}
+	return country
+}
+
+func ruleRequest(info RequestInfo, now time.Time) rules.Request {
+	return rules.Request{
+		Platform:  rules.ParsePlatform(info.UserAgent),
+		Languages: rules.ParseAcceptLanguage(info.AcceptLanguage),
+		Time:      now,
+		Query:     info.Query,
+	}
+}
+
+// applyRules points resolved at the target of the first matching rule.
+func (u *UrlShortener) applyRules(ctx context.Context, resolved *Resolved, req ResolveRequest, now time.Time) {
+	if len(resolved.Rules) == 0 {
+		return
+	}
+	rr := ruleRequest(req.RequestInfo, now)
+	// Only look up the country if a rule can use it.
+	if slices.ContainsFunc(resolved.Rules, func(r rules.Rule) bool { return len(r.Conditions.Countries) > 0 }) {
+		rr.Country = u.country(ctx, req.Client)
+	}
+	if rule, ok := rules.Evaluate(resolved.Rules, rr); ok {
+		resolved.Target = rule.Target
+		resolved.RuleID = rule.ID
+	}
+}
+
+func newRuleID() string {
+	id := make([]byte, 6)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func validateRule(rule rules.Rule) error {
+	if err := validateTarget(rule.Target); err != nil {
+		return err
+	}
+	return rule.Validate()
+}
+
+// insertAt inserts rule at index, or appends it if index is negative or past the end.
+func insertAt(rs []rules.Rule, rule rules.Rule, index int) []rules.Rule {
+	if index < 0 || index > len(rs) {
+		index = len(rs)
+	}
+	return slices.Insert(rs, index, rule)
+}
+
+func (u *UrlShortener) ListRules(ctx context.Context, code string) ([]rules.Rule, error) {
+	link, err := u.GetLink(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return link.Rules, nil
+}
+
+func (u *UrlShortener) CreateRule(ctx context.Context, code string, rule rules.Rule, index int) (rules.Rule, error) {
+	if err := validateRule(rule); err != nil {
+		return rules.Rule{}, err
+	}
+	id, err := u.lookupID(ctx, code)
+	if err != nil {
+		return rules.Rule{}, err
+	}
+	rule.ID = newRuleID()
+	_, err = u.urlRepo.UpdateRules(ctx, id, func(rs []rules.Rule) ([]rules.Rule, error) {
+		if len(rs) >= MaxRules {
+			return nil, ErrTooManyRules
+		}
+		return insertAt(rs, rule, index), nil
+	})
+	if err != nil {
+		return rules.Rule{}, err
+	}
+	u.logger(ctx).Debug("created redirect rule", zap.Int64("id", id), zap.String("rule", rule.ID))
+	return rule, nil
+}
+
+func (u *UrlShortener) UpdateRule(ctx context.Context, code string, rule rules.Rule, index int) (rules.Rule, error) {
+	if err := validateRule(rule); err != nil {
+		return rules.Rule{}, err
+	}
+	id, err := u.lookupID(ctx, code)
+	if err != nil {
+		return rules.Rule{}, err
+	}
+	_, err = u.urlRepo.UpdateRules(ctx, id, func(rs []rules.Rule) ([]rules.Rule, error) {
+		i := slices.IndexFunc(rs, func(r rules.Rule) bool { return r.ID == rule.ID })
+		if i < 0 {
+			return nil, ErrRuleNotFound
+		}
+		if index < 0 {
+			rs[i] = rule
+			return rs, nil
+		}
+		return insertAt(slices.Delete(rs, i, i+1), rule, index), nil
+	})
+	if err != nil {
+		return rules.Rule{}, err
+	}
+	u.logger(ctx).Debug("updated redirect rule", zap.Int64("id", id), zap.String("rule", rule.ID))
+	return rule, nil
+}
+
+func (u *UrlShortener) DeleteRule(ctx context.Context, code string, ruleID string) error {
+	id, err := u.lookupID(ctx, code)
+	if err != nil {
+		return err
+	}
+	_, err = u.urlRepo.UpdateRules(ctx, id, func(rs []rules.Rule) ([]rules.Rule, error) {
+		i := slices.IndexFunc(rs, func(r rules.Rule) bool { return r.ID == ruleID })
+		if i < 0 {
+			return nil, ErrRuleNotFound
+		}
+		return slices.Delete(rs, i, i+1), nil
+	})
+	if err != nil {
+		return err
+	}
+	u.logger(ctx).Debug("deleted redirect rule", zap.Int64("id", id), zap.String("rule", ruleID))
+	return nil
+}
+
+// EvaluateRules reports where the link would redirect the given request to, without
+// checking whether the link is active and without using up a click.
+func (u *UrlShortener) EvaluateRules(ctx context.Context, code string, req EvaluateRulesRequest) (RuleEvaluation, error) {
+	link, err := u.GetLink(ctx, code)
+	if err != nil {
+		return RuleEvaluation{}, err
+	}
+	now := req.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	eval := RuleEvaluation{Request: ruleRequest(req.RequestInfo, now), Target: link.Target}
+	eval.Request.Country = req.Country
+	if eval.Request.Country == "" {
+		eval.Request.Country = u.country(ctx, req.Client)
+	}
+	if rule, ok := rules.Evaluate(link.Rules, eval.Request); ok {
+		eval.Rule = &rule
+		eval.Target = rule.Target
+	}
+	return eval, nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fakeGeoIP struct {
+	countries map[string]string
+	lookups   atomic.Int64
+}
+
+func (g *fakeGeoIP) Country(ip string) (string, error) {
+	g.lookups.Add(1)
+	return g.countries[ip], nil
+}
+
+func newRuleLink(t *testing.T, rs []rules.Rule) (*UrlShortener, *fakeGeoIP, string) {
+	t.Helper()
+	code, err := encodeID(7)
+	require.NoError(t, err)
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	urlRepo.On("GetLink", mock.Anything, int64(7)).Return(database.Link{
+		ID:     7,
+		Target: "https://example.com/web",
+		Rules:  rs,
+	}, nil)
+
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	geo := &fakeGeoIP{countries: map[string]string{"192.0.2.1": "DE", "198.51.100.1": "US"}}
+	u.geo.once.Do(func() { u.geo.lookup = geo })
+	return u, geo, code
+}
+
+const iPhone = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"
+
+func TestUrlShortener_ResolveRules(t *testing.T) {
+	ctx := context.Background()
+	u, geo, code := newRuleLink(t, []rules.Rule{
+		{ID: "ios", Target: "https://apps.apple.com/app", Conditions: rules.Conditions{Platforms: []rules.Platform{rules.PlatformIOS}}},
+		{ID: "campaign", Target: "https://example.com/campaign", Conditions: rules.Conditions{Query: map[string]string{"src": "qr"}}},
+	})
+
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: code, RequestInfo: RequestInfo{UserAgent: iPhone}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://apps.apple.com/app", resolved.Target)
+	assert.Equal(t, "ios", resolved.RuleID)
+
+	resolved, err = u.Resolve(ctx, ResolveRequest{Code: code, RequestInfo: RequestInfo{Query: url.Values{"src": {"qr"}}}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/campaign", resolved.Target)
+
+	target, err := u.GetFullUrl(ctx, code)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/web", target, "no rule matches falls back to the link target")
+	assert.Zero(t, geo.lookups.Load(), "no rule needs the country")
+}
+
+func TestUrlShortener_ResolveRulesCountry(t *testing.T) {
+	ctx := context.Background()
+	u, geo, code := newRuleLink(t, []rules.Rule{
+		{ID: "de", Target: "https://example.de", Conditions: rules.Conditions{Countries: []string{"DE", "AT"}}},
+	})
+
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: code, Client: "192.0.2.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.de", resolved.Target)
+
+	resolved, err = u.Resolve(ctx, ResolveRequest{Code: code, Client: "198.51.100.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/web", resolved.Target)
+	assert.EqualValues(t, 2, geo.lookups.Load())
+}
+
+func TestUrlShortener_EvaluateRules(t *testing.T) {
+	ctx := context.Background()
+	u, _, code := newRuleLink(t, []rules.Rule{
+		{ID: "weekend", Target: "https://example.com/weekend", Conditions: rules.Conditions{
+			Days:     []time.Weekday{time.Saturday, time.Sunday},
+			TimeZone: "Europe/Berlin",
+		}},
+		{ID: "us", Target: "https://example.com/us", Conditions: rules.Conditions{Countries: []string{"US"}}},
+	})
+
+	saturday := time.Date(2025, 1, 4, 12, 0, 0, 0, time.UTC)
+	eval, err := u.EvaluateRules(ctx, code, EvaluateRulesRequest{Time: saturday, Client: "192.0.2.1"})
+	require.NoError(t, err)
+	require.NotNil(t, eval.Rule)
+	assert.Equal(t, "weekend", eval.Rule.ID)
+	assert.Equal(t, "https://example.com/weekend", eval.Target)
+	assert.Equal(t, "DE", eval.Request.Country, "the country is looked up for display even if no rule needs it")
+	assert.Equal(t, saturday, eval.Request.Time)
+
+	monday := saturday.AddDate(0, 0, 2)
+	eval, err = u.EvaluateRules(ctx, code, EvaluateRulesRequest{
+		Time:        monday,
+		Client:      "192.0.2.1",
+		Country:     "us",
+		RequestInfo: RequestInfo{UserAgent: iPhone, AcceptLanguage: "en-US,en;q=0.9"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, eval.Rule)
+	assert.Equal(t, "us", eval.Rule.ID, "country overrides the lookup")
+	assert.Equal(t, rules.PlatformIOS, eval.Request.Platform)
+	assert.Equal(t, []string{"en-US", "en"}, eval.Request.Languages)
+
+	eval, err = u.EvaluateRules(ctx, code, EvaluateRulesRequest{Time: monday})
+	require.NoError(t, err)
+	assert.Nil(t, eval.Rule)
+	assert.Equal(t, "https://example.com/web", eval.Target)
+}
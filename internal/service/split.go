@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/split"
+
+	"go.uber.org/zap"
+)
+
+// VariantStats is a split variant of a link together with the resolves that picked it.
+type VariantStats struct {
+	split.Variant
+	Clicks int64
+	// Removed marks variants that have clicks recorded but are no longer configured.
+	Removed bool
+}
+
+func validateVariants(variants []split.Variant) error {
+	for _, v := range variants {
+		if err := validateTarget(v.Target); err != nil {
+			return err
+		}
+	}
+	return split.Validate(variants)
+}
+
+func validateSplitMode(mode split.Mode) error {
+	if !mode.Valid() {
+		return fmt.Errorf("%w: unknown split mode %q", split.ErrInvalidVariants, mode)
+	}
+	return nil
+}
+
+// applySplit points resolved at one of the split variants of the link, unless a redirect
+// rule already chose the target. Sticky links hash the visitor, falling back to the client address,
+// with the link ID.
+func (u *UrlShortener) applySplit(ctx context.Context, resolved *Resolved, req ResolveRequest) {
+	if resolved.RuleID != "" || len(resolved.Variants) == 0 {
+		return
+	}
+	key := req.Visitor
+	if key == "" {
+		key = req.Client
+	}
+	variant, ok := u.picker.Pick(uint64(resolved.ID), resolved.Variants, resolved.SplitMode, key)
+	if !ok {
+		return
+	}
+	resolved.Target = variant.Target
+	resolved.VariantID = variant.ID
+}
+
+// recordVariantClick counts the click of the split variant resolved points at, if any.
+func (u *UrlShortener) recordVariantClick(ctx context.Context, resolved Resolved) {
+	if resolved.VariantID == "" {
+		return
+	}
+	// A failure to count the click shouldn't fail the redirect.
+	if err := u.urlRepo.RecordVariantClick(ctx, resolved.ID, resolved.VariantID); err != nil {
+		u.logger(ctx).Warn("failed to record variant click", zap.Int64("id", resolved.ID),
+			zap.String("variant", resolved.VariantID), zap_utils.Err(err))
+		return
+	}
+	u.logger(ctx).Debug("picked split variant", zap.Int64("id", resolved.ID), zap.String("variant", resolved.VariantID))
+}
+
+// VariantStats returns the split variants of the link with the given code and how often each was picked.
+func (u *UrlShortener) VariantStats(ctx context.Context, code string) ([]VariantStats, error) {
+	link, err := u.GetLink(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	clicks, err := u.urlRepo.VariantClicks(ctx, link.ID)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]VariantStats, 0, len(link.Variants))
+	for _, v := range link.Variants {
+		stats = append(stats, VariantStats{Variant: v, Clicks: clicks[v.ID]})
+		delete(clicks, v.ID)
+	}
+	removed := make([]string, 0, len(clicks))
+	for id := range clicks {
+		removed = append(removed, id)
+	}
+	slices.Sort(removed)
+	for _, id := range removed {
+		stats = append(stats, VariantStats{Variant: split.Variant{ID: id}, Clicks: clicks[id], Removed: true})
+	}
+	return stats, nil
+}
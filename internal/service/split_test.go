@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+var abVariants = []split.Variant{
+	{ID: "a", Target: "https://example.com/a", Weight: 1},
+	{ID: "b", Target: "https://example.com/b", Weight: 1},
+}
+
+func newSplitLink(t *testing.T, link database.Link) (*UrlShortener, *UrlRepositoryMock, string) {
+	t.Helper()
+	link.ID = 9
+	link.Target = "https://example.com/web"
+	code, err := encodeID(link.ID)
+	require.NoError(t, err)
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	urlRepo.On("GetLink", mock.Anything, link.ID).Return(link, nil)
+	urlRepo.On("RecordVariantClick", mock.Anything, link.ID, mock.Anything).Return(nil)
+
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	u.picker = split.NewPicker(rand.NewPCG(1, 2))
+	return u, urlRepo, code
+}
+
+func TestUrlShortener_ResolveSplit(t *testing.T) {
+	ctx := context.Background()
+	resolveAll := func() []string {
+		u, _, code := newSplitLink(t, database.Link{Variants: abVariants})
+		var targets []string
+		for range 10 {
+			resolved, err := u.Resolve(ctx, ResolveRequest{Code: code})
+			require.NoError(t, err)
+			targets = append(targets, resolved.VariantID)
+		}
+		return targets
+	}
+	first := resolveAll()
+	assert.Equal(t, first, resolveAll(), "a seeded picker picks the same variants")
+	assert.Contains(t, first, "a")
+	assert.Contains(t, first, "b")
+
+	u, urlRepo, code := newSplitLink(t, database.Link{Variants: abVariants})
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: code})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/"+resolved.VariantID, resolved.Target)
+	urlRepo.AssertCalled(t, "RecordVariantClick", mock.Anything, int64(9), resolved.VariantID)
+}
+
+func TestUrlShortener_ResolveSplitSticky(t *testing.T) {
+	ctx := context.Background()
+	u, _, code := newSplitLink(t, database.Link{Variants: abVariants, SplitMode: split.ModeSticky})
+
+	picked := map[string]string{}
+	for _, req := range []ResolveRequest{
+		{Code: code, Visitor: "cookie-1"},
+		{Code: code, Visitor: "cookie-2"},
+		{Code: code, Visitor: "cookie-3"},
+		{Code: code, Client: "192.0.2.1"},
+		{Code: code, Client: "192.0.2.2"},
+	} {
+		key := req.Visitor + req.Client
+		for range 5 {
+			resolved, err := u.Resolve(ctx, req)
+			require.NoError(t, err)
+			if prev, ok := picked[key]; ok {
+				require.Equal(t, prev, resolved.VariantID, "%s keeps its variant", key)
+			}
+			picked[key] = resolved.VariantID
+		}
+	}
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: code, Visitor: "cookie-1", Client: "192.0.2.2"})
+	require.NoError(t, err)
+	assert.Equal(t, picked["cookie-1"], resolved.VariantID, "the visitor takes precedence over the client")
+}
+
+func TestUrlShortener_ResolveSplitRule(t *testing.T) {
+	u, urlRepo, code := newSplitLink(t, database.Link{
+		Variants: abVariants,
+		Rules:    []rules.Rule{{ID: "qr", Target: "https://example.com/qr", Conditions: rules.Conditions{Query: map[string]string{"src": "qr"}}}},
+	})
+	resolved, err := u.Resolve(context.Background(), ResolveRequest{Code: code, RequestInfo: RequestInfo{Query: map[string][]string{"src": {"qr"}}}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/qr", resolved.Target, "a matching rule wins over the split")
+	assert.Empty(t, resolved.VariantID)
+	urlRepo.AssertNotCalled(t, "RecordVariantClick", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUrlShortener_ResolveSplitRecordFailure(t *testing.T) {
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	urlRepo.On("GetLink", mock.Anything, int64(9)).Return(database.Link{ID: 9, Target: "https://example.com/web", Variants: abVariants}, nil)
+	urlRepo.On("RecordVariantClick", mock.Anything, int64(9), mock.Anything).Return(errors.New("db down"))
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	code, err := encodeID(9)
+	require.NoError(t, err)
+
+	resolved, err := u.Resolve(context.Background(), ResolveRequest{Code: code})
+	require.NoError(t, err, "failing to count a click doesn't fail the redirect")
+	assert.NotEmpty(t, resolved.VariantID)
+}
+
+func TestUrlShortener_VariantStats(t *testing.T) {
+	u, urlRepo, code := newSplitLink(t, database.Link{Variants: abVariants})
+	urlRepo.On("VariantClicks", mock.Anything, int64(9)).Return(map[string]int64{"a": 7, "old-2": 1, "old-1": 3}, nil)
+
+	stats, err := u.VariantStats(context.Background(), code)
+	require.NoError(t, err)
+	assert.Equal(t, []VariantStats{
+		{Variant: abVariants[0], Clicks: 7},
+		{Variant: abVariants[1]},
+		{Variant: split.Variant{ID: "old-1"}, Clicks: 3, Removed: true},
+		{Variant: split.Variant{ID: "old-2"}, Clicks: 1, Removed: true},
+	}, stats)
+}
+
+func TestUrlShortener_SplitValidation(t *testing.T) {
+	ctx := context.Background()
+	u := NewUrlShortener(new(UrlRepositoryMock), zaptest.NewLogger(t))
+	code, err := encodeID(9)
+	require.NoError(t, err)
+
+	_, err = u.CreateLink(ctx, database.Link{Target: "https://example.com", Variants: []split.Variant{{ID: "a", Target: "ftp://example.com", Weight: 1}}})
+	assert.ErrorIs(t, err, ErrInvalidTarget)
+	_, err = u.CreateLink(ctx, database.Link{Target: "https://example.com", Variants: []split.Variant{{ID: "a", Target: "https://example.com"}}})
+	assert.ErrorIs(t, err, split.ErrInvalidVariants)
+	_, err = u.UpdateLink(ctx, code, database.Link{SplitMode: "round-robin"}, []database.LinkField{database.LinkFieldSplitMode})
+	assert.ErrorIs(t, err, split.ErrInvalidVariants)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+
+	"go.uber.org/zap"
+)
+
+// MaxTagBatchSize is the number of links TagLinks takes at once.
+const MaxTagBatchSize = 1000
+
+var ErrTooManyCodes = errors.New("too many codes")
+
+// TagLinksResult is the outcome of tagging links in bulk.
+type TagLinksResult struct {
+	// Links are the links whose tags changed.
+	Links []Link
+	// NotFound are the codes that are malformed or name no link of the domain.
+	NotFound []string
+}
+
+// TagLinks adds the tags add to and removes the tags remove from the links with the given codes
+// on the domain selected in ctx, all at once. Unknown codes are reported rather than failing the
+// others.
+func (u *UrlShortener) TagLinks(ctx context.Context, codes []string, add, remove []string) (TagLinksResult, error) {
+	if len(codes) > MaxTagBatchSize {
+		return TagLinksResult{}, ErrTooManyCodes
+	}
+	d, err := u.Domain(ctx)
+	if err != nil {
+		return TagLinksResult{}, err
+	}
+	var result TagLinksResult
+	ids := make([]int64, 0, len(codes))
+	codeIDs := make(map[string]int64, len(codes))
+	for _, code := range codes {
+		id, ok := d.Decode(code)
+		if !ok {
+			result.NotFound = append(result.NotFound, code)
+			continue
+		}
+		ids = append(ids, id)
+		codeIDs[code] = id
+	}
+	updated, found, err := u.urlRepo.UpdateTags(ctx, d.Name(), ids, add, remove)
+	if err != nil {
+		u.logger(ctx).Error("failed to tag links", zap_utils.Err(err))
+		return TagLinksResult{}, err
+	}
+	for code, id := range codeIDs {
+		if !slices.Contains(found, id) {
+			result.NotFound = append(result.NotFound, code)
+		}
+	}
+	slices.Sort(result.NotFound)
+	u.logger(ctx).Debug("tagged links", zap.Int("updated", len(updated)), zap.Int("not_found", len(result.NotFound)))
+
+	fields := []database.LinkField{database.LinkFieldTags}
+	result.Links = make([]Link, 0, len(updated))
+	for _, link := range updated {
+		l, err := withCode(d, link)
+		if err != nil {
+			return TagLinksResult{}, err
+		}
+		result.Links = append(result.Links, l)
+		u.publishUpdated(ctx, l, fields)
+	}
+	return result, nil
+}
+
+// CampaignStats returns the number of links and clicks of every campaign on the domain selected in ctx.
+func (u *UrlShortener) CampaignStats(ctx context.Context) ([]database.CampaignStats, error) {
+	d, err := u.Domain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := u.urlRepo.CampaignStats(ctx, d.Name())
+	if err != nil {
+		u.logger(ctx).Error("failed to get campaign stats", zap_utils.Err(err))
+		return nil, err
+	}
+	return stats, nil
+}
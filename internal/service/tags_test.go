@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestUrlShortener_TagLinks(t *testing.T) {
+	urlRepo := new(UrlRepositoryMock)
+	tagged := database.Link{ID: 9, Target: "https://example.com", Tags: []string{"q2"}}
+	urlRepo.On("UpdateTags", mock.Anything, "", []int64{9, 10}, []string{"q2"}, []string(nil)).
+		Return([]database.Link{tagged}, []int64{9}, nil).Once()
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t))
+	found, err := encodeID(9)
+	require.NoError(t, err)
+	missing, err := encodeID(10)
+	require.NoError(t, err)
+
+	result, err := u.TagLinks(context.Background(), []string{found, missing, "not a code"}, []string{"q2"}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Links, 1)
+	assert.Equal(t, found, result.Links[0].Code)
+	assert.ElementsMatch(t, []string{missing, "not a code"}, result.NotFound)
+
+	_, err = u.TagLinks(context.Background(), make([]string, MaxTagBatchSize+1), []string{"q2"}, nil)
+	assert.ErrorIs(t, err, ErrTooManyCodes)
+	urlRepo.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+)
+
+// validateLinkTarget checks the target of the link, against its template if it has one.
+func validateLinkTarget(link database.Link) error {
+	if link.Template == nil {
+		return validateTarget(link.Target)
+	}
+	if err := link.Template.Validate(link.Target); err != nil {
+		return err
+	}
+	if link.Template.Path != "" && link.Passthrough.Path {
+		return fmt.Errorf("%w: the trailing path can't be both matched by the template and forwarded", urltemplate.ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// validateTargetUpdate checks the target, template and passthrough options a partial update
+// leaves the link with. Unless all of them are updated, they are checked against the stored link.
+func validateTargetUpdate(stored, link database.Link, fields []database.LinkField) error {
+	target := slices.Contains(fields, database.LinkFieldTarget)
+	template := slices.Contains(fields, database.LinkFieldTemplate)
+	passthrough := slices.Contains(fields, database.LinkFieldPassthrough)
+	if !target && !template && !passthrough {
+		return nil
+	}
+	merged := link
+	if !target || !template || !passthrough {
+		merged = stored
+		if target {
+			merged.Target = link.Target
+		}
+		if template {
+			merged.Template = link.Template
+		}
+		if passthrough {
+			merged.Passthrough = link.Passthrough
+		}
+	}
+	return validateLinkTarget(merged)
+}
+
+// expandTemplate returns the target of the link for the request and the trailing path left
+// for passthrough, which is none if the path pattern of the template took it.
+func expandTemplate(link database.Link, req ResolveRequest) (target, path string, err error) {
+	if link.Template == nil {
+		return link.Target, req.Path, nil
+	}
+	target, err = link.Template.Expand(link.Target, req.Path, req.Query)
+	if err != nil {
+		return "", "", err
+	}
+	if link.Template.Path != "" {
+		return target, "", nil
+	}
+	return target, req.Path, nil
+}
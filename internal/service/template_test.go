@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/passthrough"
+	"github.com/Parzival-05/url-shortener/internal/urltemplate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTemplateLink(t *testing.T, link database.Link) (*UrlShortener, *UrlRepositoryMock, string) {
+	t.Helper()
+	link.ID = 9
+	code, err := encodeID(link.ID)
+	require.NoError(t, err)
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	urlRepo.On("GetLink", mock.Anything, link.ID).Return(link, nil)
+	return NewUrlShortener(urlRepo, zaptest.NewLogger(t)), urlRepo, code
+}
+
+func TestUrlShortener_ResolveTemplate(t *testing.T) {
+	ctx := context.Background()
+	u, _, code := newTemplateLink(t, database.Link{
+		Target:      "https://example.com/docs/{version=latest}/{+page=index.html}?user={uid=}",
+		Template:    &urltemplate.Template{Path: "{version}/{+page}"},
+		Passthrough: passthrough.Options{UTM: passthrough.UTM{Source: "short"}},
+	})
+
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: code, Path: "v2/guide/intro", RequestInfo: RequestInfo{Query: map[string][]string{"uid": {"42"}}}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs/v2/guide/intro?user=42&utm_source=short", resolved.Target,
+		"the path pattern takes the trailing path, which isn't checked by passthrough")
+
+	resolved, err = u.Resolve(ctx, ResolveRequest{Code: code})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs/latest/index.html?user=&utm_source=short", resolved.Target)
+
+	_, err = u.Resolve(ctx, ResolveRequest{Code: code, Path: "v2/.."})
+	assert.ErrorIs(t, err, urltemplate.ErrInvalidValue)
+}
+
+func TestUrlShortener_ResolveTemplateErrors(t *testing.T) {
+	ctx := context.Background()
+	u, urlRepo, code := newTemplateLink(t, database.Link{
+		Target:    "https://example.com/profile?user={uid}",
+		Template:  &urltemplate.Template{},
+		MaxClicks: 5, RemainingClicks: 5,
+	})
+	_, err := u.Resolve(ctx, ResolveRequest{Code: code})
+	assert.ErrorIs(t, err, urltemplate.ErrMissingVariable)
+	_, err = u.Resolve(ctx, ResolveRequest{Code: code, Path: "extra", RequestInfo: RequestInfo{Query: map[string][]string{"uid": {"1"}}}})
+	assert.ErrorIs(t, err, passthrough.ErrInvalidPath, "without a path pattern sub-paths need path passthrough")
+	urlRepo.AssertNotCalled(t, "ConsumeClick", mock.Anything, mock.Anything)
+
+	u, _, code = newTemplateLink(t, database.Link{Target: "https://example.com/{id}"})
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: code, RequestInfo: RequestInfo{Query: map[string][]string{"id": {"1"}}}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/{id}", resolved.Target, "exact links are never expanded")
+}
+
+func TestUrlShortener_TemplateValidation(t *testing.T) {
+	ctx := context.Background()
+	u, urlRepo, code := newTemplateLink(t, database.Link{
+		Target:   "https://example.com/{id}",
+		Template: &urltemplate.Template{Path: "{id}"},
+	})
+
+	_, err := u.CreateLink(ctx, database.Link{Target: "https://{sub}.example.com/", Template: &urltemplate.Template{}})
+	assert.ErrorIs(t, err, urltemplate.ErrInvalidTemplate)
+	_, err = u.CreateLink(ctx, database.Link{
+		Target:      "https://example.com/{id}",
+		Template:    &urltemplate.Template{Path: "{id}"},
+		Passthrough: passthrough.Options{Path: true},
+	})
+	assert.ErrorIs(t, err, urltemplate.ErrInvalidTemplate, "the path can't be matched and forwarded")
+
+	_, err = u.UpdateLink(ctx, code, database.Link{Target: "https://example.com/"}, []database.LinkField{database.LinkFieldTarget})
+	assert.ErrorIs(t, err, urltemplate.ErrInvalidTemplate, "the new target must still use the path variables")
+	_, err = u.UpdateLink(ctx, code, database.Link{Passthrough: passthrough.Options{Path: true}}, []database.LinkField{database.LinkFieldPassthrough})
+	assert.ErrorIs(t, err, urltemplate.ErrInvalidTemplate)
+
+	updated := database.Link{ID: 9, Target: "https://example.com/"}
+	urlRepo.On("UpdateLink", mock.Anything, updated, []database.LinkField{database.LinkFieldTarget, database.LinkFieldTemplate}).Return(updated, nil)
+	_, err = u.UpdateLink(ctx, code, database.Link{Target: "https://example.com/"}, []database.LinkField{database.LinkFieldTarget, database.LinkFieldTemplate})
+	assert.NoError(t, err, "removing the template turns the link into an exact one")
+}
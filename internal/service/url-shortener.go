@@ -1,94 +1,162 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"math/rand/v2"
 	"os"
 
+	"github.com/Parzival-05/url-shortener/internal/attempts"
 	"github.com/Parzival-05/url-shortener/internal/database"
-	"github.com/sqids/sqids-go"
+	"github.com/Parzival-05/url-shortener/internal/domains"
+	"github.com/Parzival-05/url-shortener/internal/logger/zap_utils"
+	"github.com/Parzival-05/url-shortener/internal/rules"
+	"github.com/Parzival-05/url-shortener/internal/split"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
 	"go.uber.org/zap"
 )
 
 var (
 	ErrUrlNotFound = errors.New("url not found")
 	ErrInvalidUrl  = errors.New("invalid shorten url")
+	// ErrUnavailable means the storage is failing or too slow and the request was given up on.
+	ErrUnavailable = errors.New("storage is unavailable, try again later")
 )
 
 type IUrlShortener interface {
-	// GetShortenUrl returns the shorten URL for a given full URL
+	// GetShortenUrl returns the shorten URL for a given full URL on the domain selected in ctx,
+	// the bare code if the domain has no base URL
 	GetShortenUrl(ctx context.Context, fullUrl string) (string, error)
 	// SaveShortenUrl saves a new shorten URL
 	SaveShortenUrl(ctx context.Context, fullUrl string) error
-	// GetFullUrl returns the full URL for a given shorten URL
+	// GetFullUrl returns the full URL for a given short code or fully qualified short URL
 	GetFullUrl(ctx context.Context, shortenUrl string) (string, error)
+	// Resolve returns an active link, checking the password of protected links
+	Resolve(ctx context.Context, req ResolveRequest) (Resolved, error)
 	// CreateUrl creates a new short link for a given URL or returns the existing
 	CreateUrl(ctx context.Context, fullUrl string) (string, error)
+
+	// CreateLink always creates a new link, even if one with the same target exists
+	CreateLink(ctx context.Context, link database.Link) (Link, error)
+	// GetLink returns the link for a given code
+	GetLink(ctx context.Context, code string) (Link, error)
+	// UpdateLink updates the given fields of the link with the given code
+	UpdateLink(ctx context.Context, code string, link database.Link, fields []database.LinkField) (Link, error)
+	// DeleteLink deletes the link with the given code
+	DeleteLink(ctx context.Context, code string) error
+	// ListLinks returns a page of links matching the query
+	ListLinks(ctx context.Context, query ListLinksQuery) (ListLinksPage, error)
+	// ImportLink creates a migrated link, keeping its code if possible
+	ImportLink(ctx context.Context, link database.Link, code string, opts ImportOptions) (ImportedLink, error)
+	// LinkQRCode renders a QR code of the public short URL of the link with the given code
+	LinkQRCode(ctx context.Context, code string, opts QRCodeOptions) (QRCode, error)
+
+	// ListRules returns the redirect rules of the link with the given code in evaluation order
+	ListRules(ctx context.Context, code string) ([]rules.Rule, error)
+	// CreateRule inserts a redirect rule at index, or appends it if index is negative
+	CreateRule(ctx context.Context, code string, rule rules.Rule, index int) (rules.Rule, error)
+	// UpdateRule replaces the redirect rule with rule.ID and moves it to index unless index is negative
+	UpdateRule(ctx context.Context, code string, rule rules.Rule, index int) (rules.Rule, error)
+	// DeleteRule deletes a redirect rule
+	DeleteRule(ctx context.Context, code string, ruleID string) error
+	// EvaluateRules reports which redirect rule a request would match, without resolving the link
+	EvaluateRules(ctx context.Context, code string, req EvaluateRulesRequest) (RuleEvaluation, error)
+
+	// VariantStats returns the split variants of the link with the given code and how often each was picked
+	VariantStats(ctx context.Context, code string) ([]VariantStats, error)
+
+	// TagLinks adds and removes tags of the links with the given codes at once
+	TagLinks(ctx context.Context, codes []string, add, remove []string) (TagLinksResult, error)
+	// CampaignStats returns the number of links and clicks of every campaign
+	CampaignStats(ctx context.Context) ([]database.CampaignStats, error)
+	// ClickHistory returns the clicks of the link with the given code in total and on each of the last days
+	ClickHistory(ctx context.Context, code string, days int) (ClickHistory, error)
+
+	// Domain returns the domain selected in ctx, see domains.WithHost and domains.WithName
+	Domain(ctx context.Context) (*domains.Domain, error)
 }
 
 type UrlShortener struct {
-	urlRepo database.IUrlRepository
-	log     *zap.Logger
+	urlRepo  database.IUrlRepository
+	log      *zap.Logger
+	logo     qrLogo
+	geo      geoIP
+	domains  domainRegistry
+	access   accessSigner
+	attempts *attempts.Limiter
+	picker   *split.Picker
+	events   EventPublisher
+	expired  expiredLinks
 }
 
 func NewUrlShortener(urlRepo database.IUrlRepository, log *zap.Logger) *UrlShortener {
 	return &UrlShortener{
-		urlRepo: urlRepo,
-		log:     log,
+		urlRepo:  urlRepo,
+		log:      log,
+		access:   newAccessSigner(log),
+		attempts: newAttemptLimiter(),
+		picker:   split.NewPicker(rand.NewPCG(rand.Uint64(), rand.Uint64())),
 	}
 }
 
-func encodeID(id int64) (string, error) {
-	s, err := sqids.New(sqids.Options{
-		Alphabet:  os.Getenv("SECRET_ALPHABET"),
-		MinLength: 10,
-	})
-	if err != nil {
-		return "", err
-	}
-	shortUrl, err := s.Encode([]uint64{uint64(id)})
-	if err != nil {
-		return "", err
-	}
-	return shortUrl, err
+// logger returns the request-scoped logger if the transport put one into ctx.
+func (u *UrlShortener) logger(ctx context.Context) *zap.Logger {
+	return zap_utils.FromContext(ctx, u.log)
 }
 
-func decodeToID(str string) (int64, error) {
-	var id int64
-	s, err := sqids.New(sqids.Options{
-		Alphabet:  os.Getenv("SECRET_ALPHABET"),
-		MinLength: 10,
-	})
-	if err != nil {
-		return 0, err
+// ValidateConfig reports whether the domain configuration (SECRET_ALPHABET, BASE_URL and
+// DOMAINS_PATH) is usable, as well as QR_LOGO_PATH and GEOIP_DB_PATH when they are set.
+func ValidateConfig() error {
+	if _, err := domains.FromEnv(); err != nil {
+		return err
+	}
+	if path := os.Getenv("QR_LOGO_PATH"); path != "" {
+		if _, err := loadLogo(path); err != nil {
+			return err
+		}
 	}
-	numbers := s.Decode(str)
-	if len(numbers) == 0 {
-		return 0, ErrInvalidUrl
+	if path := os.Getenv("GEOIP_DB_PATH"); path != "" {
+		db, err := rules.OpenGeoIP(path)
+		if err != nil {
+			return err
+		}
+		_ = db.Close()
 	}
-	id = int64(numbers[0])
-	return id, nil
+	return nil
 }
 
 func (u *UrlShortener) GetShortenUrl(ctx context.Context, fullUrl string) (string, error) {
-	id, err := u.urlRepo.GetID(ctx, fullUrl)
+	d, err := u.Domain(ctx)
+	if err != nil {
+		return "", err
+	}
+	id, err := u.urlRepo.GetID(ctx, d.Name(), fullUrl)
+	if err != nil {
+		return "", err
+	}
+	link, err := withCode(d, database.Link{ID: id})
 	if err != nil {
 		return "", err
 	}
-	return encodeID(id)
+	return cmp.Or(link.ShortURL, link.Code), nil
 }
 
 func (u *UrlShortener) SaveShortenUrl(ctx context.Context, fullUrl string) error {
-	err := u.urlRepo.SaveUrl(ctx, fullUrl)
+	d, err := u.Domain(ctx)
+	if err != nil {
+		return err
+	}
+	err = u.urlRepo.SaveUrl(ctx, d.Name(), fullUrl)
 	return err
 }
 
 func (u *UrlShortener) GetFullUrl(ctx context.Context, shortenUrl string) (string, error) {
-	id, err := decodeToID(shortenUrl)
+	resolved, err := u.Resolve(ctx, ResolveRequest{Code: shortenUrl})
 	if err != nil {
 		return "", err
 	}
-	return u.urlRepo.GetUrlByID(ctx, id)
+	return resolved.Target, nil
 }
 
 func (u *UrlShortener) CreateUrl(ctx context.Context, fullUrl string) (string, error) {
@@ -104,5 +172,15 @@ func (u *UrlShortener) CreateUrl(ctx context.Context, fullUrl string) (string, e
 	if err != nil {
 		return "", err
 	}
-	return u.GetShortenUrl(ctx, fullUrl)
+	u.logger(ctx).Debug("saved new url", zap.String("full_url", fullUrl))
+	shortenUrl, err = u.GetShortenUrl(ctx, fullUrl)
+	if err != nil {
+		return "", err
+	}
+	if u.events != nil {
+		if link, err := u.getLink(ctx, shortenUrl); err == nil {
+			u.publishLink(ctx, webhooks.EventLinkCreated, link)
+		}
+	}
+	return shortenUrl, nil
 }
@@ -6,11 +6,22 @@ import (
 	"testing"
 
 	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/domains"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 )
 
+// encodeID returns the code of a link ID on the default domain.
+func encodeID(id int64) (string, error) {
+	registry, err := domains.FromEnv()
+	if err != nil {
+		return "", err
+	}
+	return registry.Default().Encode(id)
+}
+
 func TestUrlShortener_GetShortenUrl(t *testing.T) {
 	ctx := context.Background()
 	mockedLog := zaptest.NewLogger(t)
@@ -21,13 +32,13 @@ func TestUrlShortener_GetShortenUrl(t *testing.T) {
 	argFullUrl1 := "https://fullUrl1.com"
 	mockRes1Id := 1
 	var mockRes1Err error = nil
-	urlRepo.On(mockedGetID, ctx, argFullUrl1).Return(mockRes1Id, mockRes1Err).Once()
+	urlRepo.On(mockedGetID, ctx, "", argFullUrl1).Return(mockRes1Id, mockRes1Err).Once()
 
 	// Test case 2: URL is not found in the database
 	argFullUrl2 := "https://fullUrl2.com"
 	mockRes2Id := 0
 	mockRes2Err := ErrUrlNotFound
-	urlRepo.On(mockedGetID, ctx, argFullUrl2).Return(mockRes2Id, mockRes2Err).Once()
+	urlRepo.On(mockedGetID, ctx, "", argFullUrl2).Return(mockRes2Id, mockRes2Err).Once()
 
 	shortUrl, err := encodeID(1)
 	if err != nil {
@@ -78,14 +89,15 @@ func TestUrlShortener_GetFullUrl(t *testing.T) {
 	ctx := context.Background()
 	mockedLog := zaptest.NewLogger(t)
 
-	mockedGetUrlByID := "GetUrlByID"
+	mockedGetLink := "GetLink"
 	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	// Test case 1: URL is found in the database
 	mockArg1Id := int64(1)
 	mockRes1Url := "https://fullUrl1.com"
 	var mockRes1Err error = nil
-	urlRepo.On(mockedGetUrlByID, ctx, mockArg1Id).Return(mockRes1Url, mockRes1Err).Once()
+	urlRepo.On(mockedGetLink, ctx, mockArg1Id).Return(database.Link{ID: mockArg1Id, Target: mockRes1Url}, mockRes1Err).Once()
 	arg1ShortenUrl, err := encodeID(mockArg1Id)
 	if err != nil {
 		t.Fatal(err)
@@ -98,7 +110,7 @@ func TestUrlShortener_GetFullUrl(t *testing.T) {
 	}
 	mockRes2Url := ""
 	mockRes2Err := ErrUrlNotFound
-	urlRepo.On(mockedGetUrlByID, ctx, mockArg2Id).Return(mockRes2Url, mockRes2Err).Once()
+	urlRepo.On(mockedGetLink, ctx, mockArg2Id).Return(database.Link{}, mockRes2Err).Once()
 	arg2ShortenUrl, err := encodeID(mockArg2Id)
 	if err != nil {
 		t.Fatal(err)
@@ -156,7 +168,7 @@ func TestUrlShortener_CreateUrl(t *testing.T) {
 	mockArg1Url := "https://fullUrl1.com"
 	mockRes1Id := 1
 	var mockRes1Err error = nil
-	urlRepository.On(mockedGetID, ctx, mockArg1Url).Return(mockRes1Id, mockRes1Err).Once()
+	urlRepository.On(mockedGetID, ctx, "", mockArg1Url).Return(mockRes1Id, mockRes1Err).Once()
 	mockRes1ShortUrl, err := encodeID(int64(mockRes1Id))
 	if err != nil {
 		t.Fatal(err)
@@ -166,19 +178,19 @@ func TestUrlShortener_CreateUrl(t *testing.T) {
 	mockRes2Id := 2
 	var mockRes2Err error = nil
 	// First call -- URL not found
-	urlRepository.On(mockedGetID, ctx, mockArg2Url).Return(0, ErrUrlNotFound).Once()
+	urlRepository.On(mockedGetID, ctx, "", mockArg2Url).Return(0, ErrUrlNotFound).Once()
 	// Save URL
-	urlRepository.On(mockedSaveUrl, ctx, mockArg2Url).Return(nil).Once()
+	urlRepository.On(mockedSaveUrl, ctx, "", mockArg2Url).Return(nil).Once()
 	// Second call -- URL found
-	urlRepository.On(mockedGetID, ctx, mockArg2Url).Return(mockRes2Id, mockRes2Err).Once()
+	urlRepository.On(mockedGetID, ctx, "", mockArg2Url).Return(mockRes2Id, mockRes2Err).Once()
 	mockRes2ShortUrl, err := encodeID(int64(mockRes2Id))
 
 	// Test case 3: Error on save
 	mockArgTC3Url := "https://fullUrl3.com"
 	// First call -- URL not found
-	urlRepository.On(mockedGetID, ctx, mockArgTC3Url).Return(0, ErrUrlNotFound).Once()
+	urlRepository.On(mockedGetID, ctx, "", mockArgTC3Url).Return(0, ErrUrlNotFound).Once()
 	// Error on save
-	urlRepository.On(mockedSaveUrl, ctx, mockArgTC3Url).Return(errors.New("save error")).Once()
+	urlRepository.On(mockedSaveUrl, ctx, "", mockArgTC3Url).Return(errors.New("save error")).Once()
 	mockResTC3Res := ""
 
 	tests := []struct {
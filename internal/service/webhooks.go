@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+	"go.uber.org/zap"
+)
+
+// EventPublisher receives the link lifecycle and click events of the service, e.g. a webhooks.Dispatcher.
+// Publish must not block the request.
+type EventPublisher interface {
+	Publish(ctx context.Context, event webhooks.Event)
+}
+
+// WithPublisher makes the service publish its events to p.
+func (u *UrlShortener) WithPublisher(p EventPublisher) *UrlShortener {
+	u.events = p
+	return u
+}
+
+func (u *UrlShortener) publish(ctx context.Context, typ webhooks.EventType, data any) {
+	if u.events == nil {
+		return
+	}
+	event, err := webhooks.NewEvent(typ, data)
+	if err != nil {
+		u.logger(ctx).Error("failed to build event", zap.String("event", string(typ)), zap.Error(err))
+		return
+	}
+	u.events.Publish(ctx, event)
+}
+
+func linkData(link Link) webhooks.LinkData {
+	return webhooks.LinkData{
+		Code:            link.Code,
+		ShortURL:        link.ShortURL,
+		Domain:          link.Domain,
+		Target:          link.Target,
+		Owner:           link.Owner,
+		Tags:            link.Tags,
+		Campaign:        link.Campaign,
+		Disabled:        link.Disabled,
+		ExpiresAt:       link.ExpiresAt,
+		MaxClicks:       link.MaxClicks,
+		RemainingClicks: link.RemainingClicks,
+		CreatedAt:       link.CreatedAt,
+		UpdatedAt:       link.UpdatedAt,
+	}
+}
+
+func (u *UrlShortener) publishLink(ctx context.Context, typ webhooks.EventType, link Link) {
+	u.publish(ctx, typ, linkData(link))
+}
+
+func (u *UrlShortener) publishUpdated(ctx context.Context, link Link, fields []database.LinkField) {
+	data := linkData(link)
+	for _, field := range fields {
+		data.Fields = append(data.Fields, string(field))
+	}
+	u.publish(ctx, webhooks.EventLinkUpdated, data)
+}
+
+func (u *UrlShortener) publishClicked(ctx context.Context, resolved Resolved) {
+	u.publish(ctx, webhooks.EventLinkClicked, webhooks.ClickData{
+		Code:      resolved.Code,
+		ShortURL:  resolved.ShortURL,
+		Domain:    resolved.Domain,
+		Target:    resolved.Target,
+		RuleID:    resolved.RuleID,
+		VariantID: resolved.VariantID,
+	})
+}
+
+// expiredLinks remembers the links link.expired was sent for, so that every resolve of an
+// expired link does not send it again. It maps link IDs to the UpdatedAt of the link at the
+// time, so a link that is revived by an update and expires again is reported again.
+type expiredLinks struct {
+	sent sync.Map
+}
+
+// first reports whether the link has not been reported expired yet.
+func (e *expiredLinks) first(link database.Link) bool {
+	version := link.UpdatedAt.UnixNano()
+	prev, loaded := e.sent.Swap(link.ID, version)
+	return !loaded || prev.(int64) != version
+}
+
+func (e *expiredLinks) forget(id int64) {
+	e.sent.Delete(id)
+}
+
+// publishExpired sends link.expired if err reports the link expired or ran out of clicks.
+func (u *UrlShortener) publishExpired(ctx context.Context, link Link, err error) {
+	var reason string
+	switch {
+	case errors.Is(err, ErrLinkExpired):
+		reason = "expired"
+	case errors.Is(err, ErrLinkExhausted):
+		reason = "exhausted"
+	default:
+		return
+	}
+	if u.events == nil || !u.expired.first(link.Link) {
+		return
+	}
+	data := linkData(link)
+	data.Reason = reason
+	u.publish(ctx, webhooks.EventLinkExpired, data)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []webhooks.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event webhooks.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+// take returns the types of the events published since the last call and the data of the last one.
+func (p *recordingPublisher) take(t *testing.T) ([]webhooks.EventType, webhooks.LinkData) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var types []webhooks.EventType
+	var data webhooks.LinkData
+	for _, event := range p.events {
+		types = append(types, event.Type)
+		require.NoError(t, json.Unmarshal(event.Data, &data))
+	}
+	p.events = nil
+	return types, data
+}
+
+func TestUrlShortener_Events(t *testing.T) {
+	ctx := context.Background()
+	updatedAt := time.Now().Add(-time.Hour)
+	limited := database.Link{ID: 1, Target: "https://example.com/limited", MaxClicks: 2, RemainingClicks: 2, UpdatedAt: updatedAt}
+	exhausted := limited
+	exhausted.RemainingClicks = 0
+	expiresAt := time.Now().Add(-time.Minute)
+	expired := database.Link{ID: 2, Target: "https://example.com/expired", ExpiresAt: &expiresAt, UpdatedAt: updatedAt}
+
+	urlRepo := new(UrlRepositoryMock)
+	urlRepo.On("RecordClick", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	urlRepo.On("GetLink", mock.Anything, int64(1)).Return(limited, nil).Once()
+	urlRepo.On("GetLink", mock.Anything, int64(1)).Return(exhausted, nil)
+	// Another resolve took a click since the link was read.
+	urlRepo.On("ConsumeClick", mock.Anything, int64(1)).Return(int64(0), nil)
+	urlRepo.On("GetLink", mock.Anything, int64(2)).Return(expired, nil)
+	urlRepo.On("CreateLink", mock.Anything, mock.Anything).Return(nil)
+	urlRepo.On("UpdateLink", mock.Anything, mock.Anything, mock.Anything).Return(expired, nil)
+	urlRepo.On("DeleteLink", mock.Anything, int64(2)).Return(nil)
+	publisher := &recordingPublisher{}
+	u := NewUrlShortener(urlRepo, zaptest.NewLogger(t)).WithPublisher(publisher)
+	limitedCode, err := encodeID(1)
+	require.NoError(t, err)
+	expiredCode, err := encodeID(2)
+	require.NoError(t, err)
+
+	_, err = u.CreateLink(ctx, database.Link{Target: "https://example.com"})
+	require.NoError(t, err)
+	types, data := publisher.take(t)
+	assert.Equal(t, []webhooks.EventType{webhooks.EventLinkCreated}, types)
+	assert.Equal(t, "https://example.com", data.Target)
+
+	// Taking the last click reports the link expired, and only once.
+	_, err = u.Resolve(ctx, ResolveRequest{Code: limitedCode})
+	require.NoError(t, err)
+	types, data = publisher.take(t)
+	assert.Equal(t, []webhooks.EventType{webhooks.EventLinkClicked, webhooks.EventLinkExpired}, types)
+	assert.Equal(t, "exhausted", data.Reason)
+	assert.Equal(t, int64(0), data.RemainingClicks)
+	_, err = u.Resolve(ctx, ResolveRequest{Code: limitedCode})
+	assert.ErrorIs(t, err, ErrLinkExhausted)
+	types, _ = publisher.take(t)
+	assert.Empty(t, types)
+
+	for range 2 {
+		_, err = u.Resolve(ctx, ResolveRequest{Code: expiredCode})
+		assert.ErrorIs(t, err, ErrLinkExpired)
+	}
+	types, data = publisher.take(t)
+	assert.Equal(t, []webhooks.EventType{webhooks.EventLinkExpired}, types)
+	assert.Equal(t, "expired", data.Reason)
+	assert.Equal(t, expiredCode, data.Code)
+
+	_, err = u.UpdateLink(ctx, expiredCode, database.Link{Owner: "team-a"}, []database.LinkField{database.LinkFieldOwner})
+	require.NoError(t, err)
+	types, data = publisher.take(t)
+	assert.Equal(t, []webhooks.EventType{webhooks.EventLinkUpdated}, types)
+	assert.Equal(t, []string{string(database.LinkFieldOwner)}, data.Fields)
+
+	require.NoError(t, u.DeleteLink(ctx, expiredCode))
+	types, data = publisher.take(t)
+	assert.Equal(t, []webhooks.EventType{webhooks.EventLinkDeleted}, types)
+	assert.Equal(t, expiredCode, data.Code)
+}
@@ -0,0 +1,122 @@
+// Package split picks one of several weighted destinations of a link for A/B tests.
+package split
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+var ErrInvalidVariants = errors.New("invalid split variants")
+
+const (
+	// MaxVariants bounds the variants of a link.
+	MaxVariants = 16
+	// MaxWeight bounds the weight of a single variant.
+	MaxWeight = 10000
+)
+
+// Mode selects how a visitor is assigned to a variant.
+type Mode string
+
+const (
+	// ModeRandom picks a variant at random on every visit. It is the default.
+	ModeRandom Mode = "random"
+	// ModeSticky hashes the visitor, so repeated visits get the same variant
+	// as long as the variants don't change.
+	ModeSticky Mode = "sticky"
+)
+
+// Valid reports whether m is a known mode. The empty mode means ModeRandom.
+func (m Mode) Valid() bool {
+	return m == "" || m == ModeRandom || m == ModeSticky
+}
+
+// Variant is one destination of a split link. Visitors are assigned to variants
+// in proportion to their weights; a zero weight pauses the variant.
+type Variant struct {
+	ID     string `json:"id"`
+	Target string `json:"target"`
+	Weight int    `json:"weight"`
+}
+
+var variantIDPattern = regexp.MustCompile(`^[0-9A-Za-z_-]{1,32}$`)
+
+// Validate checks the variant IDs and weights. Targets are left to the caller.
+func Validate(variants []Variant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+	if len(variants) > MaxVariants {
+		return fmt.Errorf("%w: at most %d variants are allowed", ErrInvalidVariants, MaxVariants)
+	}
+	total := 0
+	for i, v := range variants {
+		if !variantIDPattern.MatchString(v.ID) {
+			return fmt.Errorf("%w: invalid id %q", ErrInvalidVariants, v.ID)
+		}
+		if slices.ContainsFunc(variants[:i], func(other Variant) bool { return other.ID == v.ID }) {
+			return fmt.Errorf("%w: duplicate id %q", ErrInvalidVariants, v.ID)
+		}
+		if v.Weight < 0 || v.Weight > MaxWeight {
+			return fmt.Errorf("%w: weight of %q must be between 0 and %d", ErrInvalidVariants, v.ID, MaxWeight)
+		}
+		total += v.Weight
+	}
+	if total == 0 {
+		return fmt.Errorf("%w: at least one variant needs a positive weight", ErrInvalidVariants)
+	}
+	return nil
+}
+
+// Picker assigns visitors to variants. It is safe for concurrent use.
+type Picker struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewPicker returns a picker drawing random assignments from src. A seeded source
+// makes the assignments reproducible.
+func NewPicker(src rand.Source) *Picker {
+	return &Picker{rnd: rand.New(src)}
+}
+
+// Pick returns the variant for a visit. In sticky mode the visitor key, e.g. a cookie value
+// or an IP address, decides the variant together with seed, e.g. the link ID, so that a visitor
+// lands in unrelated buckets of different links; without a key the pick is random. It returns
+// false if no variant has a positive weight.
+func (p *Picker) Pick(seed uint64, variants []Variant, mode Mode, key string) (Variant, bool) {
+	total := 0
+	for _, v := range variants {
+		total += max(v.Weight, 0)
+	}
+	if total == 0 {
+		return Variant{}, false
+	}
+	var n int
+	if mode == ModeSticky && key != "" {
+		h := fnv.New64a()
+		_, _ = h.Write(binary.BigEndian.AppendUint64(nil, seed))
+		_, _ = h.Write([]byte(key))
+		n = int(h.Sum64() % uint64(total))
+	} else {
+		p.mu.Lock()
+		n = p.rnd.IntN(total)
+		p.mu.Unlock()
+	}
+	for _, v := range variants {
+		if v.Weight <= 0 {
+			continue
+		}
+		if n < v.Weight {
+			return v, true
+		}
+		n -= v.Weight
+	}
+	return Variant{}, false
+}
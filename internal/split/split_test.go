@@ -0,0 +1,105 @@
+package split
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var abc = []Variant{
+	{ID: "a", Target: "https://example.com/a", Weight: 70},
+	{ID: "b", Target: "https://example.com/b", Weight: 20},
+	{ID: "paused", Target: "https://example.com/paused", Weight: 0},
+	{ID: "c", Target: "https://example.com/c", Weight: 10},
+}
+
+func TestPicker_PickRandom(t *testing.T) {
+	pick := func(seed uint64) []string {
+		p := NewPicker(rand.NewPCG(seed, seed))
+		var ids []string
+		for range 20 {
+			v, ok := p.Pick(1, abc, ModeRandom, "visitor")
+			require.True(t, ok)
+			ids = append(ids, v.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, pick(1), pick(1), "a seeded picker is deterministic")
+
+	p := NewPicker(rand.NewPCG(1, 2))
+	counts := map[string]int{}
+	const visits = 10000
+	for range visits {
+		v, ok := p.Pick(1, abc, "", "")
+		require.True(t, ok)
+		counts[v.ID]++
+	}
+	assert.Zero(t, counts["paused"])
+	assert.InDelta(t, 0.7, float64(counts["a"])/visits, 0.03)
+	assert.InDelta(t, 0.2, float64(counts["b"])/visits, 0.03)
+	assert.InDelta(t, 0.1, float64(counts["c"])/visits, 0.03)
+}
+
+func TestPicker_PickSticky(t *testing.T) {
+	p := NewPicker(rand.NewPCG(1, 2))
+	counts := map[string]int{}
+	const visitors = 5000
+	for i := range visitors {
+		key := fmt.Sprintf("visitor-%d", i)
+		first, ok := p.Pick(1, abc, ModeSticky, key)
+		require.True(t, ok)
+		for range 3 {
+			again, _ := p.Pick(1, abc, ModeSticky, key)
+			require.Equal(t, first.ID, again.ID, "a visitor keeps its variant")
+		}
+		counts[first.ID]++
+	}
+	assert.Zero(t, counts["paused"])
+	assert.InDelta(t, 0.7, float64(counts["a"])/visitors, 0.04)
+	assert.InDelta(t, 0.2, float64(counts["b"])/visitors, 0.04)
+
+	_, ok := p.Pick(1, []Variant{{ID: "a", Weight: 0}}, ModeSticky, "visitor")
+	assert.False(t, ok)
+	_, ok = p.Pick(1, nil, ModeRandom, "")
+	assert.False(t, ok)
+}
+
+func TestPicker_PickStickyAcrossLinks(t *testing.T) {
+	p := NewPicker(rand.NewPCG(1, 2))
+	half := []Variant{{ID: "a", Weight: 1}, {ID: "b", Weight: 1}}
+	const links = 1000
+	counts := map[string]int{}
+	for link := range uint64(links) {
+		v, ok := p.Pick(link, half, ModeSticky, "visitor")
+		require.True(t, ok)
+		again, _ := p.Pick(link, half, ModeSticky, "visitor")
+		require.Equal(t, v.ID, again.ID, "a visitor keeps its variant of a link")
+		counts[v.ID]++
+	}
+	// one visitor is not in the same bucket of every test
+	assert.InDelta(t, 0.5, float64(counts["a"])/links, 0.05)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(nil))
+	assert.NoError(t, Validate(abc))
+
+	for name, variants := range map[string][]Variant{
+		"id":        {{ID: "a b", Weight: 1}},
+		"duplicate": {{ID: "a", Weight: 1}, {ID: "a", Weight: 1}},
+		"negative":  {{ID: "a", Weight: -1}, {ID: "b", Weight: 2}},
+		"too heavy": {{ID: "a", Weight: MaxWeight + 1}},
+		"all zero":  {{ID: "a"}, {ID: "b"}},
+		"too many":  make([]Variant, MaxVariants+1),
+	} {
+		err := Validate(variants)
+		assert.True(t, errors.Is(err, ErrInvalidVariants), "%s: %v", name, err)
+	}
+	assert.True(t, Mode("").Valid())
+	assert.True(t, ModeSticky.Valid())
+	assert.False(t, Mode("round-robin").Valid())
+}
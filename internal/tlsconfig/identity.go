@@ -0,0 +1,63 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+)
+
+// Identity is who a verified client certificate was issued to.
+type Identity struct {
+	CommonName string
+	DNSNames   []string
+	Emails     []string
+	URIs       []string
+}
+
+// Names returns the common name followed by the subject alternative names, for matching against
+// allowed clients.
+func (id Identity) Names() []string {
+	var names []string
+	if id.CommonName != "" {
+		names = append(names, id.CommonName)
+	}
+	names = append(names, id.DNSNames...)
+	names = append(names, id.Emails...)
+	return append(names, id.URIs...)
+}
+
+// IdentityFromCertificate returns the identity of cert.
+func IdentityFromCertificate(cert *x509.Certificate) Identity {
+	id := Identity{
+		CommonName: cert.Subject.CommonName,
+		DNSNames:   cert.DNSNames,
+		Emails:     cert.EmailAddresses,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+	}
+	return id
+}
+
+// IdentityFromState returns the identity of the verified client certificate of a connection,
+// false if the client was not verified.
+func IdentityFromState(state tls.ConnectionState) (Identity, bool) {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return Identity{}, false
+	}
+	return IdentityFromCertificate(state.VerifiedChains[0][0]), true
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx carrying the identity of the client.
+func NewContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFromContext returns the identity of the client stored in ctx, false if the client was
+// not verified.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
@@ -0,0 +1,293 @@
+// Package tlsconfig serves TLS from certificate and key files that are reloaded without a
+// restart, when they change on disk or on SIGHUP, and optionally verifies client certificates
+// against a CA bundle (mutual TLS), whose identity it makes available to authorization.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Config configures TLS for a listener.
+type Config struct {
+	// CertFile and KeyFile are the PEM encoded certificate chain and key served. TLS is off
+	// if CertFile is empty.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is a PEM bundle of the CAs client certificates must be issued by. Clients
+	// need no certificate if it is empty.
+	ClientCAFile string
+	// MinVersion is the lowest TLS version accepted.
+	MinVersion uint16
+	// CipherSuites are the cipher suites accepted for TLS 1.2, none for Go's default ones.
+	// TLS 1.3 suites are not configurable.
+	CipherSuites []uint16
+	// ReloadInterval is how often the files are checked for changes.
+	ReloadInterval time.Duration
+}
+
+var DefaultConfig = Config{
+	MinVersion:     tls.VersionTLS12,
+	ReloadInterval: 30 * time.Second,
+}
+
+// Enabled reports whether TLS is configured.
+func (c Config) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// ConfigFromEnv reads the config from TLS_CERT_FILE, TLS_KEY_FILE, TLS_MIN_VERSION (1.2 or
+// 1.3), TLS_CIPHER_SUITES (comma separated names such as TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256)
+// and TLS_RELOAD_INTERVAL. The client CA bundle is left to the caller, since only some listeners
+// verify clients. Unlike other settings, malformed values are errors rather than falling back to
+// defaults, so that a typo cannot weaken the policy.
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig
+	cfg.CertFile = os.Getenv("TLS_CERT_FILE")
+	cfg.KeyFile = os.Getenv("TLS_KEY_FILE")
+	if cfg.CertFile != "" && cfg.KeyFile == "" {
+		return cfg, errors.New("TLS_KEY_FILE must be set with TLS_CERT_FILE")
+	}
+	if v := os.Getenv("TLS_MIN_VERSION"); v != "" {
+		version, err := ParseVersion(v)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.MinVersion = version
+	}
+	if v := os.Getenv("TLS_CIPHER_SUITES"); v != "" {
+		suites, err := ParseCipherSuites(v)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.CipherSuites = suites
+	}
+	if v := os.Getenv("TLS_RELOAD_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return cfg, fmt.Errorf("invalid TLS_RELOAD_INTERVAL %q", v)
+		}
+		cfg.ReloadInterval = d
+	}
+	return cfg, nil
+}
+
+// ParseVersion parses a TLS version such as "1.3".
+func ParseVersion(s string) (uint16, error) {
+	switch strings.TrimSpace(s) {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version %q, want 1.2 or 1.3", s)
+	}
+}
+
+// ParseCipherSuites parses comma separated cipher suite names. Suites Go considers insecure are
+// rejected.
+func ParseCipherSuites(s string) ([]uint16, error) {
+	byName := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		byName[suite.Name] = suite.ID
+	}
+	var ids []uint16
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Reloader holds the certificate and client CAs read last from the files of its config.
+type Reloader struct {
+	cfg Config
+	log *zap.Logger
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+	cas  *x509.CertPool
+	// stamps identify the versions of the files read last, to reload them only once they change.
+	stamps []fileStamp
+}
+
+// New reads the files of cfg, failing if they cannot be loaded.
+func New(cfg Config, log *zap.Logger) (*Reloader, error) {
+	if cfg.MinVersion == 0 {
+		cfg.MinVersion = DefaultConfig.MinVersion
+	}
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = DefaultConfig.ReloadInterval
+	}
+	r := &Reloader{cfg: cfg, log: log}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FromEnv returns a reloader configured by ConfigFromEnv with the client CAs of clientCAFile,
+// watching its files until the process exits, nil if TLS is not configured.
+func FromEnv(clientCAFile string, log *zap.Logger) (*Reloader, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil || !cfg.Enabled() {
+		return nil, err
+	}
+	cfg.ClientCAFile = clientCAFile
+	r, err := New(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	go r.Watch(context.Background())
+	return r, nil
+}
+
+// Reload reads the files again. On failure the certificate and CAs read before stay in use.
+func (r *Reloader) Reload() error {
+	stamps := r.stat()
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS certificate: %w", err)
+	}
+	if cert.Leaf == nil && len(cert.Certificate) > 0 {
+		cert.Leaf, _ = x509.ParseCertificate(cert.Certificate[0])
+	}
+	var cas *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client CA bundle: %w", err)
+		}
+		cas = x509.NewCertPool()
+		if !cas.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("client CA bundle %s holds no certificates", r.cfg.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert, r.cas, r.stamps = &cert, cas, stamps
+	if cert.Leaf != nil {
+		r.log.Info("Loaded TLS certificate",
+			zap.String("subject", cert.Leaf.Subject.String()),
+			zap.Time("not_after", cert.Leaf.NotAfter))
+	}
+	return nil
+}
+
+// Watch reloads the files when they change, checking every ReloadInterval, or on SIGHUP, until
+// ctx is done. Failed reloads are logged.
+func (r *Reloader) Watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reload("signal")
+		case <-ticker.C:
+			if r.changed() {
+				r.reload("file change")
+			}
+		}
+	}
+}
+
+func (r *Reloader) reload(reason string) {
+	if err := r.Reload(); err != nil {
+		r.log.Error("Reloading TLS certificate failed, keeping the current one", zap.String("reason", reason), zap.Error(err))
+	}
+}
+
+// fileStamp identifies a version of a file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// stat returns the stamps of the files, zero for the ones that cannot be read.
+func (r *Reloader) stat() []fileStamp {
+	files := r.files()
+	stamps := make([]fileStamp, len(files))
+	for i, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			stamps[i] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return stamps
+}
+
+// changed reports whether any file changed since it was read.
+func (r *Reloader) changed() bool {
+	stamps := r.stat()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := range stamps {
+		if stamps[i] != r.stamps[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Certificate returns the certificate served.
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+// ServerConfig returns a TLS config serving the current certificate and, if a client CA bundle
+// is configured, requiring client certificates issued by the current CAs. Handshakes pick up
+// reloads right away. nextProtos are the ALPN protocols, which the config handed out per
+// handshake must carry itself.
+func (r *Reloader) ServerConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: r.cfg.MinVersion,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			cfg := &tls.Config{
+				Certificates: []tls.Certificate{*r.cert},
+				MinVersion:   r.cfg.MinVersion,
+				CipherSuites: r.cfg.CipherSuites,
+				NextProtos:   nextProtos,
+			}
+			if r.cas != nil {
+				cfg.ClientCAs = r.cas
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return cfg, nil
+		},
+	}
+}
@@ -0,0 +1,182 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/tlsconfig/tlstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// handshake connects a client with clientCfg to a server with serverCfg and returns the server
+// side state. The connection is buffered, since TLS 1.3 clients are done before the server tells
+// them it rejects their certificate.
+func handshake(t *testing.T, serverCfg, clientCfg *tls.Config) (tls.ConnectionState, error) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	clientConn, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer clientConn.Close()
+	serverConn, err := lis.Accept()
+	require.NoError(t, err)
+	defer serverConn.Close()
+	server := tls.Server(serverConn, serverCfg)
+	client := tls.Client(clientConn, clientCfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	clientErr := make(chan error, 1)
+	go func() { clientErr <- client.HandshakeContext(ctx) }()
+	err = server.HandshakeContext(ctx)
+	if err != nil {
+		clientConn.Close()
+	}
+	if cerr := <-clientErr; err == nil {
+		err = cerr
+	}
+	return server.ConnectionState(), err
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t, "test CA")
+	certFile, keyFile := tlstest.WriteKeyPair(t, dir, "server", ca.Issue(t, "first"))
+
+	r, err := New(Config{CertFile: certFile, KeyFile: keyFile}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	client := &tls.Config{RootCAs: ca.Pool(), ServerName: "localhost"}
+	state, err := handshake(t, r.ServerConfig(), client)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), state.Version)
+	assert.Equal(t, "first", r.Certificate().Leaf.Subject.CommonName)
+	assert.False(t, r.changed())
+
+	// a broken file keeps the certificate read before
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0o600))
+	assert.True(t, r.changed())
+	require.Error(t, r.Reload())
+	_, err = handshake(t, r.ServerConfig(), client)
+	require.NoError(t, err)
+
+	tlstest.WriteKeyPair(t, dir, "server", ca.Issue(t, "second"))
+	assert.True(t, r.changed())
+	require.NoError(t, r.Reload())
+	assert.False(t, r.changed())
+	assert.Equal(t, "second", r.Certificate().Leaf.Subject.CommonName)
+	_, err = handshake(t, r.ServerConfig(), client)
+	require.NoError(t, err)
+
+	_, err = New(Config{CertFile: certFile, KeyFile: certFile}, zaptest.NewLogger(t))
+	assert.Error(t, err, "files that cannot be loaded fail right away")
+}
+
+func TestReloader_Watch(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t, "test CA")
+	certFile, keyFile := tlstest.WriteKeyPair(t, dir, "server", ca.Issue(t, "first"))
+	r, err := New(Config{CertFile: certFile, KeyFile: keyFile, ReloadInterval: 10 * time.Millisecond}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx)
+
+	// Files written within the same clock tick may keep their modification time, but not their size.
+	tlstest.WriteKeyPair(t, dir, "server", ca.Issue(t, "second, with a longer name"))
+	assert.Eventually(t, func() bool {
+		return r.Certificate().Leaf.Subject.CommonName == "second, with a longer name"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReloader_ClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := tlstest.NewCA(t, "server CA")
+	clientCA := tlstest.NewCA(t, "client CA")
+	otherCA := tlstest.NewCA(t, "other CA")
+	certFile, keyFile := tlstest.WriteKeyPair(t, dir, "server", serverCA.Issue(t, "server"))
+	caFile := clientCA.WriteCA(t, dir, "clients.crt")
+
+	r, err := New(Config{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	clientConfig := func(certs ...tls.Certificate) *tls.Config {
+		return &tls.Config{RootCAs: serverCA.Pool(), ServerName: "localhost", Certificates: certs}
+	}
+
+	_, err = handshake(t, r.ServerConfig(), clientConfig())
+	assert.Error(t, err, "clients need a certificate")
+	_, err = handshake(t, r.ServerConfig(), clientConfig(otherCA.Issue(t, "stranger")))
+	assert.Error(t, err, "issued by a client CA")
+
+	state, err := handshake(t, r.ServerConfig(), clientConfig(clientCA.Issue(t, "billing", "billing.internal")))
+	require.NoError(t, err)
+	id, ok := IdentityFromState(state)
+	require.True(t, ok)
+	assert.Equal(t, "billing", id.CommonName)
+	assert.Equal(t, []string{"billing", "localhost", "billing.internal"}, id.Names())
+
+	// rotating the CA bundle takes effect on reload
+	otherCA.WriteCA(t, dir, "clients.crt")
+	require.NoError(t, r.Reload())
+	_, err = handshake(t, r.ServerConfig(), clientConfig(otherCA.Issue(t, "stranger")))
+	assert.NoError(t, err)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("TLS_CERT_FILE", "server.crt")
+	t.Setenv("TLS_KEY_FILE", "server.key")
+	t.Setenv("TLS_MIN_VERSION", "1.3")
+	t.Setenv("TLS_CIPHER_SUITES", "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled())
+	assert.Equal(t, uint16(tls.VersionTLS13), cfg.MinVersion)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}, cfg.CipherSuites)
+	assert.Equal(t, DefaultConfig.ReloadInterval, cfg.ReloadInterval)
+
+	for key, value := range map[string]string{
+		"TLS_MIN_VERSION":     "1.0",
+		"TLS_CIPHER_SUITES":   "TLS_RSA_WITH_RC4_128_SHA",
+		"TLS_RELOAD_INTERVAL": "often",
+		"TLS_KEY_FILE":        "",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			_, err := ConfigFromEnv()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestServerConfig_CipherSuites(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t, "test CA")
+	certFile, keyFile := tlstest.WriteKeyPair(t, dir, "server", ca.Issue(t, "server"))
+	r, err := New(Config{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256},
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	client := &tls.Config{
+		RootCAs:      ca.Pool(),
+		ServerName:   "localhost",
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+	}
+	_, err = handshake(t, r.ServerConfig(), client)
+	assert.Error(t, err, "suites outside the policy are refused")
+
+	client.CipherSuites = []uint16{tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256}
+	state, err := handshake(t, r.ServerConfig(), client)
+	require.NoError(t, err)
+	assert.Equal(t, tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256, state.CipherSuite)
+}
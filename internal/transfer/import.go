@@ -0,0 +1,243 @@
+package transfer
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/service"
+)
+
+// Status is the outcome of importing a row.
+type Status string
+
+const (
+	// StatusImported rows were created with their code, or a new one if they had none.
+	StatusImported Status = "imported"
+	// StatusRemapped rows were created with a new code because theirs is taken or foreign.
+	StatusRemapped Status = "remapped"
+	// StatusSkipped rows were not created because their code is taken.
+	StatusSkipped Status = "skipped"
+	// StatusInvalid rows could not be read or failed validation.
+	StatusInvalid Status = "invalid"
+)
+
+// Result is the outcome of importing a row.
+type Result struct {
+	Line   int    `json:"line"`
+	Status Status `json:"status"`
+	// Code and Alias are those of the row.
+	Code  string `json:"code,omitempty"`
+	Alias string `json:"alias,omitempty"`
+	// NewCode is the code of the created link. Dry runs leave it empty for links that get a new code.
+	NewCode  string `json:"new_code,omitempty"`
+	ShortURL string `json:"short_url,omitempty"`
+	Target   string `json:"target,omitempty"`
+	// Error is why the row was remapped, skipped or invalid.
+	Error string `json:"error,omitempty"`
+}
+
+// Mapped reports whether the result belongs in a mapping file: its link had a code or alias
+// that does not resolve to it.
+func (r Result) Mapped() bool {
+	return r.Status == StatusRemapped || (r.Alias != "" && r.Status == StatusImported)
+}
+
+// Summary counts the results of an import.
+type Summary struct {
+	DryRun   bool `json:"dry_run"`
+	Imported int  `json:"imported"`
+	Remapped int  `json:"remapped"`
+	Skipped  int  `json:"skipped"`
+	Invalid  int  `json:"invalid"`
+}
+
+func (s *Summary) add(status Status) {
+	switch status {
+	case StatusImported:
+		s.Imported++
+	case StatusRemapped:
+		s.Remapped++
+	case StatusSkipped:
+		s.Skipped++
+	case StatusInvalid:
+		s.Invalid++
+	}
+}
+
+// Options control an import.
+type Options struct {
+	Format Format
+	// DryRun validates every row and reports what would happen without creating links.
+	DryRun bool
+	// SkipTaken skips rows whose code is taken instead of giving them a new code, so that
+	// importing a file again does not duplicate its links.
+	SkipTaken bool
+}
+
+// Import creates the links of a file on the domain selected in ctx, reporting the result of
+// every row as it goes. Invalid rows are reported and skipped; only errors of the file or the
+// storage and errors of report end the import.
+func Import(ctx context.Context, shortener service.IUrlShortener, r io.Reader, opts Options, report func(Result) error) (Summary, error) {
+	summary := Summary{DryRun: opts.DryRun}
+	reader, err := NewReader(r, opts.Format)
+	if err != nil {
+		return summary, err
+	}
+	// kept tracks the codes dry runs would keep, since nothing is stored to make them taken.
+	kept := map[string]bool{}
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return summary, nil
+		}
+		var result Result
+		var rowErr *RowError
+		switch {
+		case errors.As(err, &rowErr):
+			result = Result{Line: rowErr.Line, Status: StatusInvalid, Error: rowErr.Err.Error()}
+		case err != nil:
+			return summary, err
+		default:
+			result, err = importRow(ctx, shortener, row, opts, kept)
+			if err != nil {
+				return summary, err
+			}
+			result.Line = reader.Line()
+		}
+		summary.add(result.Status)
+		if err := report(result); err != nil {
+			return summary, err
+		}
+	}
+}
+
+// importRow imports a row. Errors of the row make it invalid, others are returned.
+func importRow(ctx context.Context, shortener service.IUrlShortener, row Row, opts Options, kept map[string]bool) (Result, error) {
+	result := Result{Code: row.Code, Alias: row.Alias, Target: row.Target}
+	link := database.Link{
+		Target:    row.Target,
+		Owner:     row.Owner,
+		Tags:      row.Tags,
+		Disabled:  row.Disabled,
+		ExpiresAt: row.ExpiresAt,
+		MaxClicks: row.MaxClicks,
+	}
+	if row.CreatedAt != nil {
+		link.CreatedAt = *row.CreatedAt
+	}
+	code := row.Code
+	var remapped error
+	if opts.DryRun && kept[code] {
+		// An earlier row of the file takes the code.
+		if opts.SkipTaken {
+			result.Status, result.Error = StatusSkipped, service.ErrCodeTaken.Error()
+			return result, nil
+		}
+		code, remapped = "", service.ErrCodeTaken
+	}
+	imported, err := shortener.ImportLink(ctx, link, code, service.ImportOptions{DryRun: opts.DryRun, RemapTaken: !opts.SkipTaken})
+	switch {
+	case errors.Is(err, service.ErrCodeTaken):
+		result.Status, result.Error = StatusSkipped, err.Error()
+		return result, nil
+	case isInvalid(err):
+		result.Status, result.Error = StatusInvalid, err.Error()
+		return result, nil
+	case err != nil:
+		return Result{}, err
+	}
+	if imported.Remapped != nil {
+		remapped = imported.Remapped
+	}
+	result.NewCode, result.ShortURL = imported.Code, imported.ShortURL
+	result.Status = StatusImported
+	if remapped != nil && row.Code != "" {
+		result.Status, result.Error = StatusRemapped, remapped.Error()
+	}
+	if opts.DryRun && imported.Code != "" {
+		kept[imported.Code] = true
+	}
+	return result, nil
+}
+
+// isInvalid reports whether an error of ImportLink is due to the row.
+func isInvalid(err error) bool {
+	return errors.Is(err, service.ErrInvalidTarget) ||
+		errors.Is(err, service.ErrInvalidMaxClicks) ||
+		errors.Is(err, service.ErrInvalidUrl)
+}
+
+// MappingWriter writes the mapping of old codes and aliases to new codes as CSV with the columns
+// old_code, alias, new_code and short_url.
+type MappingWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func NewMappingWriter(w io.Writer) *MappingWriter {
+	return &MappingWriter{w: csv.NewWriter(w)}
+}
+
+// Write adds the result if it belongs in the mapping.
+func (m *MappingWriter) Write(result Result) error {
+	if !result.Mapped() {
+		return nil
+	}
+	if !m.header {
+		m.header = true
+		if err := m.w.Write([]string{"old_code", "alias", "new_code", "short_url"}); err != nil {
+			return err
+		}
+	}
+	return m.w.Write([]string{result.Code, result.Alias, result.NewCode, result.ShortURL})
+}
+
+func (m *MappingWriter) Flush() error {
+	m.w.Flush()
+	return m.w.Error()
+}
+
+// Export writes every link of the domain selected in ctx, disabled ones included, in ID order.
+// It returns the number of links written.
+func Export(ctx context.Context, shortener service.IUrlShortener, w io.Writer, format Format) (int, error) {
+	writer, err := NewWriter(w, format)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	query := service.ListLinksQuery{PageSize: service.MaxPageSize, IncludeDisabled: true}
+	for {
+		page, err := shortener.ListLinks(ctx, query)
+		if err != nil {
+			return count, err
+		}
+		for _, link := range page.Links {
+			if err := writer.Write(toRow(link)); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if page.NextPageToken == "" {
+			return count, writer.Flush()
+		}
+		query.PageToken = page.NextPageToken
+	}
+}
+
+func toRow(link service.Link) Row {
+	created := link.CreatedAt.UTC().Truncate(time.Second)
+	return Row{
+		Code:      link.Code,
+		Target:    link.Target,
+		Tags:      link.Tags,
+		Owner:     link.Owner,
+		Disabled:  link.Disabled,
+		CreatedAt: &created,
+		ExpiresAt: link.ExpiresAt,
+		MaxClicks: link.MaxClicks,
+	}
+}
@@ -0,0 +1,384 @@
+// Package transfer imports and exports links as CSV or NDJSON files, and imports the CSV
+// exports of other shorteners.
+//
+// Both native formats have the fields code, target, created_at, alias, tags, owner, disabled,
+// expires_at and max_clicks; only target is required. Imported links keep their code whenever
+// it is a free code of their domain, so links moved between instances keep working. The other
+// links get a new code, which the import reports together with the old code and alias, e.g. to
+// write a mapping file.
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown file format")
+	ErrNoTarget      = errors.New("target is required")
+	ErrBadHeader     = errors.New("invalid CSV header")
+)
+
+// Format is the format of a transfer file.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	// FormatBitly reads Bitly CSV exports: bitlink or link, long_url, created_at and tags.
+	FormatBitly Format = "bitly"
+	// FormatYOURLS reads YOURLS CSV exports: keyword, url and timestamp.
+	FormatYOURLS Format = "yourls"
+)
+
+// ImportFormats lists the formats Import reads, ExportFormats those Export writes.
+var (
+	ImportFormats = []Format{FormatCSV, FormatNDJSON, FormatBitly, FormatYOURLS}
+	ExportFormats = []Format{FormatCSV, FormatNDJSON}
+)
+
+// ContentType returns the media type of files of the format.
+func (f Format) ContentType() string {
+	if f == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// Row is a link in a transfer file.
+type Row struct {
+	// Code is the code of the link where it comes from. Short URLs are accepted as well.
+	Code   string `json:"code,omitempty"`
+	Target string `json:"target"`
+	// Alias is a custom name of the link where it comes from. Codes derive from link IDs here,
+	// so aliases are only reported with the new code.
+	Alias     string     `json:"alias,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Owner     string     `json:"owner,omitempty"`
+	Disabled  bool       `json:"disabled,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxClicks int64      `json:"max_clicks,omitempty"`
+}
+
+// columns are the CSV columns of the native format, in order.
+var columns = []string{"code", "target", "created_at", "alias", "tags", "owner", "disabled", "expires_at", "max_clicks"}
+
+// formatColumns maps the normalized CSV headers of every import format to native columns.
+var formatColumns = map[Format]map[string]string{
+	FormatBitly: {
+		"bitlink":    "code",
+		"link":       "code",
+		"long_url":   "target",
+		"created_at": "created_at",
+		"tags":       "tags",
+	},
+	FormatYOURLS: {
+		"keyword":   "code",
+		"url":       "target",
+		"timestamp": "created_at",
+	},
+}
+
+func init() {
+	native := map[string]string{}
+	for _, c := range columns {
+		native[c] = c
+	}
+	formatColumns[FormatCSV] = native
+}
+
+// RowError is a row of a file that could not be read. Reading goes on with the next row.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// maxLineSize bounds the size of an NDJSON line.
+const maxLineSize = 1 << 20
+
+// Reader reads the rows of a file one at a time.
+type Reader struct {
+	next func() (Row, error)
+	line int
+}
+
+// NewReader returns a reader of a file of the given format. CSV files must start with a header
+// naming a target column.
+func NewReader(r io.Reader, format Format) (*Reader, error) {
+	reader := &Reader{}
+	switch format {
+	case FormatNDJSON:
+		lines := bufio.NewScanner(r)
+		lines.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		reader.next = func() (Row, error) {
+			for lines.Scan() {
+				reader.line++
+				line := bytes.TrimSpace(lines.Bytes())
+				if len(line) == 0 {
+					continue
+				}
+				var row Row
+				if err := json.Unmarshal(line, &row); err != nil {
+					return Row{}, &RowError{Line: reader.line, Err: err}
+				}
+				return reader.checked(row)
+			}
+			if err := lines.Err(); err != nil {
+				return Row{}, err
+			}
+			return Row{}, io.EOF
+		}
+	case FormatCSV, FormatBitly, FormatYOURLS:
+		next, err := reader.csv(r, formatColumns[format])
+		if err != nil {
+			return nil, err
+		}
+		reader.next = next
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	return reader, nil
+}
+
+// Read returns the next row. It fails with a *RowError for malformed rows and io.EOF at the end.
+func (r *Reader) Read() (Row, error) {
+	return r.next()
+}
+
+// Line returns the line of the row read last.
+func (r *Reader) Line() int {
+	return r.line
+}
+
+func (r *Reader) checked(row Row) (Row, error) {
+	row.Code = codeOf(row.Code)
+	if row.Target == "" {
+		return Row{}, &RowError{Line: r.line, Err: ErrNoTarget}
+	}
+	return row, nil
+}
+
+func (r *Reader) csv(in io.Reader, known map[string]string) (func() (Row, error), error) {
+	cr := csv.NewReader(in)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: file is empty", ErrBadHeader)
+		}
+		return nil, err
+	}
+	// index maps native columns to their index in the records.
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		if column, ok := known[name]; ok {
+			if _, dup := index[column]; !dup {
+				index[column] = i
+			}
+		}
+	}
+	if _, ok := index["target"]; !ok {
+		return nil, fmt.Errorf("%w: no target column", ErrBadHeader)
+	}
+	return func() (Row, error) {
+		for {
+			record, err := cr.Read()
+			if err != nil {
+				var parseErr *csv.ParseError
+				if errors.As(err, &parseErr) {
+					r.line = parseErr.Line
+					return Row{}, &RowError{Line: r.line, Err: parseErr.Err}
+				}
+				return Row{}, err
+			}
+			r.line, _ = cr.FieldPos(0)
+			if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+				continue
+			}
+			field := func(column string) string {
+				if i, ok := index[column]; ok && i < len(record) {
+					return strings.TrimSpace(record[i])
+				}
+				return ""
+			}
+			row, err := parseRecord(field)
+			if err != nil {
+				return Row{}, &RowError{Line: r.line, Err: err}
+			}
+			return r.checked(row)
+		}
+	}, nil
+}
+
+func parseRecord(field func(column string) string) (Row, error) {
+	row := Row{
+		Code:   field("code"),
+		Target: field("target"),
+		Alias:  field("alias"),
+		Owner:  field("owner"),
+		Tags:   splitTags(field("tags")),
+	}
+	var err error
+	if row.CreatedAt, err = parseTime(field("created_at")); err != nil {
+		return Row{}, fmt.Errorf("created_at: %w", err)
+	}
+	if row.ExpiresAt, err = parseTime(field("expires_at")); err != nil {
+		return Row{}, fmt.Errorf("expires_at: %w", err)
+	}
+	if s := field("disabled"); s != "" {
+		if row.Disabled, err = strconv.ParseBool(s); err != nil {
+			return Row{}, fmt.Errorf("disabled: %q is not a boolean", s)
+		}
+	}
+	if s := field("max_clicks"); s != "" {
+		if row.MaxClicks, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return Row{}, fmt.Errorf("max_clicks: %q is not an integer", s)
+		}
+	}
+	return row, nil
+}
+
+// timeLayouts are the accepted CSV time formats besides Unix seconds. Times without a zone are UTC.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+func parseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		t := time.Unix(secs, 0).UTC()
+		return &t, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not an RFC 3339 time, a date or Unix seconds", s)
+}
+
+// tagSeparator separates the tags in a CSV field.
+const tagSeparator = ","
+
+func splitTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, tagSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// codeOf returns the code of a short URL, or s itself if it is not a URL.
+func codeOf(s string) string {
+	if !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+	return strings.Trim(u.Path, "/")
+}
+
+// Writer writes rows to a file.
+type Writer struct {
+	write func(Row) error
+	flush func() error
+}
+
+// NewWriter returns a writer of files of an export format.
+func NewWriter(w io.Writer, format Format) (*Writer, error) {
+	switch format {
+	case FormatNDJSON:
+		buf := bufio.NewWriter(w)
+		enc := json.NewEncoder(buf)
+		return &Writer{write: func(row Row) error { return enc.Encode(row) }, flush: buf.Flush}, nil
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		header := false
+		return &Writer{
+			write: func(row Row) error {
+				if !header {
+					header = true
+					if err := cw.Write(columns); err != nil {
+						return err
+					}
+				}
+				return cw.Write(formatRecord(row))
+			},
+			flush: func() error {
+				if !header {
+					if err := cw.Write(columns); err != nil {
+						return err
+					}
+				}
+				cw.Flush()
+				return cw.Error()
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q cannot be exported", ErrUnknownFormat, format)
+	}
+}
+
+func (w *Writer) Write(row Row) error {
+	return w.write(row)
+}
+
+// Flush writes buffered rows, and the header of CSV files without rows.
+func (w *Writer) Flush() error {
+	return w.flush()
+}
+
+func formatRecord(row Row) []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	maxClicks := ""
+	if row.MaxClicks != 0 {
+		maxClicks = strconv.FormatInt(row.MaxClicks, 10)
+	}
+	disabled := ""
+	if row.Disabled {
+		disabled = "true"
+	}
+	return []string{
+		row.Code,
+		row.Target,
+		formatTime(row.CreatedAt),
+		row.Alias,
+		strings.Join(row.Tags, tagSeparator),
+		row.Owner,
+		disabled,
+		formatTime(row.ExpiresAt),
+		maxClicks,
+	}
+}
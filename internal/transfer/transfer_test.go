@@ -0,0 +1,239 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Parzival-05/url-shortener/internal/database"
+	"github.com/Parzival-05/url-shortener/internal/database/inmemory"
+	"github.com/Parzival-05/url-shortener/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// readAll reads every row of a file, collecting row errors by line.
+func readAll(t *testing.T, input string, format Format) ([]Row, map[int]error) {
+	t.Helper()
+	r, err := NewReader(strings.NewReader(input), format)
+	require.NoError(t, err)
+	var rows []Row
+	rowErrs := map[int]error{}
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, rowErrs
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrs[rowErr.Line] = rowErr.Err
+			continue
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestReader(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("csv", func(t *testing.T) {
+		rows, rowErrs := readAll(t, strings.Join([]string{
+			"\ufeffCode,Target,Created At,Tags,Disabled,Max Clicks,Unknown",
+			`abc,https://example.com/a,2024-03-01T12:00:00Z,"x, y",true,3,?`,
+			``,
+			`,https://example.com/b,,,,,`,
+			`def,,,,,,`,
+			`ghi,https://example.com/c,yesterday,,,,`,
+		}, "\n"), FormatCSV)
+		require.Len(t, rows, 2)
+		assert.Equal(t, Row{Code: "abc", Target: "https://example.com/a", CreatedAt: &created, Tags: []string{"x", "y"}, Disabled: true, MaxClicks: 3}, rows[0])
+		assert.Equal(t, Row{Target: "https://example.com/b"}, rows[1])
+		require.Len(t, rowErrs, 2)
+		assert.ErrorIs(t, rowErrs[5], ErrNoTarget)
+		assert.ErrorContains(t, rowErrs[6], "created_at")
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		rows, rowErrs := readAll(t, strings.Join([]string{
+			`{"code": "abc", "target": "https://example.com/a", "created_at": "2024-03-01T12:00:00Z", "id": 7}`,
+			`{"target": 1}`,
+			``,
+			`{"alias": "docs", "target": "https://example.com/b"}`,
+		}, "\n"), FormatNDJSON)
+		require.Len(t, rows, 2)
+		assert.Equal(t, Row{Code: "abc", Target: "https://example.com/a", CreatedAt: &created}, rows[0])
+		assert.Equal(t, "docs", rows[1].Alias)
+		assert.Contains(t, rowErrs, 2)
+	})
+
+	t.Run("bitly", func(t *testing.T) {
+		rows, _ := readAll(t, strings.Join([]string{
+			"bitlink,long_url,title,created_at,tags",
+			"https://bit.ly/3xYz,https://example.com/a,A,2024-03-01 12:00:00,docs",
+		}, "\n"), FormatBitly)
+		require.Len(t, rows, 1)
+		assert.Equal(t, Row{Code: "3xYz", Target: "https://example.com/a", CreatedAt: &created, Tags: []string{"docs"}}, rows[0])
+	})
+
+	t.Run("yourls", func(t *testing.T) {
+		rows, _ := readAll(t, strings.Join([]string{
+			"keyword,url,title,timestamp,ip,clicks",
+			"docs,https://example.com/a,A,1709294400,127.0.0.1,5",
+		}, "\n"), FormatYOURLS)
+		require.Len(t, rows, 1)
+		assert.Equal(t, Row{Code: "docs", Target: "https://example.com/a", CreatedAt: &created}, rows[0])
+	})
+
+	t.Run("bad header", func(t *testing.T) {
+		_, err := NewReader(strings.NewReader("code,url\n"), FormatCSV)
+		assert.ErrorIs(t, err, ErrBadHeader)
+		_, err = NewReader(strings.NewReader(""), FormatCSV)
+		assert.ErrorIs(t, err, ErrBadHeader)
+		_, err = NewReader(strings.NewReader(""), "xml")
+		assert.ErrorIs(t, err, ErrUnknownFormat)
+	})
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expires := created.Add(24 * time.Hour)
+	rows := []Row{
+		{Code: "abc", Target: "https://example.com/a?x=1,2", CreatedAt: &created, Tags: []string{"x", "y"}, Owner: "alice", Disabled: true, ExpiresAt: &expires, MaxClicks: 3},
+		{Target: "https://example.com/b"},
+	}
+	for _, format := range ExportFormats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, format)
+			require.NoError(t, err)
+			for _, row := range rows {
+				require.NoError(t, w.Write(row))
+			}
+			require.NoError(t, w.Flush())
+			read, rowErrs := readAll(t, buf.String(), format)
+			assert.Empty(t, rowErrs)
+			assert.Equal(t, rows, read)
+		})
+	}
+
+	_, err := NewWriter(io.Discard, FormatBitly)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func newTestShortener(t *testing.T) *service.UrlShortener {
+	t.Helper()
+	db := inmemory.NewInMemoryDBService()
+	return service.NewUrlShortener(db.NewUrlRepository(), zaptest.NewLogger(t))
+}
+
+func importAll(t *testing.T, shortener service.IUrlShortener, input string, opts Options) (Summary, []Result) {
+	t.Helper()
+	var results []Result
+	summary, err := Import(context.Background(), shortener, strings.NewReader(input), opts, func(r Result) error {
+		results = append(results, r)
+		return nil
+	})
+	require.NoError(t, err)
+	return summary, results
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	shortener := newTestShortener(t)
+	existing, err := shortener.CreateLink(ctx, database.Link{Target: "https://example.com/existing"})
+	require.NoError(t, err)
+	d, err := shortener.Domain(ctx)
+	require.NoError(t, err)
+	free, err := d.Encode(1000)
+	require.NoError(t, err)
+
+	input := strings.Join([]string{
+		"code,target,alias",
+		free + ",https://example.com/kept,",
+		existing.Code + ",https://example.com/taken,",
+		"not-ours,https://example.com/foreign,",
+		",https://example.com/aliased,docs",
+		",ftp://example.com,",
+	}, "\n")
+
+	t.Run("dry run", func(t *testing.T) {
+		summary, results := importAll(t, shortener, input+"\n"+free+",https://example.com/twice,", Options{Format: FormatCSV, DryRun: true})
+		assert.Equal(t, Summary{DryRun: true, Imported: 2, Remapped: 3, Invalid: 1}, summary)
+		require.Len(t, results, 6)
+		assert.Equal(t, free, results[0].NewCode)
+		assert.Equal(t, StatusRemapped, results[5].Status, "an earlier row takes the code")
+		_, err := shortener.GetLink(ctx, free)
+		assert.ErrorIs(t, err, service.ErrUrlNotFound)
+	})
+
+	t.Run("skip taken", func(t *testing.T) {
+		summary, results := importAll(t, newTestShortener(t), "code,target\n"+free+",https://example.com/a\n"+free+",https://example.com/b", Options{Format: FormatCSV, SkipTaken: true})
+		assert.Equal(t, Summary{Imported: 1, Skipped: 1}, summary)
+		assert.Equal(t, StatusSkipped, results[1].Status)
+	})
+
+	summary, results := importAll(t, shortener, input, Options{Format: FormatCSV})
+	assert.Equal(t, Summary{Imported: 2, Remapped: 2, Invalid: 1}, summary)
+	require.Len(t, results, 5)
+
+	assert.Equal(t, Result{Line: 2, Status: StatusImported, Code: free, NewCode: free, Target: "https://example.com/kept"}, results[0])
+	kept, err := shortener.GetLink(ctx, free)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/kept", kept.Target)
+
+	assert.Equal(t, StatusRemapped, results[1].Status)
+	assert.Equal(t, service.ErrCodeTaken.Error(), results[1].Error)
+	assert.NotEqual(t, existing.Code, results[1].NewCode)
+	assert.Equal(t, StatusRemapped, results[2].Status)
+	assert.Equal(t, service.ErrForeignCode.Error(), results[2].Error)
+	assert.Equal(t, StatusImported, results[3].Status)
+	assert.Equal(t, StatusInvalid, results[4].Status)
+	assert.Equal(t, 6, results[4].Line)
+
+	// new links are not given the IDs of imported ones
+	created, err := shortener.CreateLink(ctx, database.Link{Target: "https://example.com/new"})
+	require.NoError(t, err)
+	assert.NotEqual(t, free, created.Code)
+
+	var mapping bytes.Buffer
+	m := NewMappingWriter(&mapping)
+	for _, r := range results {
+		require.NoError(t, m.Write(r))
+	}
+	require.NoError(t, m.Flush())
+	lines := strings.Split(strings.TrimSpace(mapping.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "old_code,alias,new_code,short_url", lines[0])
+	assert.True(t, strings.HasPrefix(lines[3], ",docs,"+results[3].NewCode))
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	shortener := newTestShortener(t)
+	for _, target := range []string{"https://example.com/a", "https://example.com/b"} {
+		_, err := shortener.CreateLink(ctx, database.Link{Target: target, Disabled: target == "https://example.com/b"})
+		require.NoError(t, err)
+	}
+	var buf bytes.Buffer
+	count, err := Export(ctx, shortener, &buf, FormatNDJSON)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// an export imports into an empty storage with the same codes
+	target := newTestShortener(t)
+	summary, results := importAll(t, target, buf.String(), Options{Format: FormatNDJSON})
+	assert.Equal(t, Summary{Imported: 2}, summary)
+	for _, r := range results {
+		assert.Equal(t, r.Code, r.NewCode)
+	}
+	link, err := target.GetLink(ctx, results[1].NewCode)
+	require.NoError(t, err)
+	assert.True(t, link.Disabled)
+}
@@ -0,0 +1,331 @@
+// Package urltemplate expands link targets with named placeholders, such as
+// https://example.com/docs/{version}?user={uid}, from the trailing path and query of a request.
+//
+// A placeholder is {name}, {name=default} or {+name}. Values are escaped for the part of the
+// URL they end up in: path segments (a "/" in the value is escaped too), query or fragment.
+// {+name} keeps the "/" of a value in the path, so it can fill several segments.
+// A placeholder without a default is required. Placeholders are only allowed after the host,
+// so a value can never change where a link points to.
+package urltemplate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidTemplate = errors.New("invalid template")
+	// ErrMissingVariable is returned when a required variable has no value.
+	ErrMissingVariable = errors.New("missing template variable")
+	// ErrNoMatch is returned when the trailing path doesn't match the path pattern.
+	ErrNoMatch = errors.New("path doesn't match the template")
+	// ErrInvalidValue is returned for values that can't be expanded, e.g. dot segments in the path.
+	ErrInvalidValue = errors.New("invalid template value")
+)
+
+const (
+	// MaxVariables bounds the placeholders of a template.
+	MaxVariables = 16
+	// MaxValueLength bounds the expanded value of a variable.
+	MaxValueLength = 1024
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z_][0-9A-Za-z_]{0,31}$`)
+
+// Template turns the target of a link into a template. The zero value expands variables
+// from the query only.
+type Template struct {
+	// Path is the pattern of the trailing path, e.g. "{version}/{page}". A segment is either a literal
+	// or a placeholder {name}; the last one may be {+name}, which takes the rest of the path.
+	// Trailing placeholders may be left out by a request.
+	Path string `json:"path,omitempty"`
+}
+
+// part is the section of the URL a placeholder is in, which decides how its value is escaped.
+type part int
+
+const (
+	partPath part = iota
+	partQuery
+	partFragment
+)
+
+type placeholder struct {
+	name       string
+	def        string
+	hasDefault bool
+	reserved   bool
+	part       part
+}
+
+// token is a literal or, if ph is set, a placeholder of the target.
+type token struct {
+	literal string
+	ph      *placeholder
+}
+
+// parseTarget splits target into literals and placeholders.
+func parseTarget(target string) ([]token, error) {
+	var tokens []token
+	rest := target
+	p := partPath
+	afterHost := false
+	names := map[string]bool{}
+	for rest != "" {
+		i := strings.IndexAny(rest, "{}")
+		if i < 0 {
+			tokens = append(tokens, token{literal: rest})
+			break
+		}
+		if rest[i] == '}' {
+			return nil, fmt.Errorf("%w: unmatched }", ErrInvalidTemplate)
+		}
+		literal := rest[:i]
+		tokens = append(tokens, token{literal: literal})
+		afterHost = afterHost || pathStarted(target[:len(target)-len(rest)+i])
+		p = partOf(p, literal)
+		end := strings.IndexByte(rest[i:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unmatched {", ErrInvalidTemplate)
+		}
+		ph, err := parsePlaceholder(rest[i+1 : i+end])
+		if err != nil {
+			return nil, err
+		}
+		if !afterHost {
+			return nil, fmt.Errorf("%w: placeholder {%s} before the path", ErrInvalidTemplate, ph.name)
+		}
+		if ph.reserved && p != partPath {
+			return nil, fmt.Errorf("%w: {+%s} is only allowed in the path", ErrInvalidTemplate, ph.name)
+		}
+		ph.part = p
+		names[ph.name] = true
+		tokens = append(tokens, token{ph: &ph})
+		rest = rest[i+end+1:]
+	}
+	if len(names) > MaxVariables {
+		return nil, fmt.Errorf("%w: more than %d variables", ErrInvalidTemplate, MaxVariables)
+	}
+	return tokens, nil
+}
+
+// pathStarted reports whether prefix of a target ends after its host.
+func pathStarted(prefix string) bool {
+	_, rest, ok := strings.Cut(prefix, "://")
+	return ok && strings.ContainsAny(rest, "/?#")
+}
+
+// partOf returns the part of the URL following literal, which comes after a placeholder in p.
+func partOf(p part, literal string) part {
+	switch {
+	case strings.Contains(literal, "#"):
+		return partFragment
+	case p == partPath && strings.Contains(literal, "?"):
+		return partQuery
+	}
+	return p
+}
+
+func parsePlaceholder(expr string) (placeholder, error) {
+	var ph placeholder
+	if strings.HasPrefix(expr, "+") {
+		ph.reserved = true
+		expr = expr[1:]
+	}
+	ph.name, ph.def, ph.hasDefault = strings.Cut(expr, "=")
+	if !namePattern.MatchString(ph.name) {
+		return placeholder{}, fmt.Errorf("%w: invalid variable name %q", ErrInvalidTemplate, ph.name)
+	}
+	if len(ph.def) > MaxValueLength {
+		return placeholder{}, fmt.Errorf("%w: default of %s is longer than %d bytes", ErrInvalidTemplate, ph.name, MaxValueLength)
+	}
+	return ph, nil
+}
+
+// segment is a segment of the path pattern: a literal, or a variable if name is set.
+type segment struct {
+	literal  string
+	name     string
+	reserved bool
+}
+
+func parsePath(pattern string) ([]segment, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	segments := make([]segment, 0, len(parts))
+	for i, p := range parts {
+		if !strings.HasPrefix(p, "{") {
+			if strings.ContainsAny(p, "{}") || p == "" || p == "." || p == ".." {
+				return nil, fmt.Errorf("%w: invalid path segment %q", ErrInvalidTemplate, p)
+			}
+			segments = append(segments, segment{literal: p})
+			continue
+		}
+		if !strings.HasSuffix(p, "}") {
+			return nil, fmt.Errorf("%w: invalid path segment %q", ErrInvalidTemplate, p)
+		}
+		ph, err := parsePlaceholder(p[1 : len(p)-1])
+		if err != nil {
+			return nil, err
+		}
+		if ph.hasDefault {
+			return nil, fmt.Errorf("%w: defaults belong in the target, not the path pattern", ErrInvalidTemplate)
+		}
+		if ph.reserved && i != len(parts)-1 {
+			return nil, fmt.Errorf("%w: {+%s} must be the last path segment", ErrInvalidTemplate, ph.name)
+		}
+		segments = append(segments, segment{name: ph.name, reserved: ph.reserved})
+	}
+	return segments, nil
+}
+
+// Validate checks the template and that target is an absolute http(s) URL once expanded.
+func (t Template) Validate(target string) error {
+	tokens, err := parseTarget(target)
+	if err != nil {
+		return err
+	}
+	// Expanding every placeholder to a plain value must give a valid URL.
+	var b strings.Builder
+	names := map[string]bool{}
+	for _, tok := range tokens {
+		if tok.ph == nil {
+			b.WriteString(tok.literal)
+			continue
+		}
+		names[tok.ph.name] = true
+		b.WriteString("x")
+	}
+	u, err := url.Parse(b.String())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: target must be an absolute http(s) URL", ErrInvalidTemplate)
+	}
+	segments, err := parsePath(t.Path)
+	if err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, s := range segments {
+		if s.name == "" {
+			continue
+		}
+		if seen[s.name] {
+			return fmt.Errorf("%w: variable %s appears twice in the path pattern", ErrInvalidTemplate, s.name)
+		}
+		if !names[s.name] {
+			return fmt.Errorf("%w: path variable %s isn't used by the target", ErrInvalidTemplate, s.name)
+		}
+		seen[s.name] = true
+	}
+	return nil
+}
+
+// match binds the variables of the path pattern to the segments of the escaped path.
+// Without a pattern the path is left alone.
+func match(segments []segment, path string) (map[string]string, error) {
+	vars := map[string]string{}
+	path = strings.Trim(path, "/")
+	if len(segments) == 0 || path == "" {
+		return vars, nil
+	}
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if i >= len(segments) {
+			return nil, ErrNoMatch
+		}
+		s := segments[i]
+		if s.reserved {
+			value, err := url.PathUnescape(strings.Join(parts[i:], "/"))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrNoMatch, err)
+			}
+			vars[s.name] = value
+			return vars, nil
+		}
+		value, err := url.PathUnescape(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrNoMatch, err)
+		}
+		if s.name == "" {
+			if value != s.literal {
+				return nil, ErrNoMatch
+			}
+			continue
+		}
+		vars[s.name] = value
+	}
+	return vars, nil
+}
+
+// Expand fills target with the variables of the escaped trailing path and the query.
+// Templates without a path pattern ignore the path.
+// Path variables take precedence over query parameters, which take precedence over defaults.
+func (t Template) Expand(target, path string, query url.Values) (string, error) {
+	tokens, err := parseTarget(target)
+	if err != nil {
+		return "", err
+	}
+	segments, err := parsePath(t.Path)
+	if err != nil {
+		return "", err
+	}
+	vars, err := match(segments, path)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, tok := range tokens {
+		if tok.ph == nil {
+			b.WriteString(tok.literal)
+			continue
+		}
+		value, ok := vars[tok.ph.name]
+		if !ok {
+			value = query.Get(tok.ph.name)
+		}
+		// Empty values count as missing.
+		if value == "" {
+			if !tok.ph.hasDefault {
+				return "", fmt.Errorf("%w: %s", ErrMissingVariable, tok.ph.name)
+			}
+			value = tok.ph.def
+		}
+		if len(value) > MaxValueLength {
+			return "", fmt.Errorf("%w: %s is longer than %d bytes", ErrInvalidValue, tok.ph.name, MaxValueLength)
+		}
+		escaped, err := escape(value, tok.ph)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(escaped)
+	}
+	return b.String(), nil
+}
+
+func escape(value string, ph *placeholder) (string, error) {
+	switch ph.part {
+	case partQuery:
+		return url.QueryEscape(value), nil
+	case partFragment:
+		return url.PathEscape(value), nil
+	}
+	if !ph.reserved {
+		if value == "." || value == ".." {
+			return "", fmt.Errorf("%w: %s is a dot segment", ErrInvalidValue, ph.name)
+		}
+		return url.PathEscape(value), nil
+	}
+	parts := strings.Split(value, "/")
+	for i, p := range parts {
+		if p == "." || p == ".." {
+			return "", fmt.Errorf("%w: %s has dot segments", ErrInvalidValue, ph.name)
+		}
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/"), nil
+}
@@ -0,0 +1,110 @@
+package urltemplate
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_Expand(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   Template
+		target string
+		path   string
+		query  string
+		want   string
+	}{
+		{"path variable", Template{Path: "{version}"}, "https://example.com/docs/{version}/index.html", "v2", "", "https://example.com/docs/v2/index.html"},
+		{"query variable", Template{}, "https://example.com/profile?user={uid}", "", "uid=42&x=1", "https://example.com/profile?user=42"},
+		{"default", Template{Path: "{version}"}, "https://example.com/docs/{version=latest}", "", "", "https://example.com/docs/latest"},
+		{"empty value takes the default", Template{}, "https://example.com/{lang=en}/", "", "lang=", "https://example.com/en/"},
+		{"empty default", Template{}, "https://example.com/search?q={q=}", "", "", "https://example.com/search?q="},
+		{"path before query", Template{Path: "{id}"}, "https://example.com/items/{id}", "7", "id=8", "https://example.com/items/7"},
+		{"literal segments", Template{Path: "docs/{version}/{page}"}, "https://example.com/{version}/{page=index}.html", "docs/v1", "", "https://example.com/v1/index.html"},
+		{"path escaping", Template{Path: "{name}"}, "https://example.com/users/{name}", "a%2Fb%20c", "", "https://example.com/users/a%2Fb%20c"},
+		{"reserved", Template{Path: "{+rest}"}, "https://example.com/files/{+rest}", "a/b%20c/d.txt", "", "https://example.com/files/a/b%20c/d.txt"},
+		{"query escaping", Template{}, "https://example.com/?next={next}&v=1", "", "next=" + url.QueryEscape("https://x.com/?a=1&b=2"), "https://example.com/?next=https%3A%2F%2Fx.com%2F%3Fa%3D1%26b%3D2&v=1"},
+		{"fragment", Template{}, "https://example.com/page#{section}", "", "section=a b", "https://example.com/page#a%20b"},
+		{"repeated", Template{Path: "{id}"}, "https://example.com/{id}?ref={id}", "x&y", "", "https://example.com/x&y?ref=x%26y"},
+		{"no placeholders", Template{}, "https://example.com/a?b=c", "", "d=e", "https://example.com/a?b=c"},
+		{"path without pattern", Template{}, "https://example.com/{id=1}", "7", "", "https://example.com/1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.tmpl.Validate(tt.target))
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			got, err := tt.tmpl.Expand(tt.target, tt.path, query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTemplate_ExpandErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   Template
+		target string
+		path   string
+		query  string
+		want   error
+	}{
+		{"missing", Template{}, "https://example.com/?user={uid}", "", "", ErrMissingVariable},
+		{"empty", Template{}, "https://example.com/?user={uid}", "", "uid=", ErrMissingVariable},
+		{"too many segments", Template{Path: "{id}"}, "https://example.com/{id}", "7/8", "", ErrNoMatch},
+		{"literal mismatch", Template{Path: "docs/{id}"}, "https://example.com/{id}", "blog/7", "", ErrNoMatch},
+		{"dot segment", Template{Path: "{id}"}, "https://example.com/a/{id}", "..", "", ErrInvalidValue},
+		{"dot segment from query", Template{}, "https://example.com/a/{id}", "", "id=..", ErrInvalidValue},
+		{"reserved dot segment", Template{Path: "{+rest}"}, "https://example.com/a/{+rest}", "b/%2E%2E/c", "", ErrInvalidValue},
+		{"too long", Template{}, "https://example.com/{id}", "", "id=" + strings.Repeat("x", MaxValueLength+1), ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.tmpl.Validate(tt.target))
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			_, err = tt.tmpl.Expand(tt.target, tt.path, query)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   Template
+		target string
+	}{
+		{"host", Template{}, "https://{sub}.example.com/"},
+		{"scheme", Template{}, "{scheme}://example.com/"},
+		{"no path", Template{}, "https://example.com{path}"},
+		{"unmatched open", Template{}, "https://example.com/{id"},
+		{"unmatched close", Template{}, "https://example.com/id}"},
+		{"bad name", Template{}, "https://example.com/{1d}"},
+		{"reserved in query", Template{}, "https://example.com/?q={+q}"},
+		{"not http", Template{}, "ftp://example.com/{id}"},
+		{"relative", Template{}, "/docs/{id}"},
+		{"unused path variable", Template{Path: "{id}"}, "https://example.com/"},
+		{"duplicate path variable", Template{Path: "{id}/{id}"}, "https://example.com/{id}"},
+		{"default in pattern", Template{Path: "{id=1}"}, "https://example.com/{id}"},
+		{"reserved not last", Template{Path: "{+a}/{b}"}, "https://example.com/{+a}/{b}"},
+		{"dot segment in pattern", Template{Path: "../{id}"}, "https://example.com/{id}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.tmpl.Validate(tt.target), ErrInvalidTemplate)
+		})
+	}
+
+	var many strings.Builder
+	many.WriteString("https://example.com/")
+	for i := range MaxVariables + 1 {
+		many.WriteString("{v" + strings.Repeat("x", i) + "=}")
+	}
+	assert.ErrorIs(t, Template{}.Validate(many.String()), ErrInvalidTemplate)
+}
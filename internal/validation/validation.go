@@ -0,0 +1,108 @@
+// Package validation runs the protoc-gen-validate rules declared in the proto files,
+// so that gRPC and HTTP reject the same inputs.
+package validation
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// FieldViolation describes why a single request field is invalid.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Error is returned when a message breaks its validation rules.
+type Error struct {
+	Violations []FieldViolation
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, v.Field+": "+v.Description)
+	}
+	return "invalid request: " + strings.Join(parts, "; ")
+}
+
+type validatorAll interface {
+	ValidateAll() error
+}
+
+type validator interface {
+	Validate() error
+}
+
+// fieldError is implemented by every generated *ValidationError type.
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+type multiError interface {
+	AllErrors() []error
+}
+
+// Validate checks msg against its generated rules. Messages without rules are always valid.
+// A non-nil result is always an *Error.
+func Validate(msg any) error {
+	var err error
+	switch v := msg.(type) {
+	case validatorAll:
+		err = v.ValidateAll()
+	case validator:
+		err = v.Validate()
+	}
+	if err == nil {
+		return nil
+	}
+	return &Error{Violations: violations("", err)}
+}
+
+// violations flattens nested generated errors into field paths such as "link.tags[0]".
+func violations(prefix string, err error) []FieldViolation {
+	var multi multiError
+	if errors.As(err, &multi) {
+		var result []FieldViolation
+		for _, e := range multi.AllErrors() {
+			result = append(result, violations(prefix, e)...)
+		}
+		return result
+	}
+	var fe fieldError
+	if !errors.As(err, &fe) {
+		return []FieldViolation{{Field: prefix, Description: err.Error()}}
+	}
+	field := joinPath(prefix, snakeCase(fe.Field()))
+	if cause := fe.Cause(); cause != nil {
+		if nested := violations(field, cause); len(nested) > 0 {
+			return nested
+		}
+	}
+	return []FieldViolation{{Field: field, Description: fe.Reason()}}
+}
+
+func joinPath(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	return prefix + "." + field
+}
+
+// snakeCase converts generated Go field names ("CreateTime", "Tags[0]") back to proto names.
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
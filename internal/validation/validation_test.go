@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	url_shortener_v1 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v1"
+	url_shortener_v2 "github.com/Parzival-05/url-shortener/api/gen/proto/url_shortener/v2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    any
+		fields []string
+	}{
+		{
+			name: "valid create request",
+			msg:  &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/path?q=1"},
+		},
+		{
+			name:   "empty url",
+			msg:    &url_shortener_v1.CreateShortURLRequest{},
+			fields: []string{"url"},
+		},
+		{
+			name:   "relative url",
+			msg:    &url_shortener_v1.CreateShortURLRequest{Url: "/just/a/path"},
+			fields: []string{"url"},
+		},
+		{
+			name:   "non-http scheme",
+			msg:    &url_shortener_v1.CreateShortURLRequest{Url: "javascript:alert(1)"},
+			fields: []string{"url"},
+		},
+		{
+			name:   "too long url",
+			msg:    &url_shortener_v1.CreateShortURLRequest{Url: "https://example.com/" + strings.Repeat("a", 100_000)},
+			fields: []string{"url"},
+		},
+		{
+			name:   "bad code",
+			msg:    &url_shortener_v1.GetOriginalURLRequest{ShortUrl: "not a code!"},
+			fields: []string{"short_url"},
+		},
+		{
+			name:   "missing link",
+			msg:    &url_shortener_v2.CreateLinkRequest{},
+			fields: []string{"link"},
+		},
+		{
+			name: "nested violations are flattened",
+			msg: &url_shortener_v2.CreateLinkRequest{Link: &url_shortener_v2.Link{
+				Target: "nope",
+				Tags:   []string{"ok", "not ok"},
+			}},
+			fields: []string{"link.target", "link.tags[1]"},
+		},
+		{
+			name:   "page size out of range",
+			msg:    &url_shortener_v2.ListLinksRequest{PageSize: 5000},
+			fields: []string{"page_size"},
+		},
+		{
+			name: "message without rules",
+			msg:  struct{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.msg)
+			if len(tt.fields) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			verr, ok := err.(*Error)
+			require.True(t, ok)
+			var fields []string
+			for _, v := range verr.Violations {
+				fields = append(fields, v.Field)
+				assert.NotEmpty(t, v.Description)
+			}
+			// A field may break several rules at once.
+			assert.Equal(t, tt.fields, slices.Compact(fields))
+		})
+	}
+}
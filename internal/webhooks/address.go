@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, private to the network of a provider.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// publicAddr reports whether addr may be posted to: anything but loopback, private,
+// link-local (which includes the 169.254.169.254 metadata endpoint of clouds), unspecified,
+// multicast and shared addresses.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() && !addr.IsLoopback() && !addr.IsPrivate() && !addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() && !addr.IsInterfaceLocalMulticast() && !addr.IsMulticast() &&
+		!addr.IsUnspecified() && !sharedAddressSpace.Contains(addr)
+}
+
+// checkEndpoint fails with ErrInvalidSubscription if the host of rawURL is, or resolves to, an
+// address that is not public. Hosts that don't resolve pass: the dialer of the dispatcher checks
+// the address of every connection anyway, which also covers hosts changing their addresses.
+func checkEndpoint(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidSubscription)
+	}
+	host := u.Hostname()
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else if addrs, err = net.DefaultResolver.LookupNetIP(ctx, "ip", host); err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !publicAddr(addr) {
+			return fmt.Errorf("%w: url must not point to a private, loopback or link-local address", ErrInvalidSubscription)
+		}
+	}
+	return nil
+}
+
+// dialPublic refuses connections to addresses that are not public. It runs after name
+// resolution, so endpoints can't reach them by resolving differently later.
+func dialPublic(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !publicAddr(addrPort.Addr()) {
+		return fmt.Errorf("webhook endpoint address %s is not public", addrPort.Addr())
+	}
+	return nil
+}
+
+// newClient returns the client deliveries are posted with. Unless private endpoints are allowed
+// it only connects to public addresses, and directly, as a proxy would connect for it.
+func newClient(cfg Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !cfg.AllowPrivateEndpoints {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialPublic}
+		transport.DialContext = dialer.DialContext
+		transport.Proxy = nil
+	}
+	return &http.Client{Timeout: cfg.Timeout, Transport: transport}
+}
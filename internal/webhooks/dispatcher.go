@@ -0,0 +1,389 @@
+package webhooks
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Config tunes the delivery of a Dispatcher. Zero fields take the value of DefaultConfig.
+type Config struct {
+	// MaxAttempts is the number of attempts after which a delivery is dead-lettered.
+	MaxAttempts int
+	// MinBackoff is the delay after the first failed attempt. It doubles with every further one, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Timeout bounds a single attempt.
+	Timeout time.Duration
+	// PollInterval is how often due retries are looked for.
+	PollInterval time.Duration
+	// QueueSize is the number of published events waiting to be persisted. Events beyond it are dropped.
+	QueueSize int
+	// BatchSize is the number of deliveries attempted concurrently.
+	BatchSize int
+	// AllowPrivateEndpoints lets endpoints be loopback, private and link-local addresses. Off,
+	// they are refused on subscription and on every connection, so that subscribers can't make
+	// the server probe its own network.
+	AllowPrivateEndpoints bool
+}
+
+var DefaultConfig = Config{
+	MaxAttempts:  8,
+	MinBackoff:   time.Second,
+	MaxBackoff:   time.Hour,
+	Timeout:      10 * time.Second,
+	PollInterval: time.Second,
+	QueueSize:    1024,
+	BatchSize:    100,
+}
+
+// ConfigFromEnv reads the config from WEBHOOK_MAX_ATTEMPTS, WEBHOOK_MIN_BACKOFF, WEBHOOK_MAX_BACKOFF,
+// WEBHOOK_TIMEOUT, WEBHOOK_POLL_INTERVAL, WEBHOOK_QUEUE_SIZE, WEBHOOK_BATCH_SIZE and
+// WEBHOOK_ALLOW_PRIVATE_ENDPOINTS, using DefaultConfig for unset or malformed values.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig
+	envInt := func(key string, v *int) {
+		if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+			*v = n
+		}
+	}
+	envDuration := func(key string, v *time.Duration) {
+		if d, err := time.ParseDuration(os.Getenv(key)); err == nil && d > 0 {
+			*v = d
+		}
+	}
+	envInt("WEBHOOK_MAX_ATTEMPTS", &cfg.MaxAttempts)
+	envDuration("WEBHOOK_MIN_BACKOFF", &cfg.MinBackoff)
+	envDuration("WEBHOOK_MAX_BACKOFF", &cfg.MaxBackoff)
+	envDuration("WEBHOOK_TIMEOUT", &cfg.Timeout)
+	envDuration("WEBHOOK_POLL_INTERVAL", &cfg.PollInterval)
+	envInt("WEBHOOK_QUEUE_SIZE", &cfg.QueueSize)
+	envInt("WEBHOOK_BATCH_SIZE", &cfg.BatchSize)
+	cfg.AllowPrivateEndpoints, _ = strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE_ENDPOINTS"))
+	return cfg
+}
+
+func (c Config) withDefaults() Config {
+	c.MaxAttempts = cmp.Or(c.MaxAttempts, DefaultConfig.MaxAttempts)
+	c.MinBackoff = cmp.Or(c.MinBackoff, DefaultConfig.MinBackoff)
+	c.MaxBackoff = max(cmp.Or(c.MaxBackoff, DefaultConfig.MaxBackoff), c.MinBackoff)
+	c.Timeout = cmp.Or(c.Timeout, DefaultConfig.Timeout)
+	c.PollInterval = cmp.Or(c.PollInterval, DefaultConfig.PollInterval)
+	c.QueueSize = cmp.Or(c.QueueSize, DefaultConfig.QueueSize)
+	c.BatchSize = cmp.Or(c.BatchSize, DefaultConfig.BatchSize)
+	return c
+}
+
+// Backoff returns the delay before the retry that follows the given number of failed attempts:
+// MinBackoff doubled for every attempt after the first, capped at MaxBackoff, of which a random
+// half is dropped, so that deliveries failing together do not retry in lockstep.
+func (c Config) Backoff(attempts int) time.Duration {
+	d := c.MinBackoff
+	for i := 1; i < attempts && d < c.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, c.MaxBackoff)
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// Dispatcher persists published events as deliveries and posts them to the subscribers.
+type Dispatcher struct {
+	store  Store
+	log    *zap.Logger
+	cfg    Config
+	client *http.Client
+	events chan Event
+	// wake makes the deliverer look for due deliveries before its next poll.
+	wake chan struct{}
+}
+
+func NewDispatcher(store Store, log *zap.Logger, cfg Config) *Dispatcher {
+	cfg = cfg.withDefaults()
+	return &Dispatcher{
+		store:  store,
+		log:    log,
+		cfg:    cfg,
+		client: newClient(cfg),
+		events: make(chan Event, cfg.QueueSize),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Publish queues the event for delivery without blocking. If the queue is full, the event is dropped.
+func (d *Dispatcher) Publish(ctx context.Context, event Event) {
+	select {
+	case d.events <- event:
+	default:
+		d.log.Error("Webhook queue is full, dropping event", zap.String("event_id", event.ID), zap.String("event", string(event.Type)))
+	}
+}
+
+// Run persists published events and delivers them until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		d.persist(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		d.deliver(ctx)
+	}()
+	wg.Wait()
+}
+
+func (d *Dispatcher) persist(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-d.events:
+			if err := d.enqueue(ctx, event); err != nil {
+				d.log.Error("Failed to persist webhook event", zap.String("event_id", event.ID), zap.Error(err))
+			}
+		}
+	}
+}
+
+// enqueue creates a delivery of the event for every subscription that wants it.
+func (d *Dispatcher) enqueue(ctx context.Context, event Event) error {
+	subs, err := d.store.ListSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	var deliveries []Delivery
+	for _, sub := range subs {
+		if sub.Wants(event.Type) {
+			deliveries = append(deliveries, newDelivery(sub.ID, event, now))
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := d.store.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	d.notify()
+	return nil
+}
+
+func newDelivery(subscriptionID string, event Event, now time.Time) Delivery {
+	return Delivery{
+		ID:             newID("whd"),
+		SubscriptionID: subscriptionID,
+		Event:          event,
+		Status:         StatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+func (d *Dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+		for ctx.Err() == nil {
+			// A claim outlives the attempt, so that only a crashed dispatcher's deliveries are claimed again.
+			claimed, err := d.store.ClaimDeliveries(ctx, time.Now().UTC(), 2*d.cfg.Timeout, d.cfg.BatchSize)
+			if err != nil {
+				d.log.Error("Failed to claim webhook deliveries", zap.Error(err))
+				break
+			}
+			var wg sync.WaitGroup
+			for _, delivery := range claimed {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					d.attempt(ctx, delivery)
+				}()
+			}
+			wg.Wait()
+			if len(claimed) < d.cfg.BatchSize {
+				break
+			}
+		}
+	}
+}
+
+// attempt posts the delivery once and records the outcome.
+func (d *Dispatcher) attempt(ctx context.Context, delivery Delivery) {
+	log := d.log.With(zap.String("delivery_id", delivery.ID), zap.String("subscription_id", delivery.SubscriptionID))
+	sub, err := d.store.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		if !errors.Is(err, ErrSubscriptionNotFound) {
+			log.Error("Failed to get webhook subscription", zap.Error(err))
+		}
+		return
+	}
+
+	statusCode, err := d.post(ctx, sub, delivery)
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+	switch {
+	case err == nil:
+		delivery.Status = StatusSucceeded
+	case delivery.Attempts >= d.cfg.MaxAttempts:
+		delivery.Status = StatusDead
+		delivery.LastError = err.Error()
+		log.Warn("Webhook delivery failed for good", zap.Int("attempts", delivery.Attempts), zap.Error(err))
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(d.cfg.Backoff(delivery.Attempts))
+		log.Debug("Webhook delivery failed, retrying", zap.Int("attempts", delivery.Attempts), zap.Time("next_attempt_at", delivery.NextAttemptAt), zap.Error(err))
+	}
+	// The outcome is stored even on shutdown, so that a delivered event is not sent again.
+	if err := d.store.UpdateDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		log.Error("Failed to update webhook delivery", zap.Error(err))
+	}
+}
+
+// post sends the signed event and returns the response status, failing on anything but 2xx.
+func (d *Dispatcher) post(ctx context.Context, sub Subscription, delivery Delivery) (int, error) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, now, body))
+	req.Header.Set(EventHeader, string(delivery.Event.Type))
+	req.Header.Set(IDHeader, delivery.Event.ID)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Subscribe stores a new subscription, generating its secret if none is given.
+func (d *Dispatcher) Subscribe(ctx context.Context, sub Subscription) (Subscription, error) {
+	if sub.Secret == "" {
+		secret := make([]byte, 24)
+		_, _ = crand.Read(secret)
+		sub.Secret = hex.EncodeToString(secret)
+	}
+	if err := sub.Validate(); err != nil {
+		return Subscription{}, err
+	}
+	if !d.cfg.AllowPrivateEndpoints {
+		if err := checkEndpoint(ctx, sub.URL); err != nil {
+			return Subscription{}, err
+		}
+	}
+	sub.ID = newID("whs")
+	sub.CreatedAt = time.Now().UTC()
+	if err := d.store.CreateSubscription(ctx, sub); err != nil {
+		return Subscription{}, err
+	}
+	return sub, nil
+}
+
+func (d *Dispatcher) Subscriptions(ctx context.Context) ([]Subscription, error) {
+	return d.store.ListSubscriptions(ctx)
+}
+
+func (d *Dispatcher) Subscription(ctx context.Context, id string) (Subscription, error) {
+	return d.store.GetSubscription(ctx, id)
+}
+
+// Unsubscribe deletes the subscription and its delivery log.
+func (d *Dispatcher) Unsubscribe(ctx context.Context, id string) error {
+	return d.store.DeleteSubscription(ctx, id)
+}
+
+// Deliveries returns the delivery log of a subscription, newest first, see Store.ListDeliveries.
+func (d *Dispatcher) Deliveries(ctx context.Context, subscriptionID string, status DeliveryStatus, limit int) ([]Delivery, error) {
+	if _, err := d.store.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return d.store.ListDeliveries(ctx, subscriptionID, status, limit)
+}
+
+// Replay sends the event of a delivery again as a new delivery. A replayed dead delivery
+// becomes StatusReplayed, so that it is not replayed twice by ReplayDead.
+func (d *Dispatcher) Replay(ctx context.Context, deliveryID string) (Delivery, error) {
+	delivery, err := d.store.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return Delivery{}, err
+	}
+	replayed, err := d.replay(ctx, []Delivery{delivery})
+	if err != nil {
+		return Delivery{}, err
+	}
+	return replayed[0], nil
+}
+
+// ReplayDead replays every dead delivery of the subscription.
+func (d *Dispatcher) ReplayDead(ctx context.Context, subscriptionID string) ([]Delivery, error) {
+	dead, err := d.Deliveries(ctx, subscriptionID, StatusDead, 0)
+	if err != nil || len(dead) == 0 {
+		return nil, err
+	}
+	return d.replay(ctx, dead)
+}
+
+func (d *Dispatcher) replay(ctx context.Context, deliveries []Delivery) ([]Delivery, error) {
+	now := time.Now().UTC()
+	replayed := make([]Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		replayed = append(replayed, newDelivery(delivery.SubscriptionID, delivery.Event, now))
+	}
+	if err := d.store.CreateDeliveries(ctx, replayed); err != nil {
+		return nil, err
+	}
+	for _, delivery := range deliveries {
+		if delivery.Status != StatusDead {
+			continue
+		}
+		delivery.Status = StatusReplayed
+		if err := d.store.UpdateDelivery(ctx, delivery); err != nil {
+			return nil, err
+		}
+	}
+	d.notify()
+	return replayed, nil
+}
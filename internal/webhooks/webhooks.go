@@ -0,0 +1,185 @@
+// Package webhooks delivers link lifecycle and click events to subscribed HTTP endpoints.
+//
+// Published events are persisted as one delivery per matching subscription. A Dispatcher posts
+// the deliveries asynchronously, signed with an HMAC-SHA256 of the subscription secret, and
+// retries failed ones with exponential backoff until they succeed or run out of attempts, which
+// leaves them dead-lettered until they are replayed.
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidSubscription  = errors.New("invalid webhook subscription")
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+)
+
+// EventType names what happened to a link.
+type EventType string
+
+const (
+	EventLinkCreated EventType = "link.created"
+	EventLinkUpdated EventType = "link.updated"
+	EventLinkDeleted EventType = "link.deleted"
+	// EventLinkExpired is sent once when a link stops resolving because it expired or ran out of clicks.
+	EventLinkExpired EventType = "link.expired"
+	EventLinkClicked EventType = "link.clicked"
+)
+
+// EventTypes lists every event type.
+var EventTypes = []EventType{EventLinkCreated, EventLinkUpdated, EventLinkDeleted, EventLinkExpired, EventLinkClicked}
+
+// Event is the payload posted to subscribers.
+type Event struct {
+	ID   string          `json:"id"`
+	Type EventType       `json:"type"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// NewEvent returns an event of the given type carrying data, e.g. LinkData or ClickData.
+func NewEvent(typ EventType, data any) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{ID: newID("evt"), Type: typ, Time: time.Now().UTC(), Data: raw}, nil
+}
+
+// LinkData describes the link of a lifecycle event.
+type LinkData struct {
+	Code            string     `json:"code"`
+	ShortURL        string     `json:"short_url,omitempty"`
+	Domain          string     `json:"domain,omitempty"`
+	Target          string     `json:"target"`
+	Owner           string     `json:"owner,omitempty"`
+	Tags            []string   `json:"tags,omitempty"`
+	Campaign        string     `json:"campaign,omitempty"`
+	Disabled        bool       `json:"disabled"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	MaxClicks       int64      `json:"max_clicks,omitempty"`
+	RemainingClicks int64      `json:"remaining_clicks,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	// Fields are the updated fields of link.updated events.
+	Fields []string `json:"fields,omitempty"`
+	// Reason is why a link.expired link stopped resolving: "expired" or "exhausted".
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClickData describes a resolve of a link.
+type ClickData struct {
+	Code     string `json:"code"`
+	ShortURL string `json:"short_url,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	// Target is where the click was sent to.
+	Target    string `json:"target"`
+	RuleID    string `json:"rule_id,omitempty"`
+	VariantID string `json:"variant_id,omitempty"`
+}
+
+// Subscription is an endpoint events are posted to.
+type Subscription struct {
+	ID  string
+	URL string
+	// Secret is the key of the HMAC-SHA256 signature of every delivery.
+	Secret string
+	// Events filters the delivered event types. Empty means all of them.
+	Events    []EventType
+	CreatedAt time.Time
+}
+
+// Wants reports whether the subscription receives events of the given type.
+func (s Subscription) Wants(typ EventType) bool {
+	return len(s.Events) == 0 || slices.Contains(s.Events, typ)
+}
+
+// Bounds of the length of subscription secrets.
+const (
+	MinSecretLength = 16
+	MaxSecretLength = 256
+)
+
+// Validate checks the endpoint URL, the secret and the event filter.
+func (s Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidSubscription)
+	}
+	if len(s.Secret) < MinSecretLength || len(s.Secret) > MaxSecretLength {
+		return fmt.Errorf("%w: secret must be %d to %d bytes long", ErrInvalidSubscription, MinSecretLength, MaxSecretLength)
+	}
+	for _, typ := range s.Events {
+		if !slices.Contains(EventTypes, typ) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidSubscription, typ)
+		}
+	}
+	return nil
+}
+
+// DeliveryStatus is the state of a delivery.
+type DeliveryStatus string
+
+const (
+	// StatusPending deliveries are posted at NextAttemptAt.
+	StatusPending DeliveryStatus = "pending"
+	// StatusSucceeded deliveries got a 2xx response.
+	StatusSucceeded DeliveryStatus = "succeeded"
+	// StatusDead deliveries failed every attempt and are kept until they are replayed.
+	StatusDead DeliveryStatus = "dead"
+	// StatusReplayed deliveries were dead and have been replayed as a new delivery.
+	StatusReplayed DeliveryStatus = "replayed"
+)
+
+// Delivery is an event on its way to one subscription, and its entry in the delivery log.
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	Event          Event
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	// LastStatusCode is the response status of the last attempt, 0 if there was no response.
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+}
+
+// Store persists subscriptions and deliveries.
+type Store interface {
+	CreateSubscription(ctx context.Context, sub Subscription) error
+	// GetSubscription fails with ErrSubscriptionNotFound for unknown IDs.
+	GetSubscription(ctx context.Context, id string) (Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]Subscription, error)
+	// DeleteSubscription removes the subscription together with its deliveries.
+	DeleteSubscription(ctx context.Context, id string) error
+
+	CreateDeliveries(ctx context.Context, deliveries []Delivery) error
+	// GetDelivery fails with ErrDeliveryNotFound for unknown IDs.
+	GetDelivery(ctx context.Context, id string) (Delivery, error)
+	// ListDeliveries returns up to limit deliveries of a subscription, newest first,
+	// only those with the given status unless it is empty. A limit <= 0 means no limit.
+	ListDeliveries(ctx context.Context, subscriptionID string, status DeliveryStatus, limit int) ([]Delivery, error)
+	// ClaimDeliveries returns up to limit pending deliveries due at now, oldest first, and moves
+	// their NextAttemptAt to now+lease, so that no other dispatcher claims them meanwhile.
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+	// UpdateDelivery stores the outcome of an attempt.
+	UpdateDelivery(ctx context.Context, delivery Delivery) error
+}
+
+func newID(prefix string) string {
+	id := make([]byte, 12)
+	_, _ = rand.Read(id)
+	return prefix + "_" + hex.EncodeToString(id)
+}
@@ -0,0 +1,117 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSignVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"evt_1"}`)
+	signature := Sign("0123456789abcdef", now, body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+
+	assert.True(t, Verify("0123456789abcdef", "1700000000", signature, body))
+	assert.False(t, Verify("0123456789abcdeF", "1700000000", signature, body), "other secret")
+	assert.False(t, Verify("0123456789abcdef", "1700000001", signature, body), "other timestamp")
+	assert.False(t, Verify("0123456789abcdef", "1700000000", signature, []byte(`{"id":"evt_2"}`)), "other body")
+	assert.False(t, Verify("0123456789abcdef", "1700000000", signature[len("sha256="):], body), "no prefix")
+	assert.False(t, Verify("0123456789abcdef", "1700000000", "sha256=zz", body))
+}
+
+func TestConfig_Backoff(t *testing.T) {
+	cfg := Config{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	tests := []struct {
+		attempts int
+		max      time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			d := cfg.Backoff(tt.attempts)
+			assert.GreaterOrEqual(t, d, tt.max/2, "attempt %d", tt.attempts)
+			assert.LessOrEqual(t, d, tt.max, "attempt %d", tt.attempts)
+		}
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+	t.Setenv("WEBHOOK_MIN_BACKOFF", "250ms")
+	t.Setenv("WEBHOOK_MAX_BACKOFF", "-1s")
+	t.Setenv("WEBHOOK_QUEUE_SIZE", "many")
+
+	cfg := ConfigFromEnv()
+	assert.Equal(t, 3, cfg.MaxAttempts)
+	assert.Equal(t, 250*time.Millisecond, cfg.MinBackoff)
+	assert.Equal(t, DefaultConfig.MaxBackoff, cfg.MaxBackoff)
+	assert.Equal(t, DefaultConfig.QueueSize, cfg.QueueSize)
+}
+
+func TestSubscription(t *testing.T) {
+	sub := Subscription{URL: "https://hooks.example.com/links", Secret: "0123456789abcdef", Events: []EventType{EventLinkCreated}}
+	require.NoError(t, sub.Validate())
+	assert.True(t, sub.Wants(EventLinkCreated))
+	assert.False(t, sub.Wants(EventLinkClicked))
+	assert.True(t, Subscription{}.Wants(EventLinkClicked), "no filter receives every event")
+
+	for name, invalid := range map[string]Subscription{
+		"relative url": {URL: "/links", Secret: sub.Secret},
+		"scheme":       {URL: "ftp://hooks.example.com", Secret: sub.Secret},
+		"short secret": {URL: sub.URL, Secret: "secret"},
+		"event":        {URL: sub.URL, Secret: sub.Secret, Events: []EventType{"link.opened"}},
+	} {
+		assert.ErrorIs(t, invalid.Validate(), ErrInvalidSubscription, name)
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	for _, addr := range []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"} {
+		assert.True(t, publicAddr(netip.MustParseAddr(addr)), addr)
+	}
+	for _, addr := range []string{
+		"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "fe80::1",
+		"fd00::1", "0.0.0.0", "::", "100.64.0.1", "224.0.0.1", "::ffff:127.0.0.1", "::ffff:10.0.0.1",
+	} {
+		assert.False(t, publicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestDispatcher_RefusesPrivateEndpoints(t *testing.T) {
+	ctx := context.Background()
+	dispatcher := NewDispatcher(nil, zaptest.NewLogger(t), Config{})
+	for _, endpoint := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://[::1]/hook",
+		"http://10.0.0.5/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://localhost/hook",
+	} {
+		_, err := dispatcher.Subscribe(ctx, Subscription{URL: endpoint})
+		assert.ErrorIs(t, err, ErrInvalidSubscription, endpoint)
+	}
+
+	// Names resolving to private addresses later are refused when connecting.
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(receiver.Close)
+	_, err := newClient(Config{}).Get(receiver.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not public")
+	resp, err := newClient(Config{AllowPrivateEndpoints: true}).Get(receiver.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}